@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/domain/survey"
+)
+
+var (
+	ErrNotSessionOwner = errors.New("only the member who submitted the health check can change it")
+	ErrPeriodClosed    = errors.New("the assessment period is closed")
+)
+
+// AmendHealthCheckCommand represents the command to replace the responses of a submitted health check
+type AmendHealthCheckCommand struct {
+	SessionID string
+	UserID    string
+	Responses []HealthCheckResponseCommand
+}
+
+// AmendHealthCheckHandler amends and withdraws submitted health checks while their assessment period is open.
+// Every change is recorded in the session's revision history.
+type AmendHealthCheckHandler struct {
+	repository healthcheck.Repository
+	submit     *SubmitHealthCheckHandler
+	now        func() time.Time
+}
+
+// NewAmendHealthCheckHandler creates a new amend handler.
+// templates may be nil, in which case survey templates are not enforced.
+func NewAmendHealthCheckHandler(repository healthcheck.Repository, templates survey.Repository) *AmendHealthCheckHandler {
+	return &AmendHealthCheckHandler{
+		repository: repository,
+		submit:     NewSubmitHealthCheckHandler(repository, templates),
+		now:        time.Now,
+	}
+}
+
+// Amend replaces the responses of the member's submitted health check
+func (h *AmendHealthCheckHandler) Amend(ctx context.Context, cmd AmendHealthCheckCommand) (*healthcheck.HealthCheckSession, error) {
+	existing, err := h.findOwnedSession(ctx, cmd.SessionID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := h.submit.buildSession(ctx, SubmitHealthCheckCommand{
+		ID:               existing.ID,
+		TeamID:           existing.TeamID,
+		UserID:           cmd.UserID,
+		Date:             existing.Date,
+		AssessmentPeriod: existing.AssessmentPeriod,
+		SurveyType:       existing.SurveyType,
+		Responses:        cmd.Responses,
+		Completed:        true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The amendment keeps the respondent recorded at submission, even if the anonymity policy changed since
+	session.UserID = existing.UserID
+	session.Anonymous = existing.Anonymous
+	session.ParticipantID = ""
+	if existing.Anonymous {
+		session.ParticipantID = cmd.UserID
+	}
+
+	if err := h.repository.Amend(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to amend session: %w", err)
+	}
+
+	return session, nil
+}
+
+// Withdraw removes the member's submitted health check from the period
+func (h *AmendHealthCheckHandler) Withdraw(ctx context.Context, sessionID, userID string) error {
+	session, err := h.findOwnedSession(ctx, sessionID, userID)
+	if err != nil {
+		return err
+	}
+
+	if session.Anonymous {
+		session.ParticipantID = userID
+	}
+
+	if err := h.repository.Withdraw(ctx, session); err != nil {
+		return fmt.Errorf("failed to withdraw session: %w", err)
+	}
+
+	return nil
+}
+
+// Revisions returns the revision history of a session, oldest first.
+// A session that was never changed has no revisions.
+func (h *AmendHealthCheckHandler) Revisions(ctx context.Context, sessionID string) ([]*healthcheck.Revision, error) {
+	return h.repository.FindRevisions(ctx, sessionID)
+}
+
+// IsOwner reports whether the user submitted the session.
+// Anonymous sessions are matched through the member's respondent token.
+func (h *AmendHealthCheckHandler) IsOwner(session *healthcheck.HealthCheckSession, userID string) bool {
+	if !session.Anonymous {
+		return session.UserID == userID
+	}
+	if len(h.submit.tokenSecret) == 0 {
+		return false
+	}
+	return session.UserID == healthcheck.RespondentToken(h.submit.tokenSecret, session.TeamID, session.AssessmentPeriod, userID)
+}
+
+// findOwnedSession loads a completed session the user may still change
+func (h *AmendHealthCheckHandler) findOwnedSession(ctx context.Context, sessionID, userID string) (*healthcheck.HealthCheckSession, error) {
+	session, err := h.repository.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.Completed {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	if !h.IsOwner(session, userID) {
+		return nil, ErrNotSessionOwner
+	}
+
+	open, err := h.periodOpen(ctx, session.TeamID, session.AssessmentPeriod)
+	if err != nil {
+		return nil, err
+	}
+	if !open {
+		return nil, ErrPeriodClosed
+	}
+
+	return session, nil
+}
+
+// periodOpen reports whether submissions for the team's period may still change.
+// A period with a campaign is open while the campaign is; otherwise only the team's
+// current period, according to its cadence, is open.
+func (h *AmendHealthCheckHandler) periodOpen(ctx context.Context, teamID, assessmentPeriod string) (bool, error) {
+	if assessmentPeriod == "" {
+		return false, nil
+	}
+
+	window, err := h.repository.FindSubmissionWindow(ctx, teamID, assessmentPeriod)
+	if err != nil {
+		return false, fmt.Errorf("failed to load submission window: %w", err)
+	}
+
+	if window.CampaignStatus != "" {
+		return window.CampaignStatus == campaign.StatusOpen, nil
+	}
+
+	return campaign.PeriodFor(window.Cadence, h.now()).Label == assessmentPeriod, nil
+}
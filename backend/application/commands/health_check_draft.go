@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/domain/survey"
+)
+
+// SaveHealthCheckDraftCommand represents the command to autosave a member's partial individual survey.
+// Unanswered responses carry a zero Score and/or empty Trend.
+type SaveHealthCheckDraftCommand struct {
+	TeamID           string
+	UserID           string
+	AssessmentPeriod string
+	Responses        []HealthCheckResponseCommand
+}
+
+// HealthCheckDraftHandler saves, resumes, finalizes and discards survey drafts.
+// A draft is the member's incomplete individual session for a team and period; there is at most one.
+type HealthCheckDraftHandler struct {
+	repository healthcheck.Repository
+	templates  survey.Repository
+	submit     *SubmitHealthCheckHandler
+}
+
+// NewHealthCheckDraftHandler creates a new draft handler.
+// templates may be nil, in which case survey templates are not enforced.
+func NewHealthCheckDraftHandler(repository healthcheck.Repository, templates survey.Repository) *HealthCheckDraftHandler {
+	return &HealthCheckDraftHandler{
+		repository: repository,
+		templates:  templates,
+		submit:     NewSubmitHealthCheckHandler(repository, templates),
+	}
+}
+
+// Save creates or replaces the member's draft with the given responses
+func (h *HealthCheckDraftHandler) Save(ctx context.Context, cmd SaveHealthCheckDraftCommand) (*healthcheck.HealthCheckSession, error) {
+	if err := h.validate(ctx, cmd); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	respondentID, anonymous, err := h.submit.respondentID(ctx, cmd.TeamID, cmd.AssessmentPeriod, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &healthcheck.HealthCheckSession{
+		ID:               fmt.Sprintf("session-%d", time.Now().UnixNano()),
+		TeamID:           cmd.TeamID,
+		UserID:           respondentID,
+		Date:             time.Now().UTC().Format(time.RFC3339),
+		AssessmentPeriod: cmd.AssessmentPeriod,
+		SurveyType:       healthcheck.SurveyTypeIndividual,
+		Responses:        make([]healthcheck.HealthCheckResponse, len(cmd.Responses)),
+		Completed:        false,
+		Anonymous:        anonymous,
+	}
+
+	// Keep the existing draft's ID so autosaves update it in place
+	existing, err := h.repository.FindDraft(ctx, cmd.TeamID, respondentID, cmd.AssessmentPeriod)
+	if err == nil {
+		session.ID = existing.ID
+	}
+
+	for i, resp := range cmd.Responses {
+		session.Responses[i] = healthcheck.HealthCheckResponse{
+			DimensionID: resp.DimensionID,
+			Score:       resp.Score,
+			Trend:       resp.Trend,
+			Comment:     resp.Comment,
+		}
+	}
+
+	if err := h.repository.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save draft: %w", err)
+	}
+
+	return session, nil
+}
+
+// Find returns the member's draft for the team and period
+func (h *HealthCheckDraftHandler) Find(ctx context.Context, teamID, userID, assessmentPeriod string) (*healthcheck.HealthCheckSession, error) {
+	respondentID, _, err := h.submit.respondentID(ctx, teamID, assessmentPeriod, userID)
+	if err != nil {
+		return nil, err
+	}
+	return h.repository.FindDraft(ctx, teamID, respondentID, assessmentPeriod)
+}
+
+// Submit finalizes the member's draft. The draft must pass the same validation as a full submission;
+// on success it becomes the completed session, keeping its ID.
+func (h *HealthCheckDraftHandler) Submit(ctx context.Context, teamID, userID, assessmentPeriod string) (*healthcheck.HealthCheckSession, error) {
+	draft, err := h.Find(ctx, teamID, userID, assessmentPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := SubmitHealthCheckCommand{
+		ID:               draft.ID,
+		TeamID:           teamID,
+		UserID:           userID,
+		Date:             time.Now().UTC().Format(time.RFC3339),
+		AssessmentPeriod: assessmentPeriod,
+		SurveyType:       healthcheck.SurveyTypeIndividual,
+		Responses:        make([]HealthCheckResponseCommand, len(draft.Responses)),
+		Completed:        true,
+	}
+	for i, resp := range draft.Responses {
+		cmd.Responses[i] = HealthCheckResponseCommand{
+			DimensionID: resp.DimensionID,
+			Score:       resp.Score,
+			Trend:       resp.Trend,
+			Comment:     resp.Comment,
+		}
+	}
+
+	return h.submit.Handle(cmd)
+}
+
+// Discard deletes the member's draft
+func (h *HealthCheckDraftHandler) Discard(ctx context.Context, teamID, userID, assessmentPeriod string) error {
+	draft, err := h.Find(ctx, teamID, userID, assessmentPeriod)
+	if err != nil {
+		return err
+	}
+	return h.repository.Delete(ctx, draft.ID)
+}
+
+// validate checks the answers given so far; unlike a submission, responses may be missing or partial
+func (h *HealthCheckDraftHandler) validate(ctx context.Context, cmd SaveHealthCheckDraftCommand) error {
+	if cmd.TeamID == "" {
+		return fmt.Errorf("teamId is required")
+	}
+
+	if cmd.UserID == "" {
+		return fmt.Errorf("userId is required")
+	}
+
+	if cmd.AssessmentPeriod == "" {
+		return fmt.Errorf("assessmentPeriod is required")
+	}
+
+	var version *survey.Version
+	if h.templates != nil {
+		var err error
+		if version, err = h.templates.FindCurrentForTeam(ctx, cmd.TeamID); err != nil {
+			return fmt.Errorf("failed to load survey template: %w", err)
+		}
+	}
+
+	answered := make(map[string]bool, len(cmd.Responses))
+	for i, resp := range cmd.Responses {
+		if resp.DimensionID == "" {
+			return fmt.Errorf("response %d: dimensionId is required", i)
+		}
+
+		if answered[resp.DimensionID] {
+			return fmt.Errorf("response %d: dimension '%s' answered more than once", i, resp.DimensionID)
+		}
+		answered[resp.DimensionID] = true
+
+		if version != nil && !version.Includes(resp.DimensionID) {
+			return fmt.Errorf("response %d: dimension '%s' is not part of survey template %s v%d", i, resp.DimensionID, version.TemplateID, version.Version)
+		}
+
+		if resp.Score != 0 && (resp.Score < 1 || resp.Score > 3) {
+			return fmt.Errorf("response %d: score must be between 1 and 3", i)
+		}
+
+		if resp.Trend != "" && resp.Trend != "improving" && resp.Trend != "stable" && resp.Trend != "declining" {
+			return fmt.Errorf("response %d: trend must be 'improving', 'stable', or 'declining'", i)
+		}
+	}
+
+	return nil
+}
@@ -3,9 +3,11 @@ package commands
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/domain/survey"
 )
 
 // SubmitHealthCheckCommand represents the command to submit a health check
@@ -30,23 +32,63 @@ type HealthCheckResponseCommand struct {
 
 // SubmitHealthCheckHandler handles the submit health check command
 type SubmitHealthCheckHandler struct {
-	repository healthcheck.Repository
+	repository  healthcheck.Repository
+	templates   survey.Repository
+	tokenSecret []byte
 }
 
-// NewSubmitHealthCheckHandler creates a new command handler
-func NewSubmitHealthCheckHandler(repository healthcheck.Repository) *SubmitHealthCheckHandler {
+// NewSubmitHealthCheckHandler creates a new command handler.
+// templates may be nil, in which case survey templates are not enforced.
+func NewSubmitHealthCheckHandler(repository healthcheck.Repository, templates survey.Repository) *SubmitHealthCheckHandler {
 	return &SubmitHealthCheckHandler{
-		repository: repository,
+		repository:  repository,
+		templates:   templates,
+		tokenSecret: respondentTokenSecret(),
 	}
 }
 
+// respondentTokenSecret returns the key used to derive anonymous respondent tokens:
+// ANONYMITY_TOKEN_SECRET, falling back to JWT_SECRET
+func respondentTokenSecret() []byte {
+	if secret := os.Getenv("ANONYMITY_TOKEN_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return []byte(os.Getenv("JWT_SECRET"))
+}
+
 // Handle executes the command
 func (h *SubmitHealthCheckHandler) Handle(cmd SubmitHealthCheckCommand) (*healthcheck.HealthCheckSession, error) {
+	ctx := context.Background()
+
 	// Generate ID if not provided
-	if cmd.ID == "" {
+	generatedID := cmd.ID == ""
+	if generatedID {
 		cmd.ID = fmt.Sprintf("session-%d", time.Now().UnixNano())
 	}
 
+	session, err := h.buildSession(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// A completed individual survey without an explicit ID replaces the member's draft
+	if generatedID && cmd.Completed && session.SurveyType == healthcheck.SurveyTypeIndividual {
+		if draft, err := h.repository.FindDraft(ctx, cmd.TeamID, session.UserID, cmd.AssessmentPeriod); err == nil {
+			session.ID = draft.ID
+		}
+	}
+
+	// Save to repository
+	if err := h.repository.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return session, nil
+}
+
+// buildSession validates the command and converts it to a session, applying the team's survey
+// template and anonymity policy. The session is not saved.
+func (h *SubmitHealthCheckHandler) buildSession(ctx context.Context, cmd SubmitHealthCheckCommand) (*healthcheck.HealthCheckSession, error) {
 	// Validate command
 	if err := h.validate(cmd); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -79,14 +121,53 @@ func (h *SubmitHealthCheckHandler) Handle(cmd SubmitHealthCheckCommand) (*health
 		}
 	}
 
-	// Save to repository
-	if err := h.repository.Save(context.Background(), session); err != nil {
-		return nil, fmt.Errorf("failed to save session: %w", err)
+	// Hold responses to the team's survey template and record the version answered
+	if h.templates != nil {
+		version, err := h.templates.FindCurrentForTeam(ctx, cmd.TeamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load survey template: %w", err)
+		}
+		if version != nil {
+			if err := validateTemplateResponses(version, cmd.Responses); err != nil {
+				return nil, fmt.Errorf("validation failed: %w", err)
+			}
+			session.TemplateID = version.TemplateID
+			session.TemplateVersion = version.Version
+		}
+	}
+
+	// Post-workshop sessions record the team's consensus and are never anonymous
+	if surveyType == healthcheck.SurveyTypeIndividual {
+		respondentID, anonymous, err := h.respondentID(ctx, cmd.TeamID, cmd.AssessmentPeriod, cmd.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if anonymous {
+			session.Anonymous = true
+			session.ParticipantID = cmd.UserID
+			session.UserID = respondentID
+		}
 	}
 
 	return session, nil
 }
 
+// respondentID returns the user ID stored on the member's individual sessions for the team and period.
+// Anonymous teams and campaigns store a respondent token instead of the member's ID.
+func (h *SubmitHealthCheckHandler) respondentID(ctx context.Context, teamID, assessmentPeriod, userID string) (string, bool, error) {
+	policy, err := h.repository.FindAnonymityPolicy(ctx, teamID, assessmentPeriod)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to load anonymity policy: %w", err)
+	}
+	if !policy.Anonymous {
+		return userID, false, nil
+	}
+	if len(h.tokenSecret) == 0 {
+		return "", false, fmt.Errorf("anonymous surveys require ANONYMITY_TOKEN_SECRET or JWT_SECRET")
+	}
+	return healthcheck.RespondentToken(h.tokenSecret, teamID, assessmentPeriod, userID), true, nil
+}
+
 // validate ensures the command is valid
 func (h *SubmitHealthCheckHandler) validate(cmd SubmitHealthCheckCommand) error {
 	if cmd.TeamID == "" {
@@ -126,3 +207,25 @@ func (h *SubmitHealthCheckHandler) validate(cmd SubmitHealthCheckCommand) error
 
 	return nil
 }
+
+// validateTemplateResponses ensures the responses answer exactly the template version's dimensions
+func validateTemplateResponses(version *survey.Version, responses []HealthCheckResponseCommand) error {
+	answered := make(map[string]bool, len(responses))
+	for i, resp := range responses {
+		if !version.Includes(resp.DimensionID) {
+			return fmt.Errorf("response %d: dimension '%s' is not part of survey template %s v%d", i, resp.DimensionID, version.TemplateID, version.Version)
+		}
+		if answered[resp.DimensionID] {
+			return fmt.Errorf("response %d: dimension '%s' answered more than once", i, resp.DimensionID)
+		}
+		answered[resp.DimensionID] = true
+	}
+
+	for _, dimensionID := range version.Dimensions {
+		if !answered[dimensionID] {
+			return fmt.Errorf("dimension '%s' is required by survey template %s v%d", dimensionID, version.TemplateID, version.Version)
+		}
+	}
+
+	return nil
+}
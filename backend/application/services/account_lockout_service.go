@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/email"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+)
+
+var (
+	// ErrAccountLocked is returned while an account is locked after too many failed logins
+	ErrAccountLocked = errors.New("account is temporarily locked")
+	// ErrLoginThrottled is returned while an account must wait before its next login attempt
+	ErrLoginThrottled = errors.New("too many failed login attempts")
+)
+
+// LoginAttempts is the failed login state of a username
+type LoginAttempts struct {
+	FailedCount   int
+	NextAttemptAt *time.Time
+	LockedUntil   *time.Time
+}
+
+// LoginAttemptStore persists failed logins per normalized username
+type LoginAttemptStore interface {
+	// ReserveAttempt atomically checks and counts a login attempt: unless logins are blocked at
+	// now, it counts the attempt as a failure (restarting the count when the previous failure was
+	// outside the policy's window) and applies the policy's blocks for the new count. Concurrent
+	// attempts for the same username are serialized. Returns the state and whether the attempt
+	// was counted; when it was not, the state holds the blocks that refused it.
+	ReserveAttempt(ctx context.Context, username string, now time.Time, policy AccountLockoutPolicy) (*LoginAttempts, bool, error)
+	// ResetLoginAttempts clears the username's failed logins. Returns false if it had none.
+	ResetLoginAttempts(ctx context.Context, username string) (bool, error)
+	// DeleteStaleAttempts deletes the failed logins of usernames whose last failure was before
+	// before and that are neither delayed nor locked at now. Returns the number deleted.
+	DeleteStaleAttempts(ctx context.Context, before, now time.Time) (int64, error)
+}
+
+// AccountLockoutPolicy configures how failed logins are throttled
+type AccountLockoutPolicy struct {
+	MaxFailures     int           // failures that lock the account
+	LockoutDuration time.Duration // how long a locked account stays locked
+	FailureWindow   time.Duration // failures further apart than this start a new count
+	DelayAfter      int           // failures allowed before delays start
+	BaseDelay       time.Duration // first delay, doubled for every further failure
+	MaxDelay        time.Duration
+}
+
+// DefaultAccountLockoutPolicy locks an account for 15 minutes after 10 failures within an hour,
+// with delays from 1 second up to 30 seconds between guesses after the third failure
+func DefaultAccountLockoutPolicy() AccountLockoutPolicy {
+	return AccountLockoutPolicy{
+		MaxFailures:     10,
+		LockoutDuration: 15 * time.Minute,
+		FailureWindow:   time.Hour,
+		DelayAfter:      3,
+		BaseDelay:       time.Second,
+		MaxDelay:        30 * time.Second,
+	}
+}
+
+// Blocks returns until when logins are refused after the count-th failure at now: locked for
+// LockoutDuration from MaxFailures on, delayed progressively beyond DelayAfter. A nil time blocks nothing.
+func (p AccountLockoutPolicy) Blocks(count int, now time.Time) (nextAttemptAt, lockedUntil *time.Time) {
+	if count >= p.MaxFailures {
+		until := now.Add(p.LockoutDuration)
+		lockedUntil = &until
+	}
+	if delay := p.delay(count); delay > 0 {
+		next := now.Add(delay)
+		nextAttemptAt = &next
+	}
+	return nextAttemptAt, lockedUntil
+}
+
+// delay returns how long the username waits after its count-th failure
+func (p AccountLockoutPolicy) delay(count int) time.Duration {
+	if count <= p.DelayAfter {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := p.DelayAfter + 1; i < count && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// AccountLockoutService protects local accounts against password guessing.
+//
+// Unlike AuthRateLimitMiddleware, which limits requests per client IP in memory, it counts
+// failed logins per username in Postgres, so guesses spread over many IPs or API replicas
+// are limited too. Every attempt is reserved as a failure before the credentials are checked,
+// in the same statement that checks the blocks, so concurrent guesses cannot slip past them;
+// a successful login or an admin unlock clears the count. Failures beyond DelayAfter make the
+// username wait progressively longer before its next attempt; MaxFailures locks it for
+// LockoutDuration and notifies the user by email. Every further failure within the window
+// locks it again. Unknown usernames are counted and refused the same way, so responses do
+// not reveal which accounts exist.
+type AccountLockoutService struct {
+	store  LoginAttemptStore
+	sender email.Sender // nil when email is not configured
+	policy AccountLockoutPolicy
+	now    func() time.Time
+}
+
+// NewAccountLockoutService creates a new account lockout service.
+// sender may be nil (email disabled), in which case no lockout emails are sent.
+func NewAccountLockoutService(store LoginAttemptStore, sender email.Sender, policy AccountLockoutPolicy) *AccountLockoutService {
+	return &AccountLockoutService{
+		store:  store,
+		sender: sender,
+		policy: policy,
+		now:    time.Now,
+	}
+}
+
+// Start deletes stale failed logins immediately and then on every interval until ctx is cancelled.
+// Every attempt at an unknown username leaves a row behind, so without this anyone could grow
+// the table without limit by guessing random usernames.
+func (s *AccountLockoutService) Start(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "login attempt cleanup", func() error {
+		deleted, err := s.RunAt(ctx, s.now())
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Get().WithField("deleted", deleted).Info("login attempt cleanup: run completed")
+		}
+		return nil
+	})
+}
+
+// RunAt deletes the failed logins that no longer count at at: the last failure is outside the
+// failure window and the username is neither delayed nor locked. Returns the number deleted.
+func (s *AccountLockoutService) RunAt(ctx context.Context, at time.Time) (int64, error) {
+	return s.store.DeleteStaleAttempts(ctx, at.Add(-s.policy.FailureWindow), at)
+}
+
+// ReserveLogin counts a login attempt for the username before its credentials are checked.
+// It returns ErrAccountLocked or ErrLoginThrottled, with the time left until the username may
+// try again, when the attempt must be refused. Otherwise the attempt counts as a failure until
+// RecordSuccess clears it; pass the returned state to RecordFailure when the login fails.
+func (s *AccountLockoutService) ReserveLogin(ctx context.Context, username string) (*LoginAttempts, time.Duration, error) {
+	now := s.now()
+	attempts, reserved, err := s.store.ReserveAttempt(ctx, NormalizeLoginUsername(username), now, s.policy)
+	if err != nil {
+		return nil, 0, err
+	}
+	if reserved {
+		return attempts, 0, nil
+	}
+
+	if attempts.LockedUntil != nil && attempts.LockedUntil.After(now) {
+		return attempts, attempts.LockedUntil.Sub(now), ErrAccountLocked
+	}
+	return attempts, attempts.NextAttemptAt.Sub(now), ErrLoginThrottled
+}
+
+// RecordFailure reports a failed login of an existing account, whose attempt ReserveLogin
+// already counted. The user is emailed when that attempt locked the account.
+func (s *AccountLockoutService) RecordFailure(ctx context.Context, usr *user.User, attempts *LoginAttempts) {
+	if attempts.LockedUntil == nil || attempts.FailedCount < s.policy.MaxFailures {
+		return
+	}
+
+	logger.Get().Security("account_locked").
+		UserID(usr.ID).
+		Details("Account locked after repeated failed logins until " + attempts.LockedUntil.UTC().Format(time.RFC3339)).
+		Log()
+
+	// Notify once per lockout episode, not on every re-lock
+	if attempts.FailedCount == s.policy.MaxFailures {
+		s.sendLockoutEmail(ctx, usr, attempts.FailedCount, *attempts.LockedUntil)
+	}
+}
+
+// RecordSuccess clears the user's failed logins after a successful login
+func (s *AccountLockoutService) RecordSuccess(ctx context.Context, usr *user.User) error {
+	_, err := s.store.ResetLoginAttempts(ctx, NormalizeLoginUsername(usr.Username))
+	return err
+}
+
+// Unlock clears a locked or delayed account. Returns false if it had no failed logins.
+func (s *AccountLockoutService) Unlock(ctx context.Context, usr *user.User) (bool, error) {
+	return s.store.ResetLoginAttempts(ctx, NormalizeLoginUsername(usr.Username))
+}
+
+// NormalizeLoginUsername returns the key failed logins are counted under
+func NormalizeLoginUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+func (s *AccountLockoutService) sendLockoutEmail(ctx context.Context, usr *user.User, failures int, lockedUntil time.Time) {
+	if s.sender == nil || usr.Email == "" {
+		return
+	}
+
+	htmlBody := email.RenderAccountLockoutEmail(email.AccountLockoutEmailData{
+		UserName:    usr.Name,
+		Username:    usr.Username,
+		Failures:    failures,
+		LockedUntil: lockedUntil.UTC().Format("Jan 2, 2006 15:04 MST"),
+	})
+	if err := s.sender.SendHTML(ctx, usr.Email, "Your Teams360 account has been locked", htmlBody); err != nil {
+		logger.Get().WithError(err).WithField("user_id", usr.ID).Warn("failed to send account lockout email")
+	}
+}
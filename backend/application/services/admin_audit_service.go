@@ -0,0 +1,281 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/pkg/xlsx"
+)
+
+// ErrInvalidAuditQuery is returned for audit log filters or export formats that are not supported
+var ErrInvalidAuditQuery = errors.New("invalid audit log query")
+
+// Audit log page sizes
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 500
+)
+
+// adminAuditColumns are the header row of an audit log export
+var adminAuditColumns = []string{
+	"ID", "Time", "Actor ID", "Actor", "Action", "Target Type", "Target ID",
+	"Changed Fields", "Before", "After", "IP Address", "Request ID",
+}
+
+// AdminAuditEntry records one admin write: who did it, what it targeted, and the target's
+// state before and after as JSON
+type AdminAuditEntry struct {
+	ID            int64
+	ActorID       string
+	ActorUsername string
+	Action        string // e.g. user.update
+	TargetType    string // e.g. user
+	TargetID      string // empty for singletons such as settings
+	Before        json.RawMessage
+	After         json.RawMessage
+	IPAddress     string
+	RequestID     string
+	CreatedAt     time.Time
+}
+
+// AdminAuditChange is one top-level field that differs between an entry's before and after state
+type AdminAuditChange struct {
+	Field  string
+	Before json.RawMessage // nil when the field was added
+	After  json.RawMessage // nil when the field was removed
+}
+
+// AdminAuditFilter selects audit log entries. Empty fields match everything.
+type AdminAuditFilter struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time // inclusive
+	To         *time.Time // exclusive
+	Limit      int        // page size, ignored by exports
+	Offset     int
+}
+
+// AdminAuditRepository defines the storage of the admin audit log. Entries are append-only.
+type AdminAuditRepository interface {
+	// AppendAuditEntry stores an entry, setting its ID and CreatedAt
+	AppendAuditEntry(ctx context.Context, entry *AdminAuditEntry) error
+	// FindAuditEntries returns a page of matching entries, newest first, and the number of matches
+	FindAuditEntries(ctx context.Context, filter AdminAuditFilter) ([]AdminAuditEntry, int, error)
+	// StreamAuditEntries calls fn for every matching entry, oldest first, stopping at the first error fn returns
+	StreamAuditEntries(ctx context.Context, filter AdminAuditFilter, fn func(AdminAuditEntry) error) error
+}
+
+// AdminAuditService keeps the append-only audit log of admin writes and serves it for review
+// and compliance exports
+type AdminAuditService struct {
+	repo AdminAuditRepository
+}
+
+// NewAdminAuditService creates a new admin audit service
+func NewAdminAuditService(repo AdminAuditRepository) *AdminAuditService {
+	return &AdminAuditService{repo: repo}
+}
+
+// Record appends an entry to the audit log
+func (s *AdminAuditService) Record(ctx context.Context, entry *AdminAuditEntry) error {
+	if entry.ActorID == "" || entry.Action == "" || entry.TargetType == "" {
+		return fmt.Errorf("audit entry needs an actor, action and target type")
+	}
+	return s.repo.AppendAuditEntry(ctx, entry)
+}
+
+// List returns a page of matching entries, newest first, and the total number of matches
+func (s *AdminAuditService) List(ctx context.Context, filter AdminAuditFilter) ([]AdminAuditEntry, int, error) {
+	if filter.Limit == 0 {
+		filter.Limit = DefaultAuditPageSize
+	}
+	if err := validateAuditFilter(filter); err != nil {
+		return nil, 0, err
+	}
+	if filter.Limit < 1 || filter.Limit > MaxAuditPageSize {
+		return nil, 0, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidAuditQuery, MaxAuditPageSize)
+	}
+	if filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: offset must not be negative", ErrInvalidAuditQuery)
+	}
+	return s.repo.FindAuditEntries(ctx, filter)
+}
+
+// Export streams every matching entry, oldest first, to w as CSV or XLSX.
+// Nothing is written to w until the first entry is read, so a failure to start the export leaves w untouched.
+func (s *AdminAuditService) Export(ctx context.Context, filter AdminAuditFilter, format string, w io.Writer) error {
+	if err := validateAuditFilter(filter); err != nil {
+		return err
+	}
+	if format != ExportFormatCSV && format != ExportFormatXLSX {
+		return fmt.Errorf("%w: format must be 'csv' or 'xlsx'", ErrInvalidAuditQuery)
+	}
+
+	var out auditExportWriter
+	start := func() error {
+		var err error
+		if format == ExportFormatXLSX {
+			out, err = newXLSXAuditWriter(w)
+		} else {
+			out = newCSVAuditWriter(w)
+		}
+		if err != nil {
+			return err
+		}
+		return out.WriteRow(adminAuditColumns)
+	}
+
+	err := s.repo.StreamAuditEntries(ctx, filter, func(entry AdminAuditEntry) error {
+		if out == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return out.WriteRow(auditExportRow(entry))
+	})
+	if err != nil {
+		return err
+	}
+
+	// An export with no entries still has a header row
+	if out == nil {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// AdminAuditChanges compares the top-level fields of an entry's before and after state.
+// States that are not JSON objects yield no changes.
+func AdminAuditChanges(before, after json.RawMessage) []AdminAuditChange {
+	beforeFields := auditStateFields(before)
+	afterFields := auditStateFields(after)
+	if beforeFields == nil && afterFields == nil {
+		return nil
+	}
+
+	fields := make(map[string]bool, len(beforeFields)+len(afterFields))
+	for field := range beforeFields {
+		fields[field] = true
+	}
+	for field := range afterFields {
+		fields[field] = true
+	}
+
+	changes := []AdminAuditChange{}
+	for field := range fields {
+		b, a := beforeFields[field], afterFields[field]
+		if b != nil && a != nil && bytes.Equal(compactJSON(b), compactJSON(a)) {
+			continue
+		}
+		changes = append(changes, AdminAuditChange{Field: field, Before: b, After: a})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// validateAuditFilter checks the time range of a filter
+func validateAuditFilter(filter AdminAuditFilter) error {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidAuditQuery)
+	}
+	return nil
+}
+
+// auditStateFields decodes a JSON object state into its fields, or nil if it is not an object
+func auditStateFields(state json.RawMessage) map[string]json.RawMessage {
+	if len(state) == 0 {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(state, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// compactJSON strips insignificant whitespace so equal values compare equal
+func compactJSON(value json.RawMessage) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, value); err != nil {
+		return value
+	}
+	return buf.Bytes()
+}
+
+// auditExportRow formats an entry as a row of adminAuditColumns
+func auditExportRow(entry AdminAuditEntry) []string {
+	changes := AdminAuditChanges(entry.Before, entry.After)
+	fields := make([]string, len(changes))
+	for i, change := range changes {
+		fields[i] = change.Field
+	}
+	return []string{
+		strconv.FormatInt(entry.ID, 10), entry.CreatedAt.UTC().Format(time.RFC3339), entry.ActorID, entry.ActorUsername,
+		entry.Action, entry.TargetType, entry.TargetID, strings.Join(fields, ", "),
+		string(entry.Before), string(entry.After), entry.IPAddress, entry.RequestID,
+	}
+}
+
+// auditExportWriter writes audit log rows in one format
+type auditExportWriter interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+type csvAuditWriter struct {
+	w *csv.Writer
+}
+
+func newCSVAuditWriter(w io.Writer) *csvAuditWriter {
+	return &csvAuditWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvAuditWriter) WriteRow(values []string) error {
+	safe := make([]string, len(values))
+	for i, value := range values {
+		safe[i] = csvSafe(value)
+	}
+	return c.w.Write(safe)
+}
+
+func (c *csvAuditWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type xlsxAuditWriter struct {
+	w *xlsx.Writer
+}
+
+func newXLSXAuditWriter(w io.Writer) (*xlsxAuditWriter, error) {
+	xw, err := xlsx.NewWriter(w, "Audit Log")
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxAuditWriter{w: xw}, nil
+}
+
+func (x *xlsxAuditWriter) WriteRow(values []string) error {
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		cells[i] = value
+	}
+	return x.w.WriteRow(cells...)
+}
+
+func (x *xlsxAuditWriter) Close() error {
+	return x.w.Close()
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// APITokenPrefix starts every API token, telling them apart from JWTs and making leaked
+// tokens easy to find with secret scanners
+const APITokenPrefix = "t360_"
+
+// APITokenType is the TokenType of the claims of a request authenticated by an API token
+const APITokenType = "api"
+
+// apiTokenLastUsedInterval limits how often last-used tracking writes to the database
+const apiTokenLastUsedInterval = time.Minute
+
+// APITokenKind is who an API token was created by
+type APITokenKind string
+
+const (
+	// APITokenKindPersonal is created by a user for their own scripts
+	APITokenKindPersonal APITokenKind = "personal"
+	// APITokenKindService is issued by an admin for a user, usually a dedicated service account
+	APITokenKindService APITokenKind = "service"
+)
+
+// APITokenScope is what an API token may be used for
+type APITokenScope string
+
+const (
+	ScopeReadDashboards   APITokenScope = "read:dashboards"
+	ScopeReadExports      APITokenScope = "read:exports"
+	ScopeWriteActionItems APITokenScope = "write:action-items"
+	ScopeAdminUsers       APITokenScope = "admin:users"
+)
+
+// APITokenScopes lists every scope a token can be given
+var APITokenScopes = []APITokenScope{ScopeReadDashboards, ScopeReadExports, ScopeWriteActionItems, ScopeAdminUsers}
+
+var (
+	// ErrAPITokenNotFound is returned for an unknown token, or one of another user
+	ErrAPITokenNotFound = errors.New("API token not found")
+	// ErrInvalidAPITokenScope is returned when a token is requested without scopes or with an unknown one
+	ErrInvalidAPITokenScope = errors.New("invalid API token scope")
+	// ErrInvalidAPITokenExpiry is returned when a token is requested with an expiry in the past
+	ErrInvalidAPITokenExpiry = errors.New("API token expiry must be in the future")
+)
+
+// APIToken is an API token as stored, without the secret
+type APIToken struct {
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	Prefix     string          `json:"prefix"` // first characters of the token, for display
+	UserID     string          `json:"userId"`
+	Kind       APITokenKind    `json:"kind"`
+	Scopes     []APITokenScope `json:"scopes"`
+	CreatedBy  string          `json:"createdBy"`
+	ExpiresAt  *time.Time      `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time      `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time      `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// APITokenStore persists API tokens, which are looked up by the SHA-256 hash of the secret
+type APITokenStore interface {
+	// CreateAPIToken stores a new token with the hash of its secret
+	CreateAPIToken(ctx context.Context, token *APIToken, tokenHash string) error
+	// FindAPITokenByHash returns the token with the given hash, or nil when there is none
+	FindAPITokenByHash(ctx context.Context, tokenHash string) (*APIToken, error)
+	// FindAPIToken returns the token with the given ID, or nil when there is none
+	FindAPIToken(ctx context.Context, id string) (*APIToken, error)
+	// ListAPITokens returns the user's tokens, newest first, or every token when userID is empty
+	ListAPITokens(ctx context.Context, userID string) ([]*APIToken, error)
+	// RevokeAPIToken marks an unrevoked token as revoked. Returns false if it was not.
+	RevokeAPIToken(ctx context.Context, id string, revokedAt time.Time) (bool, error)
+	// RevokeUserAPITokens revokes every unrevoked token of the user and returns how many it revoked
+	RevokeUserAPITokens(ctx context.Context, userID string, revokedAt time.Time) (int64, error)
+	// TouchAPIToken records a use of the token unless one was recorded after notBefore
+	TouchAPIToken(ctx context.Context, id string, usedAt, notBefore time.Time) error
+}
+
+// APITokenService issues and authenticates API tokens.
+//
+// API tokens let scripts call the API without signing in as a user. A token acts as its
+// owner, with the owner's hierarchy level and teams, but only on the routes its scopes
+// allow; it never grants more than the owner's level permits. The secret is shown once at
+// creation and only its hash is stored.
+type APITokenService struct {
+	store    APITokenStore
+	userRepo user.Repository
+	now      func() time.Time
+}
+
+// NewAPITokenService creates a new API token service
+func NewAPITokenService(store APITokenStore, userRepo user.Repository) *APITokenService {
+	return &APITokenService{
+		store:    store,
+		userRepo: userRepo,
+		now:      time.Now,
+	}
+}
+
+// UseAPITokens makes VerifyAccessToken, and so the JWT middleware, also accept API tokens
+func (s *JWTService) UseAPITokens(tokens *APITokenService) {
+	s.apiTokens = tokens
+}
+
+// IsAPIToken reports whether a bearer credential is an API token rather than a JWT
+func IsAPIToken(tokenString string) bool {
+	return strings.HasPrefix(tokenString, APITokenPrefix)
+}
+
+// Issue creates a token from the Name, UserID, Kind, Scopes, CreatedBy and ExpiresAt of token,
+// filling in the rest, and returns the secret. It cannot be retrieved again.
+func (s *APITokenService) Issue(ctx context.Context, token *APIToken) (string, error) {
+	if len(token.Scopes) == 0 {
+		return "", fmt.Errorf("%w: at least one scope is required", ErrInvalidAPITokenScope)
+	}
+	for _, scope := range token.Scopes {
+		if !validAPITokenScope(scope) {
+			return "", fmt.Errorf("%w: %s", ErrInvalidAPITokenScope, scope)
+		}
+	}
+	now := s.now()
+	if token.ExpiresAt != nil && !token.ExpiresAt.After(now) {
+		return "", ErrInvalidAPITokenExpiry
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate API token: %w", err)
+	}
+	secret := APITokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	token.ID = uuid.New().String()
+	token.Prefix = secret[:len(APITokenPrefix)+6]
+	token.CreatedAt = now
+	token.LastUsedAt = nil
+	token.RevokedAt = nil
+
+	if err := s.store.CreateAPIToken(ctx, token, hashAPIToken(secret)); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// Get returns a token by ID
+func (s *APITokenService) Get(ctx context.Context, id string) (*APIToken, error) {
+	token, err := s.store.FindAPIToken(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, ErrAPITokenNotFound
+	}
+	return token, nil
+}
+
+// List returns the user's tokens, or every token when userID is empty
+func (s *APITokenService) List(ctx context.Context, userID string) ([]*APIToken, error) {
+	return s.store.ListAPITokens(ctx, userID)
+}
+
+// Revoke revokes a token. When ownerID is set, only that user's tokens can be revoked.
+// Revoking a revoked token succeeds.
+func (s *APITokenService) Revoke(ctx context.Context, id, ownerID string) error {
+	token, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ownerID != "" && token.UserID != ownerID {
+		return ErrAPITokenNotFound
+	}
+	_, err = s.store.RevokeAPIToken(ctx, id, s.now())
+	return err
+}
+
+// RevokeAllForUser revokes every token that acts as the user, personal or service
+func (s *APITokenService) RevokeAllForUser(ctx context.Context, userID string) error {
+	_, err := s.store.RevokeUserAPITokens(ctx, userID, s.now())
+	return err
+}
+
+// Authenticate returns the claims a request authenticated by the token acts with: its
+// owner's current identity, hierarchy level and teams, and the token's scopes. Tokens of
+// deactivated users are rejected.
+func (s *APITokenService) Authenticate(ctx context.Context, tokenString string) (*TokenClaims, error) {
+	token, err := s.store.FindAPITokenByHash(ctx, hashAPIToken(tokenString))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API token: %w", err)
+	}
+	if token == nil {
+		return nil, ErrInvalidToken
+	}
+	now := s.now()
+	if token.RevokedAt != nil {
+		return nil, ErrTokenRevoked
+	}
+	if token.ExpiresAt != nil && !token.ExpiresAt.After(now) {
+		return nil, ErrExpiredToken
+	}
+
+	usr, err := s.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if !usr.IsActive() {
+		return nil, ErrTokenRevoked
+	}
+
+	teamIDs, err := s.userRepo.FindTeamIDsForUser(ctx, usr.ID)
+	if err != nil {
+		return nil, err
+	}
+	leadTeamIDs, err := s.userRepo.FindTeamsWhereUserIsLead(ctx, usr.ID)
+	if err != nil {
+		return nil, err
+	}
+	teamIDs = mergeTeamIDs(teamIDs, leadTeamIDs)
+
+	if err := s.store.TouchAPIToken(ctx, token.ID, now, now.Add(-apiTokenLastUsedInterval)); err != nil {
+		logger.Get().WithContext(ctx).WithError(err).WithField("token_id", token.ID).Warn("failed to record API token use")
+	}
+
+	scopes := make([]string, len(token.Scopes))
+	for i, scope := range token.Scopes {
+		scopes[i] = string(scope)
+	}
+	claims := &TokenClaims{
+		UserID:         usr.ID,
+		Username:       usr.Username,
+		Email:          usr.Email,
+		HierarchyLevel: usr.HierarchyLevelID,
+		TeamIDs:        teamIDs,
+		TokenType:      APITokenType,
+		Scopes:         scopes,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       token.ID,
+			Subject:  usr.ID,
+			IssuedAt: jwt.NewNumericDate(token.CreatedAt),
+		},
+	}
+	if token.ExpiresAt != nil {
+		claims.ExpiresAt = jwt.NewNumericDate(*token.ExpiresAt)
+	}
+	return claims, nil
+}
+
+// HasScope reports whether claims of an API token carry the scope
+func (c *TokenClaims) HasScope(scope APITokenScope) bool {
+	for _, s := range c.Scopes {
+		if s == string(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func validAPITokenScope(scope APITokenScope) bool {
+	for _, s := range APITokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeTeamIDs returns the teams a user belongs to followed by the ones they lead but are not a member of
+func mergeTeamIDs(teamIDs, leadTeamIDs []string) []string {
+	merged := make([]string, 0, len(teamIDs)+len(leadTeamIDs))
+	seen := make(map[string]bool, len(teamIDs))
+	for _, id := range append(teamIDs, leadTeamIDs...) {
+		if !seen[id] {
+			seen[id] = true
+			merged = append(merged, id)
+		}
+	}
+	return merged
+}
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
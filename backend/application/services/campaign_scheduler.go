@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+)
+
+// CampaignRunResult summarizes a single scheduler pass
+type CampaignRunResult struct {
+	Opened []*campaign.Campaign `json:"opened"`
+	Closed []*campaign.Campaign `json:"closed"`
+}
+
+// CampaignScheduler opens survey campaigns for each team according to its cadence,
+// closes campaigns whose period has ended and keeps teams.next_check_date current.
+// Every operation is idempotent, so several replicas may run the scheduler concurrently.
+type CampaignScheduler struct {
+	campaignRepo campaign.Repository
+	teamRepo     team.Repository
+}
+
+// NewCampaignScheduler creates a new campaign scheduler
+func NewCampaignScheduler(campaignRepo campaign.Repository, teamRepo team.Repository) *CampaignScheduler {
+	return &CampaignScheduler{
+		campaignRepo: campaignRepo,
+		teamRepo:     teamRepo,
+	}
+}
+
+// Start runs the scheduler immediately and then on every interval until ctx is cancelled.
+// Errors are logged; a failed pass is retried on the next tick.
+func (s *CampaignScheduler) Start(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "campaign scheduler", func() error {
+		result, err := s.RunAt(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		if len(result.Opened) > 0 || len(result.Closed) > 0 {
+			logger.Get().WithFields(map[string]interface{}{
+				"opened": len(result.Opened),
+				"closed": len(result.Closed),
+			}).Info("campaign scheduler: run completed")
+		}
+		return nil
+	})
+}
+
+// RunAt performs a single scheduler pass as of the given time:
+// expired campaigns are closed first, then each team gets a campaign for its current period.
+func (s *CampaignScheduler) RunAt(ctx context.Context, at time.Time) (*CampaignRunResult, error) {
+	result := &CampaignRunResult{
+		Opened: []*campaign.Campaign{},
+		Closed: []*campaign.Campaign{},
+	}
+
+	closed, err := s.closeExpired(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	result.Closed = closed
+
+	teams, err := s.teamRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load teams: %w", err)
+	}
+
+	for _, tm := range teams {
+		c, created, err := s.openForTeam(ctx, tm, at)
+		if err != nil {
+			logger.Get().WithError(err).WithField("team_id", tm.ID).Warn("campaign scheduler: failed to open campaign")
+			continue
+		}
+		if created {
+			result.Opened = append(result.Opened, c)
+		}
+	}
+
+	return result, nil
+}
+
+// OpenCurrent opens (or returns the existing) campaign for the team's current period
+func (s *CampaignScheduler) OpenCurrent(ctx context.Context, teamID string) (*campaign.Campaign, bool, error) {
+	tm, err := s.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		return nil, false, err
+	}
+	return s.openForTeam(ctx, tm, time.Now())
+}
+
+// Close closes an open campaign ahead of its end date
+func (s *CampaignScheduler) Close(ctx context.Context, id string) (*campaign.Campaign, error) {
+	if err := s.campaignRepo.Close(ctx, id, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.campaignRepo.FindByID(ctx, id)
+}
+
+// openForTeam creates the campaign for the period containing at and advances next_check_date
+func (s *CampaignScheduler) openForTeam(ctx context.Context, tm *team.Team, at time.Time) (*campaign.Campaign, bool, error) {
+	period := campaign.PeriodFor(tm.Cadence, at)
+
+	c := &campaign.Campaign{
+		ID:               uuid.New().String(),
+		TeamID:           tm.ID,
+		TeamName:         tm.Name,
+		AssessmentPeriod: period.Label,
+		Cadence:          tm.Cadence,
+		Status:           campaign.StatusOpen,
+		StartDate:        period.StartDate,
+		EndDate:          period.EndDate,
+	}
+	if !campaign.IsValidCadence(c.Cadence) {
+		c.Cadence = campaign.CadenceMonthly
+	}
+
+	created, err := s.campaignRepo.Create(ctx, c)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := s.teamRepo.UpdateNextCheckDate(ctx, tm.ID, campaign.NextCheckDate(c.Cadence, at)); err != nil {
+		return nil, false, err
+	}
+
+	if !created {
+		existing, err := s.campaignRepo.FindByTeamAndPeriod(ctx, tm.ID, period.Label)
+		if err != nil {
+			return nil, false, err
+		}
+		return existing, false, nil
+	}
+
+	logger.Get().WithFields(map[string]interface{}{
+		"team_id":           tm.ID,
+		"assessment_period": c.AssessmentPeriod,
+	}).Info("campaign scheduler: campaign opened")
+
+	return c, true, nil
+}
+
+// closeExpired closes every open campaign whose end date is before at
+func (s *CampaignScheduler) closeExpired(ctx context.Context, at time.Time) ([]*campaign.Campaign, error) {
+	open, err := s.campaignRepo.FindAll(ctx, campaign.Filter{Status: campaign.StatusOpen})
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	closed := []*campaign.Campaign{}
+
+	for _, c := range open {
+		if !c.EndDate.Before(today) {
+			continue
+		}
+		if err := s.campaignRepo.Close(ctx, c.ID, at); err != nil {
+			// Another replica may have closed it first
+			logger.Get().WithError(err).WithField("campaign_id", c.ID).Debug("campaign scheduler: close skipped")
+			continue
+		}
+		c.Status = campaign.StatusClosed
+		closedAt := at
+		c.ClosedAt = &closedAt
+		closed = append(closed, c)
+	}
+
+	return closed, nil
+}
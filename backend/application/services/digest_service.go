@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/email"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+)
+
+// digestScoreDropThreshold is the minimum fall in overall health reported as a drop
+const digestScoreDropThreshold = 0.1
+
+// OverdueActionItem is an unfinished action item past its due date
+type OverdueActionItem struct {
+	ID           string
+	TeamID       string
+	TeamName     string
+	Title        string
+	Status       string
+	DueDate      time.Time
+	AssigneeName string
+}
+
+// DigestRepository defines the storage needed by the weekly digest
+type DigestRepository interface {
+	// FindOverdueActionItemsByManager returns overdue action items for the manager's supervised teams
+	FindOverdueActionItemsByManager(ctx context.Context, managerID string, asOf time.Time) ([]OverdueActionItem, error)
+	// Claim records the digest for a manager and week. Returns false if already claimed.
+	Claim(ctx context.Context, managerID string, weekStart time.Time) (bool, error)
+	// Release removes a claim so a failed send can be retried
+	Release(ctx context.Context, managerID string, weekStart time.Time) error
+}
+
+// DigestService sends each manager a weekly summary of their supervised teams:
+// new submissions, teams whose overall health dropped, and overdue action items.
+// It only runs when AppSettings.WeeklyDigest is enabled and email is configured.
+type DigestService struct {
+	sender          email.Sender // nil when email is not configured
+	healthCheckRepo healthcheck.Repository
+	teamRepo        team.Repository
+	userRepo        user.Repository
+	orgRepo         organization.Repository
+	digestRepo      DigestRepository
+}
+
+// NewDigestService creates a new weekly digest service.
+// sender may be nil (email disabled), in which case runs are no-ops.
+func NewDigestService(
+	sender email.Sender,
+	healthCheckRepo healthcheck.Repository,
+	teamRepo team.Repository,
+	userRepo user.Repository,
+	orgRepo organization.Repository,
+	digestRepo DigestRepository,
+) *DigestService {
+	return &DigestService{
+		sender:          sender,
+		healthCheckRepo: healthCheckRepo,
+		teamRepo:        teamRepo,
+		userRepo:        userRepo,
+		orgRepo:         orgRepo,
+		digestRepo:      digestRepo,
+	}
+}
+
+// Start checks for due digests immediately and then on every interval until ctx is cancelled.
+// Each manager receives at most one digest per ISO week.
+func (s *DigestService) Start(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "digest", func() error {
+		sent, err := s.RunAt(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		if sent > 0 {
+			logger.Get().WithField("sent", sent).Info("digest: run completed")
+		}
+		return nil
+	})
+}
+
+// RunAt sends the digest for the week containing at to every manager who has not yet received it.
+// Returns the number of digests sent.
+func (s *DigestService) RunAt(ctx context.Context, at time.Time) (int, error) {
+	log := logger.Get()
+
+	if s.sender == nil {
+		log.Debug("email not configured, skipping weekly digest")
+		return 0, nil
+	}
+
+	settings, err := s.orgRepo.GetAppSettings(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load app settings: %w", err)
+	}
+	if !settings.WeeklyDigest {
+		return 0, nil
+	}
+
+	teams, err := s.teamRepo.FindAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load teams: %w", err)
+	}
+
+	// Group teams by every manager in their supervisor chain
+	teamsByManager := make(map[string][]*team.Team)
+	managerIDs := []string{}
+	for _, tm := range teams {
+		for _, link := range tm.SupervisorChain {
+			if _, ok := teamsByManager[link.UserID]; !ok {
+				managerIDs = append(managerIDs, link.UserID)
+			}
+			teamsByManager[link.UserID] = append(teamsByManager[link.UserID], tm)
+		}
+	}
+	sort.Strings(managerIDs)
+
+	weekStart := startOfWeek(at)
+	sent := 0
+
+	for _, managerID := range managerIDs {
+		ok, err := s.sendDigest(ctx, managerID, teamsByManager[managerID], weekStart, at)
+		if err != nil {
+			log.WithError(err).WithField("manager_id", managerID).Warn("digest: failed to send digest")
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// BuildDigest assembles the digest content for a manager as of the given time
+func (s *DigestService) BuildDigest(ctx context.Context, managerID string, teams []*team.Team, at time.Time) (*email.WeeklyDigestEmailData, error) {
+	data := &email.WeeklyDigestEmailData{
+		WeekOf:         startOfWeek(at).Format("2006-01-02"),
+		NewSubmissions: []email.DigestTeamSubmissions{},
+		ScoreDrops:     []email.DigestScoreDrop{},
+		OverdueItems:   []email.DigestActionItem{},
+	}
+
+	// New submissions in the last 7 days
+	counts, err := s.healthCheckRepo.CountSubmissionsByManagerSince(ctx, managerID, at.AddDate(0, 0, -7))
+	if err != nil {
+		return nil, err
+	}
+	for _, tm := range teams {
+		if n := counts[tm.ID]; n > 0 {
+			data.NewSubmissions = append(data.NewSubmissions, email.DigestTeamSubmissions{TeamName: tm.Name, Count: n})
+		}
+	}
+
+	// Score drops between each team's latest period with data and the period before it
+	drops, err := s.findScoreDrops(ctx, managerID, teams, at)
+	if err != nil {
+		return nil, err
+	}
+	data.ScoreDrops = drops
+
+	// Overdue action items
+	items, err := s.digestRepo.FindOverdueActionItemsByManager(ctx, managerID, at)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		data.OverdueItems = append(data.OverdueItems, email.DigestActionItem{
+			TeamName:     item.TeamName,
+			Title:        item.Title,
+			DueDate:      item.DueDate.Format("2006-01-02"),
+			AssigneeName: item.AssigneeName,
+		})
+	}
+
+	return data, nil
+}
+
+// sendDigest builds, claims and sends one manager's digest. Returns false when nothing was sent.
+func (s *DigestService) sendDigest(ctx context.Context, managerID string, teams []*team.Team, weekStart, at time.Time) (bool, error) {
+	log := logger.Get()
+
+	manager, err := s.userRepo.FindByID(ctx, managerID)
+	if err != nil {
+		return false, err
+	}
+	if manager.Email == "" {
+		log.WithField("manager_id", managerID).Debug("digest: manager has no email, skipping")
+		return false, nil
+	}
+
+	data, err := s.BuildDigest(ctx, managerID, teams, at)
+	if err != nil {
+		return false, err
+	}
+	if len(data.NewSubmissions) == 0 && len(data.ScoreDrops) == 0 && len(data.OverdueItems) == 0 {
+		return false, nil
+	}
+	data.ManagerName = manager.Name
+
+	claimed, err := s.digestRepo.Claim(ctx, managerID, weekStart)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	htmlBody := email.RenderWeeklyDigestEmail(*data)
+	subject := "Teams360 — Weekly Digest (week of " + data.WeekOf + ")"
+
+	if err := s.sender.SendHTML(ctx, manager.Email, subject, htmlBody); err != nil {
+		if releaseErr := s.digestRepo.Release(ctx, managerID, weekStart); releaseErr != nil {
+			log.WithError(releaseErr).WithField("manager_id", managerID).Warn("digest: failed to release digest claim")
+		}
+		return false, err
+	}
+
+	log.WithField("to", manager.Email).Info("digest: weekly digest sent")
+	return true, nil
+}
+
+// findScoreDrops compares each team's overall health in its latest period with data against
+// the period before it, using FindTeamHealthByManager for each cadence-derived period.
+func (s *DigestService) findScoreDrops(ctx context.Context, managerID string, teams []*team.Team, at time.Time) ([]email.DigestScoreDrop, error) {
+	summaries := make(map[string]map[string]healthcheck.TeamHealthSummary)
+	healthFor := func(period string) (map[string]healthcheck.TeamHealthSummary, error) {
+		if byTeam, ok := summaries[period]; ok {
+			return byTeam, nil
+		}
+		results, err := s.healthCheckRepo.FindTeamHealthByManager(ctx, managerID, period, healthcheck.ScoringWeighted)
+		if err != nil {
+			return nil, err
+		}
+		byTeam := make(map[string]healthcheck.TeamHealthSummary, len(results))
+		for _, r := range results {
+			byTeam[r.TeamID] = r
+		}
+		summaries[period] = byTeam
+		return byTeam, nil
+	}
+
+	drops := []email.DigestScoreDrop{}
+	for _, tm := range teams {
+		current := campaign.PeriodFor(tm.Cadence, at)
+		previous := previousPeriod(tm.Cadence, current)
+
+		currentHealth, err := healthFor(current.Label)
+		if err != nil {
+			return nil, err
+		}
+		// Early in a period there may be no data yet; fall back one period
+		if currentHealth[tm.ID].SubmissionCount == 0 {
+			current, previous = previous, previousPeriod(tm.Cadence, previous)
+			if currentHealth, err = healthFor(current.Label); err != nil {
+				return nil, err
+			}
+		}
+
+		previousHealth, err := healthFor(previous.Label)
+		if err != nil {
+			return nil, err
+		}
+
+		cur, prev := currentHealth[tm.ID], previousHealth[tm.ID]
+		if cur.SubmissionCount == 0 || prev.SubmissionCount == 0 {
+			continue
+		}
+		if prev.OverallHealth-cur.OverallHealth >= digestScoreDropThreshold {
+			drops = append(drops, email.DigestScoreDrop{
+				TeamName:       tm.Name,
+				PreviousPeriod: previous.Label,
+				CurrentPeriod:  current.Label,
+				PreviousScore:  prev.OverallHealth,
+				CurrentScore:   cur.OverallHealth,
+			})
+		}
+	}
+
+	return drops, nil
+}
+
+// previousPeriod returns the cadence period immediately before p
+func previousPeriod(cadence string, p campaign.Period) campaign.Period {
+	return campaign.PeriodFor(cadence, p.StartDate.AddDate(0, 0, -1))
+}
+
+// startOfWeek returns the Monday (UTC midnight) of the ISO week containing t
+func startOfWeek(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7 // Monday = 0
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,608 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+)
+
+// Directory import columns; username, email, full_name and hierarchy_level are required
+const (
+	ImportColumnID             = "id"       // defaults to an ID derived from the username
+	ImportColumnUsername       = "username" // matches an existing user to update
+	ImportColumnEmail          = "email"
+	ImportColumnFullName       = "full_name"
+	ImportColumnHierarchyLevel = "hierarchy_level" // level ID or name
+	ImportColumnReportsTo      = "reports_to"      // user ID or username, in the file or already present
+	ImportColumnTeams          = "teams"           // team names or IDs separated by ';'; unknown teams are created
+	ImportColumnLeads          = "leads"           // teams this user leads, as in teams
+	ImportColumnAuthType       = "auth_type"       // local (default) or sso
+	ImportColumnPassword       = "password"        // required for new local users
+)
+
+// MaxImportRows bounds the size of one import
+const MaxImportRows = 5000
+
+var ErrInvalidImport = errors.New("invalid import file")
+
+var importColumns = []string{
+	ImportColumnID, ImportColumnUsername, ImportColumnEmail, ImportColumnFullName, ImportColumnHierarchyLevel,
+	ImportColumnReportsTo, ImportColumnTeams, ImportColumnLeads, ImportColumnAuthType, ImportColumnPassword,
+}
+
+var requiredImportColumns = []string{
+	ImportColumnUsername, ImportColumnEmail, ImportColumnFullName, ImportColumnHierarchyLevel,
+}
+
+// ImportRowError reports a problem with one row of an import. Line is the line in the file; the header is line 1.
+type ImportRowError struct {
+	Line    int
+	Column  string
+	Message string
+}
+
+// ImportUser is a user an import creates or updates
+type ImportUser struct {
+	ID               string
+	Username         string
+	Email            string
+	Name             string
+	HierarchyLevelID string
+	ReportsTo        *string       // nil keeps an existing user's manager
+	AuthType         user.AuthType // empty keeps an existing user's auth type
+	Password         string        // plain text, new local users only; hashed on apply
+	Exists           bool
+}
+
+// ImportTeam is a team an import creates, assigns a lead to, or adds members to
+type ImportTeam struct {
+	ID        string
+	Name      string
+	LeadID    string // empty keeps the current lead
+	MemberIDs []string
+	Exists    bool
+}
+
+// ImportPlan is everything a valid import writes
+type ImportPlan struct {
+	Users []*ImportUser
+	Teams []*ImportTeam
+}
+
+// ImportResult reports what an import did, or on a dry run or failed validation, what it would do
+type ImportResult struct {
+	Applied      bool
+	Rows         int
+	UsersCreated int
+	UsersUpdated int
+	TeamsCreated int
+	Memberships  int
+	Errors       []ImportRowError
+	Plan         *ImportPlan
+}
+
+// DirectoryImportRepository defines the storage needed by directory imports
+type DirectoryImportRepository interface {
+	// Apply writes the plan's users, reporting lines, teams, leads and memberships in one transaction.
+	// Existing memberships are kept.
+	Apply(ctx context.Context, plan *ImportPlan) error
+}
+
+// DirectoryImportService imports users, reporting lines and teams from CSV
+type DirectoryImportService struct {
+	userRepo    user.Repository
+	teamRepo    team.Repository
+	orgRepo     organization.Repository
+	importRepo  DirectoryImportRepository
+	permissions *PermissionService
+}
+
+// NewDirectoryImportService creates a new directory import service
+func NewDirectoryImportService(userRepo user.Repository, teamRepo team.Repository, orgRepo organization.Repository, importRepo DirectoryImportRepository, permissions *PermissionService) *DirectoryImportService {
+	return &DirectoryImportService{userRepo: userRepo, teamRepo: teamRepo, orgRepo: orgRepo, importRepo: importRepo, permissions: permissions}
+}
+
+// Validate checks the file and reports what an import would do, without writing anything.
+// A file that cannot be read as an import at all returns ErrInvalidImport; problems with
+// individual rows are reported in the result. Rows that give a user, or change a user who
+// already has, a hierarchy level with permissions the caller's level lacks are row errors.
+func (s *DirectoryImportService) Validate(ctx context.Context, callerLevelID string, r io.Reader) (*ImportResult, error) {
+	rows, err := parseImportFile(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.plan(ctx, callerLevelID, rows)
+}
+
+// Import validates the file and, if no row has errors, applies it in one transaction.
+// A file with errors is not applied at all.
+func (s *DirectoryImportService) Import(ctx context.Context, callerLevelID string, r io.Reader) (*ImportResult, error) {
+	result, err := s.Validate(ctx, callerLevelID, r)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	if err := s.importRepo.Apply(ctx, result.Plan); err != nil {
+		return nil, fmt.Errorf("failed to apply import: %w", err)
+	}
+	result.Applied = true
+	return result, nil
+}
+
+// importRow is one data row of the file, keyed by column
+type importRow struct {
+	line   int
+	fields map[string]string
+}
+
+func (r importRow) get(column string) string {
+	return strings.TrimSpace(r.fields[column])
+}
+
+// parseImportFile reads the header and data rows, skipping blank lines
+func parseImportFile(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	known := make(map[string]bool, len(importColumns))
+	for _, column := range importColumns {
+		known[column] = true
+	}
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, raw := range header {
+		column := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(raw, "\ufeff")))
+		if !known[column] {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidImport, raw)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidImport, column)
+		}
+		seen[column] = true
+		columns[i] = column
+	}
+	for _, column := range requiredImportColumns {
+		if !seen[column] {
+			return nil, fmt.Errorf("%w: missing required column %q", ErrInvalidImport, column)
+		}
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		if len(record) > len(columns) {
+			return nil, fmt.Errorf("%w: line %d has %d fields, the header has %d", ErrInvalidImport, line, len(record), len(columns))
+		}
+		blank := true
+		fields := make(map[string]string, len(columns))
+		for i, value := range record {
+			fields[columns[i]] = value
+			if strings.TrimSpace(value) != "" {
+				blank = false
+			}
+		}
+		if blank {
+			continue
+		}
+
+		rows = append(rows, importRow{line: line, fields: fields})
+		if len(rows) > MaxImportRows {
+			return nil, fmt.Errorf("%w: at most %d rows can be imported at once", ErrInvalidImport, MaxImportRows)
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: the file has no rows", ErrInvalidImport)
+	}
+	return rows, nil
+}
+
+// importPlanner resolves rows against each other and the existing directory
+type importPlanner struct {
+	result        *ImportResult
+	permissions   *PermissionService
+	callerLevelID string
+
+	levels          map[string]string // lower-cased level ID and name -> level ID
+	usersByID       map[string]*user.User
+	usersByUsername map[string]*user.User
+	usersByEmail    map[string]*user.User // lower-cased
+	teamsByID       map[string]*team.Team
+	teamsByName     map[string]*team.Team // lower-cased
+
+	fileUsersByID       map[string]*ImportUser
+	fileUsersByUsername map[string]*ImportUser
+	fileEmails          map[string]bool
+	teams               map[string]*ImportTeam
+	teamOrder           []string       // team IDs in the order the file first names them
+	leadLines           map[string]int // team ID -> line of the row that leads it
+}
+
+func (p *importPlanner) fail(row importRow, column, format string, args ...interface{}) {
+	p.result.Errors = append(p.result.Errors, ImportRowError{Line: row.line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+// plan validates every row and builds the plan and counts of a valid import
+func (s *DirectoryImportService) plan(ctx context.Context, callerLevelID string, rows []importRow) (*ImportResult, error) {
+	p, err := s.newImportPlanner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.permissions = s.permissions
+	p.callerLevelID = callerLevelID
+	p.result.Rows = len(rows)
+
+	// Users first, so reporting lines and teams can refer to any row
+	users := make([]*ImportUser, len(rows))
+	for i, row := range rows {
+		if users[i], err = p.planUser(ctx, row); err != nil {
+			return nil, err
+		}
+	}
+	for i, row := range rows {
+		if users[i] != nil {
+			p.planReportsTo(row, users[i])
+			p.planTeams(row, users[i])
+		}
+	}
+	p.checkReportingCycles(rows, users)
+	sort.SliceStable(p.result.Errors, func(i, j int) bool {
+		return p.result.Errors[i].Line < p.result.Errors[j].Line
+	})
+
+	plan := &ImportPlan{}
+	for _, u := range users {
+		if u == nil {
+			continue
+		}
+		plan.Users = append(plan.Users, u)
+		if u.Exists {
+			p.result.UsersUpdated++
+		} else {
+			p.result.UsersCreated++
+		}
+	}
+	for _, id := range p.teamOrder {
+		t := p.teams[id]
+		plan.Teams = append(plan.Teams, t)
+		if !t.Exists {
+			p.result.TeamsCreated++
+		}
+		p.result.Memberships += len(t.MemberIDs)
+	}
+	p.result.Plan = plan
+
+	return p.result, nil
+}
+
+func (s *DirectoryImportService) newImportPlanner(ctx context.Context) (*importPlanner, error) {
+	levels, err := s.orgRepo.FindHierarchyLevels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hierarchy levels: %w", err)
+	}
+	existingUsers, err := s.userRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load users: %w", err)
+	}
+	existingTeams, err := s.teamRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load teams: %w", err)
+	}
+
+	p := &importPlanner{
+		result:              &ImportResult{Errors: []ImportRowError{}},
+		levels:              make(map[string]string),
+		usersByID:           make(map[string]*user.User, len(existingUsers)),
+		usersByUsername:     make(map[string]*user.User, len(existingUsers)),
+		usersByEmail:        make(map[string]*user.User, len(existingUsers)),
+		teamsByID:           make(map[string]*team.Team, len(existingTeams)),
+		teamsByName:         make(map[string]*team.Team, len(existingTeams)),
+		fileUsersByID:       make(map[string]*ImportUser),
+		fileUsersByUsername: make(map[string]*ImportUser),
+		fileEmails:          make(map[string]bool),
+		teams:               make(map[string]*ImportTeam),
+		leadLines:           make(map[string]int),
+	}
+	for _, level := range levels {
+		p.levels[strings.ToLower(level.Name)] = level.ID
+	}
+	// IDs win over names when a name matches another level's ID
+	for _, level := range levels {
+		p.levels[strings.ToLower(level.ID)] = level.ID
+	}
+	for _, u := range existingUsers {
+		p.usersByID[u.ID] = u
+		p.usersByUsername[u.Username] = u
+		if u.Email != "" {
+			p.usersByEmail[strings.ToLower(u.Email)] = u
+		}
+	}
+	for _, t := range existingTeams {
+		p.teamsByID[t.ID] = t
+		p.teamsByName[strings.ToLower(t.Name)] = t
+	}
+	return p, nil
+}
+
+// planUser validates a row's own fields and matches it to an existing user by ID or username.
+// It returns nil if the row has no username to check against the rest of the file.
+func (p *importPlanner) planUser(ctx context.Context, row importRow) (*ImportUser, error) {
+	u := &ImportUser{
+		ID:       row.get(ImportColumnID),
+		Username: row.get(ImportColumnUsername),
+		Email:    row.get(ImportColumnEmail),
+		Name:     row.get(ImportColumnFullName),
+		Password: row.fields[ImportColumnPassword],
+	}
+
+	if u.Username == "" {
+		p.fail(row, ImportColumnUsername, "username is required")
+	}
+	if u.Name == "" {
+		p.fail(row, ImportColumnFullName, "full_name is required")
+	}
+	if u.Email == "" {
+		p.fail(row, ImportColumnEmail, "email is required")
+	} else if addr, err := mail.ParseAddress(u.Email); err != nil || addr.Address != u.Email {
+		p.fail(row, ImportColumnEmail, "%q is not a valid email address", u.Email)
+	}
+
+	if level := row.get(ImportColumnHierarchyLevel); level == "" {
+		p.fail(row, ImportColumnHierarchyLevel, "hierarchy_level is required")
+	} else if levelID, ok := p.levels[strings.ToLower(level)]; ok {
+		u.HierarchyLevelID = levelID
+	} else {
+		p.fail(row, ImportColumnHierarchyLevel, "unknown hierarchy level %q", level)
+	}
+
+	switch authType := strings.ToLower(row.get(ImportColumnAuthType)); authType {
+	case "":
+	case string(user.AuthTypeLocal), string(user.AuthTypeSSO):
+		u.AuthType = user.AuthType(authType)
+	default:
+		p.fail(row, ImportColumnAuthType, "auth_type must be 'local' or 'sso'")
+	}
+
+	if u.Username == "" {
+		return nil, nil
+	}
+
+	// Match an existing user by ID, then by username
+	var existing *user.User
+	if u.ID != "" {
+		existing = p.usersByID[u.ID]
+		if other := p.usersByUsername[u.Username]; other != nil && (existing == nil || other.ID != existing.ID) {
+			p.fail(row, ImportColumnUsername, "username %q belongs to user %q", u.Username, other.ID)
+		}
+	} else if existing = p.usersByUsername[u.Username]; existing != nil {
+		u.ID = existing.ID
+	} else if u.ID = user.IDFromUsername(u.Username); u.ID == "" {
+		p.fail(row, ImportColumnID, "an ID cannot be derived from username %q; provide one", u.Username)
+	} else if other := p.usersByID[u.ID]; other != nil {
+		p.fail(row, ImportColumnID, "ID %q derived from the username belongs to user %q; provide one", u.ID, other.Username)
+	}
+	u.Exists = existing != nil
+
+	// The caller may neither hand out a level above their own nor change a user who holds one
+	if u.HierarchyLevelID != "" {
+		if err := p.authorizeLevel(ctx, row, u.HierarchyLevelID, "hierarchy level %q has permissions you do not have", row.get(ImportColumnHierarchyLevel)); err != nil {
+			return nil, err
+		}
+	}
+	if existing != nil && existing.HierarchyLevelID != "" {
+		if err := p.authorizeLevel(ctx, row, existing.HierarchyLevelID, "user %q has a hierarchy level with permissions you do not have", existing.Username); err != nil {
+			return nil, err
+		}
+	}
+
+	if other := p.usersByEmail[strings.ToLower(u.Email)]; other != nil && (existing == nil || other.ID != existing.ID) {
+		p.fail(row, ImportColumnEmail, "email %q belongs to user %q", u.Email, other.Username)
+	}
+
+	if u.Exists {
+		if u.Password != "" {
+			p.fail(row, ImportColumnPassword, "passwords can only be set for new users")
+		}
+	} else {
+		if u.AuthType == "" {
+			u.AuthType = user.AuthTypeLocal
+		}
+		if u.AuthType == user.AuthTypeLocal && len(u.Password) < 4 {
+			p.fail(row, ImportColumnPassword, "password is required for local users (min 4 characters)")
+		}
+		if u.AuthType == user.AuthTypeSSO && u.Password != "" {
+			p.fail(row, ImportColumnPassword, "SSO users cannot have a password")
+		}
+	}
+
+	// Duplicates within the file
+	if u.ID != "" {
+		if p.fileUsersByID[u.ID] != nil {
+			p.fail(row, ImportColumnID, "user %q appears more than once", u.ID)
+		}
+		p.fileUsersByID[u.ID] = u
+	}
+	if p.fileUsersByUsername[u.Username] != nil {
+		p.fail(row, ImportColumnUsername, "username %q appears more than once", u.Username)
+	}
+	p.fileUsersByUsername[u.Username] = u
+	if email := strings.ToLower(u.Email); email != "" {
+		if p.fileEmails[email] {
+			p.fail(row, ImportColumnEmail, "email %q appears more than once", u.Email)
+		}
+		p.fileEmails[email] = true
+	}
+
+	return u, nil
+}
+
+// authorizeLevel reports the row when the level has permissions the caller's level lacks
+func (p *importPlanner) authorizeLevel(ctx context.Context, row importRow, levelID, format string, args ...interface{}) error {
+	err := p.permissions.AuthorizeLevel(ctx, p.callerLevelID, levelID)
+	if errors.Is(err, ErrPermissionDenied) {
+		p.fail(row, ImportColumnHierarchyLevel, format, args...)
+		return nil
+	}
+	return err
+}
+
+// planReportsTo resolves the row's manager. A blank reports_to keeps an existing user's manager.
+func (p *importPlanner) planReportsTo(row importRow, u *ImportUser) {
+	ref := row.get(ImportColumnReportsTo)
+	if ref == "" {
+		return
+	}
+
+	managerID := p.resolveUser(ref)
+	switch {
+	case managerID == "":
+		p.fail(row, ImportColumnReportsTo, "unknown user %q", ref)
+	case managerID == u.ID:
+		p.fail(row, ImportColumnReportsTo, "a user cannot report to themselves")
+	default:
+		u.ReportsTo = &managerID
+	}
+}
+
+// resolveUser finds a user ID by ID or username, in the file first
+func (p *importPlanner) resolveUser(ref string) string {
+	if u := p.fileUsersByID[ref]; u != nil {
+		return u.ID
+	}
+	if u := p.fileUsersByUsername[ref]; u != nil {
+		return u.ID
+	}
+	if u := p.usersByID[ref]; u != nil {
+		return u.ID
+	}
+	if u := p.usersByUsername[ref]; u != nil {
+		return u.ID
+	}
+	return ""
+}
+
+// planTeams adds the row's memberships and leads, creating teams that do not exist yet
+func (p *importPlanner) planTeams(row importRow, u *ImportUser) {
+	for _, ref := range splitImportList(row.get(ImportColumnTeams)) {
+		t := p.resolveTeam(row, ImportColumnTeams, ref)
+		if t != nil && (len(t.MemberIDs) == 0 || t.MemberIDs[len(t.MemberIDs)-1] != u.ID) {
+			t.MemberIDs = append(t.MemberIDs, u.ID)
+		}
+	}
+
+	for _, ref := range splitImportList(row.get(ImportColumnLeads)) {
+		t := p.resolveTeam(row, ImportColumnLeads, ref)
+		if t == nil {
+			continue
+		}
+		if line, ok := p.leadLines[t.ID]; ok {
+			p.fail(row, ImportColumnLeads, "team %q is already led by the user on line %d", t.Name, line)
+			continue
+		}
+		p.leadLines[t.ID] = row.line
+		t.LeadID = u.ID
+	}
+}
+
+// resolveTeam finds a team by ID or name, or plans a new one named ref
+func (p *importPlanner) resolveTeam(row importRow, column, ref string) *ImportTeam {
+	existing := p.teamsByID[ref]
+	if existing == nil {
+		existing = p.teamsByName[strings.ToLower(ref)]
+	}
+
+	id := team.IDFromName(ref)
+	name := ref
+	if existing != nil {
+		id, name = existing.ID, existing.Name
+	} else if id == "" {
+		p.fail(row, column, "an ID cannot be derived from team name %q", ref)
+		return nil
+	} else if other := p.teamsByID[id]; other != nil {
+		// A new name whose derived ID is taken refers to that team
+		existing = other
+		name = other.Name
+	}
+
+	t := p.teams[id]
+	if t == nil {
+		t = &ImportTeam{ID: id, Name: name, Exists: existing != nil}
+		p.teams[id] = t
+		p.teamOrder = append(p.teamOrder, id)
+	}
+	return t
+}
+
+// checkReportingCycles reports rows whose reporting line, after the import, would lead back to themselves
+func (p *importPlanner) checkReportingCycles(rows []importRow, users []*ImportUser) {
+	managers := make(map[string]string, len(p.usersByID))
+	for id, u := range p.usersByID {
+		if u.ReportsTo != nil {
+			managers[id] = *u.ReportsTo
+		}
+	}
+	for _, u := range users {
+		if u != nil && u.ReportsTo != nil {
+			managers[u.ID] = *u.ReportsTo
+		}
+	}
+
+	for i, u := range users {
+		if u == nil || u.ReportsTo == nil {
+			continue
+		}
+		visited := map[string]bool{u.ID: true}
+		for next := managers[u.ID]; next != ""; next = managers[next] {
+			if next == u.ID {
+				p.fail(rows[i], ImportColumnReportsTo, "reporting line of %q forms a cycle", u.Username)
+				break
+			}
+			if visited[next] {
+				break // a cycle above this user, reported on its own rows
+			}
+			visited[next] = true
+		}
+	}
+}
+
+// splitImportList splits a ';' separated list, dropping blanks and repeats
+func splitImportList(value string) []string {
+	var items []string
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item != "" && !seen[item] {
+			seen[item] = true
+			items = append(items, item)
+		}
+	}
+	return items
+}
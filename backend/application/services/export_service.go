@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/pkg/xlsx"
+)
+
+// Export scopes
+const (
+	ExportScopeTeam    = "team"    // one team
+	ExportScopeManager = "manager" // the teams a manager supervises
+	ExportScopeOrg     = "org"     // every team
+)
+
+// Export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ErrInvalidExport is returned for export filters or formats that are not supported
+var ErrInvalidExport = errors.New("invalid export request")
+
+// exportColumns are the header row of every export, one row per dimension response
+var exportColumns = []string{
+	"Team ID", "Team", "Assessment Period", "Survey Type", "Session ID", "User ID", "Date",
+	"Anonymous", "Dimension ID", "Dimension", "Score", "Trend", "Comment",
+}
+
+// ExportFilter selects the completed sessions to export
+type ExportFilter struct {
+	Scope            string
+	TeamID           string // required for the team scope
+	ManagerID        string // required for the manager scope
+	AssessmentPeriod string // optional
+	SurveyType       string // optional: individual or post_workshop
+}
+
+// ExportRow is one dimension response of a completed session
+type ExportRow struct {
+	SessionID        string
+	TeamID           string
+	TeamName         string
+	UserID           string
+	Date             string
+	AssessmentPeriod string
+	SurveyType       string
+	Anonymous        bool
+	DimensionID      string
+	DimensionName    string
+	Score            int
+	Trend            string
+	Comment          string
+}
+
+// ExportRepository defines the storage needed by exports
+type ExportRepository interface {
+	// StreamResults calls fn for every response of the completed sessions matching the filter,
+	// ordered by team, period and session, stopping at the first error fn returns. Anonymous
+	// sessions are left out for any team and period below the team's minimum respondent count.
+	StreamResults(ctx context.Context, filter ExportFilter, fn func(ExportRow) error) error
+}
+
+// ExportService streams health check results as CSV or XLSX
+type ExportService struct {
+	exportRepo ExportRepository
+}
+
+// NewExportService creates a new export service
+func NewExportService(exportRepo ExportRepository) *ExportService {
+	return &ExportService{exportRepo: exportRepo}
+}
+
+// Export validates the filter and streams the matching results to w in the given format.
+// Nothing is written to w until the first row is read, so a failure to start the export leaves w untouched.
+func (s *ExportService) Export(ctx context.Context, filter ExportFilter, format string, w io.Writer) error {
+	if err := validateExport(filter, format); err != nil {
+		return err
+	}
+
+	var out exportWriter
+	start := func() error {
+		var err error
+		if format == ExportFormatXLSX {
+			out, err = newXLSXExportWriter(w)
+		} else {
+			out = newCSVExportWriter(w)
+		}
+		if err != nil {
+			return err
+		}
+		return out.WriteHeader(exportColumns)
+	}
+
+	err := s.exportRepo.StreamResults(ctx, filter, func(row ExportRow) error {
+		if out == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return out.WriteRow(redactExportRow(row))
+	})
+	if err != nil {
+		return err
+	}
+
+	// An export with no results still has a header row
+	if out == nil {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// validateExport ensures the filter names what its scope needs and the format is supported
+func validateExport(filter ExportFilter, format string) error {
+	switch filter.Scope {
+	case ExportScopeTeam:
+		if filter.TeamID == "" {
+			return fmt.Errorf("%w: teamId is required", ErrInvalidExport)
+		}
+	case ExportScopeManager:
+		if filter.ManagerID == "" {
+			return fmt.Errorf("%w: managerId is required", ErrInvalidExport)
+		}
+	case ExportScopeOrg:
+	default:
+		return fmt.Errorf("%w: scope must be 'team', 'manager' or 'org'", ErrInvalidExport)
+	}
+
+	if format != ExportFormatCSV && format != ExportFormatXLSX {
+		return fmt.Errorf("%w: format must be 'csv' or 'xlsx'", ErrInvalidExport)
+	}
+
+	if filter.SurveyType != "" && filter.SurveyType != healthcheck.SurveyTypeIndividual && filter.SurveyType != healthcheck.SurveyTypePostWorkshop {
+		return fmt.Errorf("%w: surveyType must be 'individual' or 'post_workshop'", ErrInvalidExport)
+	}
+
+	return nil
+}
+
+// redactExportRow removes what could identify the respondent of an anonymous session
+func redactExportRow(row ExportRow) ExportRow {
+	if row.Anonymous {
+		row.UserID = ""
+		row.Comment = ""
+	}
+	return row
+}
+
+// exportWriter writes export rows in one format
+type exportWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(row ExportRow) error
+	Close() error
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func newCSVExportWriter(w io.Writer) *csvExportWriter {
+	return &csvExportWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvExportWriter) WriteHeader(columns []string) error {
+	return c.w.Write(columns)
+}
+
+func (c *csvExportWriter) WriteRow(row ExportRow) error {
+	return c.w.Write([]string{
+		csvSafe(row.TeamID), csvSafe(row.TeamName), row.AssessmentPeriod, row.SurveyType, row.SessionID,
+		csvSafe(row.UserID), row.Date, strconv.FormatBool(row.Anonymous), row.DimensionID, csvSafe(row.DimensionName),
+		strconv.Itoa(row.Score), row.Trend, csvSafe(row.Comment),
+	})
+}
+
+func (c *csvExportWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// csvSafe keeps free text from being evaluated as a formula when the file is opened in a spreadsheet
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+type xlsxExportWriter struct {
+	w *xlsx.Writer
+}
+
+func newXLSXExportWriter(w io.Writer) (*xlsxExportWriter, error) {
+	xw, err := xlsx.NewWriter(w, "Results")
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxExportWriter{w: xw}, nil
+}
+
+func (x *xlsxExportWriter) WriteHeader(columns []string) error {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+	return x.w.WriteRow(values...)
+}
+
+func (x *xlsxExportWriter) WriteRow(row ExportRow) error {
+	return x.w.WriteRow(
+		row.TeamID, row.TeamName, row.AssessmentPeriod, row.SurveyType, row.SessionID,
+		row.UserID, row.Date, strconv.FormatBool(row.Anonymous), row.DimensionID, row.DimensionName,
+		row.Score, row.Trend, row.Comment,
+	)
+}
+
+func (x *xlsxExportWriter) Close() error {
+	return x.w.Close()
+}
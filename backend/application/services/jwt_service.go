@@ -11,6 +11,7 @@ import (
 
 	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // JWTService handles JWT token generation and validation
@@ -19,6 +20,8 @@ type JWTService struct {
 	accessTokenExpiry  time.Duration
 	refreshTokenExpiry time.Duration
 	issuer             string
+	store              TokenStore       // nil disables server-side revocation
+	apiTokens          *APITokenService // nil when API tokens are not accepted
 }
 
 // TokenClaims represents the JWT claims structure
@@ -28,9 +31,23 @@ type TokenClaims struct {
 	Email          string   `json:"email"`
 	HierarchyLevel string   `json:"hierarchyLevel"`
 	TeamIDs        []string `json:"teamIds"`
+	TokenType      string   `json:"tokenType,omitempty"` // empty for access tokens
+	Scopes         []string `json:"scopes,omitempty"`    // set for API tokens only
+	IssuedAtMicro  int64    `json:"iatMicro,omitempty"`  // iat in microseconds, as iat has second precision
 	jwt.RegisteredClaims
 }
 
+// issuedAt returns when the token was issued, as precisely as the token records it
+func (c *TokenClaims) issuedAt() time.Time {
+	if c.IssuedAtMicro != 0 {
+		return time.UnixMicro(c.IssuedAtMicro)
+	}
+	if c.IssuedAt == nil {
+		return time.Time{}
+	}
+	return c.IssuedAt.Time
+}
+
 // RefreshTokenClaims represents refresh token claims (minimal info)
 type RefreshTokenClaims struct {
 	UserID    string `json:"userId"`
@@ -95,8 +112,22 @@ func NewJWTService() *JWTService {
 	}
 }
 
-// GenerateTokenPair creates both access and refresh tokens for a user
+// NewJWTServiceWithStore creates a JWT service that records refresh tokens and checks
+// revocations in store, enabling logout, refresh-token rotation and reuse detection
+func NewJWTServiceWithStore(store TokenStore) *JWTService {
+	s := NewJWTService()
+	s.store = store
+	return s
+}
+
+// GenerateTokenPair creates both access and refresh tokens for a user.
+// The refresh token starts a new rotation family.
 func (s *JWTService) GenerateTokenPair(ctx context.Context, userID, username, email, hierarchyLevel string, teamIDs []string) (*TokenPair, error) {
+	return s.issueTokenPair(ctx, uuid.New().String(), userID, username, email, hierarchyLevel, teamIDs)
+}
+
+// issueTokenPair signs a new access/refresh token pair and records the refresh token in familyID
+func (s *JWTService) issueTokenPair(ctx context.Context, familyID, userID, username, email, hierarchyLevel string, teamIDs []string) (*TokenPair, error) {
 	now := time.Now()
 
 	// Generate access token
@@ -106,12 +137,14 @@ func (s *JWTService) GenerateTokenPair(ctx context.Context, userID, username, em
 		Email:          email,
 		HierarchyLevel: hierarchyLevel,
 		TeamIDs:        teamIDs,
+		IssuedAtMicro:  now.UnixMicro(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTokenExpiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    s.issuer,
 			Subject:   userID,
+			ID:        uuid.New().String(),
 		},
 	}
 
@@ -131,6 +164,7 @@ func (s *JWTService) GenerateTokenPair(ctx context.Context, userID, username, em
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    s.issuer,
 			Subject:   userID,
+			ID:        uuid.New().String(),
 		},
 	}
 
@@ -140,6 +174,20 @@ func (s *JWTService) GenerateTokenPair(ctx context.Context, userID, username, em
 		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
 	}
 
+	// Record the refresh token so it can be rotated exactly once and revoked
+	if s.store != nil {
+		err = s.store.SaveRefreshToken(ctx, &RefreshTokenRecord{
+			JTI:       refreshTokenClaims.ID,
+			FamilyID:  familyID,
+			UserID:    userID,
+			IssuedAt:  now,
+			ExpiresAt: refreshTokenClaims.ExpiresAt.Time,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record refresh token: %w", err)
+		}
+	}
+
 	return &TokenPair{
 		AccessToken:  accessTokenString,
 		RefreshToken: refreshTokenString,
@@ -168,11 +216,25 @@ func (s *JWTService) ValidateAccessToken(tokenString string) (*TokenClaims, erro
 		return nil, ErrInvalidToken
 	}
 
+	// Refresh tokens and MFA challenge tokens are signed with the same key but grant no access
+	if claims.TokenType != "" {
+		return nil, ErrInvalidToken
+	}
+
 	return claims, nil
 }
 
 // ValidateRefreshToken validates a refresh token and returns the user ID
 func (s *JWTService) ValidateRefreshToken(tokenString string) (string, error) {
+	claims, err := s.parseRefreshToken(tokenString)
+	if err != nil {
+		return "", err
+	}
+	return claims.UserID, nil
+}
+
+// parseRefreshToken verifies a refresh token's signature, expiry and type and returns its claims
+func (s *JWTService) parseRefreshToken(tokenString string) (*RefreshTokenClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &RefreshTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -182,59 +244,20 @@ func (s *JWTService) ValidateRefreshToken(tokenString string) (string, error) {
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return "", ErrExpiredToken
+			return nil, ErrExpiredToken
 		}
-		return "", ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
 
 	claims, ok := token.Claims.(*RefreshTokenClaims)
 	if !ok || !token.Valid {
-		return "", ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
 
 	// Verify token type
 	if claims.TokenType != "refresh" {
-		return "", ErrInvalidTokenType
-	}
-
-	return claims.UserID, nil
-}
-
-// RefreshAccessToken generates a new access token using a valid refresh token
-func (s *JWTService) RefreshAccessToken(ctx context.Context, refreshTokenString string, userID, username, email, hierarchyLevel string, teamIDs []string) (string, error) {
-	// Validate refresh token
-	tokenUserID, err := s.ValidateRefreshToken(refreshTokenString)
-	if err != nil {
-		return "", err
+		return nil, ErrInvalidTokenType
 	}
 
-	// Verify user ID matches
-	if tokenUserID != userID {
-		return "", ErrInvalidToken
-	}
-
-	// Generate new access token
-	now := time.Now()
-	accessTokenClaims := TokenClaims{
-		UserID:         userID,
-		Username:       username,
-		Email:          email,
-		HierarchyLevel: hierarchyLevel,
-		TeamIDs:        teamIDs,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTokenExpiry)),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-			Issuer:    s.issuer,
-			Subject:   userID,
-		},
-	}
-
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims)
-	accessTokenString, err := accessToken.SignedString(s.secretKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign access token: %w", err)
-	}
-
-	return accessTokenString, nil
+	return claims, nil
 }
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// mfaChallengeTokenType marks the token a password login returns while MFA is pending
+const mfaChallengeTokenType = "mfa_challenge"
+
+// mfaChallengeExpiry is how long the user has to enter their code after the password step
+const mfaChallengeExpiry = 5 * time.Minute
+
+// GenerateMFAChallenge returns a short-lived token proving the user passed the password step.
+// It is exchanged for a token pair together with a valid MFA code and grants no other access.
+func (s *JWTService) GenerateMFAChallenge(userID string) (string, int64, error) {
+	now := time.Now()
+	claims := RefreshTokenClaims{
+		UserID:    userID,
+		TokenType: mfaChallengeTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(mfaChallengeExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    s.issuer,
+			Subject:   userID,
+			ID:        uuid.New().String(),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secretKey)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to sign MFA challenge token: %w", err)
+	}
+	return token, int64(mfaChallengeExpiry.Seconds()), nil
+}
+
+// ValidateMFAChallenge validates an MFA challenge token and returns the user ID
+func (s *JWTService) ValidateMFAChallenge(tokenString string) (string, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &RefreshTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.secretKey, nil
+	})
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return "", ErrExpiredToken
+		}
+		return "", ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*RefreshTokenClaims)
+	if !ok || !token.Valid {
+		return "", ErrInvalidToken
+	}
+	if claims.TokenType != mfaChallengeTokenType {
+		return "", ErrInvalidTokenType
+	}
+
+	return claims.UserID, nil
+}
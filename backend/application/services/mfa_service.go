@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/pkg/totp"
+)
+
+var (
+	// ErrInvalidMFACode is returned for a wrong, expired or already used TOTP or recovery code
+	ErrInvalidMFACode = errors.New("invalid MFA code")
+	// ErrMFAAlreadyEnabled is returned when enrolling a user whose MFA is already enabled
+	ErrMFAAlreadyEnabled = errors.New("MFA is already enabled")
+	// ErrMFANotEnabled is returned when a code is checked for a user without MFA
+	ErrMFANotEnabled = errors.New("MFA is not enabled")
+	// ErrMFANotEnrolling is returned when confirming an enrollment that was never started
+	ErrMFANotEnrolling = errors.New("MFA enrollment has not been started")
+	// ErrMFARequired is returned when disabling MFA the user's hierarchy level requires
+	ErrMFARequired = errors.New("MFA is required for this hierarchy level")
+	// ErrMFANotSupported is returned for SSO users, whose identity provider handles MFA
+	ErrMFANotSupported = errors.New("MFA is only available for local accounts")
+)
+
+// recoveryCodeCount is how many recovery codes a user gets
+const recoveryCodeCount = 10
+
+// UserMFA is a user's TOTP enrollment
+type UserMFA struct {
+	Secret  string
+	Enabled bool // false while enrollment is pending confirmation
+}
+
+// MFAStatus describes a user's MFA state
+type MFAStatus struct {
+	Enabled                bool
+	Required               bool // by the user's hierarchy level
+	RecoveryCodesRemaining int
+}
+
+// MFAEnrollment is what an authenticator app needs to be set up
+type MFAEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// MFAStore persists TOTP enrollments and recovery codes
+type MFAStore interface {
+	// GetMFA returns the user's enrollment, or nil when they have none
+	GetMFA(ctx context.Context, userID string) (*UserMFA, error)
+	// SavePendingSecret starts or restarts an enrollment. Returns false if MFA is already enabled.
+	SavePendingSecret(ctx context.Context, userID, secret string) (bool, error)
+	// EnableMFA confirms a pending enrollment, recording the step of the confirming code, and
+	// replaces the recovery codes. Returns false if there was no pending enrollment.
+	EnableMFA(ctx context.Context, userID string, step int64, recoveryCodeHashes []string, now time.Time) (bool, error)
+	// UseStep records an accepted code's step. Returns false if that step or a later one was
+	// already used.
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+	// UseRecoveryCode marks an unused recovery code as used. Returns false if there was none.
+	UseRecoveryCode(ctx context.Context, userID, codeHash string, now time.Time) (bool, error)
+	// ReplaceRecoveryCodes discards the user's recovery codes and stores new ones
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+	// CountRecoveryCodes returns how many unused recovery codes the user has
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+	// DeleteMFA removes the user's enrollment and recovery codes. Returns false if there was none.
+	DeleteMFA(ctx context.Context, userID string) (bool, error)
+}
+
+// MFAService manages TOTP multi-factor authentication for local accounts.
+//
+// Users enroll by adding the secret to an authenticator app (from the provisioning URI, as a
+// QR code) and confirming with a first code, which also issues their recovery codes. From
+// then on a password login returns an MFA challenge token instead of a token pair, and the
+// token pair is only issued for the challenge plus a valid code. Hierarchy levels with
+// RequireMFA make MFA mandatory: their users cannot disable it, and users who have not
+// enrolled yet must do so to finish signing in.
+type MFAService struct {
+	store   MFAStore
+	orgRepo organization.Repository
+	issuer  string // shown as the account's issuer in authenticator apps
+	now     func() time.Time
+}
+
+// NewMFAService creates a new MFA service
+func NewMFAService(store MFAStore, orgRepo organization.Repository, issuer string) *MFAService {
+	return &MFAService{
+		store:   store,
+		orgRepo: orgRepo,
+		issuer:  issuer,
+		now:     time.Now,
+	}
+}
+
+// Required reports whether the user's hierarchy level requires MFA
+func (s *MFAService) Required(ctx context.Context, usr *user.User) (bool, error) {
+	if usr.AuthType == user.AuthTypeSSO || usr.HierarchyLevelID == "" {
+		return false, nil
+	}
+	level, err := s.orgRepo.FindHierarchyLevelByID(ctx, usr.HierarchyLevelID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return false, nil
+		}
+		return false, err
+	}
+	return level.RequireMFA, nil
+}
+
+// Status returns the user's MFA state
+func (s *MFAService) Status(ctx context.Context, usr *user.User) (*MFAStatus, error) {
+	required, err := s.Required(ctx, usr)
+	if err != nil {
+		return nil, err
+	}
+	status := &MFAStatus{Required: required}
+
+	mfa, err := s.store.GetMFA(ctx, usr.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa != nil && mfa.Enabled {
+		status.Enabled = true
+		if status.RecoveryCodesRemaining, err = s.store.CountRecoveryCodes(ctx, usr.ID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// Enabled reports whether the user has confirmed an MFA enrollment
+func (s *MFAService) Enabled(ctx context.Context, userID string) (bool, error) {
+	mfa, err := s.store.GetMFA(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return mfa != nil && mfa.Enabled, nil
+}
+
+// BeginEnrollment generates a new secret for the user. It takes effect once confirmed with
+// ConfirmEnrollment; starting again replaces an unconfirmed secret.
+func (s *MFAService) BeginEnrollment(ctx context.Context, usr *user.User) (*MFAEnrollment, error) {
+	if usr.AuthType == user.AuthTypeSSO {
+		return nil, ErrMFANotSupported
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.store.SavePendingSecret(ctx, usr.ID, secret)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	return &MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, s.issuer, usr.Username),
+	}, nil
+}
+
+// ConfirmEnrollment enables MFA once the user proves their authenticator produces valid codes,
+// and returns their recovery codes. They are shown to the user once and only stored hashed.
+func (s *MFAService) ConfirmEnrollment(ctx context.Context, userID, code string) ([]string, error) {
+	mfa, err := s.store.GetMFA(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, ErrMFANotEnrolling
+	}
+	if mfa.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(mfa.Secret, code, s.now())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := s.store.EnableMFA(ctx, userID, step, hashes, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, ErrMFANotEnrolling
+	}
+	return codes, nil
+}
+
+// Verify checks a TOTP code, or else a recovery code, for a user with MFA enabled.
+// Each code is accepted once. Returns whether a recovery code was used.
+func (s *MFAService) Verify(ctx context.Context, userID, code string) (bool, error) {
+	mfa, err := s.store.GetMFA(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if mfa == nil || !mfa.Enabled {
+		return false, ErrMFANotEnabled
+	}
+
+	if step, ok := totp.Validate(mfa.Secret, code, s.now()); ok {
+		used, err := s.store.UseStep(ctx, userID, step)
+		if err != nil {
+			return false, err
+		}
+		if !used {
+			return false, ErrInvalidMFACode
+		}
+		return false, nil
+	}
+
+	used, err := s.store.UseRecoveryCode(ctx, userID, hashRecoveryCode(code), s.now())
+	if err != nil {
+		return false, err
+	}
+	if !used {
+		return false, ErrInvalidMFACode
+	}
+	return true, nil
+}
+
+// Disable turns MFA off after checking a current code. Users whose level requires MFA cannot.
+func (s *MFAService) Disable(ctx context.Context, usr *user.User, code string) error {
+	required, err := s.Required(ctx, usr)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrMFARequired
+	}
+	if _, err := s.Verify(ctx, usr.ID, code); err != nil {
+		return err
+	}
+	_, err = s.store.DeleteMFA(ctx, usr.ID)
+	return err
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking a current code
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	if _, err := s.Verify(ctx, userID, code); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Reset removes a user's MFA, for users who lost their authenticator and recovery codes.
+// Returns false if the user had no MFA.
+func (s *MFAService) Reset(ctx context.Context, userID string) (bool, error) {
+	return s.store.DeleteMFA(ctx, userID)
+}
+
+// recoveryCodeAlphabet leaves out characters that are easily confused
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// generateRecoveryCodes returns new recovery codes (xxxxx-xxxxx) and their hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+		codes[i] = string(b[:5]) + "-" + string(b[5:])
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode hashes a recovery code, ignoring case, spaces and dashes as typed
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
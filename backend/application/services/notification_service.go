@@ -2,36 +2,41 @@ package services
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
 	"github.com/agopalakrishnan/teams360/backend/domain/organization"
 	"github.com/agopalakrishnan/teams360/backend/domain/team"
 	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/chat"
 	"github.com/agopalakrishnan/teams360/backend/infrastructure/email"
 	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
 )
 
-// NotificationService orchestrates email notifications for survey submissions.
+// NotificationService orchestrates email and chat notifications for survey submissions.
 type NotificationService struct {
-	sender   email.Sender // nil when email is not configured
-	teamRepo team.Repository
-	userRepo user.Repository
-	orgRepo  organization.Repository
+	sender       email.Sender            // nil when email is not configured
+	chatChannels map[string]chat.Channel // keyed by team chat_webhook_format
+	teamRepo     team.Repository
+	userRepo     user.Repository
+	orgRepo      organization.Repository
 }
 
 // NewNotificationService creates a new notification service.
-// sender may be nil (email disabled).
+// sender may be nil (email disabled); chatChannels may be nil (chat disabled).
 func NewNotificationService(
 	sender email.Sender,
+	chatChannels map[string]chat.Channel,
 	teamRepo team.Repository,
 	userRepo user.Repository,
 	orgRepo organization.Repository,
 ) *NotificationService {
 	return &NotificationService{
-		sender:   sender,
-		teamRepo: teamRepo,
-		userRepo: userRepo,
-		orgRepo:  orgRepo,
+		sender:       sender,
+		chatChannels: chatChannels,
+		teamRepo:     teamRepo,
+		userRepo:     userRepo,
+		orgRepo:      orgRepo,
 	}
 }
 
@@ -141,21 +146,137 @@ func (n *NotificationService) sendTeamSummaryEmail(ctx context.Context, session
 	}
 }
 
+// SendChatNotification posts a submission event to the team's chat webhook.
+// Post-workshop surveys include the dimension scores; individual submissions only
+// announce that a response came in, without naming the member or showing scores.
+// Only sent when AppSettings.SlackNotifications is enabled and the team has a webhook URL.
+// Errors are logged but never returned (fire-and-forget).
+func (n *NotificationService) SendChatNotification(ctx context.Context, session *healthcheck.HealthCheckSession) {
+	log := logger.Get()
+
+	if len(n.chatChannels) == 0 {
+		log.Debug("chat not configured, skipping chat notification")
+		return
+	}
+
+	tm, err := n.teamRepo.FindByID(ctx, session.TeamID)
+	if err != nil {
+		log.WithField("team_id", session.TeamID).Warn("notification: failed to find team for chat notification")
+		return
+	}
+	if tm.ChatWebhookURL == nil || *tm.ChatWebhookURL == "" {
+		return
+	}
+
+	settings, err := n.orgRepo.GetAppSettings(ctx)
+	if err != nil {
+		log.WithError(err).Warn("notification: failed to load app settings for chat notification")
+		return
+	}
+	if !settings.SlackNotifications {
+		return
+	}
+
+	channel, ok := n.chatChannels[tm.ChatWebhookFormat]
+	if !ok {
+		channel, ok = n.chatChannels[chat.FormatSlack]
+		if !ok {
+			log.WithField("format", tm.ChatWebhookFormat).Warn("notification: no chat channel for webhook format")
+			return
+		}
+	}
+
+	var msg chat.Message
+	if session.SurveyType == "post_workshop" {
+		msg = n.buildTeamSummaryMessage(ctx, session, tm)
+	} else {
+		msg = chat.Message{
+			Title: "New health check submission — " + tm.Name,
+			Text:  "A team member submitted their health check for " + session.AssessmentPeriod + ".",
+			Color: "6366F1",
+		}
+	}
+
+	if err := channel.Send(ctx, *tm.ChatWebhookURL, msg); err != nil {
+		log.WithError(err).WithField("team_id", tm.ID).Warn("notification: failed to send chat notification")
+	} else {
+		log.WithField("team_id", tm.ID).Info("notification: chat notification sent")
+	}
+}
+
+// buildTeamSummaryMessage builds the post-workshop chat summary with one field per dimension.
+// The overall health counts each response by its dimension's weight, like the dashboards.
+func (n *NotificationService) buildTeamSummaryMessage(ctx context.Context, session *healthcheck.HealthCheckSession, tm *team.Team) chat.Message {
+	submittedBy := session.UserID
+	if usr, err := n.userRepo.FindByID(ctx, session.UserID); err == nil {
+		submittedBy = usr.Name
+	}
+
+	dims := n.loadDimensions(ctx)
+	dimensions := dimensionResults(session.Responses, dims)
+	fields := make([]chat.Field, len(dimensions))
+	for i, d := range dimensions {
+		value := fmt.Sprintf("%s %d/3", email.ScoreToLabel(d.Score), d.Score)
+		if d.Trend != "" {
+			value += " (" + d.Trend + ")"
+		}
+		fields[i] = chat.Field{Name: d.Name, Value: value}
+	}
+
+	weightedSum, weightTotal := 0.0, 0.0
+	for _, r := range session.Responses {
+		weight := 1.0
+		if dim, ok := dims[r.DimensionID]; ok {
+			weight = dim.Weight
+		}
+		weightedSum += float64(r.Score) * weight
+		weightTotal += weight
+	}
+
+	text := "Submitted by " + submittedBy
+	color := "10B981"
+	if weightTotal > 0 {
+		avg := weightedSum / weightTotal
+		text += fmt.Sprintf(" · overall health %.2f", avg)
+		switch {
+		case avg < 1.5:
+			color = "EF4444"
+		case avg < 2.5:
+			color = "F59E0B"
+		}
+	}
+
+	return chat.Message{
+		Title:  "Post-Workshop Summary — " + tm.Name + " (" + session.AssessmentPeriod + ")",
+		Text:   text,
+		Fields: fields,
+		Color:  color,
+	}
+}
+
 // buildDimensionResults maps session responses to DimensionResult with resolved names.
 func (n *NotificationService) buildDimensionResults(ctx context.Context, responses []healthcheck.HealthCheckResponse) []email.DimensionResult {
-	// Load dimension names
-	dimMap := make(map[string]string)
+	return dimensionResults(responses, n.loadDimensions(ctx))
+}
+
+// loadDimensions returns the health dimensions by ID; empty when they cannot be loaded
+func (n *NotificationService) loadDimensions(ctx context.Context) map[string]*organization.HealthDimension {
+	dimMap := make(map[string]*organization.HealthDimension)
 	if dims, err := n.orgRepo.FindDimensions(ctx); err == nil {
 		for _, d := range dims {
-			dimMap[d.ID] = d.Name
+			dimMap[d.ID] = d
 		}
 	}
+	return dimMap
+}
 
+// dimensionResults maps responses to DimensionResult, named after their dimension when known
+func dimensionResults(responses []healthcheck.HealthCheckResponse, dims map[string]*organization.HealthDimension) []email.DimensionResult {
 	results := make([]email.DimensionResult, len(responses))
 	for i, r := range responses {
 		name := r.DimensionID
-		if n, ok := dimMap[r.DimensionID]; ok {
-			name = n
+		if d, ok := dims[r.DimensionID]; ok {
+			name = d.Name
 		}
 		results[i] = email.DimensionResult{
 			Name:    name,
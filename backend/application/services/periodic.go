@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+)
+
+// runPeriodically runs fn in the background immediately and then on every interval until
+// ctx is cancelled. A failed run is logged under name and retried on the next tick.
+func runPeriodically(ctx context.Context, interval time.Duration, name string, fn func() error) {
+	run := func() {
+		if err := fn(); err != nil {
+			logger.Get().WithError(err).Warn(name + ": run failed")
+		}
+	}
+
+	go func() {
+		run()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+)
+
+// ErrPermissionDenied is returned when a hierarchy level lacks a required permission
+var ErrPermissionDenied = errors.New("permission denied")
+
+// permissionCacheTTL bounds how long a level's permissions are cached. Edits made through this
+// instance invalidate the cache at once; the TTL is how long other API replicas take to see them.
+const permissionCacheTTL = 30 * time.Second
+
+// cachedPermissions is a hierarchy level's permissions as last loaded
+type cachedPermissions struct {
+	permissions organization.Permissions
+	expiresAt   time.Time
+}
+
+// PermissionService resolves what a caller may do from the permissions of their hierarchy level
+type PermissionService struct {
+	orgRepo organization.Repository
+
+	mu    sync.RWMutex
+	cache map[string]cachedPermissions // by hierarchy level ID
+
+	now func() time.Time
+}
+
+// NewPermissionService creates a new permission service
+func NewPermissionService(orgRepo organization.Repository) *PermissionService {
+	return &PermissionService{
+		orgRepo: orgRepo,
+		cache:   make(map[string]cachedPermissions),
+		now:     time.Now,
+	}
+}
+
+// Resolve returns the permissions of a hierarchy level. A level that does not exist has none.
+func (s *PermissionService) Resolve(ctx context.Context, hierarchyLevelID string) (organization.Permissions, error) {
+	now := s.now()
+
+	s.mu.RLock()
+	cached, found := s.cache[hierarchyLevelID]
+	s.mu.RUnlock()
+	if found && now.Before(cached.expiresAt) {
+		return cached.permissions, nil
+	}
+
+	var permissions organization.Permissions
+	level, err := s.orgRepo.FindHierarchyLevelByID(ctx, hierarchyLevelID)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			return organization.Permissions{}, fmt.Errorf("failed to load hierarchy level permissions: %w", err)
+		}
+	} else {
+		permissions = level.Permissions
+	}
+
+	s.mu.Lock()
+	s.cache[hierarchyLevelID] = cachedPermissions{permissions: permissions, expiresAt: now.Add(permissionCacheTTL)}
+	s.mu.Unlock()
+	return permissions, nil
+}
+
+// Authorize returns ErrPermissionDenied unless the hierarchy level has every required permission
+func (s *PermissionService) Authorize(ctx context.Context, hierarchyLevelID string, required ...organization.Permission) error {
+	permissions, err := s.Resolve(ctx, hierarchyLevelID)
+	if err != nil {
+		return err
+	}
+	for _, permission := range required {
+		if !permissions.Has(permission) {
+			return fmt.Errorf("%w: %s required", ErrPermissionDenied, permission)
+		}
+	}
+	return nil
+}
+
+// AuthorizeLevel returns ErrPermissionDenied unless the caller's hierarchy level has every permission
+// of another level, so callers cannot hand out or take over access they do not have themselves.
+// CanTakeSurvey grants no access and is not compared.
+func (s *PermissionService) AuthorizeLevel(ctx context.Context, callerLevelID string, levelID string) error {
+	permissions, err := s.Resolve(ctx, levelID)
+	if err != nil {
+		return err
+	}
+	permissions.CanTakeSurvey = false
+	return s.Authorize(ctx, callerLevelID, permissions.Granted()...)
+}
+
+// Invalidate drops a hierarchy level's cached permissions after it was edited
+func (s *PermissionService) Invalidate(hierarchyLevelID string) {
+	s.mu.Lock()
+	delete(s.cache, hierarchyLevelID)
+	s.mu.Unlock()
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/email"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+)
+
+// SurveyReminder records a reminder sent to a user for a campaign at a given offset
+type SurveyReminder struct {
+	ID         string
+	CampaignID string
+	UserID     string
+	OffsetDays int
+	SentAt     time.Time
+}
+
+// ReminderRepository defines the interface for reminder bookkeeping
+type ReminderRepository interface {
+	// Claim records a reminder before it is sent. Returns false if the same
+	// (campaign, user, offset) was already claimed, e.g. by another replica.
+	Claim(ctx context.Context, reminder *SurveyReminder) (bool, error)
+	// Release removes a claim so a failed send can be retried on the next run
+	Release(ctx context.Context, reminderID string) error
+}
+
+// ReminderService emails team members who have not yet submitted the survey for an open campaign.
+// A reminder is sent when the days left in the campaign reach one of the configured offsets
+// (AppSettings.ReminderOffsets); members who have submitted are skipped automatically.
+type ReminderService struct {
+	sender          email.Sender // nil when email is not configured
+	campaignRepo    campaign.Repository
+	healthCheckRepo healthcheck.Repository
+	userRepo        user.Repository
+	orgRepo         organization.Repository
+	reminderRepo    ReminderRepository
+}
+
+// NewReminderService creates a new reminder service.
+// sender may be nil (email disabled), in which case runs are no-ops.
+func NewReminderService(
+	sender email.Sender,
+	campaignRepo campaign.Repository,
+	healthCheckRepo healthcheck.Repository,
+	userRepo user.Repository,
+	orgRepo organization.Repository,
+	reminderRepo ReminderRepository,
+) *ReminderService {
+	return &ReminderService{
+		sender:          sender,
+		campaignRepo:    campaignRepo,
+		healthCheckRepo: healthCheckRepo,
+		userRepo:        userRepo,
+		orgRepo:         orgRepo,
+		reminderRepo:    reminderRepo,
+	}
+}
+
+// Start runs the reminder job immediately and then on every interval until ctx is cancelled.
+func (s *ReminderService) Start(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "reminders", func() error {
+		sent, err := s.RunAt(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		if sent > 0 {
+			logger.Get().WithField("sent", sent).Info("reminders: run completed")
+		}
+		return nil
+	})
+}
+
+// RunAt sends any reminders due as of the given time and returns how many were sent.
+// Reminders are only sent when email is configured and email notifications are enabled.
+func (s *ReminderService) RunAt(ctx context.Context, at time.Time) (int, error) {
+	log := logger.Get()
+
+	if s.sender == nil {
+		log.Debug("email not configured, skipping reminders")
+		return 0, nil
+	}
+
+	settings, err := s.orgRepo.GetAppSettings(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load app settings: %w", err)
+	}
+	if !settings.EmailNotifications || len(settings.ReminderOffsets) == 0 {
+		return 0, nil
+	}
+
+	campaigns, err := s.campaignRepo.FindAll(ctx, campaign.Filter{Status: campaign.StatusOpen})
+	if err != nil {
+		return 0, err
+	}
+
+	today := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	sent := 0
+
+	for _, c := range campaigns {
+		daysLeft := int(c.EndDate.Sub(today).Hours() / 24)
+		offset, due := dueReminderOffset(settings.ReminderOffsets, daysLeft)
+		if !due {
+			continue
+		}
+
+		n, err := s.remindCampaign(ctx, c, offset, daysLeft, at)
+		if err != nil {
+			log.WithError(err).WithField("campaign_id", c.ID).Warn("reminders: failed to process campaign")
+			continue
+		}
+		sent += n
+	}
+
+	return sent, nil
+}
+
+// remindCampaign sends the reminder for one offset to every non-submitter of a campaign
+func (s *ReminderService) remindCampaign(ctx context.Context, c *campaign.Campaign, offset, daysLeft int, at time.Time) (int, error) {
+	log := logger.Get()
+
+	status, err := s.healthCheckRepo.GetTeamSubmissionStatus(ctx, c.TeamID, c.AssessmentPeriod)
+	if err != nil {
+		return 0, err
+	}
+	if status.AllSubmitted || status.TotalMembers == 0 {
+		return 0, nil
+	}
+
+	userIDs, err := s.healthCheckRepo.FindNonSubmitters(ctx, c.TeamID, c.AssessmentPeriod)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, userID := range userIDs {
+		usr, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil || usr.Email == "" {
+			log.WithField("user_id", userID).Debug("reminders: user has no email, skipping")
+			continue
+		}
+
+		reminder := &SurveyReminder{
+			ID:         uuid.New().String(),
+			CampaignID: c.ID,
+			UserID:     userID,
+			OffsetDays: offset,
+			SentAt:     at,
+		}
+		claimed, err := s.reminderRepo.Claim(ctx, reminder)
+		if err != nil {
+			return sent, err
+		}
+		if !claimed {
+			continue
+		}
+
+		htmlBody := email.RenderSurveyReminderEmail(email.SurveyReminderEmailData{
+			UserName:         usr.Name,
+			TeamName:         c.TeamName,
+			AssessmentPeriod: c.AssessmentPeriod,
+			DueDate:          c.EndDate.Format("2006-01-02"),
+			DaysLeft:         daysLeft,
+		})
+		subject := "Teams360 — Reminder: Health Check for " + c.TeamName + " (" + c.AssessmentPeriod + ")"
+
+		if err := s.sender.SendHTML(ctx, usr.Email, subject, htmlBody); err != nil {
+			log.WithError(err).WithField("to", usr.Email).Warn("reminders: failed to send reminder email")
+			if err := s.reminderRepo.Release(ctx, reminder.ID); err != nil {
+				log.WithError(err).WithField("reminder_id", reminder.ID).Warn("reminders: failed to release reminder claim")
+			}
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// dueReminderOffset picks the reminder stage for the days left in a campaign: the smallest
+// configured offset that has been reached. Earlier, larger offsets that were missed (e.g.
+// because the campaign opened late) are skipped so members get one reminder per stage.
+func dueReminderOffset(offsets []int, daysLeft int) (int, bool) {
+	if daysLeft < 0 {
+		return 0, false
+	}
+
+	sorted := append([]int(nil), offsets...)
+	sort.Ints(sorted)
+
+	for _, offset := range sorted {
+		if offset >= daysLeft {
+			return offset, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// Retention actions recorded in the audit log
+const (
+	RetentionActionArchive = "archived"
+	RetentionActionPurge   = "purged"
+)
+
+// RetentionTriggeredBySystem identifies scheduled runs in the audit log
+const RetentionTriggeredBySystem = "system"
+
+// RetentionCount is the number of sessions past the retention window for one team and period
+type RetentionCount struct {
+	TeamID           string
+	TeamName         string
+	AssessmentPeriod string
+	Sessions         int
+	Responses        int
+	LegalHold        bool
+}
+
+// RetentionAuditEntry records what a retention run removed for one team and period
+type RetentionAuditEntry struct {
+	ID               int64
+	RunID            string
+	Action           string
+	TeamID           string
+	TeamName         string
+	AssessmentPeriod string
+	Sessions         int
+	Responses        int
+	CutoffDate       time.Time
+	TriggeredBy      string
+	ExecutedAt       time.Time
+}
+
+// RetentionRepository defines the storage needed by the retention worker
+type RetentionRepository interface {
+	// CountExpired returns per team/period counts of sessions dated before cutoff, including teams on legal hold
+	CountExpired(ctx context.Context, cutoff time.Time) ([]RetentionCount, error)
+	// RemoveExpired archives (or deletes when archive is false) sessions dated before cutoff for teams
+	// not on legal hold, and writes one audit entry per team/period in the same transaction
+	RemoveExpired(ctx context.Context, cutoff time.Time, archive bool, runID, triggeredBy string) ([]RetentionCount, error)
+	// FindAuditLog returns the most recent audit entries, newest first
+	FindAuditLog(ctx context.Context, limit int) ([]RetentionAuditEntry, error)
+}
+
+// RetentionPreview is a dry run of the retention worker
+type RetentionPreview struct {
+	Cutoff   time.Time
+	Archive  bool
+	Eligible []RetentionCount // would be removed
+	Held     []RetentionCount // skipped because the team is on legal hold
+}
+
+// RetentionRunResult is the outcome of a retention run
+type RetentionRunResult struct {
+	RunID   string
+	Cutoff  time.Time
+	Archive bool
+	Removed []RetentionCount
+}
+
+// RetentionService enforces AppSettings.RetentionMonths by archiving or purging
+// health check sessions older than the retention window. Teams on legal hold are skipped.
+type RetentionService struct {
+	orgRepo       organization.Repository
+	retentionRepo RetentionRepository
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(orgRepo organization.Repository, retentionRepo RetentionRepository) *RetentionService {
+	return &RetentionService{orgRepo: orgRepo, retentionRepo: retentionRepo}
+}
+
+// Start enforces retention immediately and then on every interval until ctx is cancelled
+func (s *RetentionService) Start(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "retention", func() error {
+		result, err := s.RunAt(ctx, time.Now(), RetentionTriggeredBySystem)
+		if err != nil {
+			return err
+		}
+		if sessions := TotalRetentionSessions(result.Removed); sessions > 0 {
+			logger.Get().WithFields(map[string]interface{}{
+				"run_id":   result.RunID,
+				"sessions": sessions,
+				"archive":  result.Archive,
+			}).Info("retention: run completed")
+		}
+		return nil
+	})
+}
+
+// Preview reports what a run at the given time would remove, without changing any data
+func (s *RetentionService) Preview(ctx context.Context, at time.Time) (*RetentionPreview, error) {
+	settings, err := s.orgRepo.GetAppSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load app settings: %w", err)
+	}
+
+	cutoff := RetentionCutoff(settings.RetentionMonths, at)
+	counts, err := s.retentionRepo.CountExpired(ctx, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &RetentionPreview{
+		Cutoff:   cutoff,
+		Archive:  settings.RetentionArchive,
+		Eligible: []RetentionCount{},
+		Held:     []RetentionCount{},
+	}
+	for _, c := range counts {
+		if c.LegalHold {
+			preview.Held = append(preview.Held, c)
+		} else {
+			preview.Eligible = append(preview.Eligible, c)
+		}
+	}
+	return preview, nil
+}
+
+// RunAt archives or purges sessions that fall outside the retention window as of at.
+// triggeredBy is recorded in the audit log (an admin user ID, or "system").
+func (s *RetentionService) RunAt(ctx context.Context, at time.Time, triggeredBy string) (*RetentionRunResult, error) {
+	settings, err := s.orgRepo.GetAppSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load app settings: %w", err)
+	}
+
+	result := &RetentionRunResult{
+		RunID:   uuid.New().String(),
+		Cutoff:  RetentionCutoff(settings.RetentionMonths, at),
+		Archive: settings.RetentionArchive,
+	}
+
+	removed, err := s.retentionRepo.RemoveExpired(ctx, result.Cutoff, result.Archive, result.RunID, triggeredBy)
+	if err != nil {
+		return nil, err
+	}
+	result.Removed = removed
+	return result, nil
+}
+
+// AuditLog returns the most recent retention audit entries
+func (s *RetentionService) AuditLog(ctx context.Context, limit int) ([]RetentionAuditEntry, error) {
+	return s.retentionRepo.FindAuditLog(ctx, limit)
+}
+
+// RetentionCutoff returns the first day still retained: sessions dated before it are expired.
+// A non-positive months value falls back to the 12 month default.
+func RetentionCutoff(months int, at time.Time) time.Time {
+	if months <= 0 {
+		months = 12
+	}
+	at = at.UTC()
+	return time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, -months, 0)
+}
+
+// TotalRetentionSessions sums the session counts
+func TotalRetentionSessions(counts []RetentionCount) int {
+	total := 0
+	for _, c := range counts {
+		total += c.Sessions
+	}
+	return total
+}
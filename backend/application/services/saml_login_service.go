@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+)
+
+// ErrSAMLLoginNotFound is returned when a SAML Response answers no pending AuthnRequest
+// (unknown, expired or already answered), or a login code cannot be redeemed
+var ErrSAMLLoginNotFound = errors.New("SAML login not found or expired")
+
+// SAMLLoginStore persists SP-initiated SAML logins, keyed by AuthnRequest ID
+type SAMLLoginStore interface {
+	// CreateLogin records an AuthnRequest sent to the IdP
+	CreateLogin(ctx context.Context, requestID, stateHash string, expiresAt time.Time) error
+	// ConsumeRequest marks a pending, unexpired login as answered. Returns false if it was not.
+	ConsumeRequest(ctx context.Context, requestID string, now time.Time) (bool, error)
+	// AttachCode stores the signed-in user and the login code of an answered login
+	AttachCode(ctx context.Context, requestID, userID, codeHash string, expiresAt time.Time) error
+	// RedeemCode marks an unexpired, unredeemed code as redeemed and returns the user ID.
+	// Returns "" when there is no such code for the state.
+	RedeemCode(ctx context.Context, codeHash, stateHash string, now time.Time) (string, error)
+	// DeleteExpired removes logins that expired before the given time
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SAMLLoginService tracks SAML logins from the AuthnRequest to the token pair.
+//
+// The IdP posts its Response to the backend's Assertion Consumer Service, not to the single
+// page app, so the ACS hands the browser a short-lived one-time code that the app redeems for
+// the token pair. The code is bound to the state the app started the login with, so a code
+// leaked from the redirect URL is useless in another browser.
+type SAMLLoginService struct {
+	store      SAMLLoginStore
+	requestTTL time.Duration // how long the user has to sign in at the IdP
+	codeTTL    time.Duration
+	now        func() time.Time
+}
+
+// NewSAMLLoginService creates a new SAML login service
+func NewSAMLLoginService(store SAMLLoginStore) *SAMLLoginService {
+	return &SAMLLoginService{
+		store:      store,
+		requestTTL: 10 * time.Minute,
+		codeTTL:    time.Minute,
+		now:        time.Now,
+	}
+}
+
+// StartLogin records the AuthnRequest about to be sent for a login started with state
+func (s *SAMLLoginService) StartLogin(ctx context.Context, requestID, state string) error {
+	now := s.now()
+
+	// Logins that were never finished are purged as new ones start
+	if _, err := s.store.DeleteExpired(ctx, now); err != nil {
+		logger.Get().WithError(err).Warn("SAML login cleanup failed")
+	}
+
+	return s.store.CreateLogin(ctx, requestID, hashSAMLSecret(state), now.Add(s.requestTTL))
+}
+
+// ConsumeRequest accepts the Response to an AuthnRequest once. It returns ErrSAMLLoginNotFound
+// for responses to unknown or expired requests, and for replayed responses.
+func (s *SAMLLoginService) ConsumeRequest(ctx context.Context, requestID string) error {
+	ok, err := s.store.ConsumeRequest(ctx, requestID, s.now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSAMLLoginNotFound
+	}
+	return nil
+}
+
+// IssueCode returns a one-time code for the user of an answered login
+func (s *SAMLLoginService) IssueCode(ctx context.Context, requestID, userID string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate login code: %w", err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(b)
+
+	if err := s.store.AttachCode(ctx, requestID, userID, hashSAMLSecret(code), s.now().Add(s.codeTTL)); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// RedeemCode exchanges a login code and the state of the login for the user ID.
+// A code can be redeemed once.
+func (s *SAMLLoginService) RedeemCode(ctx context.Context, code, state string) (string, error) {
+	userID, err := s.store.RedeemCode(ctx, hashSAMLSecret(code), hashSAMLSecret(state), s.now())
+	if err != nil {
+		return "", err
+	}
+	if userID == "" {
+		return "", ErrSAMLLoginNotFound
+	}
+	return userID, nil
+}
+
+func hashSAMLSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
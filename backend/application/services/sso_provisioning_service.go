@@ -0,0 +1,349 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// ErrSSOUserNotFound is returned when no user has the SSO email and just-in-time provisioning is off
+var ErrSSOUserNotFound = errors.New("no user found for SSO email")
+
+// SSOClaimMapping configures how the claims of an SSO login map onto a user.
+// Attributes whose claim is not configured, or absent from a login, are left unchanged.
+type SSOClaimMapping struct {
+	// JITProvisioning creates users on their first SSO login and refreshes existing SSO users on every login
+	JITProvisioning bool
+
+	UsernameClaim string // defaults to the email when absent
+	NameClaim     string
+
+	// LevelClaim holds a value of LevelMap or, when LevelMap is empty, an unprivileged hierarchy level ID.
+	// The first matching value of a list claim wins.
+	LevelClaim     string
+	LevelMap       map[string]string // claim value -> hierarchy level ID
+	DefaultLevelID string            // level of new users without a matching LevelClaim
+
+	// ManagerEmailClaim holds the email of the user's manager, who must already have an account
+	ManagerEmailClaim string
+
+	// GroupsClaim lists the user's groups. Teams in GroupTeamMap are managed by the identity provider:
+	// the user joins the teams their groups map to and leaves the others. Other memberships are kept.
+	GroupsClaim  string
+	GroupTeamMap map[string]string // group -> team ID
+}
+
+// SSOClaimMappingFromEnv reads the claim mapping from SSO_* environment variables
+func SSOClaimMappingFromEnv() SSOClaimMapping {
+	return SSOClaimMapping{
+		JITProvisioning:   os.Getenv("SSO_JIT_PROVISIONING") == "true",
+		UsernameClaim:     envOrDefault("SSO_USERNAME_CLAIM", "preferred_username"),
+		NameClaim:         envOrDefault("SSO_NAME_CLAIM", "name"),
+		LevelClaim:        os.Getenv("SSO_LEVEL_CLAIM"),
+		LevelMap:          parseClaimMap(os.Getenv("SSO_LEVEL_MAP")),
+		DefaultLevelID:    envOrDefault("SSO_DEFAULT_HIERARCHY_LEVEL", "level-5"),
+		ManagerEmailClaim: os.Getenv("SSO_MANAGER_EMAIL_CLAIM"),
+		GroupsClaim:       envOrDefault("SSO_GROUPS_CLAIM", "groups"),
+		GroupTeamMap:      parseClaimMap(os.Getenv("SSO_GROUP_TEAM_MAP")),
+	}
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// parseClaimMap parses `value=target;value=target`
+func parseClaimMap(raw string) map[string]string {
+	m := make(map[string]string)
+	for _, entry := range strings.Split(raw, ";") {
+		key, value, ok := strings.Cut(entry, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if ok && key != "" && value != "" {
+			m[key] = value
+		}
+	}
+	return m
+}
+
+// SSOLoginResult is the user an SSO login resolved to
+type SSOLoginResult struct {
+	User             *user.User
+	Created          bool
+	ReportingChanged bool // reports_to or hierarchy level changed; supervisor chains need re-deriving
+}
+
+// SSOProvisioningService resolves SSO logins to users, provisioning them just in time when enabled
+type SSOProvisioningService struct {
+	userRepo user.Repository
+	teamRepo team.Repository
+	orgRepo  organization.Repository
+	mapping  SSOClaimMapping
+}
+
+// NewSSOProvisioningService creates a new SSO provisioning service
+func NewSSOProvisioningService(
+	userRepo user.Repository,
+	teamRepo team.Repository,
+	orgRepo organization.Repository,
+	mapping SSOClaimMapping,
+) *SSOProvisioningService {
+	return &SSOProvisioningService{
+		userRepo: userRepo,
+		teamRepo: teamRepo,
+		orgRepo:  orgRepo,
+		mapping:  mapping,
+	}
+}
+
+// ResolveUser finds the user with the email of an SSO login. With JIT provisioning a missing user
+// is created from the claims, and an existing active SSO user has their attributes refreshed.
+// Local and deactivated users are returned unchanged for the caller to reject.
+func (s *SSOProvisioningService) ResolveUser(ctx context.Context, claims map[string]interface{}) (*SSOLoginResult, error) {
+	email := claimString(claims, "email")
+	if email == "" {
+		return nil, ErrSSOUserNotFound
+	}
+
+	usr, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			return nil, err
+		}
+		if !s.mapping.JITProvisioning {
+			return nil, ErrSSOUserNotFound
+		}
+		return s.provision(ctx, email, claims)
+	}
+
+	if !s.mapping.JITProvisioning || usr.AuthType != user.AuthTypeSSO || !usr.IsActive() {
+		return &SSOLoginResult{User: usr}, nil
+	}
+	return s.refresh(ctx, usr, claims)
+}
+
+// provision creates an SSO user from the claims of their first login
+func (s *SSOProvisioningService) provision(ctx context.Context, email string, claims map[string]interface{}) (*SSOLoginResult, error) {
+	log := logger.Get()
+
+	username, err := s.availableUsername(ctx, email, claimString(claims, s.mapping.UsernameClaim))
+	if err != nil {
+		return nil, err
+	}
+
+	usr := &user.User{
+		ID:               user.IDFromUsername(username),
+		Username:         username,
+		Email:            email,
+		Name:             username,
+		HierarchyLevelID: s.mapping.DefaultLevelID,
+		AuthType:         user.AuthTypeSSO,
+		TeamIDs:          []string{},
+	}
+	if existing, _ := s.userRepo.FindByID(ctx, usr.ID); usr.ID == "" || existing != nil {
+		usr.ID = uuid.NewString()
+	}
+	s.applyClaims(ctx, usr, claims)
+
+	if err := s.userRepo.Save(ctx, usr); err != nil {
+		return nil, fmt.Errorf("failed to provision SSO user: %w", err)
+	}
+
+	log.WithFields(map[string]interface{}{
+		"user_id":         usr.ID,
+		"hierarchy_level": usr.HierarchyLevelID,
+		"teams":           usr.TeamIDs,
+	}).Info("SSO login: provisioned new user")
+
+	return &SSOLoginResult{User: usr, Created: true, ReportingChanged: usr.ReportsTo != nil}, nil
+}
+
+// availableUsername picks the username claim, or the email when the claim is absent or taken
+func (s *SSOProvisioningService) availableUsername(ctx context.Context, email, claimed string) (string, error) {
+	for _, candidate := range []string{claimed, email} {
+		if candidate == "" {
+			continue
+		}
+		if _, err := s.userRepo.FindByUsername(ctx, candidate); err != nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("failed to provision SSO user: username %q is already in use", email)
+}
+
+// refresh updates an existing SSO user from the claims of a login
+func (s *SSOProvisioningService) refresh(ctx context.Context, usr *user.User, claims map[string]interface{}) (*SSOLoginResult, error) {
+	before := *usr
+	s.applyClaims(ctx, usr, claims)
+
+	reportingChanged := before.HierarchyLevelID != usr.HierarchyLevelID || !sameStringPtr(before.ReportsTo, usr.ReportsTo)
+	if before.Name == usr.Name && !reportingChanged && sameStringSet(before.TeamIDs, usr.TeamIDs) {
+		return &SSOLoginResult{User: usr}, nil
+	}
+
+	if err := s.userRepo.Update(ctx, usr); err != nil {
+		return nil, fmt.Errorf("failed to refresh SSO user: %w", err)
+	}
+
+	logger.Get().WithField("user_id", usr.ID).Info("SSO login: refreshed user attributes from claims")
+	return &SSOLoginResult{User: usr, ReportingChanged: reportingChanged}, nil
+}
+
+// applyClaims sets the mapped attributes present in the claims
+func (s *SSOProvisioningService) applyClaims(ctx context.Context, usr *user.User, claims map[string]interface{}) {
+	log := logger.Get()
+
+	if name := claimString(claims, s.mapping.NameClaim); name != "" {
+		usr.Name = name
+	} else if full := strings.TrimSpace(claimString(claims, "given_name") + " " + claimString(claims, "family_name")); full != "" {
+		usr.Name = full
+	}
+
+	if levelID := s.levelFromClaims(ctx, claims); levelID != "" {
+		usr.HierarchyLevelID = levelID
+	}
+
+	if managerEmail := claimString(claims, s.mapping.ManagerEmailClaim); managerEmail != "" {
+		manager, err := s.userRepo.FindByEmail(ctx, managerEmail)
+		switch {
+		case err != nil:
+			log.WithField("user_id", usr.ID).Warn("SSO login: manager from claims has no account; reports_to unchanged")
+		case manager.ID != usr.ID:
+			usr.ReportsTo = &manager.ID
+		}
+	}
+
+	if len(s.mapping.GroupTeamMap) > 0 {
+		if groups, ok := claimStrings(claims, s.mapping.GroupsClaim); ok {
+			usr.TeamIDs = s.teamsFromGroups(ctx, usr.TeamIDs, groups)
+		}
+	}
+}
+
+// levelFromClaims returns the hierarchy level the level claim maps to, or "" when none does.
+// When LevelMap is set only its values count. Without one a claim value may name a level
+// directly, except the system admin level and levels that can configure the system or manage users.
+func (s *SSOProvisioningService) levelFromClaims(ctx context.Context, claims map[string]interface{}) string {
+	values, _ := claimStrings(claims, s.mapping.LevelClaim)
+	for _, value := range values {
+		if len(s.mapping.LevelMap) > 0 {
+			if levelID, ok := s.mapping.LevelMap[value]; ok {
+				return levelID
+			}
+			continue
+		}
+
+		level, err := s.orgRepo.FindHierarchyLevelByID(ctx, value)
+		if err != nil || level == nil {
+			continue
+		}
+		if level.ID == "level-admin" || level.Permissions.CanConfigureSystem || level.Permissions.CanManageUsers {
+			logger.Get().WithField("level_id", level.ID).Warn("SSO login: level claim names a privileged level; map it in SSO_LEVEL_MAP to grant it")
+			continue
+		}
+		return level.ID
+	}
+	return ""
+}
+
+// teamsFromGroups keeps the user's memberships of teams not in the group mapping, and sets
+// their memberships of mapped teams to those their groups map to
+func (s *SSOProvisioningService) teamsFromGroups(ctx context.Context, current, groups []string) []string {
+	managed := make(map[string]bool, len(s.mapping.GroupTeamMap))
+	for _, teamID := range s.mapping.GroupTeamMap {
+		managed[teamID] = true
+	}
+
+	seen := make(map[string]bool)
+	teamIDs := []string{}
+	for _, teamID := range current {
+		if !managed[teamID] && !seen[teamID] {
+			seen[teamID] = true
+			teamIDs = append(teamIDs, teamID)
+		}
+	}
+	for _, group := range groups {
+		teamID, ok := s.mapping.GroupTeamMap[group]
+		if !ok || seen[teamID] {
+			continue
+		}
+		if _, err := s.teamRepo.FindByID(ctx, teamID); err != nil {
+			logger.Get().WithField("team_id", teamID).Warn("SSO login: team in SSO_GROUP_TEAM_MAP does not exist")
+			continue
+		}
+		seen[teamID] = true
+		teamIDs = append(teamIDs, teamID)
+	}
+	return teamIDs
+}
+
+// claimString returns a string claim, or the first element of a list claim
+func claimString(claims map[string]interface{}, name string) string {
+	values, _ := claimStrings(claims, name)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// claimStrings returns a claim as a list of strings; ok is false when the claim is absent
+func claimStrings(claims map[string]interface{}, name string) ([]string, bool) {
+	if name == "" {
+		return nil, false
+	}
+	raw, ok := claims[name]
+	if !ok || raw == nil {
+		return nil, false
+	}
+	switch v := raw.(type) {
+	case string:
+		if v = strings.TrimSpace(v); v == "" {
+			return []string{}, true
+		}
+		return []string{v}, true
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, isString := item.(string); isString && strings.TrimSpace(s) != "" {
+				values = append(values, strings.TrimSpace(s))
+			}
+		}
+		return values, true
+	case []string:
+		return v, true
+	}
+	return nil, false
+}
+
+func sameStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameStringSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string(nil), a...), append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
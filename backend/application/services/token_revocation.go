@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+)
+
+// RefreshTokenRecord is the server-side record of an issued refresh token.
+// Tokens rotated from the same login share a FamilyID.
+type RefreshTokenRecord struct {
+	JTI       string
+	FamilyID  string
+	UserID    string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
+
+// TokenStore persists refresh tokens and revocations, keyed by JWT ID (jti)
+type TokenStore interface {
+	// SaveRefreshToken records a newly issued refresh token
+	SaveRefreshToken(ctx context.Context, record *RefreshTokenRecord) error
+	// FindRefreshToken returns the record for a refresh token
+	FindRefreshToken(ctx context.Context, jti string) (*RefreshTokenRecord, error)
+	// UseRefreshToken marks an unused, unrevoked refresh token as used. Returns false if it was not.
+	UseRefreshToken(ctx context.Context, jti string, usedAt time.Time) (bool, error)
+	// RevokeRefreshTokenFamily revokes every refresh token rotated from the same login
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	// RevokeAccessToken denies an access token until it expires
+	RevokeAccessToken(ctx context.Context, jti, userID string, expiresAt time.Time) error
+	// RevokeAllForUser rejects every token issued to the user up to revokedAt
+	RevokeAllForUser(ctx context.Context, userID string, revokedAt time.Time) error
+	// IsRevoked reports whether the token was revoked individually or by a user-wide revocation,
+	// or its user has been deleted. A token issued at the moment of a user-wide revocation is revoked.
+	IsRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error)
+	// DeleteExpired removes records for tokens that expired before the given time
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// VerifyAccessToken validates an access token and, when a token store is configured,
+// rejects it with ErrTokenRevoked if it was logged out or its user's sessions were revoked.
+// With UseAPITokens, API tokens are accepted too; their claims have TokenType APITokenType.
+func (s *JWTService) VerifyAccessToken(ctx context.Context, tokenString string) (*TokenClaims, error) {
+	if s.apiTokens != nil && IsAPIToken(tokenString) {
+		return s.apiTokens.Authenticate(ctx, tokenString)
+	}
+
+	claims, err := s.ValidateAccessToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if s.store == nil {
+		return claims, nil
+	}
+
+	revoked, err := s.store.IsRevoked(ctx, claims.ID, claims.UserID, claims.issuedAt())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+	return claims, nil
+}
+
+// RotateRefreshToken exchanges a valid refresh token for a new token pair in the same family.
+// Each refresh token can be exchanged once; presenting one that was already used is treated
+// as theft, so the whole family is revoked and ErrTokenRevoked is returned.
+func (s *JWTService) RotateRefreshToken(ctx context.Context, refreshTokenString string, userID, username, email, hierarchyLevel string, teamIDs []string) (*TokenPair, error) {
+	claims, err := s.parseRefreshToken(refreshTokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify user ID matches
+	if claims.UserID != userID {
+		return nil, ErrInvalidToken
+	}
+
+	if s.store == nil {
+		return s.issueTokenPair(ctx, claims.ID, userID, username, email, hierarchyLevel, teamIDs)
+	}
+
+	// Tokens issued before revocation was enabled carry no jti and cannot be rotated
+	if claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	record, err := s.store.FindRefreshToken(ctx, claims.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	if record.RevokedAt != nil {
+		return nil, ErrTokenRevoked
+	}
+
+	// The record keeps the precise issue time; the token's iat is rounded down to the second
+	revoked, err := s.store.IsRevoked(ctx, claims.ID, userID, record.IssuedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+
+	now := time.Now()
+	used, err := s.store.UseRefreshToken(ctx, claims.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		// Reuse of a rotated token: someone else holds a copy of this family
+		if err := s.store.RevokeRefreshTokenFamily(ctx, record.FamilyID, now); err != nil {
+			return nil, err
+		}
+		logger.Get().Security("refresh_token_reuse").
+			UserID(userID).
+			Details("Rotated refresh token was presented again; revoked all tokens in its family").
+			Log()
+		return nil, ErrTokenRevoked
+	}
+
+	return s.issueTokenPair(ctx, record.FamilyID, userID, username, email, hierarchyLevel, teamIDs)
+}
+
+// RevokeSession revokes the given access token and the refresh token's whole family.
+// Either token may be empty; tokens that are already expired or invalid are ignored.
+// Returns the user ID the tokens belonged to, if one could be determined.
+func (s *JWTService) RevokeSession(ctx context.Context, accessTokenString, refreshTokenString string) (string, error) {
+	if s.store == nil {
+		return "", nil
+	}
+
+	userID := ""
+	now := time.Now()
+
+	if accessTokenString != "" {
+		if claims, err := s.ValidateAccessToken(accessTokenString); err == nil && claims.ID != "" {
+			if err := s.store.RevokeAccessToken(ctx, claims.ID, claims.UserID, claims.ExpiresAt.Time); err != nil {
+				return "", err
+			}
+			userID = claims.UserID
+		}
+	}
+
+	if refreshTokenString != "" {
+		if claims, err := s.parseRefreshToken(refreshTokenString); err == nil && claims.ID != "" {
+			record, err := s.store.FindRefreshToken(ctx, claims.ID)
+			if err == nil {
+				if err := s.store.RevokeRefreshTokenFamily(ctx, record.FamilyID, now); err != nil {
+					return "", err
+				}
+				if userID == "" {
+					userID = record.UserID
+				}
+			} else if !strings.Contains(err.Error(), "not found") {
+				return "", err
+			}
+		}
+	}
+
+	return userID, nil
+}
+
+// RevokeAllSessions invalidates every access and refresh token issued to the user so far,
+// and with UseAPITokens every API token that acts as the user. The user must sign in again
+// on every device and create new API tokens.
+func (s *JWTService) RevokeAllSessions(ctx context.Context, userID string) error {
+	if s.store == nil {
+		return fmt.Errorf("token revocation is not configured")
+	}
+	if err := s.store.RevokeAllForUser(ctx, userID, time.Now()); err != nil {
+		return err
+	}
+	if s.apiTokens != nil {
+		return s.apiTokens.RevokeAllForUser(ctx, userID)
+	}
+	return nil
+}
+
+// StartRevocationCleanup purges expired token records immediately and then on every
+// interval until ctx is cancelled. It is a no-op without a token store.
+func (s *JWTService) StartRevocationCleanup(ctx context.Context, interval time.Duration) {
+	if s.store == nil {
+		return
+	}
+	runPeriodically(ctx, interval, "token cleanup", func() error {
+		deleted, err := s.store.DeleteExpired(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Get().WithField("deleted", deleted).Info("token cleanup: run completed")
+		}
+		return nil
+	})
+}
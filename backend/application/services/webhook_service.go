@@ -0,0 +1,457 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/outbound"
+	"github.com/google/uuid"
+)
+
+// WebhookEvent is the type of an event sent to webhook endpoints
+type WebhookEvent string
+
+const (
+	EventHealthCheckSubmitted  WebhookEvent = "healthcheck.submitted"
+	EventPostWorkshopSubmitted WebhookEvent = "postworkshop.submitted"
+	EventActionItemCreated     WebhookEvent = "action_item.created"
+	EventActionItemUpdated     WebhookEvent = "action_item.updated"
+	EventTeamUpdated           WebhookEvent = "team.updated"
+)
+
+// WebhookEvents lists every event an endpoint can subscribe to
+var WebhookEvents = []WebhookEvent{
+	EventHealthCheckSubmitted,
+	EventPostWorkshopSubmitted,
+	EventActionItemCreated,
+	EventActionItemUpdated,
+	EventTeamUpdated,
+}
+
+// WebhookDeliveryStatus is where a delivery is in its lifecycle
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is waiting for its first attempt or a retry
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered was accepted by the endpoint with a 2xx response
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryFailed ran out of attempts
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// Headers of a webhook delivery
+const (
+	WebhookEventHeader     = "X-Teams360-Event"
+	WebhookDeliveryHeader  = "X-Teams360-Delivery"
+	WebhookTimestampHeader = "X-Teams360-Timestamp"
+	WebhookSignatureHeader = "X-Teams360-Signature"
+)
+
+var (
+	// ErrWebhookNotFound is returned for an unknown webhook endpoint or delivery
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrInvalidWebhookURL is returned for an endpoint URL that is not an absolute http(s) URL
+	ErrInvalidWebhookURL = errors.New("webhook URL must be an absolute http or https URL")
+	// ErrInvalidWebhookEvent is returned for an endpoint without events or with an unknown one
+	ErrInvalidWebhookEvent = errors.New("invalid webhook event")
+	// ErrWebhookDeliveryNotFailed is returned when retrying a delivery that has not failed
+	ErrWebhookDeliveryNotFailed = errors.New("only failed deliveries can be retried")
+	// ErrWebhookAddressNotAllowed is returned when an endpoint resolves to a loopback, private
+	// or link-local address
+	ErrWebhookAddressNotAllowed = outbound.ErrAddressNotAllowed
+)
+
+// WebhookEndpoint is a URL that receives the events it subscribes to
+type WebhookEndpoint struct {
+	ID          string         `json:"id"`
+	URL         string         `json:"url"`
+	Description string         `json:"description"`
+	Events      []WebhookEvent `json:"events"`
+	Secret      string         `json:"-"` // signs deliveries; shown once when the endpoint is created
+	Active      bool           `json:"active"`
+	CreatedBy   string         `json:"createdBy"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// WebhookDelivery is one event queued for one endpoint, and the outcome of its attempts
+type WebhookDelivery struct {
+	ID             string
+	EndpointID     string
+	EventID        string
+	EventType      WebhookEvent
+	Payload        json.RawMessage // the request body
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+// DueWebhookDelivery is a delivery claimed for an attempt, with where to send it
+type DueWebhookDelivery struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
+
+// WebhookStore persists webhook endpoints and the outbox of their deliveries
+type WebhookStore interface {
+	// CreateEndpoint stores a new endpoint
+	CreateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	// FindEndpoint returns the endpoint with the given ID, or nil when there is none
+	FindEndpoint(ctx context.Context, id string) (*WebhookEndpoint, error)
+	// ListEndpoints returns every endpoint, oldest first
+	ListEndpoints(ctx context.Context) ([]*WebhookEndpoint, error)
+	// UpdateEndpoint saves an endpoint's URL, description, events and active flag. Returns false if it does not exist.
+	UpdateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) (bool, error)
+	// DeleteEndpoint removes an endpoint and its deliveries. Returns false if it did not exist.
+	DeleteEndpoint(ctx context.Context, id string) (bool, error)
+	// EnqueueEvent queues a pending delivery of the payload to every active endpoint subscribed
+	// to the event. Returns how many were queued.
+	EnqueueEvent(ctx context.Context, event WebhookEvent, eventID string, payload json.RawMessage, at time.Time) (int, error)
+	// ClaimDueDeliveries returns up to limit pending deliveries of active endpoints due at now,
+	// moving their next attempt to leaseUntil so no other worker claims them meanwhile
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*DueWebhookDelivery, error)
+	// RecordAttempt saves the status, attempts, schedule and outcome of a delivery
+	RecordAttempt(ctx context.Context, delivery *WebhookDelivery) error
+	// ListDeliveries returns an endpoint's deliveries, newest first, optionally with one status,
+	// and the total count
+	ListDeliveries(ctx context.Context, endpointID string, status WebhookDeliveryStatus, limit, offset int) ([]*WebhookDelivery, int, error)
+	// FindDelivery returns an endpoint's delivery, or nil when there is none
+	FindDelivery(ctx context.Context, endpointID, id string) (*WebhookDelivery, error)
+	// RequeueDelivery makes a failed delivery pending again with no attempts, due at the given
+	// time. Returns false if it was not failed.
+	RequeueDelivery(ctx context.Context, id string, at time.Time) (bool, error)
+}
+
+// WebhookService sends events to the webhook endpoints admins register.
+//
+// Publish writes the event to a persistent outbox, one delivery per subscribed endpoint, and
+// a background worker sends them. Each request body is signed with the endpoint's secret: the
+// X-Teams360-Signature header is "sha256=" and the hex HMAC-SHA256 of the X-Teams360-Timestamp
+// value, a ".", and the body. A delivery succeeds on any 2xx response; otherwise it is retried
+// with exponential backoff until it runs out of attempts and is marked failed. Deliveries are
+// sent at least once, so receivers should ignore event IDs they have already seen.
+//
+// Events are published after the change they report is committed, not in its transaction, so
+// an event whose outbox write fails is lost; Publish logs it at error level with its ID and type.
+//
+// Redirects are never followed, and the default client refuses to connect to loopback, private
+// and link-local addresses, checked on the resolved address at dial time so DNS cannot point an
+// endpoint at internal services after it is registered.
+type WebhookService struct {
+	store       WebhookStore
+	client      *http.Client
+	maxAttempts int
+	baseBackoff time.Duration // wait before the first retry; doubles for each one after
+	maxBackoff  time.Duration
+	lease       time.Duration // how long a claimed delivery is reserved for its attempt
+	batchSize   int
+	now         func() time.Time
+}
+
+// NewWebhookService creates a new webhook service.
+// client may be nil, in which case a client with a 10 second timeout that only connects to
+// public addresses is used. Redirects are not followed with either.
+func NewWebhookService(store WebhookStore, client *http.Client) *WebhookService {
+	if client == nil {
+		client = outbound.NewPublicClient()
+	}
+	return &WebhookService{
+		store:       store,
+		client:      outbound.WithoutRedirects(client),
+		maxAttempts: 10,
+		baseBackoff: 30 * time.Second,
+		maxBackoff:  time.Hour,
+		lease:       2 * time.Minute,
+		batchSize:   50,
+		now:         time.Now,
+	}
+}
+
+// CreateEndpoint registers an endpoint from the URL, Description, Events, Active and CreatedBy
+// of endpoint, generating its ID and signing secret
+func (s *WebhookService) CreateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error {
+	if err := validateWebhookEndpoint(endpoint); err != nil {
+		return err
+	}
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	endpoint.ID = uuid.New().String()
+	endpoint.Secret = "whsec_" + hex.EncodeToString(b)
+	endpoint.CreatedAt = s.now()
+	endpoint.UpdatedAt = endpoint.CreatedAt
+
+	return s.store.CreateEndpoint(ctx, endpoint)
+}
+
+// GetEndpoint returns an endpoint by ID
+func (s *WebhookService) GetEndpoint(ctx context.Context, id string) (*WebhookEndpoint, error) {
+	endpoint, err := s.store.FindEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == nil {
+		return nil, ErrWebhookNotFound
+	}
+	return endpoint, nil
+}
+
+// ListEndpoints returns every endpoint
+func (s *WebhookService) ListEndpoints(ctx context.Context) ([]*WebhookEndpoint, error) {
+	return s.store.ListEndpoints(ctx)
+}
+
+// UpdateEndpoint saves an endpoint's URL, description, events and active flag.
+// Deliveries already queued are sent to the new URL.
+func (s *WebhookService) UpdateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error {
+	if err := validateWebhookEndpoint(endpoint); err != nil {
+		return err
+	}
+	endpoint.UpdatedAt = s.now()
+
+	updated, err := s.store.UpdateEndpoint(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// DeleteEndpoint removes an endpoint with its delivery log and any deliveries still queued
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, id string) error {
+	deleted, err := s.store.DeleteEndpoint(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Publish queues an event for every active endpoint subscribed to it. data is sent as the
+// "data" field of the body, next to the event's "id", "type" and "createdAt".
+func (s *WebhookService) Publish(ctx context.Context, event WebhookEvent, data interface{}) error {
+	now := s.now()
+	eventID := uuid.New().String()
+
+	payload, err := json.Marshal(struct {
+		ID        string       `json:"id"`
+		Type      WebhookEvent `json:"type"`
+		CreatedAt time.Time    `json:"createdAt"`
+		Data      interface{}  `json:"data"`
+	}{eventID, event, now.UTC(), data})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	if _, err := s.store.EnqueueEvent(ctx, event, eventID, payload, now); err != nil {
+		// The change the event reports is already committed, so the event is lost
+		logger.Get().WithContext(ctx).WithError(err).
+			WithField("event_id", eventID).
+			WithField("event_type", string(event)).
+			Error("webhooks: failed to queue event, it will not be delivered")
+		return err
+	}
+	return nil
+}
+
+// Deliveries returns an endpoint's delivery log, newest first, and the total count
+func (s *WebhookService) Deliveries(ctx context.Context, endpointID string, status WebhookDeliveryStatus, limit, offset int) ([]*WebhookDelivery, int, error) {
+	if _, err := s.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, 0, err
+	}
+	return s.store.ListDeliveries(ctx, endpointID, status, limit, offset)
+}
+
+// RetryDelivery queues a failed delivery again, with a fresh set of attempts
+func (s *WebhookService) RetryDelivery(ctx context.Context, endpointID, id string) error {
+	delivery, err := s.store.FindDelivery(ctx, endpointID, id)
+	if err != nil {
+		return err
+	}
+	if delivery == nil {
+		return ErrWebhookNotFound
+	}
+
+	requeued, err := s.store.RequeueDelivery(ctx, id, s.now())
+	if err != nil {
+		return err
+	}
+	if !requeued {
+		return ErrWebhookDeliveryNotFailed
+	}
+	return nil
+}
+
+// Start sends due deliveries immediately and then on every interval until ctx is cancelled.
+func (s *WebhookService) Start(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "webhooks", func() error {
+		delivered, err := s.RunAt(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		if delivered > 0 {
+			logger.Get().WithField("delivered", delivered).Info("webhooks: run completed")
+		}
+		return nil
+	})
+}
+
+// RunAt attempts the deliveries due as of the given time, batch by batch until none are
+// left, and returns how many were delivered
+func (s *WebhookService) RunAt(ctx context.Context, at time.Time) (int, error) {
+	delivered := 0
+	for {
+		due, err := s.store.ClaimDueDeliveries(ctx, at, at.Add(s.lease), s.batchSize)
+		if err != nil {
+			return delivered, err
+		}
+		for _, delivery := range due {
+			if s.attempt(ctx, delivery, at) {
+				delivered++
+			}
+		}
+		if len(due) < s.batchSize || ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+	}
+}
+
+// attempt sends a delivery once and records the outcome. Returns whether it was delivered.
+func (s *WebhookService) attempt(ctx context.Context, due *DueWebhookDelivery, at time.Time) bool {
+	delivery := &due.WebhookDelivery
+	status, err := s.send(ctx, due, at)
+
+	attemptedAt := at
+	delivery.Attempts++
+	delivery.LastAttemptAt = &attemptedAt
+	delivery.ResponseStatus = status
+	if err == nil {
+		delivery.Status = WebhookDeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &attemptedAt
+	} else {
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= s.maxAttempts {
+			delivery.Status = WebhookDeliveryFailed
+		} else {
+			delivery.NextAttemptAt = at.Add(s.backoff(delivery.Attempts))
+		}
+	}
+
+	if recordErr := s.store.RecordAttempt(ctx, delivery); recordErr != nil {
+		logger.Get().WithContext(ctx).WithError(recordErr).
+			WithField("delivery_id", delivery.ID).
+			Warn("webhooks: failed to record delivery attempt")
+	}
+	if delivery.Status == WebhookDeliveryFailed {
+		logger.Get().WithContext(ctx).
+			WithField("delivery_id", delivery.ID).
+			WithField("endpoint_id", delivery.EndpointID).
+			WithField("event_type", string(delivery.EventType)).
+			Warn("webhooks: delivery failed after the last attempt: " + delivery.LastError)
+	}
+	return err == nil
+}
+
+// send posts a delivery's signed payload and returns the response status, if any
+func (s *WebhookService) send(ctx context.Context, due *DueWebhookDelivery, at time.Time) (*int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, due.URL, bytes.NewReader(due.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	timestamp := at.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Teams360-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, string(due.EventType))
+	req.Header.Set(WebhookDeliveryHeader, due.ID)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(due.Secret, timestamp, due.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The body is only drained for connection reuse; it is not kept in the delivery log, which
+	// would otherwise echo whatever the endpoint's host answers
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	status := resp.StatusCode
+	if status < 200 || status >= 300 {
+		return &status, fmt.Errorf("webhook returned status %d", status)
+	}
+	return &status, nil
+}
+
+// backoff returns the wait before the retry after the given number of attempts
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	wait := s.baseBackoff
+	for i := 1; i < attempts && wait < s.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > s.maxBackoff {
+		wait = s.maxBackoff
+	}
+	return wait
+}
+
+// SignWebhook returns the X-Teams360-Signature of a delivery body sent at timestamp (Unix seconds)
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func validateWebhookEndpoint(endpoint *WebhookEndpoint) error {
+	u, err := url.Parse(endpoint.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	if len(endpoint.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", ErrInvalidWebhookEvent)
+	}
+	for _, event := range endpoint.Events {
+		if !validWebhookEvent(event) {
+			return fmt.Errorf("%w: %s", ErrInvalidWebhookEvent, event)
+		}
+	}
+	return nil
+}
+
+func validWebhookEvent(event WebhookEvent) bool {
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -4,43 +4,32 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
 )
 
-// AllDimensionIDs contains all 11 health check dimension IDs
-// This ensures trends always return all dimensions, even if no data exists
-var AllDimensionIDs = []string{
-	"mission",
-	"value",
-	"speed",
-	"fun",
-	"health",
-	"learning",
-	"support",
-	"pawns",
-	"release",
-	"process",
-	"teamwork",
-}
-
 // Service provides trend data aggregation functionality
 type Service struct {
-	db *sql.DB
+	db      *sql.DB
+	orgRepo organization.Repository
 }
 
-// NewService creates a new trends service
-func NewService(db *sql.DB) *Service {
-	return &Service{db: db}
+// NewService creates a new trends service.
+// The dimension set comes from orgRepo, so dimensions added by admins appear in trends.
+func NewService(db *sql.DB, orgRepo organization.Repository) *Service {
+	return &Service{db: db, orgRepo: orgRepo}
 }
 
 // TrendResult holds the computed trend data
 type TrendResult struct {
 	Periods    []string
+	Overall    []*float64 // overall health per period, matches Periods order; nil where no data exists
 	Dimensions []dto.DimensionTrend
 }
 
 // GetTrendsForTeam returns trend data for a single team
-func (s *Service) GetTrendsForTeam(ctx context.Context, teamID string) (*TrendResult, error) {
+func (s *Service) GetTrendsForTeam(ctx context.Context, teamID string, scoring healthcheck.Scoring) (*TrendResult, error) {
 	// Get distinct assessment periods for this team
 	periodsQuery := `
 		SELECT DISTINCT assessment_period
@@ -60,40 +49,91 @@ func (s *Service) GetTrendsForTeam(ctx context.Context, teamID string) (*TrendRe
 	if len(periods) == 0 {
 		return &TrendResult{
 			Periods:    []string{},
+			Overall:    []*float64{},
 			Dimensions: []dto.DimensionTrend{},
 		}, nil
 	}
 
-	// Get average scores per dimension per period
+	// Get average scores per dimension per period, counting each session's responses
+	// against the template version it answered
 	trendsQuery := `
 		SELECT
 			hcr.dimension_id,
 			hcs.assessment_period,
-			AVG(hcr.score) as avg_score
+			AVG(hcr.score) as avg_score,
+			COUNT(hcr.id) as response_count
 		FROM health_check_responses hcr
 		INNER JOIN health_check_sessions hcs ON hcr.session_id = hcs.id
+		LEFT JOIN survey_template_dimensions std
+			ON std.template_id = hcs.template_id AND std.version = hcs.template_version
+			AND std.dimension_id = hcr.dimension_id
 		WHERE hcs.team_id = $1
 			AND hcs.completed = true
 			AND hcs.assessment_period IS NOT NULL
 			AND hcs.assessment_period != ''
+			AND (hcs.template_id IS NULL OR std.dimension_id IS NOT NULL)
 		GROUP BY hcr.dimension_id, hcs.assessment_period
 		ORDER BY hcr.dimension_id, hcs.assessment_period
 	`
 
-	dimensions, err := s.fetchTrendData(ctx, trendsQuery, teamID, periods)
+	expected, err := s.fetchTemplateDimensions(ctx, `
+		SELECT std.dimension_id
+		FROM teams t
+		LEFT JOIN survey_templates st ON st.id = t.survey_template_id
+		LEFT JOIN survey_template_dimensions std
+			ON std.template_id = st.id AND std.version = st.current_version
+		WHERE t.id = $1
+	`, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	dimensions, overall, err := s.fetchTrendData(ctx, trendsQuery, teamID, periods, scoring, expected)
 	if err != nil {
 		return nil, err
 	}
 
 	return &TrendResult{
 		Periods:    periods,
+		Overall:    overall,
 		Dimensions: dimensions,
 	}, nil
 }
 
+// fetchTemplateDimensions runs a query returning the current template dimensions of one or
+// more teams, NULL for a team without a template. It returns nil (all active dimensions) when
+// any team has no template, otherwise the union of the teams' dimensions.
+func (s *Service) fetchTemplateDimensions(ctx context.Context, query string, id string) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expected := make(map[string]bool)
+	for rows.Next() {
+		var dimensionID sql.NullString
+		if err := rows.Scan(&dimensionID); err != nil {
+			return nil, err
+		}
+		if !dimensionID.Valid {
+			return nil, nil
+		}
+		expected[dimensionID.String] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(expected) == 0 {
+		return nil, nil
+	}
+
+	return expected, nil
+}
+
 // GetTrendsForManager returns aggregated trend data across all teams supervised by a manager.
 // Prefers post-workshop sessions when available for a team+period, otherwise falls back to individual sessions.
-func (s *Service) GetTrendsForManager(ctx context.Context, managerID string) (*TrendResult, error) {
+func (s *Service) GetTrendsForManager(ctx context.Context, managerID string, scoring healthcheck.Scoring) (*TrendResult, error) {
 	// Get distinct assessment periods for supervised teams
 	periodsQuery := `
 		SELECT DISTINCT hcs.assessment_period
@@ -115,12 +155,14 @@ func (s *Service) GetTrendsForManager(ctx context.Context, managerID string) (*T
 	if len(periods) == 0 {
 		return &TrendResult{
 			Periods:    []string{},
+			Overall:    []*float64{},
 			Dimensions: []dto.DimensionTrend{},
 		}, nil
 	}
 
 	// Get aggregated average scores per dimension per period across all teams
 	// Uses effective_sessions CTE to prefer post-workshop data when available
+	// and, like the team query, counts responses against each session's template version
 	trendsQuery := `
 		WITH post_workshop_teams AS (
 			SELECT DISTINCT hcs.team_id, hcs.assessment_period
@@ -133,7 +175,7 @@ func (s *Service) GetTrendsForManager(ctx context.Context, managerID string) (*T
 				AND hcs.assessment_period != ''
 		),
 		effective_sessions AS (
-			SELECT hcs.id, hcs.assessment_period
+			SELECT hcs.id, hcs.assessment_period, hcs.template_id, hcs.template_version
 			FROM health_check_sessions hcs
 			INNER JOIN teams t ON hcs.team_id = t.id
 			INNER JOIN team_supervisors ts ON t.id = ts.team_id
@@ -153,20 +195,39 @@ func (s *Service) GetTrendsForManager(ctx context.Context, managerID string) (*T
 		SELECT
 			hcr.dimension_id,
 			es.assessment_period,
-			AVG(hcr.score) as avg_score
+			AVG(hcr.score) as avg_score,
+			COUNT(hcr.id) as response_count
 		FROM health_check_responses hcr
 		INNER JOIN effective_sessions es ON hcr.session_id = es.id
+		LEFT JOIN survey_template_dimensions std
+			ON std.template_id = es.template_id AND std.version = es.template_version
+			AND std.dimension_id = hcr.dimension_id
+		WHERE es.template_id IS NULL OR std.dimension_id IS NOT NULL
 		GROUP BY hcr.dimension_id, es.assessment_period
 		ORDER BY hcr.dimension_id, es.assessment_period
 	`
 
-	dimensions, err := s.fetchTrendData(ctx, trendsQuery, managerID, periods)
+	expected, err := s.fetchTemplateDimensions(ctx, `
+		SELECT std.dimension_id
+		FROM teams t
+		INNER JOIN team_supervisors ts ON t.id = ts.team_id
+		LEFT JOIN survey_templates st ON st.id = t.survey_template_id
+		LEFT JOIN survey_template_dimensions std
+			ON std.template_id = st.id AND std.version = st.current_version
+		WHERE ts.user_id = $1
+	`, managerID)
+	if err != nil {
+		return nil, err
+	}
+
+	dimensions, overall, err := s.fetchTrendData(ctx, trendsQuery, managerID, periods, scoring, expected)
 	if err != nil {
 		return nil, err
 	}
 
 	return &TrendResult{
 		Periods:    periods,
+		Overall:    overall,
 		Dimensions: dimensions,
 	}, nil
 }
@@ -190,23 +251,40 @@ func (s *Service) fetchPeriods(ctx context.Context, query string, id string) ([]
 	return periods, nil
 }
 
-// fetchTrendData executes a trends query and returns the dimension trends
-// Always returns all 11 dimensions, with 0 scores for periods where no data exists
-func (s *Service) fetchTrendData(ctx context.Context, query string, id string, periods []string) ([]dto.DimensionTrend, error) {
+// fetchTrendData executes a trends query and returns the dimension trends and the overall
+// health per period. Every expected dimension is returned, plus dimensions that have data in
+// the periods; periods without data are nil (JSON null) rather than 0. A nil expected set means
+// all active dimensions (teams without a survey template).
+// The overall score counts each response by its dimension's weight unless scoring is unweighted,
+// matching OverallHealth on the dashboards.
+func (s *Service) fetchTrendData(ctx context.Context, query string, id string, periods []string, scoring healthcheck.Scoring, expected map[string]bool) ([]dto.DimensionTrend, []*float64, error) {
+	dims, err := s.orgRepo.FindDimensions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	rows, err := s.db.QueryContext(ctx, query, id)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
-	// Build a map of dimension -> period -> score
+	weights := make(map[string]float64, len(dims))
+	for _, dim := range dims {
+		weights[dim.ID] = dim.Weight
+	}
+
+	// Build a map of dimension -> period -> score, and weighted sums per period
 	trendMap := make(map[string]map[string]float64)
+	weightedSum := make(map[string]float64)
+	weightTotal := make(map[string]float64)
 	for rows.Next() {
 		var dimensionID string
 		var period string
 		var avgScore float64
+		var responseCount int
 
-		if err := rows.Scan(&dimensionID, &period, &avgScore); err != nil {
+		if err := rows.Scan(&dimensionID, &period, &avgScore, &responseCount); err != nil {
 			continue
 		}
 
@@ -214,31 +292,49 @@ func (s *Service) fetchTrendData(ctx context.Context, query string, id string, p
 			trendMap[dimensionID] = make(map[string]float64)
 		}
 		trendMap[dimensionID][period] = avgScore
+
+		weight := 1.0
+		if w, ok := weights[dimensionID]; ok && scoring.IsWeighted() {
+			weight = w
+		}
+		weightedSum[period] += avgScore * float64(responseCount) * weight
+		weightTotal[period] += float64(responseCount) * weight
+	}
+
+	overall := make([]*float64, len(periods))
+	for i, period := range periods {
+		if weightTotal[period] > 0 {
+			score := weightedSum[period] / weightTotal[period]
+			overall[i] = &score
+		}
 	}
 
 	// Convert to ordered array format matching periods order
-	// IMPORTANT: Iterate over AllDimensionIDs to ensure all 11 dimensions are included
 	dimensions := []dto.DimensionTrend{}
-	for _, dimensionID := range AllDimensionIDs {
-		scores := make([]float64, len(periods))
-
-		// Get scores from the map if they exist
-		if periodScores, exists := trendMap[dimensionID]; exists {
-			for i, period := range periods {
-				if score, ok := periodScores[period]; ok {
-					scores[i] = score
-				} else {
-					scores[i] = 0 // No data for this period
-				}
+	for _, dim := range dims {
+		periodScores, hasData := trendMap[dim.ID]
+		isExpected := dim.IsActive
+		if expected != nil {
+			isExpected = expected[dim.ID]
+		}
+		if !isExpected && !hasData {
+			continue
+		}
+
+		scores := make([]*float64, len(periods))
+		for i, period := range periods {
+			if score, ok := periodScores[period]; ok {
+				scores[i] = &score
 			}
 		}
-		// If dimension has no data at all, scores array remains all zeros
 
 		dimensions = append(dimensions, dto.DimensionTrend{
-			DimensionID: dimensionID,
+			DimensionID: dim.ID,
+			Name:        dim.Name,
+			IsActive:    dim.IsActive,
 			Scores:      scores,
 		})
 	}
 
-	return dimensions, nil
+	return dimensions, overall, nil
 }
@@ -7,12 +7,15 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/XSAM/otelsql"
 	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/application/trends"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/chat"
 	"github.com/agopalakrishnan/teams360/backend/infrastructure/email"
 	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/api/middleware"
@@ -158,10 +161,17 @@ func main() {
 	userRepo := postgres.NewUserRepository(db)
 	teamRepo := postgres.NewTeamRepository(db)
 	orgRepo := postgres.NewOrganizationRepository(db)
+	campaignRepo := postgres.NewCampaignRepository(db)
+	templateRepo := postgres.NewSurveyTemplateRepository(db)
 
 	// Initialize services
-	trendsService := trends.NewService(db)
-	jwtService := services.NewJWTService()
+	trendsService := trends.NewService(db, orgRepo)
+	// JWT service with server-side revocation (logout, refresh rotation, admin revoke)
+	tokenRepo := postgres.NewTokenRepository(db)
+	jwtService := services.NewJWTServiceWithStore(tokenRepo)
+	// API tokens for scripts are accepted wherever access tokens are, within their scopes
+	apiTokenService := services.NewAPITokenService(postgres.NewAPITokenRepository(db), userRepo)
+	jwtService.UseAPITokens(apiTokenService)
 
 	// Initialize email service: SES > SMTP > disabled
 	var emailSender email.Sender
@@ -179,13 +189,72 @@ func main() {
 		log.Info("No email service configured, email notifications disabled")
 	}
 
-	// Initialize notification service
-	notificationService := services.NewNotificationService(emailSender, teamRepo, userRepo, orgRepo)
+	// Initialize notification service (chat posts go to each team's webhook URL)
+	chatChannels := chat.NewChannels(nil)
+	notificationService := services.NewNotificationService(emailSender, chatChannels, teamRepo, userRepo, orgRepo)
 
 	// Initialize password reset service
 	passwordResetRepo := postgres.NewPasswordResetRepository(db)
 	passwordResetService := services.NewPasswordResetService(passwordResetRepo, userRepo, emailSender)
 
+	// Initialize account lockout (per-account failed login tracking, shared through Postgres)
+	lockoutPolicy := services.DefaultAccountLockoutPolicy()
+	lockoutPolicy.MaxFailures = envInt("LOGIN_MAX_FAILED_ATTEMPTS", lockoutPolicy.MaxFailures)
+	lockoutPolicy.LockoutDuration = envDuration("LOGIN_LOCKOUT_DURATION", lockoutPolicy.LockoutDuration)
+	lockoutPolicy.FailureWindow = envDuration("LOGIN_FAILURE_WINDOW", lockoutPolicy.FailureWindow)
+	lockoutService := services.NewAccountLockoutService(postgres.NewLoginAttemptRepository(db), emailSender, lockoutPolicy)
+
+	// Initialize TOTP MFA for local accounts (MFA_ISSUER names the app in authenticator apps)
+	mfaIssuer := os.Getenv("MFA_ISSUER")
+	if mfaIssuer == "" {
+		mfaIssuer = "Teams360"
+	}
+	mfaService := services.NewMFAService(postgres.NewMFARepository(db), orgRepo, mfaIssuer)
+
+	// Initialize the append-only audit log of admin writes
+	auditService := services.NewAdminAuditService(postgres.NewAdminAuditRepository(db))
+
+	// Background workers stop when the server shuts down
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	defer stopWorkers()
+
+	// Initialize campaign scheduler (opens survey campaigns per team cadence)
+	campaignScheduler := services.NewCampaignScheduler(campaignRepo, teamRepo)
+	campaignScheduler.Start(workerCtx, envDuration("CAMPAIGN_SCHEDULER_INTERVAL", time.Hour))
+
+	// Initialize reminder job (emails members who have not submitted for an open campaign)
+	reminderRepo := postgres.NewReminderRepository(db)
+	reminderService := services.NewReminderService(emailSender, campaignRepo, healthCheckRepo, userRepo, orgRepo, reminderRepo)
+	reminderService.Start(workerCtx, envDuration("REMINDER_INTERVAL", time.Hour))
+
+	// Initialize weekly digest (one summary per manager per week)
+	digestRepo := postgres.NewDigestRepository(db)
+	digestService := services.NewDigestService(emailSender, healthCheckRepo, teamRepo, userRepo, orgRepo, digestRepo)
+	digestService.Start(workerCtx, envDuration("DIGEST_INTERVAL", time.Hour))
+
+	// Initialize retention worker (archives or purges sessions past the retention window)
+	retentionService := services.NewRetentionService(orgRepo, postgres.NewRetentionRepository(db))
+	retentionService.Start(workerCtx, envDuration("RETENTION_INTERVAL", 24*time.Hour))
+
+	// Initialize webhook delivery worker (sends queued events to registered endpoints)
+	webhookService := services.NewWebhookService(postgres.NewWebhookRepository(db), nil)
+	webhookService.Start(workerCtx, envDuration("WEBHOOK_INTERVAL", 10*time.Second))
+
+	// Initialize export service (streams health check results as CSV or XLSX)
+	exportService := services.NewExportService(postgres.NewExportRepository(db))
+
+	// Initialize permission policy (route access follows each hierarchy level's permissions)
+	permissionService := services.NewPermissionService(orgRepo)
+
+	// Initialize directory import (bulk CSV import of users and teams)
+	directoryImportService := services.NewDirectoryImportService(userRepo, teamRepo, orgRepo, postgres.NewDirectoryImportRepository(db), permissionService)
+
+	// Purge expired refresh tokens and revocations
+	jwtService.StartRevocationCleanup(workerCtx, envDuration("TOKEN_CLEANUP_INTERVAL", 6*time.Hour))
+
+	// Purge failed logins that no longer count, including those of unknown usernames
+	lockoutService.Start(workerCtx, envDuration("LOGIN_ATTEMPT_CLEANUP_INTERVAL", time.Hour))
+
 	// Initialize router (use gin.New() instead of gin.Default() to disable default logger)
 	router := gin.New()
 	router.Use(gin.Recovery()) // Keep panic recovery
@@ -208,16 +277,35 @@ func main() {
 	})
 
 	// Setup API routes with repository injection
-	v1.SetupHealthCheckRoutes(router, healthCheckRepo, orgRepo, jwtService, notificationService)
-	v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService)
-	v1.SetupSSORoutes(router, userRepo, jwtService, orgRepo)
-	v1.SetupManagerRoutes(router, healthCheckRepo, trendsService, jwtService, userRepo)
-	v1.SetupTeamRoutes(router, healthCheckRepo, teamRepo, jwtService)
-	v1.SetupTeamDashboardRoutes(router, db, jwtService) // Dashboard routes with JWT + team membership
-	v1.SetupActionItemRoutes(router, db, jwtService)    // Action item CRUD routes
-	v1.SetupUserRoutes(router, db, jwtService)          // User routes with JWT + same-user-or-manager
-	v1.SetupProtectedUserRoutes(router, db, jwtService) // Protected routes requiring JWT
-	v1.SetupAdminRoutes(router, orgRepo, userRepo, teamRepo, jwtService)
+	v1.SetupHealthCheckRoutes(router, healthCheckRepo, orgRepo, templateRepo, teamRepo, jwtService, permissionService, notificationService, webhookService)
+	v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService, lockoutService, mfaService)
+	v1.SetupSSORoutes(router, userRepo, teamRepo, jwtService, orgRepo, postgres.NewSAMLLoginRepository(db))
+	v1.SetupSCIMRoutes(router, userRepo, teamRepo, jwtService)
+	v1.SetupManagerRoutes(router, healthCheckRepo, trendsService, jwtService, permissionService, userRepo)
+	v1.SetupTeamRoutes(router, healthCheckRepo, teamRepo, jwtService, permissionService)
+	v1.SetupTeamDashboardRoutes(router, db, trendsService, jwtService, permissionService) // Dashboard routes with JWT + team membership
+	v1.SetupActionItemRoutes(router, db, jwtService, permissionService, webhookService)   // Action item CRUD routes
+	v1.SetupUserRoutes(router, db, jwtService, permissionService)                         // User routes with JWT + same-user-or-manager
+	v1.SetupProtectedUserRoutes(router, db, jwtService)                                   // Protected routes requiring JWT
+	v1.SetupAdminRoutes(router, v1.AdminRouteDeps{
+		OrgRepo:     orgRepo,
+		UserRepo:    userRepo,
+		TeamRepo:    teamRepo,
+		JWTService:  jwtService,
+		Permissions: permissionService,
+		Lockout:     lockoutService,
+		MFA:         mfaService,
+		Audit:       auditService,
+		Webhooks:    webhookService,
+	})
+	v1.SetupCampaignRoutes(router, campaignRepo, campaignScheduler, jwtService, permissionService, auditService)
+	v1.SetupRetentionRoutes(router, retentionService, jwtService, permissionService, auditService)
+	v1.SetupExportRoutes(router, exportService, jwtService, permissionService)
+	v1.SetupDirectoryImportRoutes(router, directoryImportService, userRepo, teamRepo, orgRepo, jwtService, permissionService, auditService)
+	v1.SetupSurveyTemplateRoutes(router, templateRepo, orgRepo, jwtService, permissionService, auditService)
+	v1.SetupTeamSettingsRoutes(router, db, teamRepo, userRepo, jwtService, auditService, webhookService)
+	v1.SetupAPITokenRoutes(router, apiTokenService, userRepo, jwtService, permissionService, auditService)
+	v1.SetupWebhookRoutes(router, webhookService, jwtService, permissionService, auditService)
 	v1.SetupPasswordResetRoutes(router, passwordResetService, userRepo)
 
 	// Static file serving for frontend SPA
@@ -312,3 +400,31 @@ func main() {
 	<-quit
 	log.Info("shutting down server gracefully...")
 }
+
+// envDuration reads a time.Duration (e.g. "30m") from the environment, falling back to def
+func envDuration(key string, def time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		logger.Get().WithField(key, value).Warn("invalid duration in environment, using default")
+		return def
+	}
+	return d
+}
+
+// envInt reads a positive integer from the environment, falling back to def
+func envInt(key string, def int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		logger.Get().WithField(key, value).Warn("invalid integer in environment, using default")
+		return def
+	}
+	return n
+}
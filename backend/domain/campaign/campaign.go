@@ -0,0 +1,56 @@
+package campaign
+
+import (
+	"context"
+	"time"
+)
+
+// Campaign status constants
+const (
+	StatusOpen   = "open"
+	StatusClosed = "closed"
+)
+
+// Campaign represents a scheduled survey window for a single team and assessment period.
+// Campaigns are opened by the scheduler according to the team's cadence and closed
+// once the period has ended (or manually by an admin).
+type Campaign struct {
+	ID               string     `json:"id"`
+	TeamID           string     `json:"teamId"`
+	TeamName         string     `json:"teamName,omitempty"`
+	AssessmentPeriod string     `json:"assessmentPeriod"`
+	Cadence          string     `json:"cadence"`
+	Status           string     `json:"status"` // open, closed
+	StartDate        time.Time  `json:"startDate"`
+	EndDate          time.Time  `json:"endDate"`
+	ClosedAt         *time.Time `json:"closedAt,omitempty"`
+	Anonymous        bool       `json:"anonymous"` // individual surveys for the period store a respondent token, not the user ID
+	CreatedAt        time.Time  `json:"createdAt,omitempty"`
+	UpdatedAt        time.Time  `json:"updatedAt,omitempty"`
+}
+
+// IsOpen reports whether the campaign is currently accepting submissions
+func (c *Campaign) IsOpen() bool {
+	return c.Status == StatusOpen
+}
+
+// Filter narrows campaign listings. Empty fields are ignored.
+type Filter struct {
+	TeamID           string
+	Status           string
+	AssessmentPeriod string
+}
+
+// Repository defines the interface for campaign data access
+type Repository interface {
+	FindByID(ctx context.Context, id string) (*Campaign, error)
+	FindAll(ctx context.Context, filter Filter) ([]*Campaign, error)
+	FindByTeamAndPeriod(ctx context.Context, teamID, assessmentPeriod string) (*Campaign, error)
+	// Create inserts a campaign unless one already exists for the same team and period.
+	// Returns false when the campaign already existed (e.g. opened by another replica).
+	Create(ctx context.Context, c *Campaign) (bool, error)
+	Close(ctx context.Context, id string, closedAt time.Time) error
+	// SetAnonymous turns anonymous mode on or off for a campaign. It fails once individual
+	// surveys have been submitted for the campaign's team and period, so a period is never mixed.
+	SetAnonymous(ctx context.Context, id string, anonymous bool) error
+}
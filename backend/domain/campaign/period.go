@@ -0,0 +1,75 @@
+package campaign
+
+import (
+	"fmt"
+	"time"
+)
+
+// Team cadence values (mirrors the CHECK constraint on teams.cadence)
+const (
+	CadenceMonthly    = "monthly"
+	CadenceQuarterly  = "quarterly"
+	CadenceHalfYearly = "half-yearly"
+	CadenceYearly     = "yearly"
+)
+
+// Period is a concrete assessment window derived from a cadence.
+// Label uses the same formats accepted by the health check API:
+//   - Monthly:     "YYYY Mon"
+//   - Quarterly:   "YYYY Q1" through "YYYY Q4"
+//   - Half-yearly: "YYYY H1" or "YYYY H2"
+//   - Yearly:      "YYYY"
+type Period struct {
+	Label     string
+	StartDate time.Time // first day of the period (inclusive)
+	EndDate   time.Time // last day of the period (inclusive)
+}
+
+// PeriodFor returns the assessment period containing the given time for a cadence.
+// Unknown or empty cadences fall back to monthly, matching the teams table default.
+func PeriodFor(cadence string, at time.Time) Period {
+	year := at.Year()
+	month := int(at.Month())
+
+	var startMonth, months int
+	var label string
+
+	switch cadence {
+	case CadenceQuarterly:
+		quarter := (month-1)/3 + 1
+		startMonth, months = (quarter-1)*3+1, 3
+		label = fmt.Sprintf("%d Q%d", year, quarter)
+	case CadenceHalfYearly:
+		half := 1
+		if month > 6 {
+			half = 2
+		}
+		startMonth, months = (half-1)*6+1, 6
+		label = fmt.Sprintf("%d H%d", year, half)
+	case CadenceYearly:
+		startMonth, months = 1, 12
+		label = fmt.Sprintf("%d", year)
+	default:
+		startMonth, months = month, 1
+		label = fmt.Sprintf("%d %s", year, time.Month(month).String()[:3])
+	}
+
+	start := time.Date(year, time.Month(startMonth), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, months, -1)
+
+	return Period{Label: label, StartDate: start, EndDate: end}
+}
+
+// NextCheckDate returns the first day of the period following the one containing at.
+func NextCheckDate(cadence string, at time.Time) time.Time {
+	return PeriodFor(cadence, at).EndDate.AddDate(0, 0, 1)
+}
+
+// IsValidCadence reports whether cadence is one of the supported team cadences
+func IsValidCadence(cadence string) bool {
+	switch cadence {
+	case CadenceMonthly, CadenceQuarterly, CadenceHalfYearly, CadenceYearly:
+		return true
+	}
+	return false
+}
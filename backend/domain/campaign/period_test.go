@@ -0,0 +1,42 @@
+package campaign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodFor_Unit(t *testing.T) {
+	at := time.Date(2025, time.August, 14, 9, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		cadence   string
+		label     string
+		startDate string
+		endDate   string
+		nextCheck string
+	}{
+		{"monthly", "2025 Aug", "2025-08-01", "2025-08-31", "2025-09-01"},
+		{"quarterly", "2025 Q3", "2025-07-01", "2025-09-30", "2025-10-01"},
+		{"half-yearly", "2025 H2", "2025-07-01", "2025-12-31", "2026-01-01"},
+		{"yearly", "2025", "2025-01-01", "2025-12-31", "2026-01-01"},
+		{"", "2025 Aug", "2025-08-01", "2025-08-31", "2025-09-01"},
+	}
+
+	for _, tc := range cases {
+		t.Run("cadence "+tc.cadence, func(t *testing.T) {
+			period := PeriodFor(tc.cadence, at)
+			assert.Equal(t, tc.label, period.Label)
+			assert.Equal(t, tc.startDate, period.StartDate.Format("2006-01-02"))
+			assert.Equal(t, tc.endDate, period.EndDate.Format("2006-01-02"))
+			assert.Equal(t, tc.nextCheck, NextCheckDate(tc.cadence, at).Format("2006-01-02"))
+		})
+	}
+
+	t.Run("february end date handles leap years", func(t *testing.T) {
+		period := PeriodFor("monthly", time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, "2024 Feb", period.Label)
+		assert.Equal(t, "2024-02-29", period.EndDate.Format("2006-01-02"))
+	})
+}
@@ -0,0 +1,73 @@
+package healthcheck
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// DefaultMinRespondents is the k-anonymity threshold used when a team has not set one
+const DefaultMinRespondents = 3
+
+// respondentTokenPrefix marks user IDs that are respondent tokens
+const respondentTokenPrefix = "anon_"
+
+// AnonymityPolicy describes how a team's individual surveys for a period are reported
+type AnonymityPolicy struct {
+	Anonymous      bool
+	MinRespondents int // anonymous responses are hidden until this many members have responded
+}
+
+// RespondentToken derives the one-way token stored in place of a user ID for an anonymous session.
+// It is stable for a member within one team and period, so repeat submissions count once, but
+// cannot be linked across periods or reversed without the secret.
+func RespondentToken(secret []byte, teamID, assessmentPeriod, userID string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(teamID + "\x00" + assessmentPeriod + "\x00" + userID))
+	return respondentTokenPrefix + hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// RedactAnonymous applies k-anonymity to sessions before they are reported. Anonymous sessions are
+// dropped for any team and period with fewer than minRespondents distinct respondents; the rest
+// keep their scores but lose their comments, which callers report without attribution.
+// Returns the visible sessions and the number suppressed.
+func RedactAnonymous(sessions []*HealthCheckSession, minRespondents int) ([]*HealthCheckSession, int) {
+	if minRespondents < 1 {
+		minRespondents = DefaultMinRespondents
+	}
+
+	respondents := make(map[string]map[string]bool)
+	for _, s := range sessions {
+		if !s.Anonymous {
+			continue
+		}
+		key := s.TeamID + "\x00" + s.AssessmentPeriod
+		if respondents[key] == nil {
+			respondents[key] = make(map[string]bool)
+		}
+		respondents[key][s.UserID] = true
+	}
+
+	visible := make([]*HealthCheckSession, 0, len(sessions))
+	suppressed := 0
+	for _, s := range sessions {
+		if !s.Anonymous {
+			visible = append(visible, s)
+			continue
+		}
+		if len(respondents[s.TeamID+"\x00"+s.AssessmentPeriod]) < minRespondents {
+			suppressed++
+			continue
+		}
+
+		redacted := *s
+		redacted.Responses = make([]HealthCheckResponse, len(s.Responses))
+		for i, resp := range s.Responses {
+			resp.Comment = ""
+			redacted.Responses[i] = resp
+		}
+		visible = append(visible, &redacted)
+	}
+
+	return visible, suppressed
+}
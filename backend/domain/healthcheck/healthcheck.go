@@ -1,6 +1,10 @@
 package healthcheck
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 // Survey type constants
 const (
@@ -16,7 +20,8 @@ type HealthCheckResponse struct {
 	Comment     string `json:"comment,omitempty"`
 }
 
-// HealthCheckSession represents a completed health check
+// HealthCheckSession represents a health check. Sessions with Completed false are a member's
+// draft: responses may be partial (zero Score or empty Trend) and they are left out of every report.
 // This is an aggregate root in DDD terms
 type HealthCheckSession struct {
 	ID               string                `json:"id"`
@@ -27,6 +32,36 @@ type HealthCheckSession struct {
 	SurveyType       string                `json:"surveyType,omitempty"`
 	Responses        []HealthCheckResponse `json:"responses"`
 	Completed        bool                  `json:"completed"`
+	TemplateID       string                `json:"templateId,omitempty"`      // survey template answered; empty when the team had none
+	TemplateVersion  int                   `json:"templateVersion,omitempty"` // version of TemplateID answered
+	Anonymous        bool                  `json:"anonymous,omitempty"`       // UserID holds a respondent token, not a user ID
+	ParticipantID    string                `json:"-"`                         // member recorded as having submitted an anonymous session; never stored with it
+}
+
+// Scoring selects how response scores are combined into an overall health score
+type Scoring string
+
+const (
+	// ScoringWeighted counts each response by its dimension's weight (the default)
+	ScoringWeighted Scoring = "weighted"
+	// ScoringUnweighted is the plain average of all response scores
+	ScoringUnweighted Scoring = "unweighted"
+)
+
+// ParseScoring parses a scoring mode; an empty value means weighted
+func ParseScoring(value string) (Scoring, error) {
+	switch Scoring(value) {
+	case "", ScoringWeighted:
+		return ScoringWeighted, nil
+	case ScoringUnweighted:
+		return ScoringUnweighted, nil
+	}
+	return "", fmt.Errorf("invalid scoring %q: must be weighted or unweighted", value)
+}
+
+// IsWeighted reports whether dimension weights apply
+func (s Scoring) IsWeighted() bool {
+	return s != ScoringUnweighted
 }
 
 // TeamHealthSummary represents aggregated health data for a team
@@ -66,12 +101,42 @@ type Repository interface {
 	Delete(ctx context.Context, id string) error
 
 	// Advanced queries for manager dashboard
-	FindTeamHealthByManager(ctx context.Context, managerID string, assessmentPeriod string) ([]TeamHealthSummary, error)
+	// FindTeamHealthByManager computes OverallHealth according to scoring
+	FindTeamHealthByManager(ctx context.Context, managerID string, assessmentPeriod string, scoring Scoring) ([]TeamHealthSummary, error)
 	FindAggregatedDimensionsByManager(ctx context.Context, managerID string, assessmentPeriod string) ([]DimensionSummary, error)
 
 	// Team submission status for post-workshop survey
 	GetTeamSubmissionStatus(ctx context.Context, teamID string, assessmentPeriod string) (*TeamSubmissionStatus, error)
 
+	// CountSubmissionsByManagerSince counts completed sessions created since a time, keyed by team ID,
+	// for teams where the manager is in the supervisor chain
+	CountSubmissionsByManagerSince(ctx context.Context, managerID string, since time.Time) (map[string]int, error)
+
+	// FindNonSubmitters returns IDs of team members without a completed individual survey for the period
+	FindNonSubmitters(ctx context.Context, teamID string, assessmentPeriod string) ([]string, error)
+
 	// FindDistinctAssessmentPeriods returns all unique assessment periods from submitted sessions
 	FindDistinctAssessmentPeriods(ctx context.Context) ([]string, error)
+
+	// FindDraft returns the member's open individual draft for the team and period.
+	// userID is the value stored on the session, i.e. the respondent token for anonymous surveys.
+	FindDraft(ctx context.Context, teamID string, userID string, assessmentPeriod string) (*HealthCheckSession, error)
+
+	// Amend replaces the responses of a completed session and appends its revisions
+	Amend(ctx context.Context, session *HealthCheckSession) error
+
+	// Withdraw deletes a completed session, keeping its revisions and recording the withdrawal.
+	// For anonymous sessions session.ParticipantID identifies the member whose participation is removed.
+	Withdraw(ctx context.Context, session *HealthCheckSession) error
+
+	// FindRevisions returns a session's revisions, oldest first. Revisions outlive withdrawn sessions.
+	FindRevisions(ctx context.Context, sessionID string) ([]*Revision, error)
+
+	// FindSubmissionWindow returns the campaign status and cadence that decide whether
+	// the team's sessions for the period can still be amended or withdrawn
+	FindSubmissionWindow(ctx context.Context, teamID string, assessmentPeriod string) (*SubmissionWindow, error)
+
+	// FindAnonymityPolicy returns whether individual surveys for the team and period are anonymous,
+	// either because the team is anonymous or because the period's campaign is
+	FindAnonymityPolicy(ctx context.Context, teamID string, assessmentPeriod string) (*AnonymityPolicy, error)
 }
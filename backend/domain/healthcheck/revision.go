@@ -0,0 +1,30 @@
+package healthcheck
+
+import "time"
+
+// Revision actions
+const (
+	RevisionSubmitted = "submitted" // the responses as originally submitted
+	RevisionAmended   = "amended"   // the responses after an amendment by the respondent
+	RevisionWithdrawn = "withdrawn" // the respondent withdrew the session
+)
+
+// Revision is an append-only record of a submitted session's responses.
+// A session gains revisions only once it is amended or withdrawn; revision 1 then holds the
+// original responses. Responses are never recorded for anonymous sessions, so a revision
+// shows that a change happened without revealing earlier answers.
+type Revision struct {
+	SessionID        string                `json:"sessionId"`
+	TeamID           string                `json:"teamId"`
+	AssessmentPeriod string                `json:"assessmentPeriod"`
+	Revision         int                   `json:"revision"`
+	Action           string                `json:"action"`
+	Responses        []HealthCheckResponse `json:"responses,omitempty"` // nil for anonymous sessions and withdrawals
+	CreatedAt        time.Time             `json:"createdAt"`
+}
+
+// SubmissionWindow holds what decides whether a team's sessions for a period can still be changed
+type SubmissionWindow struct {
+	CampaignStatus string // status of the period's survey campaign; empty when there is none
+	Cadence        string // the team's survey cadence
+}
@@ -12,6 +12,7 @@ type HierarchyLevel struct {
 	Position    int         `json:"position"` // Renamed from Level to Position for clarity
 	Color       string      `json:"color,omitempty"`
 	Permissions Permissions `json:"permissions"`
+	RequireMFA  bool        `json:"requireMfa"` // local users at this level must use TOTP MFA
 	CreatedAt   time.Time   `json:"createdAt,omitempty"`
 	UpdatedAt   time.Time   `json:"updatedAt,omitempty"`
 }
@@ -28,6 +29,58 @@ type Permissions struct {
 	CanExportData      bool `json:"canExportData,omitempty"`
 }
 
+// Permission names one of the Permissions flags
+type Permission string
+
+// Permissions a hierarchy level can be granted
+const (
+	PermissionViewAllTeams    Permission = "canViewAllTeams"
+	PermissionEditTeams       Permission = "canEditTeams"
+	PermissionManageUsers     Permission = "canManageUsers"
+	PermissionTakeSurvey      Permission = "canTakeSurvey"
+	PermissionViewAnalytics   Permission = "canViewAnalytics"
+	PermissionConfigureSystem Permission = "canConfigureSystem"
+	PermissionViewReports     Permission = "canViewReports"
+	PermissionExportData      Permission = "canExportData"
+)
+
+// Has reports whether the permission is granted. Unknown permissions are never granted.
+func (p Permissions) Has(permission Permission) bool {
+	switch permission {
+	case PermissionViewAllTeams:
+		return p.CanViewAllTeams
+	case PermissionEditTeams:
+		return p.CanEditTeams
+	case PermissionManageUsers:
+		return p.CanManageUsers
+	case PermissionTakeSurvey:
+		return p.CanTakeSurvey
+	case PermissionViewAnalytics:
+		return p.CanViewAnalytics
+	case PermissionConfigureSystem:
+		return p.CanConfigureSystem
+	case PermissionViewReports:
+		return p.CanViewReports
+	case PermissionExportData:
+		return p.CanExportData
+	}
+	return false
+}
+
+// Granted lists the granted permissions
+func (p Permissions) Granted() []Permission {
+	var granted []Permission
+	for _, permission := range []Permission{
+		PermissionViewAllTeams, PermissionEditTeams, PermissionManageUsers, PermissionTakeSurvey,
+		PermissionViewAnalytics, PermissionConfigureSystem, PermissionViewReports, PermissionExportData,
+	} {
+		if p.Has(permission) {
+			granted = append(granted, permission)
+		}
+	}
+	return granted
+}
+
 // HealthDimension represents a health check dimension
 type HealthDimension struct {
 	ID              string    `json:"id"`
@@ -47,8 +100,10 @@ type AppSettings struct {
 	SlackNotifications bool   `json:"slackNotifications"`
 	WeeklyDigest       bool   `json:"weeklyDigest"`
 	RetentionMonths    int    `json:"retentionMonths"`
+	RetentionArchive   bool   `json:"retentionArchive"` // archive rather than delete expired sessions
 	CompanyName        string `json:"companyName"`
 	LogoURL            string `json:"logoURL"`
+	ReminderOffsets    []int  `json:"reminderOffsets"` // days before a campaign ends
 }
 
 // OrganizationConfig represents the organization configuration
@@ -95,4 +150,6 @@ type Repository interface {
 	UpdateBrandingSettings(ctx context.Context, companyName string, logoURL string) error
 	UpdateNotificationSettings(ctx context.Context, email, slack, digest bool) error
 	UpdateRetentionSettings(ctx context.Context, months int) error
+	UpdateRetentionArchive(ctx context.Context, archive bool) error
+	UpdateReminderOffsets(ctx context.Context, offsets []int) error
 }
@@ -0,0 +1,57 @@
+package survey
+
+import (
+	"context"
+	"time"
+)
+
+// Template is a named collection of health dimensions that can be assigned to teams.
+// Its dimension set is versioned: changing the dimensions publishes a new, immutable
+// version, and every health check session records the version it answered.
+// Teams without a template answer all active dimensions.
+type Template struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Version     int       `json:"version"`      // current version
+	Dimensions  []string  `json:"dimensionIds"` // dimensions of the current version, in survey order
+	TeamCount   int       `json:"teamCount"`
+	CreatedAt   time.Time `json:"createdAt,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt,omitempty"`
+}
+
+// Version is an immutable snapshot of a template's dimension set
+type Version struct {
+	TemplateID string    `json:"templateId"`
+	Version    int       `json:"version"`
+	Dimensions []string  `json:"dimensionIds"`
+	CreatedAt  time.Time `json:"createdAt,omitempty"`
+}
+
+// Includes reports whether the version contains the dimension
+func (v *Version) Includes(dimensionID string) bool {
+	for _, id := range v.Dimensions {
+		if id == dimensionID {
+			return true
+		}
+	}
+	return false
+}
+
+// Repository defines the interface for survey template data access
+type Repository interface {
+	FindAll(ctx context.Context) ([]*Template, error)
+	FindByID(ctx context.Context, id string) (*Template, error)
+	FindVersions(ctx context.Context, templateID string) ([]*Version, error)
+	FindVersion(ctx context.Context, templateID string, version int) (*Version, error)
+	// FindCurrentForTeam returns the current version of the team's template, or nil if none is assigned
+	FindCurrentForTeam(ctx context.Context, teamID string) (*Version, error)
+	// Create inserts the template with its dimensions as version 1
+	Create(ctx context.Context, t *Template) error
+	// Update saves name and description. When dimensionIDs differ from the current version
+	// a new version is published; t.Version and t.Dimensions are updated accordingly.
+	Update(ctx context.Context, t *Template, dimensionIDs []string) error
+	Delete(ctx context.Context, id string) error
+	// CountSessions returns how many health check sessions recorded any version of the template
+	CountSessions(ctx context.Context, templateID string) (int, error)
+}
@@ -0,0 +1,19 @@
+package team
+
+import "strings"
+
+// IDFromName creates a URL-safe ID from a team name
+// e.g., "Test Team 123" -> "test-team-123"
+func IDFromName(name string) string {
+	// Convert to lowercase and replace spaces with hyphens
+	id := strings.ToLower(name)
+	id = strings.ReplaceAll(id, " ", "-")
+	// Remove any characters that aren't alphanumeric or hyphens
+	var result strings.Builder
+	for _, r := range id {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
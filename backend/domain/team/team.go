@@ -8,21 +8,27 @@ import (
 // Team represents a team in the organization
 // This is an aggregate root in DDD terms
 type Team struct {
-	ID                    string           `json:"id"`
-	Name                  string           `json:"name"`
-	Cadence               string           `json:"cadence"` // monthly, quarterly, half-yearly, yearly
-	NextCheckDate         string           `json:"nextCheckDate"`
-	TeamLeadID            *string          `json:"teamLeadId,omitempty"`
-	TeamLeadName          *string          `json:"teamLeadName,omitempty"`
-	Members               []TeamMember     `json:"members"`
-	MemberCount           int              `json:"memberCount"`
-	SupervisorChain       []SupervisorLink `json:"supervisorChain"`
-	DistributionListEmail *string          `json:"distributionListEmail,omitempty"`
-	Department            string           `json:"department,omitempty"`
-	Division              string           `json:"division,omitempty"`
-	Tags                  []string         `json:"tags,omitempty"`
-	CreatedAt             time.Time        `json:"createdAt,omitempty"`
-	UpdatedAt             time.Time        `json:"updatedAt,omitempty"`
+	ID                      string           `json:"id"`
+	Name                    string           `json:"name"`
+	Cadence                 string           `json:"cadence"` // monthly, quarterly, half-yearly, yearly
+	NextCheckDate           string           `json:"nextCheckDate"`
+	TeamLeadID              *string          `json:"teamLeadId,omitempty"`
+	TeamLeadName            *string          `json:"teamLeadName,omitempty"`
+	Members                 []TeamMember     `json:"members"`
+	MemberCount             int              `json:"memberCount"`
+	SupervisorChain         []SupervisorLink `json:"supervisorChain"`
+	DistributionListEmail   *string          `json:"distributionListEmail,omitempty"`
+	ChatWebhookURL          *string          `json:"chatWebhookUrl,omitempty"`
+	ChatWebhookFormat       string           `json:"chatWebhookFormat,omitempty"` // slack, generic
+	LegalHold               bool             `json:"legalHold"`                   // exempt from data retention
+	SurveyTemplateID        *string          `json:"surveyTemplateId,omitempty"`  // nil answers all active dimensions
+	Anonymous               bool             `json:"anonymous"`                   // individual surveys store a respondent token, not the user ID
+	AnonymityMinRespondents int              `json:"anonymityMinRespondents"`     // anonymous responses are hidden below this many respondents
+	Department              string           `json:"department,omitempty"`
+	Division                string           `json:"division,omitempty"`
+	Tags                    []string         `json:"tags,omitempty"`
+	CreatedAt               time.Time        `json:"createdAt,omitempty"`
+	UpdatedAt               time.Time        `json:"updatedAt,omitempty"`
 }
 
 // TeamMember represents a member of a team
@@ -59,6 +65,7 @@ type Repository interface {
 	AddMember(ctx context.Context, teamID, userID string) error
 	RemoveMember(ctx context.Context, teamID, userID string) error
 	UpdateSupervisorChain(ctx context.Context, teamID string, chain []*SupervisorLink) error
+	UpdateNextCheckDate(ctx context.Context, teamID string, nextCheckDate time.Time) error
 	// Additional methods for team details
 	FindTeamMembers(ctx context.Context, teamID string) ([]TeamMember, error)
 	CountTeamMembers(ctx context.Context, teamID string) (int, error)
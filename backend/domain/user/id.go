@@ -0,0 +1,20 @@
+package user
+
+import "strings"
+
+// IDFromUsername creates a URL-safe ID from a username
+// e.g., "test_user" -> "test-user"
+func IDFromUsername(username string) string {
+	// Convert to lowercase, replace underscores and spaces with hyphens
+	id := strings.ToLower(username)
+	id = strings.ReplaceAll(id, "_", "-")
+	id = strings.ReplaceAll(id, " ", "-")
+	// Remove any characters that aren't alphanumeric or hyphens
+	var result strings.Builder
+	for _, r := range id {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
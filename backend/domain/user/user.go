@@ -16,18 +16,25 @@ const (
 // User represents a user in the system
 // This is an aggregate root in DDD terms
 type User struct {
-	ID               string    `json:"id"`
-	Username         string    `json:"username"`
-	Name             string    `json:"name"`
-	Email            string    `json:"email,omitempty"`
-	HierarchyLevelID string    `json:"hierarchyLevelId,omitempty"`
-	ReportsTo        *string   `json:"reportsTo,omitempty"`
-	TeamIDs          []string  `json:"teamIds"`
-	IsAdmin          bool      `json:"isAdmin,omitempty"`
-	PasswordHash     string    `json:"-"` // Never serialize to JSON
-	AuthType         AuthType  `json:"authType,omitempty"`
-	CreatedAt        time.Time `json:"createdAt,omitempty"`
-	UpdatedAt        time.Time `json:"updatedAt,omitempty"`
+	ID               string     `json:"id"`
+	Username         string     `json:"username"`
+	Name             string     `json:"name"`
+	Email            string     `json:"email,omitempty"`
+	HierarchyLevelID string     `json:"hierarchyLevelId,omitempty"`
+	ReportsTo        *string    `json:"reportsTo,omitempty"`
+	TeamIDs          []string   `json:"teamIds"`
+	IsAdmin          bool       `json:"isAdmin,omitempty"`
+	PasswordHash     string     `json:"-"` // Never serialize to JSON
+	AuthType         AuthType   `json:"authType,omitempty"`
+	ExternalID       string     `json:"externalId,omitempty"`    // identity provider ID of a provisioned user
+	DeactivatedAt    *time.Time `json:"deactivatedAt,omitempty"` // nil while the user is active
+	CreatedAt        time.Time  `json:"createdAt,omitempty"`
+	UpdatedAt        time.Time  `json:"updatedAt,omitempty"`
+}
+
+// IsActive reports whether the user may sign in
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
 }
 
 // Repository defines the interface for user data access
@@ -47,4 +54,7 @@ type Repository interface {
 	FindTeamsWhereUserIsLead(ctx context.Context, userID string) ([]string, error)
 	// Password management
 	UpdatePassword(ctx context.Context, userID string, hashedPassword string) error
+	// Provisioning
+	FindByExternalID(ctx context.Context, externalID string) (*User, error)
+	SetActive(ctx context.Context, userID string, active bool) error
 }
@@ -0,0 +1,86 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/agopalakrishnan/teams360/backend/pkg/outbound"
+)
+
+// Supported webhook payload formats
+const (
+	FormatSlack   = "slack"   // Slack incoming webhooks
+	FormatGeneric = "generic" // Microsoft Teams / Mattermost compatible JSON
+)
+
+// Field is a labelled value shown beneath the message text
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Message is a transport-neutral chat notification
+type Message struct {
+	Title  string
+	Text   string
+	Fields []Field
+	Color  string // hex colour without '#', e.g. "10B981"
+}
+
+// Channel delivers messages to a chat webhook URL.
+// All webhook implementations (Slack, generic) satisfy this.
+type Channel interface {
+	Send(ctx context.Context, webhookURL string, msg Message) error
+}
+
+// IsValidFormat reports whether format is a supported webhook payload format
+func IsValidFormat(format string) bool {
+	return format == FormatSlack || format == FormatGeneric
+}
+
+// NewChannels returns a channel for every supported format, keyed by format name.
+// client may be nil, in which case a client with a 10 second timeout that only connects to
+// public addresses is used. Redirects are not followed with either.
+func NewChannels(client *http.Client) map[string]Channel {
+	if client == nil {
+		client = outbound.NewPublicClient()
+	}
+	client = outbound.WithoutRedirects(client)
+	return map[string]Channel{
+		FormatSlack:   NewSlackChannel(client),
+		FormatGeneric: NewGenericChannel(client),
+	}
+}
+
+// postJSON posts payload to url and treats any non-2xx response as an error.
+// Errors carry only the status, so nothing the remote server sends ends up in logs.
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The body is only drained for connection reuse
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
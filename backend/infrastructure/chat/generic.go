@@ -0,0 +1,51 @@
+package chat
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// GenericChannel posts messages as legacy MessageCard JSON. Microsoft Teams renders the
+// card sections; Mattermost and other Slack-compatible receivers use the top-level "text".
+type GenericChannel struct {
+	client *http.Client
+}
+
+// NewGenericChannel creates a new generic webhook channel
+func NewGenericChannel(client *http.Client) *GenericChannel {
+	return &GenericChannel{client: client}
+}
+
+// Send posts msg to the webhook URL
+func (g *GenericChannel) Send(ctx context.Context, webhookURL string, msg Message) error {
+	return postJSON(ctx, g.client, webhookURL, genericPayload(msg))
+}
+
+// genericPayload builds the MessageCard JSON body for msg
+func genericPayload(msg Message) map[string]interface{} {
+	facts := make([]map[string]string, 0, len(msg.Fields))
+	lines := []string{"**" + msg.Title + "**"}
+	if msg.Text != "" {
+		lines = append(lines, msg.Text)
+	}
+	for _, f := range msg.Fields {
+		facts = append(facts, map[string]string{"name": f.Name, "value": f.Value})
+		lines = append(lines, "- "+f.Name+": "+f.Value)
+	}
+
+	payload := map[string]interface{}{
+		"@type":    "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"summary":  msg.Title,
+		"title":    msg.Title,
+		"text":     strings.Join(lines, "\n"),
+		"sections": []map[string]interface{}{
+			{"activityText": msg.Text, "facts": facts},
+		},
+	}
+	if msg.Color != "" {
+		payload["themeColor"] = msg.Color
+	}
+	return payload
+}
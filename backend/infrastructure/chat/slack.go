@@ -0,0 +1,65 @@
+package chat
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// SlackChannel posts messages to Slack incoming webhooks using Block Kit,
+// with a plain-text fallback for notifications and older clients.
+type SlackChannel struct {
+	client *http.Client
+}
+
+// NewSlackChannel creates a new Slack webhook channel
+func NewSlackChannel(client *http.Client) *SlackChannel {
+	return &SlackChannel{client: client}
+}
+
+// Send posts msg to the Slack incoming webhook URL
+func (s *SlackChannel) Send(ctx context.Context, webhookURL string, msg Message) error {
+	return postJSON(ctx, s.client, webhookURL, slackPayload(msg))
+}
+
+// slackPayload builds the Slack webhook JSON body for msg
+func slackPayload(msg Message) map[string]interface{} {
+	blocks := []map[string]interface{}{
+		{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": msg.Title},
+		},
+	}
+	if msg.Text != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": msg.Text},
+		})
+	}
+
+	// Slack allows at most 10 fields per section block
+	for start := 0; start < len(msg.Fields); start += 10 {
+		end := start + 10
+		if end > len(msg.Fields) {
+			end = len(msg.Fields)
+		}
+		fields := make([]map[string]interface{}, 0, end-start)
+		for _, f := range msg.Fields[start:end] {
+			fields = append(fields, map[string]interface{}{
+				"type": "mrkdwn",
+				"text": "*" + f.Name + "*\n" + f.Value,
+			})
+		}
+		blocks = append(blocks, map[string]interface{}{"type": "section", "fields": fields})
+	}
+
+	fallback := []string{msg.Title}
+	if msg.Text != "" {
+		fallback = append(fallback, msg.Text)
+	}
+
+	return map[string]interface{}{
+		"text":   strings.Join(fallback, "\n"),
+		"blocks": blocks,
+	}
+}
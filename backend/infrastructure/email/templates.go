@@ -31,6 +31,55 @@ type TeamSummaryEmailData struct {
 	Dimensions       []DimensionResult
 }
 
+// DigestTeamSubmissions holds the number of new submissions for a team in the digest week.
+type DigestTeamSubmissions struct {
+	TeamName string
+	Count    int
+}
+
+// DigestScoreDrop describes a team whose overall health fell since its previous period.
+type DigestScoreDrop struct {
+	TeamName       string
+	PreviousPeriod string
+	CurrentPeriod  string
+	PreviousScore  float64
+	CurrentScore   float64
+}
+
+// DigestActionItem describes an overdue action item in the digest.
+type DigestActionItem struct {
+	TeamName     string
+	Title        string
+	DueDate      string // YYYY-MM-DD
+	AssigneeName string
+}
+
+// WeeklyDigestEmailData holds data for the weekly manager digest.
+type WeeklyDigestEmailData struct {
+	ManagerName    string
+	WeekOf         string // YYYY-MM-DD, Monday of the digest week
+	NewSubmissions []DigestTeamSubmissions
+	ScoreDrops     []DigestScoreDrop
+	OverdueItems   []DigestActionItem
+}
+
+// SurveyReminderEmailData holds data for the reminder sent to members who have not submitted.
+type SurveyReminderEmailData struct {
+	UserName         string
+	TeamName         string
+	AssessmentPeriod string
+	DueDate          string // YYYY-MM-DD, last day of the campaign
+	DaysLeft         int
+}
+
+// AccountLockoutEmailData holds data for the notice sent when an account is locked after failed logins.
+type AccountLockoutEmailData struct {
+	UserName    string
+	Username    string
+	Failures    int
+	LockedUntil string // formatted time the lockout ends
+}
+
 // ScoreToLabel converts a numeric score to a label.
 func ScoreToLabel(score int) string {
 	switch score {
@@ -213,3 +262,197 @@ func RenderTeamSummaryEmail(data TeamSummaryEmailData) string {
 </body>
 </html>`, escapedTeamName, escapedPeriod, escapedSubmittedBy, rows.String())
 }
+
+// RenderWeeklyDigestEmail renders the weekly summary sent to managers for their supervised teams.
+func RenderWeeklyDigestEmail(data WeeklyDigestEmailData) string {
+	const cell = `padding:8px 12px;border-bottom:1px solid #E5E7EB;`
+	const emptyRow = `<tr><td colspan="%d" style="padding:8px 12px;font-size:13px;color:#9CA3AF;">%s</td></tr>`
+
+	var submissions strings.Builder
+	for _, s := range data.NewSubmissions {
+		submissions.WriteString(fmt.Sprintf(`<tr>
+  <td style="%sfont-weight:500;">%s</td>
+  <td style="%stext-align:center;">%d</td>
+</tr>`, cell, html.EscapeString(s.TeamName), cell, s.Count))
+	}
+	if len(data.NewSubmissions) == 0 {
+		submissions.WriteString(fmt.Sprintf(emptyRow, 2, "No new submissions this week."))
+	}
+
+	var drops strings.Builder
+	for _, d := range data.ScoreDrops {
+		drops.WriteString(fmt.Sprintf(`<tr>
+  <td style="%sfont-weight:500;">%s</td>
+  <td style="%stext-align:center;">%.2f <span style="color:#9CA3AF;font-size:11px;">(%s)</span></td>
+  <td style="%stext-align:center;color:#EF4444;font-weight:600;">%.2f <span style="color:#9CA3AF;font-size:11px;font-weight:400;">(%s)</span></td>
+</tr>`, cell, html.EscapeString(d.TeamName),
+			cell, d.PreviousScore, html.EscapeString(d.PreviousPeriod),
+			cell, d.CurrentScore, html.EscapeString(d.CurrentPeriod)))
+	}
+	if len(data.ScoreDrops) == 0 {
+		drops.WriteString(fmt.Sprintf(emptyRow, 3, "No teams dropped in health."))
+	}
+
+	var overdue strings.Builder
+	for _, item := range data.OverdueItems {
+		assignee := item.AssigneeName
+		if assignee == "" {
+			assignee = "Unassigned"
+		}
+		overdue.WriteString(fmt.Sprintf(`<tr>
+  <td style="%sfont-weight:500;">%s</td>
+  <td style="%s">%s</td>
+  <td style="%stext-align:center;color:#EF4444;">%s</td>
+  <td style="%sfont-size:13px;color:#4B5563;">%s</td>
+</tr>`, cell, html.EscapeString(item.TeamName),
+			cell, html.EscapeString(item.Title),
+			cell, html.EscapeString(item.DueDate),
+			cell, html.EscapeString(assignee)))
+	}
+	if len(data.OverdueItems) == 0 {
+		overdue.WriteString(fmt.Sprintf(emptyRow, 4, "No overdue action items."))
+	}
+
+	const header = `<th style="padding:10px 12px;text-align:%s;font-size:12px;color:#6B7280;text-transform:uppercase;">%s</th>`
+	const table = `<h2 style="margin:0 0 8px;color:#374151;font-size:16px;">%s</h2>
+    <table width="100%%" cellpadding="0" cellspacing="0" style="border:1px solid #E5E7EB;border-radius:6px;overflow:hidden;margin:0 0 24px;">
+      <thead><tr style="background:#F9FAFB;">%s</tr></thead>
+      <tbody>%s</tbody>
+    </table>`
+	section := func(title, headers, body string) string {
+		return fmt.Sprintf(table, title, headers, body)
+	}
+
+	submissionsTable := section("New Submissions",
+		fmt.Sprintf(header, "left", "Team")+fmt.Sprintf(header, "center", "Submissions"),
+		submissions.String())
+	dropsTable := section("Teams with Declining Health",
+		fmt.Sprintf(header, "left", "Team")+fmt.Sprintf(header, "center", "Previous")+fmt.Sprintf(header, "center", "Current"),
+		drops.String())
+	overdueTable := section("Overdue Action Items",
+		fmt.Sprintf(header, "left", "Team")+fmt.Sprintf(header, "left", "Action Item")+fmt.Sprintf(header, "center", "Due")+fmt.Sprintf(header, "left", "Assignee"),
+		overdue.String())
+
+	escapedManagerName := html.EscapeString(data.ManagerName)
+	escapedWeekOf := html.EscapeString(data.WeekOf)
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"></head>
+<body style="margin:0;padding:0;background:#F3F4F6;font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,sans-serif;">
+<table width="100%%" cellpadding="0" cellspacing="0" style="background:#F3F4F6;padding:24px 0;">
+<tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background:#fff;border-radius:8px;overflow:hidden;box-shadow:0 1px 3px rgba(0,0,0,0.1);">
+  <tr><td style="background:#0F766E;padding:24px 32px;">
+    <h1 style="margin:0;color:#fff;font-size:20px;">Teams360</h1>
+    <p style="margin:4px 0 0;color:#99F6E4;font-size:14px;">Weekly Digest — week of %s</p>
+  </td></tr>
+  <tr><td style="padding:24px 32px;">
+    <p style="margin:0 0 16px;color:#374151;">Hi <strong>%s</strong>, here is what happened across your teams this week.</p>
+    %s
+    %s
+    %s
+    <p style="margin:0;color:#9CA3AF;font-size:12px;">You receive this digest because weekly digests are enabled. Log in to Teams360 for full analytics.</p>
+  </td></tr>
+  <tr><td style="background:#F9FAFB;padding:16px 32px;text-align:center;">
+    <p style="margin:0;color:#9CA3AF;font-size:11px;">Teams360 — Team Health Check Platform</p>
+  </td></tr>
+</table>
+</td></tr>
+</table>
+</body>
+</html>`, escapedWeekOf, escapedManagerName, submissionsTable, dropsTable, overdueTable)
+}
+
+// RenderSurveyReminderEmail renders the HTML reminder for a team member who has not yet submitted.
+func RenderSurveyReminderEmail(data SurveyReminderEmailData) string {
+	escapedUserName := html.EscapeString(data.UserName)
+	escapedTeamName := html.EscapeString(data.TeamName)
+	escapedPeriod := html.EscapeString(data.AssessmentPeriod)
+	escapedDueDate := html.EscapeString(data.DueDate)
+
+	deadline := fmt.Sprintf("%d days", data.DaysLeft)
+	switch data.DaysLeft {
+	case 0:
+		deadline = "today"
+	case 1:
+		deadline = "1 day"
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"></head>
+<body style="margin:0;padding:0;background:#F3F4F6;font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,sans-serif;">
+<table width="100%%" cellpadding="0" cellspacing="0" style="background:#F3F4F6;padding:24px 0;">
+<tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background:#fff;border-radius:8px;overflow:hidden;box-shadow:0 1px 3px rgba(0,0,0,0.1);">
+  <tr><td style="background:#1E40AF;padding:24px 32px;">
+    <h1 style="margin:0;color:#fff;font-size:20px;">Teams360</h1>
+    <p style="margin:4px 0 0;color:#BFDBFE;font-size:14px;">Health Check Reminder</p>
+  </td></tr>
+  <tr><td style="padding:24px 32px;">
+    <p style="margin:0 0 8px;color:#374151;">Hi <strong>%s</strong>,</p>
+    <p style="margin:0 0 16px;color:#6B7280;font-size:14px;">
+      The <strong>%s</strong> health check for <strong>%s</strong> is still waiting for your responses.
+    </p>
+    <table width="100%%" cellpadding="0" cellspacing="0" style="border:1px solid #E5E7EB;border-radius:6px;overflow:hidden;">
+      <tr>
+        <td style="padding:10px 12px;font-size:12px;color:#6B7280;text-transform:uppercase;background:#F9FAFB;">Closes</td>
+        <td style="padding:10px 12px;font-weight:500;color:#374151;">%s</td>
+      </tr>
+      <tr>
+        <td style="padding:10px 12px;font-size:12px;color:#6B7280;text-transform:uppercase;background:#F9FAFB;border-top:1px solid #E5E7EB;">Time left</td>
+        <td style="padding:10px 12px;font-weight:500;color:#374151;border-top:1px solid #E5E7EB;">%s</td>
+      </tr>
+    </table>
+    <p style="margin:24px 0 0;color:#9CA3AF;font-size:12px;">Log in to Teams360 to complete the survey. You will stop receiving reminders once you submit.</p>
+  </td></tr>
+  <tr><td style="background:#F9FAFB;padding:16px 32px;text-align:center;">
+    <p style="margin:0;color:#9CA3AF;font-size:11px;">Teams360 — Team Health Check Platform</p>
+  </td></tr>
+</table>
+</td></tr>
+</table>
+</body>
+</html>`, escapedUserName, escapedPeriod, escapedTeamName, escapedDueDate, deadline)
+}
+
+// RenderAccountLockoutEmail renders the HTML notice sent when repeated failed logins lock an account.
+func RenderAccountLockoutEmail(data AccountLockoutEmailData) string {
+	escapedUserName := html.EscapeString(data.UserName)
+	escapedUsername := html.EscapeString(data.Username)
+	escapedLockedUntil := html.EscapeString(data.LockedUntil)
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"></head>
+<body style="margin:0;padding:0;background:#F3F4F6;font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,sans-serif;">
+<table width="100%%" cellpadding="0" cellspacing="0" style="background:#F3F4F6;padding:24px 0;">
+<tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background:#fff;border-radius:8px;overflow:hidden;box-shadow:0 1px 3px rgba(0,0,0,0.1);">
+  <tr><td style="background:#B91C1C;padding:24px 32px;">
+    <h1 style="margin:0;color:#fff;font-size:20px;">Teams360</h1>
+    <p style="margin:4px 0 0;color:#FECACA;font-size:14px;">Account Locked</p>
+  </td></tr>
+  <tr><td style="padding:24px 32px;">
+    <p style="margin:0 0 8px;color:#374151;">Hi <strong>%s</strong>,</p>
+    <p style="margin:0 0 16px;color:#6B7280;font-size:14px;">
+      Your account <strong>%s</strong> was locked after <strong>%d</strong> failed sign-in attempts.
+    </p>
+    <table width="100%%" cellpadding="0" cellspacing="0" style="border:1px solid #E5E7EB;border-radius:6px;overflow:hidden;">
+      <tr>
+        <td style="padding:10px 12px;font-size:12px;color:#6B7280;text-transform:uppercase;background:#F9FAFB;">Locked until</td>
+        <td style="padding:10px 12px;font-weight:500;color:#374151;">%s</td>
+      </tr>
+    </table>
+    <p style="margin:24px 0 0;color:#6B7280;font-size:14px;">The account unlocks automatically at that time, or an administrator can unlock it sooner. If these attempts were not you, reset your password once the account is unlocked.</p>
+  </td></tr>
+  <tr><td style="background:#F9FAFB;padding:16px 32px;text-align:center;">
+    <p style="margin:0;color:#9CA3AF;font-size:11px;">Teams360 — Team Health Check Platform</p>
+  </td></tr>
+</table>
+</td></tr>
+</table>
+</body>
+</html>`, escapedUserName, escapedUsername, data.Failures, escapedLockedUntil)
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+)
+
+// adminAuditFilterClause matches the filter arguments built by adminAuditFilterArgs
+const adminAuditFilterClause = `
+	WHERE ($1 = '' OR actor_id = $1)
+		AND ($2 = '' OR action = $2)
+		AND ($3 = '' OR target_type = $3)
+		AND ($4 = '' OR target_id = $4)
+		AND ($5::timestamptz IS NULL OR created_at >= $5)
+		AND ($6::timestamptz IS NULL OR created_at < $6)
+`
+
+// AdminAuditRepository implements services.AdminAuditRepository
+type AdminAuditRepository struct {
+	db *sql.DB
+}
+
+// NewAdminAuditRepository creates a new admin audit repository
+func NewAdminAuditRepository(db *sql.DB) *AdminAuditRepository {
+	return &AdminAuditRepository{db: db}
+}
+
+// AppendAuditEntry stores an entry, setting its ID and CreatedAt
+func (r *AdminAuditRepository) AppendAuditEntry(ctx context.Context, entry *services.AdminAuditEntry) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO admin_audit_log
+			(actor_id, actor_username, action, target_type, target_id, before_state, after_state, ip_address, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`, entry.ActorID, entry.ActorUsername, entry.Action, entry.TargetType, entry.TargetID,
+		nullableJSON(entry.Before), nullableJSON(entry.After), entry.IPAddress, entry.RequestID,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
+
+// FindAuditEntries returns a page of matching entries, newest first, and the number of matches
+func (r *AdminAuditRepository) FindAuditEntries(ctx context.Context, filter services.AdminAuditFilter) ([]services.AdminAuditEntry, int, error) {
+	args := adminAuditFilterArgs(filter)
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM admin_audit_log`+adminAuditFilterClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, actor_id, actor_username, action, target_type, target_id,
+			before_state, after_state, ip_address, request_id, created_at
+		FROM admin_audit_log`+adminAuditFilterClause+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $7 OFFSET $8
+	`, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []services.AdminAuditEntry{}
+	for rows.Next() {
+		entry, err := scanAdminAuditEntry(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// StreamAuditEntries calls fn for every matching entry, oldest first, stopping at the first error fn returns
+func (r *AdminAuditRepository) StreamAuditEntries(ctx context.Context, filter services.AdminAuditFilter, fn func(services.AdminAuditEntry) error) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, actor_id, actor_username, action, target_type, target_id,
+			before_state, after_state, ip_address, request_id, created_at
+		FROM admin_audit_log`+adminAuditFilterClause+`
+		ORDER BY created_at, id
+	`, adminAuditFilterArgs(filter)...)
+	if err != nil {
+		return fmt.Errorf("failed to query audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanAdminAuditEntry(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+	return nil
+}
+
+func adminAuditFilterArgs(filter services.AdminAuditFilter) []interface{} {
+	var from, to sql.NullTime
+	if filter.From != nil {
+		from = sql.NullTime{Time: *filter.From, Valid: true}
+	}
+	if filter.To != nil {
+		to = sql.NullTime{Time: *filter.To, Valid: true}
+	}
+	return []interface{}{filter.ActorID, filter.Action, filter.TargetType, filter.TargetID, from, to}
+}
+
+func scanAdminAuditEntry(rows *sql.Rows) (services.AdminAuditEntry, error) {
+	var entry services.AdminAuditEntry
+	var before, after []byte
+	if err := rows.Scan(&entry.ID, &entry.ActorID, &entry.ActorUsername, &entry.Action, &entry.TargetType, &entry.TargetID,
+		&before, &after, &entry.IPAddress, &entry.RequestID, &entry.CreatedAt); err != nil {
+		return entry, fmt.Errorf("failed to scan audit entry: %w", err)
+	}
+	entry.Before = before
+	entry.After = after
+	return entry, nil
+}
+
+// nullableJSON stores an empty JSON value as NULL
+func nullableJSON(value []byte) interface{} {
+	if len(value) == 0 {
+		return nil
+	}
+	return string(value)
+}
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/lib/pq"
+)
+
+// APITokenRepository implements services.APITokenStore
+type APITokenRepository struct {
+	db *sql.DB
+}
+
+// NewAPITokenRepository creates a new API token repository
+func NewAPITokenRepository(db *sql.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+const apiTokenColumns = `id, name, token_prefix, user_id, kind, scopes, created_by, expires_at, last_used_at, revoked_at, created_at`
+
+// CreateAPIToken stores a new token with the hash of its secret
+func (r *APITokenRepository) CreateAPIToken(ctx context.Context, token *services.APIToken, tokenHash string) error {
+	scopes := make([]string, len(token.Scopes))
+	for i, scope := range token.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO api_tokens (id, name, token_hash, token_prefix, user_id, kind, scopes, created_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, token.ID, token.Name, tokenHash, token.Prefix, token.UserID, string(token.Kind), pq.Array(scopes), token.CreatedBy, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+	return nil
+}
+
+// FindAPITokenByHash returns the token with the given hash, or nil when there is none
+func (r *APITokenRepository) FindAPITokenByHash(ctx context.Context, tokenHash string) (*services.APIToken, error) {
+	token, err := scanAPIToken(r.db.QueryRowContext(ctx, `
+		SELECT `+apiTokenColumns+` FROM api_tokens WHERE token_hash = $1
+	`, tokenHash))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API token: %w", err)
+	}
+	return token, nil
+}
+
+// FindAPIToken returns the token with the given ID, or nil when there is none
+func (r *APITokenRepository) FindAPIToken(ctx context.Context, id string) (*services.APIToken, error) {
+	token, err := scanAPIToken(r.db.QueryRowContext(ctx, `
+		SELECT `+apiTokenColumns+` FROM api_tokens WHERE id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API token: %w", err)
+	}
+	return token, nil
+}
+
+// ListAPITokens returns the user's tokens, newest first, or every token when userID is empty
+func (r *APITokenRepository) ListAPITokens(ctx context.Context, userID string) ([]*services.APIToken, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+apiTokenColumns+` FROM api_tokens
+		WHERE $1 = '' OR user_id = $1
+		ORDER BY created_at DESC, id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []*services.APIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// RevokeAPIToken marks an unrevoked token as revoked
+func (r *APITokenRepository) RevokeAPIToken(ctx context.Context, id string, revokedAt time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE api_tokens SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL
+	`, revokedAt, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke API token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RevokeUserAPITokens revokes every unrevoked token of the user and returns how many it revoked
+func (r *APITokenRepository) RevokeUserAPITokens(ctx context.Context, userID string, revokedAt time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE api_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL
+	`, revokedAt, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke user API tokens: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// TouchAPIToken records a use of the token unless one was recorded after notBefore
+func (r *APITokenRepository) TouchAPIToken(ctx context.Context, id string, usedAt, notBefore time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE api_tokens SET last_used_at = $1
+		WHERE id = $2 AND (last_used_at IS NULL OR last_used_at < $3)
+	`, usedAt, id, notBefore)
+	if err != nil {
+		return fmt.Errorf("failed to record API token use: %w", err)
+	}
+	return nil
+}
+
+// scanAPIToken reads a row of apiTokenColumns
+func scanAPIToken(row interface{ Scan(...interface{}) error }) (*services.APIToken, error) {
+	var token services.APIToken
+	var kind string
+	var scopes []string
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+
+	err := row.Scan(&token.ID, &token.Name, &token.Prefix, &token.UserID, &kind, pq.Array(&scopes),
+		&token.CreatedBy, &expiresAt, &lastUsedAt, &revokedAt, &token.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	token.Kind = services.APITokenKind(kind)
+	token.Scopes = make([]services.APITokenScope, len(scopes))
+	for i, scope := range scopes {
+		token.Scopes[i] = services.APITokenScope(scope)
+	}
+	if expiresAt.Valid {
+		token.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		token.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	return &token, nil
+}
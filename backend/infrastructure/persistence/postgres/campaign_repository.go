@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+)
+
+// CampaignRepository implements the campaign.Repository interface
+type CampaignRepository struct {
+	db *sql.DB
+}
+
+// NewCampaignRepository creates a new repository instance
+func NewCampaignRepository(db *sql.DB) campaign.Repository {
+	return &CampaignRepository{db: db}
+}
+
+const campaignSelectColumns = `
+	SELECT c.id, c.team_id, t.name, c.assessment_period, c.cadence, c.status,
+	       c.start_date, c.end_date, c.closed_at, c.anonymous, c.created_at, c.updated_at
+	FROM survey_campaigns c
+	INNER JOIN teams t ON t.id = c.team_id
+`
+
+// FindByID retrieves a campaign by ID
+func (r *CampaignRepository) FindByID(ctx context.Context, id string) (*campaign.Campaign, error) {
+	row := r.db.QueryRowContext(ctx, campaignSelectColumns+`WHERE c.id = $1`, id)
+
+	c, err := scanCampaign(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("campaign not found: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find campaign: %w", err)
+	}
+	return c, nil
+}
+
+// FindByTeamAndPeriod retrieves the campaign for a team and assessment period
+func (r *CampaignRepository) FindByTeamAndPeriod(ctx context.Context, teamID, assessmentPeriod string) (*campaign.Campaign, error) {
+	row := r.db.QueryRowContext(ctx, campaignSelectColumns+`WHERE c.team_id = $1 AND c.assessment_period = $2`, teamID, assessmentPeriod)
+
+	c, err := scanCampaign(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("campaign not found: %s/%s", teamID, assessmentPeriod)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find campaign: %w", err)
+	}
+	return c, nil
+}
+
+// FindAll retrieves campaigns matching the filter, newest period first
+func (r *CampaignRepository) FindAll(ctx context.Context, filter campaign.Filter) ([]*campaign.Campaign, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.TeamID != "" {
+		args = append(args, filter.TeamID)
+		conditions = append(conditions, fmt.Sprintf("c.team_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("c.status = $%d", len(args)))
+	}
+	if filter.AssessmentPeriod != "" {
+		args = append(args, filter.AssessmentPeriod)
+		conditions = append(conditions, fmt.Sprintf("c.assessment_period = $%d", len(args)))
+	}
+
+	query := campaignSelectColumns
+	if len(conditions) > 0 {
+		query += "WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY c.start_date DESC, t.name"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	campaigns := []*campaign.Campaign{}
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan campaign: %w", err)
+		}
+		campaigns = append(campaigns, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return campaigns, nil
+}
+
+// Create inserts a new campaign; existing (team, period) pairs are left untouched
+func (r *CampaignRepository) Create(ctx context.Context, c *campaign.Campaign) (bool, error) {
+	now := time.Now()
+	if c.Status == "" {
+		c.Status = campaign.StatusOpen
+	}
+	c.CreatedAt = now
+	c.UpdatedAt = now
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO survey_campaigns (id, team_id, assessment_period, cadence, status, start_date, end_date, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (team_id, assessment_period) DO NOTHING
+	`, c.ID, c.TeamID, c.AssessmentPeriod, c.Cadence, c.Status, c.StartDate, c.EndDate, c.CreatedAt, c.UpdatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Close marks a campaign as closed
+func (r *CampaignRepository) Close(ctx context.Context, id string, closedAt time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE survey_campaigns
+		SET status = $1, closed_at = $2, updated_at = $2
+		WHERE id = $3 AND status = $4
+	`, campaign.StatusClosed, closedAt, id, campaign.StatusOpen)
+	if err != nil {
+		return fmt.Errorf("failed to close campaign: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("open campaign not found: %s", id)
+	}
+
+	return nil
+}
+
+// SetAnonymous turns anonymous mode on or off unless individual surveys were already submitted.
+// Drafts do not count as submissions.
+func (r *CampaignRepository) SetAnonymous(ctx context.Context, id string, anonymous bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var hasSubmissions bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM health_check_sessions s
+			WHERE s.team_id = c.team_id AND s.assessment_period = c.assessment_period
+				AND s.survey_type = 'individual' AND s.completed = true
+		)
+		FROM survey_campaigns c
+		WHERE c.id = $1
+		FOR UPDATE OF c
+	`, id).Scan(&hasSubmissions)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("campaign not found: %s", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check campaign submissions: %w", err)
+	}
+	if hasSubmissions {
+		return fmt.Errorf("campaign already has submissions: %s", id)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE survey_campaigns SET anonymous = $1, updated_at = NOW() WHERE id = $2
+	`, anonymous, id)
+	if err != nil {
+		return fmt.Errorf("failed to update campaign: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// rowScanner abstracts *sql.Row and *sql.Rows for shared scan helpers
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCampaign scans a single campaign row selected with campaignSelectColumns
+func scanCampaign(s rowScanner) (*campaign.Campaign, error) {
+	var c campaign.Campaign
+	var closedAt, createdAt, updatedAt sql.NullTime
+
+	err := s.Scan(
+		&c.ID,
+		&c.TeamID,
+		&c.TeamName,
+		&c.AssessmentPeriod,
+		&c.Cadence,
+		&c.Status,
+		&c.StartDate,
+		&c.EndDate,
+		&closedAt,
+		&c.Anonymous,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if closedAt.Valid {
+		c.ClosedAt = &closedAt.Time
+	}
+	if createdAt.Valid {
+		c.CreatedAt = createdAt.Time
+	}
+	if updatedAt.Valid {
+		c.UpdatedAt = updatedAt.Time
+	}
+
+	return &c, nil
+}
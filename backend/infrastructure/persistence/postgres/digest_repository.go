@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+)
+
+// DigestRepository implements services.DigestRepository
+type DigestRepository struct {
+	db *sql.DB
+}
+
+// NewDigestRepository creates a new digest repository
+func NewDigestRepository(db *sql.DB) *DigestRepository {
+	return &DigestRepository{db: db}
+}
+
+// FindOverdueActionItemsByManager returns unfinished action items past their due date
+// for teams where the manager is in the supervisor chain
+func (r *DigestRepository) FindOverdueActionItemsByManager(ctx context.Context, managerID string, asOf time.Time) ([]services.OverdueActionItem, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT DISTINCT ai.id, ai.team_id, t.name, ai.title, ai.status, ai.due_date, au.full_name
+		FROM action_items ai
+		INNER JOIN teams t ON t.id = ai.team_id
+		INNER JOIN team_supervisors ts ON ts.team_id = ai.team_id
+		LEFT JOIN users au ON au.id = ai.assigned_to
+		WHERE ts.user_id = $1 AND ai.status <> 'done'
+			AND ai.due_date IS NOT NULL AND ai.due_date < $2
+		ORDER BY ai.due_date, t.name
+	`, managerID, asOf.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query overdue action items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []services.OverdueActionItem{}
+	for rows.Next() {
+		var item services.OverdueActionItem
+		var assigneeName sql.NullString
+		if err := rows.Scan(&item.ID, &item.TeamID, &item.TeamName, &item.Title, &item.Status, &item.DueDate, &assigneeName); err != nil {
+			return nil, fmt.Errorf("failed to scan overdue action item: %w", err)
+		}
+		if assigneeName.Valid {
+			item.AssigneeName = assigneeName.String
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return items, nil
+}
+
+// Claim records that a manager's digest for a week is being sent.
+// Returns false if it was already claimed.
+func (r *DigestRepository) Claim(ctx context.Context, managerID string, weekStart time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO weekly_digests (manager_id, week_start, sent_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (manager_id, week_start) DO NOTHING
+	`, managerID, weekStart.Format("2006-01-02"))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim weekly digest: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Release removes a digest claim so a failed send can be retried
+func (r *DigestRepository) Release(ctx context.Context, managerID string, weekStart time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM weekly_digests WHERE manager_id = $1 AND week_start = $2
+	`, managerID, weekStart.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("failed to release weekly digest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DirectoryImportRepository implements services.DirectoryImportRepository
+type DirectoryImportRepository struct {
+	db *sql.DB
+}
+
+// NewDirectoryImportRepository creates a new DirectoryImportRepository
+func NewDirectoryImportRepository(db *sql.DB) *DirectoryImportRepository {
+	return &DirectoryImportRepository{db: db}
+}
+
+// Apply writes the plan in one transaction. Users are written before any reporting line,
+// so a user may report to someone later in the file.
+func (r *DirectoryImportRepository) Apply(ctx context.Context, plan *services.ImportPlan) error {
+	// Hash before the transaction starts so it is not held open by bcrypt
+	hashes := make(map[string]string)
+	for _, u := range plan.Users {
+		if u.Password == "" {
+			continue
+		}
+		hashed, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("failed to hash password: %w", err)
+		}
+		hashes[u.ID] = string(hashed)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, u := range plan.Users {
+		if u.Exists {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE users SET
+					username = $1,
+					full_name = $2,
+					email = $3,
+					hierarchy_level_id = $4,
+					auth_type = COALESCE(NULLIF($5, ''), auth_type),
+					updated_at = $6
+				WHERE id = $7
+			`, u.Username, u.Name, u.Email, u.HierarchyLevelID, string(u.AuthType), now, u.ID)
+			if err != nil {
+				return fmt.Errorf("failed to update user %s: %w", u.ID, err)
+			}
+			continue
+		}
+
+		authType := u.AuthType
+		if authType == "" {
+			authType = user.AuthTypeLocal
+		}
+		// password_hash is NOT NULL; SSO users get an empty string
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO users (id, username, full_name, email, hierarchy_level_id, password_hash, auth_type, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		`, u.ID, u.Username, u.Name, u.Email, u.HierarchyLevelID, hashes[u.ID], string(authType), now)
+		if err != nil {
+			return fmt.Errorf("failed to create user %s: %w", u.ID, err)
+		}
+	}
+
+	for _, u := range plan.Users {
+		if u.ReportsTo == nil {
+			continue
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE users SET reports_to = $1 WHERE id = $2`, *u.ReportsTo, u.ID)
+		if err != nil {
+			return fmt.Errorf("failed to set reporting line of user %s: %w", u.ID, err)
+		}
+	}
+
+	for _, t := range plan.Teams {
+		var leadID sql.NullString
+		if t.LeadID != "" {
+			leadID = sql.NullString{String: t.LeadID, Valid: true}
+		}
+
+		if t.Exists {
+			if leadID.Valid {
+				_, err = tx.ExecContext(ctx, `UPDATE teams SET team_lead_id = $1, updated_at = $2 WHERE id = $3`, leadID, now, t.ID)
+				if err != nil {
+					return fmt.Errorf("failed to set lead of team %s: %w", t.ID, err)
+				}
+			}
+		} else {
+			// Same defaults as TeamRepository.Save
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO teams (id, name, team_lead_id, cadence, chat_webhook_format, anonymity_min_respondents, created_at, updated_at)
+				VALUES ($1, $2, $3, 'monthly', 'slack', $4, $5, $5)
+			`, t.ID, t.Name, leadID, healthcheck.DefaultMinRespondents, now)
+			if err != nil {
+				return fmt.Errorf("failed to create team %s: %w", t.ID, err)
+			}
+		}
+
+		for _, memberID := range t.MemberIDs {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO team_members (team_id, user_id)
+				VALUES ($1, $2)
+				ON CONFLICT (team_id, user_id) DO NOTHING
+			`, t.ID, memberID)
+			if err != nil {
+				return fmt.Errorf("failed to add user %s to team %s: %w", memberID, t.ID, err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import: %w", err)
+	}
+	return nil
+}
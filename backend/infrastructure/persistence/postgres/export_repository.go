@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+)
+
+// ExportRepository implements services.ExportRepository
+type ExportRepository struct {
+	db *sql.DB
+}
+
+// NewExportRepository creates a new ExportRepository
+func NewExportRepository(db *sql.DB) *ExportRepository {
+	return &ExportRepository{db: db}
+}
+
+// StreamResults reads the matching responses row by row, so an export never holds the whole result.
+// Respondents of anonymous sessions are counted per team and period, as in healthcheck.RedactAnonymous.
+func (r *ExportRepository) StreamResults(ctx context.Context, filter services.ExportFilter, fn func(services.ExportRow) error) error {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH scoped AS (
+			SELECT s.id, s.team_id, s.user_id, s.date,
+				COALESCE(s.assessment_period, '') AS assessment_period,
+				COALESCE(s.survey_type, 'individual') AS survey_type,
+				s.anonymous
+			FROM health_check_sessions s
+			WHERE s.completed = true
+				AND ($1 = '' OR s.team_id = $1)
+				AND ($2 = '' OR s.team_id IN (SELECT ts.team_id FROM team_supervisors ts WHERE ts.user_id = $2))
+				AND ($3 = '' OR s.assessment_period = $3)
+				AND ($4 = '' OR s.survey_type = $4)
+		),
+		respondents AS (
+			SELECT team_id, assessment_period, COUNT(DISTINCT user_id) AS respondent_count
+			FROM scoped
+			WHERE anonymous
+			GROUP BY team_id, assessment_period
+		)
+		SELECT sc.id, sc.team_id, COALESCE(t.name, sc.team_id), sc.user_id, sc.date,
+			sc.assessment_period, sc.survey_type, sc.anonymous,
+			hcr.dimension_id, COALESCE(d.name, hcr.dimension_id),
+			COALESCE(hcr.score, 0), COALESCE(hcr.trend, ''), COALESCE(hcr.comment, '')
+		FROM scoped sc
+		INNER JOIN health_check_responses hcr ON hcr.session_id = sc.id
+		LEFT JOIN teams t ON t.id = sc.team_id
+		LEFT JOIN health_dimensions d ON d.id = hcr.dimension_id
+		LEFT JOIN respondents rc ON rc.team_id = sc.team_id AND rc.assessment_period = sc.assessment_period
+		WHERE NOT sc.anonymous
+			OR rc.respondent_count >= CASE
+				WHEN COALESCE(t.anonymity_min_respondents, 0) < 1 THEN $5
+				ELSE t.anonymity_min_respondents
+			END
+		ORDER BY COALESCE(t.name, sc.team_id), sc.team_id, sc.assessment_period, sc.date, sc.id, hcr.dimension_id
+	`, filter.TeamID, filter.ManagerID, filter.AssessmentPeriod, filter.SurveyType, healthcheck.DefaultMinRespondents)
+	if err != nil {
+		return fmt.Errorf("failed to query export results: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row services.ExportRow
+		err := rows.Scan(
+			&row.SessionID, &row.TeamID, &row.TeamName, &row.UserID, &row.Date,
+			&row.AssessmentPeriod, &row.SurveyType, &row.Anonymous,
+			&row.DimensionID, &row.DimensionName,
+			&row.Score, &row.Trend, &row.Comment,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan export row: %w", err)
+		}
+		if len(row.Date) >= 10 {
+			row.Date = row.Date[:10]
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	return nil
+}
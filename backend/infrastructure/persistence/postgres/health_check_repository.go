@@ -3,7 +3,9 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
 )
@@ -33,11 +35,19 @@ func (r *HealthCheckRepository) Save(ctx context.Context, session *healthcheck.H
 	}
 	defer tx.Rollback()
 
+	// Sessions without a survey template store NULL for both template columns
+	var templateID sql.NullString
+	var templateVersion sql.NullInt64
+	if session.TemplateID != "" {
+		templateID = sql.NullString{String: session.TemplateID, Valid: true}
+		templateVersion = sql.NullInt64{Int64: int64(session.TemplateVersion), Valid: true}
+	}
+
 	// Insert or update session
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO health_check_sessions (
-			id, team_id, user_id, date, assessment_period, survey_type, completed, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+			id, team_id, user_id, date, assessment_period, survey_type, completed, template_id, template_version, anonymous, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP)
 		ON CONFLICT (id) DO UPDATE SET
 			team_id = EXCLUDED.team_id,
 			user_id = EXCLUDED.user_id,
@@ -45,32 +55,32 @@ func (r *HealthCheckRepository) Save(ctx context.Context, session *healthcheck.H
 			assessment_period = EXCLUDED.assessment_period,
 			survey_type = EXCLUDED.survey_type,
 			completed = EXCLUDED.completed,
+			template_id = EXCLUDED.template_id,
+			template_version = EXCLUDED.template_version,
+			anonymous = EXCLUDED.anonymous,
 			updated_at = CURRENT_TIMESTAMP
-	`, session.ID, session.TeamID, session.UserID, session.Date, session.AssessmentPeriod, surveyType, session.Completed)
+	`, session.ID, session.TeamID, session.UserID, session.Date, session.AssessmentPeriod, surveyType, session.Completed, templateID, templateVersion, session.Anonymous)
 
 	if err != nil {
 		return fmt.Errorf("failed to save session: %w", err)
 	}
 
-	// Delete existing responses (for updates)
-	_, err = tx.ExecContext(ctx, "DELETE FROM health_check_responses WHERE session_id = $1", session.ID)
-	if err != nil {
-		return fmt.Errorf("failed to delete existing responses: %w", err)
-	}
-
-	// Insert responses
-	for _, response := range session.Responses {
+	// Record who submitted an anonymous survey separately from the session itself
+	if session.Anonymous && session.Completed && session.ParticipantID != "" {
 		_, err = tx.ExecContext(ctx, `
-			INSERT INTO health_check_responses (
-				session_id, dimension_id, score, trend, comment
-			) VALUES ($1, $2, $3, $4, $5)
-		`, session.ID, response.DimensionID, response.Score, response.Trend, response.Comment)
-
+			INSERT INTO survey_participation (team_id, assessment_period, user_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (team_id, assessment_period, user_id) DO NOTHING
+		`, session.TeamID, session.AssessmentPeriod, session.ParticipantID)
 		if err != nil {
-			return fmt.Errorf("failed to save response: %w", err)
+			return fmt.Errorf("failed to record survey participation: %w", err)
 		}
 	}
 
+	if err = r.replaceResponsesTx(ctx, tx, session); err != nil {
+		return err
+	}
+
 	// Commit transaction
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -83,7 +93,7 @@ func (r *HealthCheckRepository) Save(ctx context.Context, session *healthcheck.H
 func (r *HealthCheckRepository) FindByID(ctx context.Context, id string) (*healthcheck.HealthCheckSession, error) {
 	sessions, err := r.scanSessions(ctx, `
 		SELECT s.id, s.team_id, s.user_id, s.date, s.assessment_period, s.survey_type, s.completed,
-		       r.dimension_id, r.score, r.trend, r.comment
+		       s.template_id, s.template_version, s.anonymous, r.dimension_id, r.score, r.trend, r.comment
 		FROM health_check_sessions s
 		LEFT JOIN health_check_responses r ON s.id = r.session_id
 		WHERE s.id = $1
@@ -101,45 +111,48 @@ func (r *HealthCheckRepository) FindByID(ctx context.Context, id string) (*healt
 	return sessions[0], nil
 }
 
-// FindByTeamID retrieves all sessions for a team
+// FindByTeamID retrieves all completed sessions for a team
 func (r *HealthCheckRepository) FindByTeamID(ctx context.Context, teamID string) ([]*healthcheck.HealthCheckSession, error) {
 	return r.scanSessions(ctx, `
 		SELECT s.id, s.team_id, s.user_id, s.date, s.assessment_period, s.survey_type, s.completed,
-		       r.dimension_id, r.score, r.trend, r.comment
+		       s.template_id, s.template_version, s.anonymous, r.dimension_id, r.score, r.trend, r.comment
 		FROM health_check_sessions s
 		LEFT JOIN health_check_responses r ON s.id = r.session_id
-		WHERE s.team_id = $1
+		WHERE s.team_id = $1 AND s.completed = true
 		ORDER BY s.date DESC, r.dimension_id
 	`, teamID)
 }
 
-// FindByUserID retrieves all sessions for a user
+// FindByUserID retrieves all completed sessions for a user
 func (r *HealthCheckRepository) FindByUserID(ctx context.Context, userID string) ([]*healthcheck.HealthCheckSession, error) {
 	return r.scanSessions(ctx, `
 		SELECT s.id, s.team_id, s.user_id, s.date, s.assessment_period, s.survey_type, s.completed,
-		       r.dimension_id, r.score, r.trend, r.comment
+		       s.template_id, s.template_version, s.anonymous, r.dimension_id, r.score, r.trend, r.comment
 		FROM health_check_sessions s
 		LEFT JOIN health_check_responses r ON s.id = r.session_id
-		WHERE s.user_id = $1
+		WHERE s.user_id = $1 AND s.completed = true
 		ORDER BY s.date DESC, r.dimension_id
 	`, userID)
 }
 
-// FindByAssessmentPeriod retrieves all sessions for an assessment period
+// FindByAssessmentPeriod retrieves all completed sessions for an assessment period
 func (r *HealthCheckRepository) FindByAssessmentPeriod(ctx context.Context, period string) ([]*healthcheck.HealthCheckSession, error) {
 	return r.scanSessions(ctx, `
 		SELECT s.id, s.team_id, s.user_id, s.date, s.assessment_period, s.survey_type, s.completed,
-		       r.dimension_id, r.score, r.trend, r.comment
+		       s.template_id, s.template_version, s.anonymous, r.dimension_id, r.score, r.trend, r.comment
 		FROM health_check_sessions s
 		LEFT JOIN health_check_responses r ON s.id = r.session_id
-		WHERE s.assessment_period = $1
+		WHERE s.assessment_period = $1 AND s.completed = true
 		ORDER BY s.date DESC, r.dimension_id
 	`, period)
 }
 
 // FindTeamHealthByManager retrieves aggregated health data for teams under a manager.
 // Prefers post-workshop sessions when available for a team+period, otherwise falls back to individual sessions.
-func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, managerID string, assessmentPeriod string) ([]healthcheck.TeamHealthSummary, error) {
+// With weighted scoring each response counts by its dimension's weight; dimensions weighted 0 are left out of
+// the overall score. Per-dimension averages are unaffected by weights. Responses count only for dimensions in
+// the template version their session answered.
+func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, managerID string, assessmentPeriod string, scoring healthcheck.Scoring) ([]healthcheck.TeamHealthSummary, error) {
 	var query string
 	var rows *sql.Rows
 	var err error
@@ -155,7 +168,7 @@ func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, man
 					AND s.completed = true AND s.assessment_period = $2
 			),
 			effective_sessions AS (
-				SELECT s.id, s.team_id
+				SELECT s.id, s.team_id, s.template_id, s.template_version
 				FROM health_check_sessions s
 				INNER JOIN team_supervisors ts ON s.team_id = ts.team_id
 				WHERE ts.user_id = $1 AND s.completed = true AND s.assessment_period = $2
@@ -165,19 +178,33 @@ func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, man
 							AND s.team_id NOT IN (SELECT pw.team_id FROM post_workshop_teams pw))
 					)
 			),
+			effective_responses AS (
+				SELECT r.session_id, r.dimension_id, r.score
+				FROM effective_sessions es
+				INNER JOIN health_check_responses r ON es.id = r.session_id
+				LEFT JOIN survey_template_dimensions std
+					ON std.template_id = es.template_id AND std.version = es.template_version
+					AND std.dimension_id = r.dimension_id
+				WHERE es.template_id IS NULL OR std.dimension_id IS NOT NULL
+			),
+			dimension_weights AS (
+				SELECT id AS dimension_id, CASE WHEN $3 THEN COALESCE(weight, 1) ELSE 1 END AS weight
+				FROM health_dimensions
+			),
 			team_overall AS (
 				SELECT
 					t.id AS team_id,
 					t.name AS team_name,
 					COUNT(DISTINCT es.id) AS submission_count,
-					AVG(r.score) AS overall_health,
+					SUM(r.score * dw.weight) / NULLIF(SUM(dw.weight), 0) AS overall_health,
 					CASE WHEN EXISTS (
 						SELECT 1 FROM post_workshop_teams pw WHERE pw.team_id = t.id
 					) THEN 'submitted' ELSE 'pending' END AS post_workshop_status
 				FROM teams t
 				INNER JOIN team_supervisors ts ON t.id = ts.team_id
 				LEFT JOIN effective_sessions es ON t.id = es.team_id
-				LEFT JOIN health_check_responses r ON es.id = r.session_id
+				LEFT JOIN effective_responses r ON es.id = r.session_id
+				LEFT JOIN dimension_weights dw ON dw.dimension_id = r.dimension_id
 				WHERE ts.user_id = $1
 				GROUP BY t.id, t.name
 			),
@@ -188,7 +215,7 @@ func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, man
 					AVG(r.score) AS avg_score,
 					COUNT(r.dimension_id) AS response_count
 				FROM effective_sessions es
-				INNER JOIN health_check_responses r ON es.id = r.session_id
+				INNER JOIN effective_responses r ON es.id = r.session_id
 				GROUP BY es.team_id, r.dimension_id
 			)
 			SELECT
@@ -204,7 +231,7 @@ func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, man
 			LEFT JOIN team_dimensions d ON o.team_id = d.team_id
 			ORDER BY o.overall_health ASC NULLS LAST, o.team_name, d.dimension_id
 		`
-		rows, err = r.db.QueryContext(ctx, query, managerID, assessmentPeriod)
+		rows, err = r.db.QueryContext(ctx, query, managerID, assessmentPeriod, scoring.IsWeighted())
 	} else {
 		query = `
 			WITH post_workshop_teams AS (
@@ -215,7 +242,7 @@ func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, man
 					AND s.completed = true
 			),
 			effective_sessions AS (
-				SELECT s.id, s.team_id
+				SELECT s.id, s.team_id, s.template_id, s.template_version
 				FROM health_check_sessions s
 				INNER JOIN team_supervisors ts ON s.team_id = ts.team_id
 				WHERE ts.user_id = $1 AND s.completed = true
@@ -225,19 +252,33 @@ func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, man
 							AND s.team_id NOT IN (SELECT pw.team_id FROM post_workshop_teams pw))
 					)
 			),
+			effective_responses AS (
+				SELECT r.session_id, r.dimension_id, r.score
+				FROM effective_sessions es
+				INNER JOIN health_check_responses r ON es.id = r.session_id
+				LEFT JOIN survey_template_dimensions std
+					ON std.template_id = es.template_id AND std.version = es.template_version
+					AND std.dimension_id = r.dimension_id
+				WHERE es.template_id IS NULL OR std.dimension_id IS NOT NULL
+			),
+			dimension_weights AS (
+				SELECT id AS dimension_id, CASE WHEN $2 THEN COALESCE(weight, 1) ELSE 1 END AS weight
+				FROM health_dimensions
+			),
 			team_overall AS (
 				SELECT
 					t.id AS team_id,
 					t.name AS team_name,
 					COUNT(DISTINCT es.id) AS submission_count,
-					AVG(r.score) AS overall_health,
+					SUM(r.score * dw.weight) / NULLIF(SUM(dw.weight), 0) AS overall_health,
 					CASE WHEN EXISTS (
 						SELECT 1 FROM post_workshop_teams pw WHERE pw.team_id = t.id
 					) THEN 'submitted' ELSE 'pending' END AS post_workshop_status
 				FROM teams t
 				INNER JOIN team_supervisors ts ON t.id = ts.team_id
 				LEFT JOIN effective_sessions es ON t.id = es.team_id
-				LEFT JOIN health_check_responses r ON es.id = r.session_id
+				LEFT JOIN effective_responses r ON es.id = r.session_id
+				LEFT JOIN dimension_weights dw ON dw.dimension_id = r.dimension_id
 				WHERE ts.user_id = $1
 				GROUP BY t.id, t.name
 			),
@@ -248,7 +289,7 @@ func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, man
 					AVG(r.score) AS avg_score,
 					COUNT(r.dimension_id) AS response_count
 				FROM effective_sessions es
-				INNER JOIN health_check_responses r ON es.id = r.session_id
+				INNER JOIN effective_responses r ON es.id = r.session_id
 				GROUP BY es.team_id, r.dimension_id
 			)
 			SELECT
@@ -264,7 +305,7 @@ func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, man
 			LEFT JOIN team_dimensions d ON o.team_id = d.team_id
 			ORDER BY o.overall_health ASC NULLS LAST, o.team_name, d.dimension_id
 		`
-		rows, err = r.db.QueryContext(ctx, query, managerID)
+		rows, err = r.db.QueryContext(ctx, query, managerID, scoring.IsWeighted())
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to query team health by manager: %w", err)
@@ -342,6 +383,7 @@ func (r *HealthCheckRepository) FindTeamHealthByManager(ctx context.Context, man
 
 // FindAggregatedDimensionsByManager retrieves aggregated dimension data across all teams under a manager.
 // Prefers post-workshop sessions when available for a team+period, otherwise falls back to individual sessions.
+// Responses count only for dimensions in the template version their session answered.
 func (r *HealthCheckRepository) FindAggregatedDimensionsByManager(ctx context.Context, managerID string, assessmentPeriod string) ([]healthcheck.DimensionSummary, error) {
 	var query string
 	var rows *sql.Rows
@@ -357,7 +399,7 @@ func (r *HealthCheckRepository) FindAggregatedDimensionsByManager(ctx context.Co
 					AND s.completed = true AND s.assessment_period = $2
 			),
 			effective_sessions AS (
-				SELECT s.id
+				SELECT s.id, s.template_id, s.template_version
 				FROM health_check_sessions s
 				INNER JOIN team_supervisors ts ON s.team_id = ts.team_id
 				WHERE ts.user_id = $1 AND s.completed = true AND s.assessment_period = $2
@@ -373,6 +415,10 @@ func (r *HealthCheckRepository) FindAggregatedDimensionsByManager(ctx context.Co
 				COUNT(r.dimension_id) AS response_count
 			FROM effective_sessions es
 			INNER JOIN health_check_responses r ON es.id = r.session_id
+			LEFT JOIN survey_template_dimensions std
+				ON std.template_id = es.template_id AND std.version = es.template_version
+				AND std.dimension_id = r.dimension_id
+			WHERE es.template_id IS NULL OR std.dimension_id IS NOT NULL
 			GROUP BY r.dimension_id
 			ORDER BY r.dimension_id
 		`
@@ -387,7 +433,7 @@ func (r *HealthCheckRepository) FindAggregatedDimensionsByManager(ctx context.Co
 					AND s.completed = true
 			),
 			effective_sessions AS (
-				SELECT s.id
+				SELECT s.id, s.template_id, s.template_version
 				FROM health_check_sessions s
 				INNER JOIN team_supervisors ts ON s.team_id = ts.team_id
 				WHERE ts.user_id = $1 AND s.completed = true
@@ -403,6 +449,10 @@ func (r *HealthCheckRepository) FindAggregatedDimensionsByManager(ctx context.Co
 				COUNT(r.dimension_id) AS response_count
 			FROM effective_sessions es
 			INNER JOIN health_check_responses r ON es.id = r.session_id
+			LEFT JOIN survey_template_dimensions std
+				ON std.template_id = es.template_id AND std.version = es.template_version
+				AND std.dimension_id = r.dimension_id
+			WHERE es.template_id IS NULL OR std.dimension_id IS NOT NULL
 			GROUP BY r.dimension_id
 			ORDER BY r.dimension_id
 		`
@@ -442,12 +492,13 @@ func (r *HealthCheckRepository) FindAggregatedDimensionsByManager(ctx context.Co
 	return dimensions, nil
 }
 
-// GetTeamSubmissionStatus returns submission status for a team in a given assessment period
+// GetTeamSubmissionStatus returns submission status for a team in a given assessment period.
+// Anonymous submissions are counted from survey_participation, since their sessions carry no user ID.
 func (r *HealthCheckRepository) GetTeamSubmissionStatus(ctx context.Context, teamID string, assessmentPeriod string) (*healthcheck.TeamSubmissionStatus, error) {
 	query := `
 		SELECT
 			COUNT(DISTINCT tm.user_id) AS total_members,
-			COUNT(DISTINCT CASE WHEN hcs.completed = true THEN hcs.user_id END) AS submitted_members,
+			COUNT(DISTINCT CASE WHEN hcs.completed = true OR sp.user_id IS NOT NULL THEN tm.user_id END) AS submitted_members,
 			EXISTS(
 				SELECT 1 FROM health_check_sessions
 				WHERE team_id = $1 AND assessment_period = $2
@@ -456,6 +507,8 @@ func (r *HealthCheckRepository) GetTeamSubmissionStatus(ctx context.Context, tea
 		FROM team_members tm
 		LEFT JOIN health_check_sessions hcs ON tm.user_id = hcs.user_id AND tm.team_id = hcs.team_id
 			AND hcs.assessment_period = $2 AND hcs.survey_type = 'individual'
+		LEFT JOIN survey_participation sp ON sp.team_id = tm.team_id AND sp.user_id = tm.user_id
+			AND sp.assessment_period = $2
 		WHERE tm.team_id = $1
 	`
 
@@ -481,6 +534,77 @@ func (r *HealthCheckRepository) GetTeamSubmissionStatus(ctx context.Context, tea
 	}, nil
 }
 
+// CountSubmissionsByManagerSince counts completed sessions created since a time for the manager's teams
+func (r *HealthCheckRepository) CountSubmissionsByManagerSince(ctx context.Context, managerID string, since time.Time) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT s.team_id, COUNT(*)
+		FROM health_check_sessions s
+		INNER JOIN team_supervisors ts ON s.team_id = ts.team_id
+		WHERE ts.user_id = $1 AND s.completed = true AND s.created_at >= $2
+		GROUP BY s.team_id
+	`, managerID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent submissions: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var teamID string
+		var count int
+		if err := rows.Scan(&teamID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan submission count: %w", err)
+		}
+		counts[teamID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// FindNonSubmitters returns IDs of team members who have not completed the individual survey for a period
+// Uses the same membership and completion rules as GetTeamSubmissionStatus
+func (r *HealthCheckRepository) FindNonSubmitters(ctx context.Context, teamID string, assessmentPeriod string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT tm.user_id
+		FROM team_members tm
+		WHERE tm.team_id = $1
+			AND NOT EXISTS (
+				SELECT 1 FROM health_check_sessions hcs
+				WHERE hcs.user_id = tm.user_id AND hcs.team_id = tm.team_id
+					AND hcs.assessment_period = $2 AND hcs.survey_type = 'individual'
+					AND hcs.completed = true
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM survey_participation sp
+				WHERE sp.team_id = tm.team_id AND sp.user_id = tm.user_id AND sp.assessment_period = $2
+			)
+		ORDER BY tm.user_id
+	`, teamID, assessmentPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query non-submitters: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan non-submitter: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return userIDs, nil
+}
+
 // Delete removes a session and its responses (cascade handled by DB)
 func (r *HealthCheckRepository) Delete(ctx context.Context, id string) error {
 	result, err := r.db.ExecContext(ctx, "DELETE FROM health_check_sessions WHERE id = $1", id)
@@ -532,6 +656,287 @@ func (r *HealthCheckRepository) FindDistinctAssessmentPeriods(ctx context.Contex
 	return periods, nil
 }
 
+// FindDraft retrieves the open individual draft for a member, team and period
+func (r *HealthCheckRepository) FindDraft(ctx context.Context, teamID string, userID string, assessmentPeriod string) (*healthcheck.HealthCheckSession, error) {
+	sessions, err := r.scanSessions(ctx, `
+		SELECT s.id, s.team_id, s.user_id, s.date, s.assessment_period, s.survey_type, s.completed,
+		       s.template_id, s.template_version, s.anonymous, r.dimension_id, r.score, r.trend, r.comment
+		FROM health_check_sessions s
+		LEFT JOIN health_check_responses r ON s.id = r.session_id
+		WHERE s.team_id = $1 AND s.user_id = $2 AND s.assessment_period = $3
+			AND s.survey_type = 'individual' AND s.completed = false
+		ORDER BY r.dimension_id
+	`, teamID, userID, assessmentPeriod)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("draft not found: %s %s", teamID, assessmentPeriod)
+	}
+
+	return sessions[0], nil
+}
+
+// replaceResponsesTx replaces a session's responses.
+// Unanswered draft scores and trends are stored as NULL.
+func (r *HealthCheckRepository) replaceResponsesTx(ctx context.Context, tx *sql.Tx, session *healthcheck.HealthCheckSession) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM health_check_responses WHERE session_id = $1", session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete existing responses: %w", err)
+	}
+
+	for _, response := range session.Responses {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO health_check_responses (
+				session_id, dimension_id, score, trend, comment
+			) VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, ''), $5)
+		`, session.ID, response.DimensionID, response.Score, response.Trend, response.Comment)
+
+		if err != nil {
+			return fmt.Errorf("failed to save response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Amend replaces the responses of a completed session. The first change also records
+// the original responses as revision 1.
+func (r *HealthCheckRepository) Amend(ctx context.Context, session *healthcheck.HealthCheckSession) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = r.lockCompletedSessionTx(ctx, tx, session.ID); err != nil {
+		return err
+	}
+
+	if err = r.recordOriginalRevisionTx(ctx, tx, session.ID); err != nil {
+		return err
+	}
+
+	var templateID sql.NullString
+	var templateVersion sql.NullInt64
+	if session.TemplateID != "" {
+		templateID = sql.NullString{String: session.TemplateID, Valid: true}
+		templateVersion = sql.NullInt64{Int64: int64(session.TemplateVersion), Valid: true}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE health_check_sessions SET
+			template_id = $1,
+			template_version = $2,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+	`, templateID, templateVersion, session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	if err = r.replaceResponsesTx(ctx, tx, session); err != nil {
+		return err
+	}
+
+	if err = r.appendRevisionTx(ctx, tx, session, healthcheck.RevisionAmended, session.Responses); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Withdraw deletes a completed session after recording the withdrawal in its revisions
+func (r *HealthCheckRepository) Withdraw(ctx context.Context, session *healthcheck.HealthCheckSession) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = r.lockCompletedSessionTx(ctx, tx, session.ID); err != nil {
+		return err
+	}
+
+	if err = r.recordOriginalRevisionTx(ctx, tx, session.ID); err != nil {
+		return err
+	}
+
+	if err = r.appendRevisionTx(ctx, tx, session, healthcheck.RevisionWithdrawn, nil); err != nil {
+		return err
+	}
+
+	// The member no longer counts as having submitted an anonymous survey
+	if session.Anonymous && session.ParticipantID != "" {
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM survey_participation
+			WHERE team_id = $1 AND assessment_period = $2 AND user_id = $3
+		`, session.TeamID, session.AssessmentPeriod, session.ParticipantID)
+		if err != nil {
+			return fmt.Errorf("failed to remove survey participation: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM health_check_sessions WHERE id = $1", session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindRevisions retrieves a session's revisions, oldest first
+func (r *HealthCheckRepository) FindRevisions(ctx context.Context, sessionID string) ([]*healthcheck.Revision, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT session_id, team_id, COALESCE(assessment_period, ''), revision, action, responses, created_at
+		FROM health_check_revisions
+		WHERE session_id = $1
+		ORDER BY revision
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []*healthcheck.Revision{}
+	for rows.Next() {
+		var rev healthcheck.Revision
+		var responses []byte
+		if err := rows.Scan(&rev.SessionID, &rev.TeamID, &rev.AssessmentPeriod, &rev.Revision, &rev.Action, &responses, &rev.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan revision: %w", err)
+		}
+		if responses != nil {
+			if err := json.Unmarshal(responses, &rev.Responses); err != nil {
+				return nil, fmt.Errorf("failed to decode revision responses: %w", err)
+			}
+		}
+		revisions = append(revisions, &rev)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// FindSubmissionWindow returns the team's cadence and the status of its campaign for the period
+func (r *HealthCheckRepository) FindSubmissionWindow(ctx context.Context, teamID string, assessmentPeriod string) (*healthcheck.SubmissionWindow, error) {
+	var window healthcheck.SubmissionWindow
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(t.cadence, ''), COALESCE((
+			SELECT sc.status FROM survey_campaigns sc
+			WHERE sc.team_id = t.id AND sc.assessment_period = $2
+		), '')
+		FROM teams t
+		WHERE t.id = $1
+	`, teamID, assessmentPeriod).Scan(&window.Cadence, &window.CampaignStatus)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("team not found: %s", teamID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find submission window: %w", err)
+	}
+
+	return &window, nil
+}
+
+// lockCompletedSessionTx locks a completed session row for the rest of the transaction
+func (r *HealthCheckRepository) lockCompletedSessionTx(ctx context.Context, tx *sql.Tx, id string) error {
+	var completed bool
+	err := tx.QueryRowContext(ctx, `SELECT completed FROM health_check_sessions WHERE id = $1 FOR UPDATE`, id).Scan(&completed)
+	if err == sql.ErrNoRows || (err == nil && !completed) {
+		return fmt.Errorf("session not found: %s", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock session: %w", err)
+	}
+	return nil
+}
+
+// recordOriginalRevisionTx records the session's current responses as revision 1
+// unless the session already has revisions. Anonymous sessions record no responses.
+func (r *HealthCheckRepository) recordOriginalRevisionTx(ctx context.Context, tx *sql.Tx, id string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO health_check_revisions (session_id, team_id, assessment_period, revision, action, responses, created_at)
+		SELECT s.id, s.team_id, s.assessment_period, 1, $2,
+			CASE WHEN s.anonymous THEN NULL ELSE COALESCE((
+				SELECT jsonb_agg(jsonb_build_object(
+					'dimensionId', hcr.dimension_id,
+					'score', hcr.score,
+					'trend', hcr.trend,
+					'comment', COALESCE(hcr.comment, '')
+				) ORDER BY hcr.dimension_id)
+				FROM health_check_responses hcr WHERE hcr.session_id = s.id
+			), '[]'::jsonb) END,
+			COALESCE(s.created_at, CURRENT_TIMESTAMP)
+		FROM health_check_sessions s
+		WHERE s.id = $1
+			AND NOT EXISTS (SELECT 1 FROM health_check_revisions rev WHERE rev.session_id = s.id)
+	`, id, healthcheck.RevisionSubmitted)
+	if err != nil {
+		return fmt.Errorf("failed to record original revision: %w", err)
+	}
+	return nil
+}
+
+// appendRevisionTx appends the next revision of a session. Responses are omitted for anonymous sessions.
+func (r *HealthCheckRepository) appendRevisionTx(ctx context.Context, tx *sql.Tx, session *healthcheck.HealthCheckSession, action string, responses []healthcheck.HealthCheckResponse) error {
+	var snapshot sql.NullString
+	if responses != nil && !session.Anonymous {
+		data, err := json.Marshal(responses)
+		if err != nil {
+			return fmt.Errorf("failed to encode revision responses: %w", err)
+		}
+		snapshot = sql.NullString{String: string(data), Valid: true}
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO health_check_revisions (session_id, team_id, assessment_period, revision, action, responses)
+		SELECT $1, $2, NULLIF($3, ''), COALESCE(MAX(rev.revision), 0) + 1, $4, $5::jsonb
+		FROM health_check_revisions rev
+		WHERE rev.session_id = $1
+	`, session.ID, session.TeamID, session.AssessmentPeriod, action, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to record revision: %w", err)
+	}
+	return nil
+}
+
+// FindAnonymityPolicy returns whether individual surveys for the team and period are anonymous
+func (r *HealthCheckRepository) FindAnonymityPolicy(ctx context.Context, teamID string, assessmentPeriod string) (*healthcheck.AnonymityPolicy, error) {
+	policy := healthcheck.AnonymityPolicy{MinRespondents: healthcheck.DefaultMinRespondents}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT t.anonymous OR EXISTS (
+				SELECT 1 FROM survey_campaigns sc
+				WHERE sc.team_id = t.id AND sc.assessment_period = $2 AND sc.anonymous
+			),
+			t.anonymity_min_respondents
+		FROM teams t
+		WHERE t.id = $1
+	`, teamID, assessmentPeriod).Scan(&policy.Anonymous, &policy.MinRespondents)
+
+	if err == sql.ErrNoRows {
+		return &policy, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find anonymity policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
 // scanSessions is a helper function to scan query results into sessions
 func (r *HealthCheckRepository) scanSessions(ctx context.Context, query string, args ...interface{}) ([]*healthcheck.HealthCheckSession, error) {
 	rows, err := r.db.QueryContext(ctx, query, args...)
@@ -552,6 +957,9 @@ func (r *HealthCheckRepository) scanSessions(ctx context.Context, query string,
 			assessmentPeriod sql.NullString
 			surveyType       sql.NullString
 			completed        bool
+			templateID       sql.NullString
+			templateVersion  sql.NullInt64
+			anonymous        bool
 			dimensionID      sql.NullString
 			score            sql.NullInt64
 			trend            sql.NullString
@@ -560,7 +968,7 @@ func (r *HealthCheckRepository) scanSessions(ctx context.Context, query string,
 
 		err := rows.Scan(
 			&sessionID, &teamID, &userID, &date, &assessmentPeriod, &surveyType, &completed,
-			&dimensionID, &score, &trend, &comment,
+			&templateID, &templateVersion, &anonymous, &dimensionID, &score, &trend, &comment,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
@@ -582,6 +990,9 @@ func (r *HealthCheckRepository) scanSessions(ctx context.Context, query string,
 				SurveyType:       st,
 				Completed:        completed,
 				Responses:        []healthcheck.HealthCheckResponse{},
+				TemplateID:       templateID.String,
+				TemplateVersion:  int(templateVersion.Int64),
+				Anonymous:        anonymous,
 			}
 			sessionsMap[sessionID] = session
 			sessionOrder = append(sessionOrder, sessionID)
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+)
+
+// LoginAttemptRepository implements services.LoginAttemptStore
+type LoginAttemptRepository struct {
+	db *sql.DB
+}
+
+// NewLoginAttemptRepository creates a new login attempt repository
+func NewLoginAttemptRepository(db *sql.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+// ReserveAttempt checks and counts a login attempt in one transaction. The row is locked
+// before it is read, so concurrent attempts for the username on any replica are counted one
+// after another and none of them is checked against stale blocks.
+func (r *LoginAttemptRepository) ReserveAttempt(ctx context.Context, username string, now time.Time, policy services.AccountLockoutPolicy) (*services.LoginAttempts, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO login_attempts (username, failed_count, last_failed_at)
+		VALUES ($1, 0, $2)
+		ON CONFLICT (username) DO NOTHING
+	`, username, now)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to reserve login attempt: %w", err)
+	}
+
+	var attempts services.LoginAttempts
+	var lastFailedAt time.Time
+	var nextAttemptAt, lockedUntil sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT failed_count, last_failed_at, next_attempt_at, locked_until
+		FROM login_attempts WHERE username = $1
+		FOR UPDATE
+	`, username).Scan(&attempts.FailedCount, &lastFailedAt, &nextAttemptAt, &lockedUntil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get login attempts: %w", err)
+	}
+	if nextAttemptAt.Valid {
+		attempts.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if lockedUntil.Valid {
+		attempts.LockedUntil = &lockedUntil.Time
+	}
+
+	if (attempts.LockedUntil != nil && attempts.LockedUntil.After(now)) ||
+		(attempts.NextAttemptAt != nil && attempts.NextAttemptAt.After(now)) {
+		return &attempts, false, nil
+	}
+
+	if lastFailedAt.Before(now.Add(-policy.FailureWindow)) {
+		attempts.FailedCount = 0
+	}
+	attempts.FailedCount++
+	blockNext, blockLocked := policy.Blocks(attempts.FailedCount, now)
+
+	// GREATEST ignores NULLs, so a nil time keeps the current value and a shorter block
+	// never replaces a longer one
+	err = tx.QueryRowContext(ctx, `
+		UPDATE login_attempts SET
+			failed_count = $2,
+			last_failed_at = $3,
+			next_attempt_at = GREATEST(next_attempt_at, $4::timestamptz),
+			locked_until = GREATEST(locked_until, $5::timestamptz)
+		WHERE username = $1
+		RETURNING next_attempt_at, locked_until
+	`, username, attempts.FailedCount, now, blockNext, blockLocked).Scan(&nextAttemptAt, &lockedUntil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to record login attempt: %w", err)
+	}
+
+	attempts.NextAttemptAt, attempts.LockedUntil = nil, nil
+	if nextAttemptAt.Valid {
+		attempts.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if lockedUntil.Valid {
+		attempts.LockedUntil = &lockedUntil.Time
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &attempts, true, nil
+}
+
+// ResetLoginAttempts clears the username's failed logins
+func (r *LoginAttemptRepository) ResetLoginAttempts(ctx context.Context, username string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM login_attempts WHERE username = $1", username)
+	if err != nil {
+		return false, fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteStaleAttempts deletes failed logins last counted before before that block nothing at now
+func (r *LoginAttemptRepository) DeleteStaleAttempts(ctx context.Context, before, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM login_attempts
+		WHERE last_failed_at < $1
+		  AND (next_attempt_at IS NULL OR next_attempt_at <= $2)
+		  AND (locked_until IS NULL OR locked_until <= $2)
+	`, before, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete stale login attempts: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS survey_campaigns;
+ALTER TABLE teams DROP COLUMN IF EXISTS next_check_date;
@@ -0,0 +1,21 @@
+ALTER TABLE teams ADD COLUMN next_check_date DATE;
+
+CREATE TABLE survey_campaigns (
+    id                VARCHAR(100)  PRIMARY KEY,
+    team_id           VARCHAR(255)  NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    assessment_period VARCHAR(50)   NOT NULL,
+    cadence           VARCHAR(20)   NOT NULL
+                      CHECK (cadence IN ('monthly', 'quarterly', 'half-yearly', 'yearly')),
+    status            VARCHAR(20)   NOT NULL DEFAULT 'open'
+                      CHECK (status IN ('open', 'closed')),
+    start_date        DATE          NOT NULL,
+    end_date          DATE          NOT NULL,
+    closed_at         TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ   DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ   DEFAULT NOW(),
+    CONSTRAINT uq_survey_campaigns_team_period UNIQUE (team_id, assessment_period),
+    CONSTRAINT chk_survey_campaigns_dates CHECK (end_date >= start_date)
+);
+
+CREATE INDEX idx_survey_campaigns_status ON survey_campaigns(status);
+CREATE INDEX idx_survey_campaigns_team_id ON survey_campaigns(team_id);
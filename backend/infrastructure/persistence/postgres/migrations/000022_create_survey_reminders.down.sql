@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS survey_reminders;
+ALTER TABLE app_settings DROP COLUMN IF EXISTS reminder_offsets_days;
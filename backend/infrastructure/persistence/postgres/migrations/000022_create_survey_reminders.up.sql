@@ -0,0 +1,14 @@
+-- Days before a campaign's end date at which non-submitters are reminded
+ALTER TABLE app_settings ADD COLUMN IF NOT EXISTS reminder_offsets_days INTEGER[] NOT NULL DEFAULT '{7,3,1}';
+
+-- One row per reminder sent; the unique key makes sending idempotent across restarts and replicas
+CREATE TABLE survey_reminders (
+    id          VARCHAR(100)  PRIMARY KEY,
+    campaign_id VARCHAR(100)  NOT NULL REFERENCES survey_campaigns(id) ON DELETE CASCADE,
+    user_id     VARCHAR(255)  NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    offset_days INTEGER       NOT NULL CHECK (offset_days >= 0),
+    sent_at     TIMESTAMPTZ   DEFAULT NOW(),
+    CONSTRAINT uq_survey_reminders_campaign_user_offset UNIQUE (campaign_id, user_id, offset_days)
+);
+
+CREATE INDEX idx_survey_reminders_user_id ON survey_reminders(user_id);
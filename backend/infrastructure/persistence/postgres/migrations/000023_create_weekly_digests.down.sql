@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_action_items_due_date;
+DROP TABLE IF EXISTS weekly_digests;
@@ -0,0 +1,9 @@
+-- One row per manager per week; the primary key makes the digest idempotent across restarts and replicas
+CREATE TABLE weekly_digests (
+    manager_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    week_start DATE         NOT NULL,
+    sent_at    TIMESTAMPTZ  DEFAULT NOW(),
+    PRIMARY KEY (manager_id, week_start)
+);
+
+CREATE INDEX idx_action_items_due_date ON action_items(due_date) WHERE status <> 'done';
@@ -0,0 +1,2 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS chat_webhook_format;
+ALTER TABLE teams DROP COLUMN IF EXISTS chat_webhook_url;
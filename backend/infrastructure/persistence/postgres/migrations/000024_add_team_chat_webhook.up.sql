@@ -0,0 +1,3 @@
+ALTER TABLE teams ADD COLUMN chat_webhook_url VARCHAR(1024);
+ALTER TABLE teams ADD COLUMN chat_webhook_format VARCHAR(20) NOT NULL DEFAULT 'slack'
+    CHECK (chat_webhook_format IN ('slack', 'generic'));
@@ -0,0 +1,31 @@
+-- Every issued refresh token, grouped into rotation families. A refresh token may be
+-- exchanged exactly once; presenting a used token revokes the whole family (reuse detection).
+CREATE TABLE refresh_tokens (
+    jti         VARCHAR(64)   PRIMARY KEY,
+    family_id   VARCHAR(64)   NOT NULL,
+    user_id     VARCHAR(255)  NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issued_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ   NOT NULL,
+    used_at     TIMESTAMPTZ,
+    revoked_at  TIMESTAMPTZ
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
+
+-- Individually revoked access tokens (e.g. on logout), kept until they would have expired
+CREATE TABLE revoked_tokens (
+    jti         VARCHAR(64)   PRIMARY KEY,
+    user_id     VARCHAR(255)  NOT NULL,
+    expires_at  TIMESTAMPTZ   NOT NULL,
+    revoked_at  TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+
+-- Tokens issued to a user at or before revoked_before are rejected ("revoke all sessions")
+CREATE TABLE user_session_revocations (
+    user_id         VARCHAR(255)  PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    revoked_before  TIMESTAMPTZ   NOT NULL
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS retention_audit_log;
+DROP TABLE IF EXISTS archived_health_check_sessions;
+ALTER TABLE app_settings DROP COLUMN IF EXISTS retention_archive;
+ALTER TABLE teams DROP COLUMN IF EXISTS legal_hold;
@@ -0,0 +1,40 @@
+-- Teams under legal hold are never archived or purged by the retention worker
+ALTER TABLE teams ADD COLUMN legal_hold BOOLEAN NOT NULL DEFAULT false;
+
+-- When true, expired sessions are moved to archived_health_check_sessions; when false they are deleted
+ALTER TABLE app_settings ADD COLUMN retention_archive BOOLEAN NOT NULL DEFAULT true;
+
+-- Sessions past the retention window, with their responses denormalised as JSON
+CREATE TABLE archived_health_check_sessions (
+    id                VARCHAR(100)  PRIMARY KEY,
+    team_id           VARCHAR(255)  NOT NULL,
+    user_id           VARCHAR(255)  NOT NULL,
+    date              DATE          NOT NULL,
+    assessment_period VARCHAR(50),
+    survey_type       VARCHAR(20),
+    completed         BOOLEAN,
+    responses         JSONB         NOT NULL DEFAULT '[]',
+    created_at        TIMESTAMPTZ,
+    archived_at       TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    retention_run_id  VARCHAR(100)  NOT NULL
+);
+
+CREATE INDEX idx_archived_sessions_team_period ON archived_health_check_sessions(team_id, assessment_period);
+
+-- One row per team and period removed by a retention run
+CREATE TABLE retention_audit_log (
+    id                SERIAL        PRIMARY KEY,
+    run_id            VARCHAR(100)  NOT NULL,
+    action            VARCHAR(20)   NOT NULL CHECK (action IN ('archived', 'purged')),
+    team_id           VARCHAR(255)  NOT NULL,
+    team_name         VARCHAR(255),
+    assessment_period VARCHAR(50),
+    session_count     INTEGER       NOT NULL,
+    response_count    INTEGER       NOT NULL,
+    cutoff_date       DATE          NOT NULL,
+    triggered_by      VARCHAR(255)  NOT NULL,
+    executed_at       TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_retention_audit_log_executed_at ON retention_audit_log(executed_at DESC);
+CREATE INDEX idx_retention_audit_log_run_id ON retention_audit_log(run_id);
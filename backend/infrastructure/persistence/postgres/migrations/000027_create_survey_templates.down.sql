@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_sessions_template;
+ALTER TABLE health_check_sessions
+    DROP CONSTRAINT IF EXISTS fk_sessions_template_version,
+    DROP COLUMN IF EXISTS template_version,
+    DROP COLUMN IF EXISTS template_id;
+ALTER TABLE archived_health_check_sessions
+    DROP COLUMN IF EXISTS template_version,
+    DROP COLUMN IF EXISTS template_id;
+ALTER TABLE teams DROP COLUMN IF EXISTS survey_template_id;
+DROP TABLE IF EXISTS survey_template_dimensions;
+DROP TABLE IF EXISTS survey_template_versions;
+DROP TABLE IF EXISTS survey_templates;
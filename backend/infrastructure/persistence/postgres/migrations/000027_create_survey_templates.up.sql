@@ -0,0 +1,47 @@
+-- Survey templates: named, versioned collections of health dimensions assigned to teams.
+-- Versions are immutable. Changing a template's dimensions publishes a new version, and each
+-- session records the version it answered so historical results stay comparable.
+CREATE TABLE IF NOT EXISTS survey_templates (
+    id VARCHAR(100) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    current_version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS survey_template_versions (
+    template_id VARCHAR(100) NOT NULL REFERENCES survey_templates(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL CHECK (version > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (template_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS survey_template_dimensions (
+    template_id VARCHAR(100) NOT NULL,
+    version INTEGER NOT NULL,
+    dimension_id VARCHAR(50) NOT NULL REFERENCES health_dimensions(id) ON DELETE RESTRICT,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (template_id, version, dimension_id),
+    FOREIGN KEY (template_id, version) REFERENCES survey_template_versions(template_id, version) ON DELETE CASCADE
+);
+
+-- Teams without a template answer all active dimensions
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS survey_template_id VARCHAR(100)
+    REFERENCES survey_templates(id) ON DELETE SET NULL;
+
+-- Template version each session answered (NULL for sessions without a template)
+ALTER TABLE health_check_sessions
+    ADD COLUMN IF NOT EXISTS template_id VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS template_version INTEGER,
+    ADD CONSTRAINT fk_sessions_template_version FOREIGN KEY (template_id, template_version)
+        REFERENCES survey_template_versions(template_id, version) ON DELETE RESTRICT;
+
+ALTER TABLE archived_health_check_sessions
+    ADD COLUMN IF NOT EXISTS template_id VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS template_version INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_template ON health_check_sessions(template_id, template_version);
+
+COMMENT ON TABLE survey_templates IS 'Named, versioned dimension sets assigned to teams';
+COMMENT ON COLUMN health_check_sessions.template_version IS 'Survey template version the session answered';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS survey_participation;
+ALTER TABLE archived_health_check_sessions DROP COLUMN IF EXISTS anonymous;
+ALTER TABLE health_check_sessions DROP COLUMN IF EXISTS anonymous;
+ALTER TABLE survey_campaigns DROP COLUMN IF EXISTS anonymous;
+ALTER TABLE teams DROP COLUMN IF EXISTS anonymity_min_respondents;
+ALTER TABLE teams DROP COLUMN IF EXISTS anonymous;
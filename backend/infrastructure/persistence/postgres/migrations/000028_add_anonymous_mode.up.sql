@@ -0,0 +1,28 @@
+-- Anonymous (confidential) survey mode, enabled per team or per campaign.
+-- Anonymous sessions store a one-way respondent token in user_id instead of the member's ID.
+
+-- When true, every individual survey submitted for the team is anonymous
+ALTER TABLE teams ADD COLUMN anonymous BOOLEAN NOT NULL DEFAULT false;
+
+-- Anonymous sessions are only reported for a period once this many members have responded
+ALTER TABLE teams ADD COLUMN anonymity_min_respondents INTEGER NOT NULL DEFAULT 3
+    CHECK (anonymity_min_respondents >= 1);
+
+-- When true, individual surveys submitted for this campaign's team and period are anonymous
+ALTER TABLE survey_campaigns ADD COLUMN anonymous BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE health_check_sessions ADD COLUMN anonymous BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE archived_health_check_sessions ADD COLUMN anonymous BOOLEAN NOT NULL DEFAULT false;
+
+-- Who has submitted an anonymous survey, kept apart from the responses so that
+-- submission status and reminders work without linking members to sessions
+CREATE TABLE survey_participation (
+    team_id           VARCHAR(255)  NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    assessment_period VARCHAR(50)   NOT NULL,
+    user_id           VARCHAR(255)  NOT NULL,
+    submitted_at      TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, assessment_period, user_id)
+);
+
+COMMENT ON COLUMN health_check_sessions.anonymous IS 'When true, user_id holds a one-way respondent token rather than a user ID';
+COMMENT ON TABLE survey_participation IS 'Members who submitted an anonymous individual survey; not linked to their session';
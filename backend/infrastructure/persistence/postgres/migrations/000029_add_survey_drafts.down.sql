@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_sessions_one_draft;
+
+-- Partial draft answers cannot satisfy the original constraints
+DELETE FROM health_check_responses WHERE score IS NULL OR trend IS NULL;
+
+ALTER TABLE health_check_responses ALTER COLUMN trend SET NOT NULL;
+ALTER TABLE health_check_responses ALTER COLUMN score SET NOT NULL;
+
+COMMENT ON COLUMN health_check_responses.score IS '1 = red (poor), 2 = yellow (medium), 3 = green (good)';
+COMMENT ON COLUMN health_check_responses.trend IS 'Trend direction: improving, stable, declining';
@@ -0,0 +1,23 @@
+-- Server-side survey drafts: an incomplete individual session (completed = false)
+-- holds a member's partial answers until it is finalized
+
+-- Draft answers may pick a score before a trend, or the other way round
+ALTER TABLE health_check_responses ALTER COLUMN score DROP NOT NULL;
+ALTER TABLE health_check_responses ALTER COLUMN trend DROP NOT NULL;
+
+-- At most one open draft per member, team and period. Incomplete sessions are left out of
+-- every report, so only the most recently updated one of any duplicates is kept.
+DELETE FROM health_check_sessions s
+USING health_check_sessions newer
+WHERE s.completed = false AND newer.completed = false
+    AND s.survey_type = 'individual' AND newer.survey_type = 'individual'
+    AND s.team_id = newer.team_id AND s.user_id = newer.user_id
+    AND s.assessment_period = newer.assessment_period
+    AND (COALESCE(s.updated_at, '-infinity'), s.id) < (COALESCE(newer.updated_at, '-infinity'), newer.id);
+
+CREATE UNIQUE INDEX idx_sessions_one_draft
+    ON health_check_sessions(team_id, user_id, assessment_period)
+    WHERE completed = false AND survey_type = 'individual';
+
+COMMENT ON COLUMN health_check_responses.score IS '1 = red (poor), 2 = yellow (medium), 3 = green (good); NULL only on unanswered draft responses';
+COMMENT ON COLUMN health_check_responses.trend IS 'Trend direction: improving, stable, declining; NULL only on unanswered draft responses';
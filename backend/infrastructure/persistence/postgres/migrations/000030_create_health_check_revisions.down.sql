@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS health_check_revisions;
+DROP FUNCTION IF EXISTS reject_health_check_revision_update();
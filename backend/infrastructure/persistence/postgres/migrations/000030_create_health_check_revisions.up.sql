@@ -0,0 +1,31 @@
+-- Append-only revision history of submitted health checks. Rows are written when a member
+-- amends or withdraws a session; revision 1 then holds the responses as originally submitted.
+-- session_id has no foreign key so the history outlives withdrawn sessions.
+CREATE TABLE health_check_revisions (
+    id                BIGSERIAL     PRIMARY KEY,
+    session_id        VARCHAR(100)  NOT NULL,
+    team_id           VARCHAR(255)  NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    assessment_period VARCHAR(50),
+    revision          INTEGER       NOT NULL CHECK (revision >= 1),
+    action            VARCHAR(20)   NOT NULL
+                      CHECK (action IN ('submitted', 'amended', 'withdrawn')),
+    responses         JSONB,
+    created_at        TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_health_check_revisions_session_revision UNIQUE (session_id, revision)
+);
+
+CREATE INDEX idx_health_check_revisions_team_period ON health_check_revisions(team_id, assessment_period);
+
+-- Revisions are immutable once written; rows are only removed with their team or by retention
+CREATE FUNCTION reject_health_check_revision_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'health_check_revisions rows are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_health_check_revisions_immutable
+    BEFORE UPDATE ON health_check_revisions
+    FOR EACH ROW EXECUTE FUNCTION reject_health_check_revision_update();
+
+COMMENT ON TABLE health_check_revisions IS 'Append-only history of amended and withdrawn health check sessions';
+COMMENT ON COLUMN health_check_revisions.responses IS 'Responses as of this revision; NULL for anonymous sessions and withdrawals';
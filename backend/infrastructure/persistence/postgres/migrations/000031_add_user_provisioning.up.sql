@@ -0,0 +1,7 @@
+-- Provisioning state for users managed by an identity provider over SCIM
+-- external_id:    the identity provider's ID for the user
+-- deactivated_at: set when the user is deactivated; deactivated users cannot sign in
+ALTER TABLE users ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_external_id ON users(external_id) WHERE external_id IS NOT NULL;
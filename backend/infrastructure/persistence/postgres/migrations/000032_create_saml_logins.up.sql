@@ -0,0 +1,16 @@
+-- SP-initiated SAML logins. A row is created when the user is sent to the IdP with an
+-- AuthnRequest (request_id is its ID). The Response answering it completes the row once,
+-- attaching the user and a one-time code the frontend redeems for our token pair.
+-- state_hash binds the code to the browser that started the login (SHA-256 of its state).
+CREATE TABLE saml_logins (
+    request_id    VARCHAR(64)   PRIMARY KEY,
+    state_hash    VARCHAR(64)   NOT NULL,
+    expires_at    TIMESTAMPTZ   NOT NULL,
+    user_id       VARCHAR(255)  REFERENCES users(id) ON DELETE CASCADE,
+    code_hash     VARCHAR(64)   UNIQUE,
+    completed_at  TIMESTAMPTZ,
+    redeemed_at   TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_saml_logins_expires_at ON saml_logins(expires_at);
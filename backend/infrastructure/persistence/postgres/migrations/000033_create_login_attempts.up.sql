@@ -0,0 +1,16 @@
+-- Failed password logins per normalized username, shared by every API replica. Attempts are
+-- counted per username rather than per account, so unknown usernames are delayed and locked
+-- exactly like real ones and responses do not reveal which exist.
+-- Every attempt is counted before the password is checked (failed_count starts at 0 for a
+-- username's first attempt), restarting when the previous failure is older than the failure
+-- window. next_attempt_at holds the progressive delay between guesses and locked_until a
+-- temporary lockout; logins before either are refused without checking the password.
+-- The row is deleted on a successful login or admin unlock, or by the periodic cleanup once
+-- its last failure is outside the window and it blocks nothing.
+CREATE TABLE login_attempts (
+    username         VARCHAR(255)  PRIMARY KEY,
+    failed_count     INTEGER       NOT NULL DEFAULT 0,
+    last_failed_at   TIMESTAMPTZ   NOT NULL,
+    next_attempt_at  TIMESTAMPTZ,
+    locked_until     TIMESTAMPTZ
+);
@@ -0,0 +1,22 @@
+-- TOTP multi-factor authentication for local accounts.
+-- A row is created when the user starts enrolling and counts once enabled_at is set, after
+-- the user proved their authenticator works. last_used_step is the TOTP time step of the last
+-- accepted code, so each code is accepted once.
+CREATE TABLE user_mfa (
+    user_id         VARCHAR(255)  PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret          VARCHAR(64)   NOT NULL,
+    enabled_at      TIMESTAMPTZ,
+    last_used_step  BIGINT,
+    created_at      TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+-- One-time recovery codes for a lost authenticator, stored as SHA-256 hashes
+CREATE TABLE mfa_recovery_codes (
+    user_id    VARCHAR(255)  NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash  VARCHAR(64)   NOT NULL,
+    used_at    TIMESTAMPTZ,
+    PRIMARY KEY (user_id, code_hash)
+);
+
+-- Local users at a level with require_mfa must enroll before they can sign in
+ALTER TABLE hierarchy_levels ADD COLUMN require_mfa BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,39 @@
+-- Append-only record of every admin write: who changed what, and the target's state before
+-- and after. actor_id and target_id have no foreign keys so entries outlive deleted users
+-- and whatever they describe.
+CREATE TABLE admin_audit_log (
+    id             BIGSERIAL     PRIMARY KEY,
+    actor_id       VARCHAR(255)  NOT NULL,
+    actor_username VARCHAR(255)  NOT NULL DEFAULT '',
+    action         VARCHAR(100)  NOT NULL,
+    target_type    VARCHAR(50)   NOT NULL,
+    target_id      VARCHAR(255)  NOT NULL DEFAULT '',
+    before_state   JSONB,
+    after_state    JSONB,
+    ip_address     VARCHAR(45)   NOT NULL DEFAULT '',
+    request_id     VARCHAR(100)  NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_admin_audit_log_created_at ON admin_audit_log(created_at DESC, id DESC);
+CREATE INDEX idx_admin_audit_log_actor ON admin_audit_log(actor_id, created_at DESC);
+CREATE INDEX idx_admin_audit_log_target ON admin_audit_log(target_type, target_id, created_at DESC);
+
+-- Entries can neither be changed nor removed
+CREATE FUNCTION reject_admin_audit_log_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'admin_audit_log rows are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_admin_audit_log_immutable
+    BEFORE UPDATE OR DELETE ON admin_audit_log
+    FOR EACH ROW EXECUTE FUNCTION reject_admin_audit_log_change();
+
+CREATE TRIGGER trg_admin_audit_log_no_truncate
+    BEFORE TRUNCATE ON admin_audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_admin_audit_log_change();
+
+COMMENT ON TABLE admin_audit_log IS 'Append-only log of admin API writes';
+COMMENT ON COLUMN admin_audit_log.before_state IS 'Target as it was before the write; NULL for creates and actions without a stored target';
+COMMENT ON COLUMN admin_audit_log.after_state IS 'Target after the write, or the response for actions without a stored target; NULL for deletes';
@@ -0,0 +1,10 @@
+-- Restore the seeded permission flags of levels still as this migration left them
+UPDATE hierarchy_levels SET can_configure_system = false
+WHERE id = 'level-1'
+  AND can_view_all_teams AND can_edit_teams AND can_manage_users AND NOT can_take_survey
+  AND can_view_analytics AND can_configure_system AND can_view_reports AND can_export_data;
+
+UPDATE hierarchy_levels SET can_edit_teams = true, can_manage_users = true
+WHERE id = 'level-2'
+  AND can_view_all_teams AND NOT can_edit_teams AND NOT can_manage_users AND NOT can_take_survey
+  AND can_view_analytics AND NOT can_configure_system AND can_view_reports AND can_export_data;
@@ -0,0 +1,13 @@
+-- Route access now follows the hierarchy level permission flags instead of fixed level IDs.
+-- Align the seeded levels with the access they had before, so upgrading changes nobody's access:
+-- the VP level kept full admin access, and Directors could not reach the admin pages.
+-- Levels whose permissions an operator has already changed are left alone.
+UPDATE hierarchy_levels SET can_configure_system = true
+WHERE id = 'level-1'
+  AND can_view_all_teams AND can_edit_teams AND can_manage_users AND NOT can_take_survey
+  AND can_view_analytics AND NOT can_configure_system AND can_view_reports AND can_export_data;
+
+UPDATE hierarchy_levels SET can_edit_teams = false, can_manage_users = false
+WHERE id = 'level-2'
+  AND can_view_all_teams AND can_edit_teams AND can_manage_users AND NOT can_take_survey
+  AND can_view_analytics AND NOT can_configure_system AND can_view_reports AND can_export_data;
@@ -0,0 +1,22 @@
+-- Long-lived bearer tokens for scripts and integrations. Personal tokens are created by users
+-- for themselves; service tokens are issued by admins for a (usually dedicated) user. Only the
+-- SHA-256 hash of a token is stored; token_prefix is kept so users can tell tokens apart.
+CREATE TABLE api_tokens (
+    id            VARCHAR(255)  PRIMARY KEY,
+    name          VARCHAR(100)  NOT NULL,
+    token_hash    VARCHAR(64)   NOT NULL UNIQUE,
+    token_prefix  VARCHAR(20)   NOT NULL,
+    user_id       VARCHAR(255)  NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind          VARCHAR(20)   NOT NULL CHECK (kind IN ('personal', 'service')),
+    scopes        TEXT[]        NOT NULL,
+    created_by    VARCHAR(255)  NOT NULL,
+    expires_at    TIMESTAMPTZ,
+    last_used_at  TIMESTAMPTZ,
+    revoked_at    TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_tokens_user ON api_tokens(user_id, created_at DESC);
+
+COMMENT ON COLUMN api_tokens.expires_at IS 'NULL for tokens that do not expire';
+COMMENT ON COLUMN api_tokens.created_by IS 'User who created the token: the owner for personal tokens, the admin for service tokens';
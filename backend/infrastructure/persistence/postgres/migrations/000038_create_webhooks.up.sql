@@ -0,0 +1,38 @@
+-- Outbound webhooks. Admins register endpoints subscribed to event types; each event is
+-- queued in webhook_deliveries for every subscribed endpoint and delivered by a background
+-- worker, so events survive restarts and failed deliveries are retried. The secret signs
+-- deliveries (HMAC-SHA256) and must be kept in plain text to do so.
+CREATE TABLE webhook_endpoints (
+    id           VARCHAR(255)   PRIMARY KEY,
+    url          VARCHAR(2048)  NOT NULL,
+    description  VARCHAR(255)   NOT NULL DEFAULT '',
+    events       TEXT[]         NOT NULL,
+    secret       VARCHAR(100)   NOT NULL,
+    active       BOOLEAN        NOT NULL DEFAULT TRUE,
+    created_by   VARCHAR(255)   NOT NULL,
+    created_at   TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+-- The outbox and delivery log: one row per event per endpoint
+CREATE TABLE webhook_deliveries (
+    id               VARCHAR(255)  PRIMARY KEY,
+    endpoint_id      VARCHAR(255)  NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    event_id         VARCHAR(255)  NOT NULL,
+    event_type       VARCHAR(100)  NOT NULL,
+    payload          JSONB         NOT NULL,
+    status           VARCHAR(20)   NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts         INTEGER       NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    last_attempt_at  TIMESTAMPTZ,
+    response_status  INTEGER,
+    last_error       TEXT          NOT NULL DEFAULT '',
+    delivered_at     TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_endpoint ON webhook_deliveries(endpoint_id, created_at DESC);
+
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'When a pending delivery is next attempted; pushed ahead while a worker is sending it';
+COMMENT ON COLUMN webhook_deliveries.response_status IS 'HTTP status of the last attempt; NULL when no response was received';
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/lib/pq"
 )
 
 // OrganizationRepository implements the organization.Repository interface
@@ -75,7 +76,7 @@ func (r *OrganizationRepository) FindHierarchyLevels(ctx context.Context) ([]*or
 		       can_view_all_teams, can_edit_teams, can_manage_users,
 		       can_take_survey, can_view_analytics,
 		       can_configure_system, can_view_reports, can_export_data,
-		       created_at, updated_at
+		       require_mfa, created_at, updated_at
 		FROM hierarchy_levels
 		ORDER BY position
 	`)
@@ -105,6 +106,7 @@ func (r *OrganizationRepository) FindHierarchyLevels(ctx context.Context) ([]*or
 			&canConfigureSystem,
 			&canViewReports,
 			&canExportData,
+			&level.RequireMFA,
 			&createdAt,
 			&updatedAt,
 		)
@@ -159,7 +161,7 @@ func (r *OrganizationRepository) FindHierarchyLevelByID(ctx context.Context, id
 		       can_view_all_teams, can_edit_teams, can_manage_users,
 		       can_take_survey, can_view_analytics,
 		       can_configure_system, can_view_reports, can_export_data,
-		       created_at, updated_at
+		       require_mfa, created_at, updated_at
 		FROM hierarchy_levels
 		WHERE id = $1
 	`, id).Scan(
@@ -175,6 +177,7 @@ func (r *OrganizationRepository) FindHierarchyLevelByID(ctx context.Context, id
 		&canConfigureSystem,
 		&canViewReports,
 		&canExportData,
+		&level.RequireMFA,
 		&createdAt,
 		&updatedAt,
 	)
@@ -229,8 +232,8 @@ func (r *OrganizationRepository) SaveHierarchyLevel(ctx context.Context, level *
 			can_view_all_teams, can_edit_teams, can_manage_users,
 			can_take_survey, can_view_analytics,
 			can_configure_system, can_view_reports, can_export_data,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			require_mfa, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`,
 		level.ID,
 		level.Name,
@@ -244,6 +247,7 @@ func (r *OrganizationRepository) SaveHierarchyLevel(ctx context.Context, level *
 		level.Permissions.CanConfigureSystem,
 		level.Permissions.CanViewReports,
 		level.Permissions.CanExportData,
+		level.RequireMFA,
 		level.CreatedAt,
 		level.UpdatedAt,
 	)
@@ -278,8 +282,9 @@ func (r *OrganizationRepository) UpdateHierarchyLevel(ctx context.Context, level
 			can_configure_system = $9,
 			can_view_reports = $10,
 			can_export_data = $11,
-			updated_at = $12
-		WHERE id = $13
+			require_mfa = $12,
+			updated_at = $13
+		WHERE id = $14
 	`,
 		level.Name,
 		level.Position,
@@ -292,6 +297,7 @@ func (r *OrganizationRepository) UpdateHierarchyLevel(ctx context.Context, level
 		level.Permissions.CanConfigureSystem,
 		level.Permissions.CanViewReports,
 		level.Permissions.CanExportData,
+		level.RequireMFA,
 		level.UpdatedAt,
 		level.ID,
 	)
@@ -699,20 +705,25 @@ func (r *OrganizationRepository) DeleteDimension(ctx context.Context, id string)
 func (r *OrganizationRepository) GetAppSettings(ctx context.Context) (*organization.AppSettings, error) {
 	var s organization.AppSettings
 	var logoURL sql.NullString
+	var reminderOffsets pq.Int64Array
 	err := r.db.QueryRowContext(ctx, `
-		SELECT email_notifications, slack_notifications, weekly_digest, retention_months, company_name, logo_url
+		SELECT email_notifications, slack_notifications, weekly_digest, retention_months, company_name, logo_url, reminder_offsets_days, retention_archive
 		FROM app_settings WHERE id = 1
-	`).Scan(&s.EmailNotifications, &s.SlackNotifications, &s.WeeklyDigest, &s.RetentionMonths, &s.CompanyName, &logoURL)
+	`).Scan(&s.EmailNotifications, &s.SlackNotifications, &s.WeeklyDigest, &s.RetentionMonths, &s.CompanyName, &logoURL, &reminderOffsets, &s.RetentionArchive)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			// Return defaults if row doesn't exist yet
-			return &organization.AppSettings{RetentionMonths: 12, CompanyName: "My Company"}, nil
+			return &organization.AppSettings{RetentionMonths: 12, RetentionArchive: true, CompanyName: "My Company", ReminderOffsets: []int{7, 3, 1}}, nil
 		}
 		return nil, fmt.Errorf("failed to query app settings: %w", err)
 	}
 	if logoURL.Valid {
 		s.LogoURL = logoURL.String
 	}
+	s.ReminderOffsets = make([]int, len(reminderOffsets))
+	for i, offset := range reminderOffsets {
+		s.ReminderOffsets[i] = int(offset)
+	}
 	return &s, nil
 }
 
@@ -792,6 +803,40 @@ func (r *OrganizationRepository) UpdateRetentionSettings(ctx context.Context, mo
 	return nil
 }
 
+// UpdateRetentionArchive updates only the retention_archive column
+func (r *OrganizationRepository) UpdateRetentionArchive(ctx context.Context, archive bool) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO app_settings (id, retention_archive, updated_at)
+		VALUES (1, $1, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			retention_archive = EXCLUDED.retention_archive,
+			updated_at = NOW()
+	`, archive)
+	if err != nil {
+		return fmt.Errorf("failed to update retention archive setting: %w", err)
+	}
+	return nil
+}
+
+// UpdateReminderOffsets updates only the reminder_offsets_days column
+func (r *OrganizationRepository) UpdateReminderOffsets(ctx context.Context, offsets []int) error {
+	values := make(pq.Int64Array, len(offsets))
+	for i, offset := range offsets {
+		values[i] = int64(offset)
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO app_settings (id, reminder_offsets_days, updated_at)
+		VALUES (1, $1, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			reminder_offsets_days = EXCLUDED.reminder_offsets_days,
+			updated_at = NOW()
+	`, values)
+	if err != nil {
+		return fmt.Errorf("failed to update reminder offsets: %w", err)
+	}
+	return nil
+}
+
 // Helper methods
 
 // saveHierarchyLevelTx saves a hierarchy level within a transaction
@@ -814,8 +859,8 @@ func (r *OrganizationRepository) saveHierarchyLevelTx(ctx context.Context, tx *s
 			can_view_all_teams, can_edit_teams, can_manage_users,
 			can_take_survey, can_view_analytics,
 			can_configure_system, can_view_reports, can_export_data,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			require_mfa, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		ON CONFLICT (id) DO UPDATE SET
 			name = EXCLUDED.name,
 			position = EXCLUDED.position,
@@ -828,6 +873,7 @@ func (r *OrganizationRepository) saveHierarchyLevelTx(ctx context.Context, tx *s
 			can_configure_system = EXCLUDED.can_configure_system,
 			can_view_reports = EXCLUDED.can_view_reports,
 			can_export_data = EXCLUDED.can_export_data,
+			require_mfa = EXCLUDED.require_mfa,
 			updated_at = EXCLUDED.updated_at
 	`,
 		level.ID,
@@ -842,6 +888,7 @@ func (r *OrganizationRepository) saveHierarchyLevelTx(ctx context.Context, tx *s
 		level.Permissions.CanConfigureSystem,
 		level.Permissions.CanViewReports,
 		level.Permissions.CanExportData,
+		level.RequireMFA,
 		level.CreatedAt,
 		level.UpdatedAt,
 	)
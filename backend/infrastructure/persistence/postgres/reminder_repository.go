@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+)
+
+// ReminderRepository implements services.ReminderRepository
+type ReminderRepository struct {
+	db *sql.DB
+}
+
+// NewReminderRepository creates a new reminder repository
+func NewReminderRepository(db *sql.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+// Claim records a reminder unless one already exists for the same campaign, user and offset
+func (r *ReminderRepository) Claim(ctx context.Context, reminder *services.SurveyReminder) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO survey_reminders (id, campaign_id, user_id, offset_days, sent_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (campaign_id, user_id, offset_days) DO NOTHING
+	`, reminder.ID, reminder.CampaignID, reminder.UserID, reminder.OffsetDays, reminder.SentAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim reminder: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Release deletes a reminder claim so the send can be retried
+func (r *ReminderRepository) Release(ctx context.Context, reminderID string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM survey_reminders WHERE id = $1", reminderID)
+	if err != nil {
+		return fmt.Errorf("failed to release reminder: %w", err)
+	}
+	return nil
+}
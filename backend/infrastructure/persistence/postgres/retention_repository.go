@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+)
+
+// notOnLegalHold restricts a query over health_check_sessions s to teams without a legal hold
+const notOnLegalHold = `NOT EXISTS (SELECT 1 FROM teams lh WHERE lh.id = s.team_id AND lh.legal_hold)`
+
+// RetentionRepository implements services.RetentionRepository
+type RetentionRepository struct {
+	db *sql.DB
+}
+
+// NewRetentionRepository creates a new retention repository
+func NewRetentionRepository(db *sql.DB) *RetentionRepository {
+	return &RetentionRepository{db: db}
+}
+
+// CountExpired returns per team/period counts of sessions dated before cutoff, including teams on legal hold
+func (r *RetentionRepository) CountExpired(ctx context.Context, cutoff time.Time) ([]services.RetentionCount, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT s.team_id, COALESCE(t.name, s.team_id), COALESCE(s.assessment_period, ''),
+			COUNT(DISTINCT s.id), COUNT(hcr.id), COALESCE(t.legal_hold, false)
+		FROM health_check_sessions s
+		LEFT JOIN teams t ON t.id = s.team_id
+		LEFT JOIN health_check_responses hcr ON hcr.session_id = s.id
+		WHERE s.date < $1
+		GROUP BY s.team_id, t.name, s.assessment_period, t.legal_hold
+		ORDER BY COALESCE(t.name, s.team_id), s.assessment_period
+	`, cutoff.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count expired sessions: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []services.RetentionCount{}
+	for rows.Next() {
+		var c services.RetentionCount
+		if err := rows.Scan(&c.TeamID, &c.TeamName, &c.AssessmentPeriod, &c.Sessions, &c.Responses, &c.LegalHold); err != nil {
+			return nil, fmt.Errorf("failed to scan retention count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// RemoveExpired archives (or deletes when archive is false) sessions dated before cutoff for teams
+// not on legal hold, together with their revisions. Responses are removed by ON DELETE CASCADE. Counts, archive copies, deletes
+// and audit entries are written in one transaction so the audit log always matches what was removed.
+func (r *RetentionRepository) RemoveExpired(ctx context.Context, cutoff time.Time, archive bool, runID, triggeredBy string) ([]services.RetentionCount, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	cutoffDate := cutoff.Format("2006-01-02")
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT s.team_id, COALESCE(t.name, s.team_id), COALESCE(s.assessment_period, ''),
+			COUNT(DISTINCT s.id), COUNT(hcr.id)
+		FROM health_check_sessions s
+		LEFT JOIN teams t ON t.id = s.team_id
+		LEFT JOIN health_check_responses hcr ON hcr.session_id = s.id
+		WHERE s.date < $1 AND `+notOnLegalHold+`
+		GROUP BY s.team_id, t.name, s.assessment_period
+		ORDER BY COALESCE(t.name, s.team_id), s.assessment_period
+	`, cutoffDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count expired sessions: %w", err)
+	}
+
+	removed := []services.RetentionCount{}
+	for rows.Next() {
+		var c services.RetentionCount
+		if err := rows.Scan(&c.TeamID, &c.TeamName, &c.AssessmentPeriod, &c.Sessions, &c.Responses); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan retention count: %w", err)
+		}
+		removed = append(removed, c)
+	}
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	rows.Close()
+
+	// Revisions go with their expired sessions; those of withdrawn sessions expire on their own date
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM health_check_revisions s
+		WHERE `+notOnLegalHold+` AND (
+			s.session_id IN (
+				SELECT s.id FROM health_check_sessions s
+				WHERE s.date < $1 AND `+notOnLegalHold+`
+			)
+			OR (s.created_at < $1 AND NOT EXISTS (
+				SELECT 1 FROM health_check_sessions hcs WHERE hcs.id = s.session_id
+			))
+		)
+	`, cutoffDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired revisions: %w", err)
+	}
+
+	if len(removed) == 0 {
+		if err = tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return removed, nil
+	}
+
+	action := services.RetentionActionPurge
+	if archive {
+		action = services.RetentionActionArchive
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO archived_health_check_sessions
+				(id, team_id, user_id, date, assessment_period, survey_type, completed, template_id, template_version,
+				anonymous, responses, created_at, retention_run_id)
+			SELECT s.id, s.team_id, s.user_id, s.date, s.assessment_period, s.survey_type, s.completed,
+				s.template_id, s.template_version, s.anonymous,
+				COALESCE((
+					SELECT jsonb_agg(jsonb_build_object(
+						'dimensionId', hcr.dimension_id,
+						'score', hcr.score,
+						'trend', hcr.trend,
+						'comment', hcr.comment
+					) ORDER BY hcr.dimension_id)
+					FROM health_check_responses hcr WHERE hcr.session_id = s.id
+				), '[]'::jsonb),
+				s.created_at, $2
+			FROM health_check_sessions s
+			WHERE s.date < $1 AND `+notOnLegalHold+`
+			ON CONFLICT (id) DO NOTHING
+		`, cutoffDate, runID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to archive expired sessions: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM health_check_sessions s
+		WHERE s.date < $1 AND `+notOnLegalHold, cutoffDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+
+	for _, c := range removed {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO retention_audit_log
+				(run_id, action, team_id, team_name, assessment_period, session_count, response_count, cutoff_date, triggered_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`, runID, action, c.TeamID, c.TeamName, c.AssessmentPeriod, c.Sessions, c.Responses, cutoffDate, triggeredBy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write retention audit log: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return removed, nil
+}
+
+// FindAuditLog returns the most recent audit entries, newest first
+func (r *RetentionRepository) FindAuditLog(ctx context.Context, limit int) ([]services.RetentionAuditEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, run_id, action, team_id, COALESCE(team_name, ''), COALESCE(assessment_period, ''),
+			session_count, response_count, cutoff_date, triggered_by, executed_at
+		FROM retention_audit_log
+		ORDER BY executed_at DESC, id DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query retention audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []services.RetentionAuditEntry{}
+	for rows.Next() {
+		var e services.RetentionAuditEntry
+		if err := rows.Scan(&e.ID, &e.RunID, &e.Action, &e.TeamID, &e.TeamName, &e.AssessmentPeriod,
+			&e.Sessions, &e.Responses, &e.CutoffDate, &e.TriggeredBy, &e.ExecutedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan retention audit entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SAMLLoginRepository implements services.SAMLLoginStore
+type SAMLLoginRepository struct {
+	db *sql.DB
+}
+
+// NewSAMLLoginRepository creates a new SAML login repository
+func NewSAMLLoginRepository(db *sql.DB) *SAMLLoginRepository {
+	return &SAMLLoginRepository{db: db}
+}
+
+// CreateLogin records an AuthnRequest sent to the IdP
+func (r *SAMLLoginRepository) CreateLogin(ctx context.Context, requestID, stateHash string, expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO saml_logins (request_id, state_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`, requestID, stateHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create SAML login: %w", err)
+	}
+	return nil
+}
+
+// ConsumeRequest marks a pending, unexpired login as answered.
+// The conditional update makes a replayed Response lose the race.
+func (r *SAMLLoginRepository) ConsumeRequest(ctx context.Context, requestID string, now time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE saml_logins SET completed_at = $1
+		WHERE request_id = $2 AND completed_at IS NULL AND expires_at > $1
+	`, now, requestID)
+	if err != nil {
+		return false, fmt.Errorf("failed to consume SAML request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// AttachCode stores the signed-in user and the login code of an answered login
+func (r *SAMLLoginRepository) AttachCode(ctx context.Context, requestID, userID, codeHash string, expiresAt time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE saml_logins SET user_id = $1, code_hash = $2, expires_at = $3
+		WHERE request_id = $4 AND completed_at IS NOT NULL AND code_hash IS NULL
+	`, userID, codeHash, expiresAt, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to store SAML login code: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("SAML login not found: %s", requestID)
+	}
+
+	return nil
+}
+
+// RedeemCode marks an unexpired, unredeemed code as redeemed and returns the user ID
+func (r *SAMLLoginRepository) RedeemCode(ctx context.Context, codeHash, stateHash string, now time.Time) (string, error) {
+	var userID string
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE saml_logins SET redeemed_at = $1
+		WHERE code_hash = $2 AND state_hash = $3 AND redeemed_at IS NULL AND expires_at > $1
+		RETURNING user_id
+	`, now, codeHash, stateHash).Scan(&userID)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to redeem SAML login code: %w", err)
+	}
+
+	return userID, nil
+}
+
+// DeleteExpired removes logins that expired before the given time
+func (r *SAMLLoginRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM saml_logins WHERE expires_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired SAML logins: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,342 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/survey"
+)
+
+// SurveyTemplateRepository implements the survey.Repository interface
+type SurveyTemplateRepository struct {
+	db *sql.DB
+}
+
+// NewSurveyTemplateRepository creates a new repository instance
+func NewSurveyTemplateRepository(db *sql.DB) survey.Repository {
+	return &SurveyTemplateRepository{db: db}
+}
+
+// FindAll retrieves all templates with their current dimensions, ordered by name
+func (r *SurveyTemplateRepository) FindAll(ctx context.Context) ([]*survey.Template, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT st.id, st.name, COALESCE(st.description, ''), st.current_version, st.created_at, st.updated_at,
+			(SELECT COUNT(*) FROM teams t WHERE t.survey_template_id = st.id)
+		FROM survey_templates st
+		ORDER BY st.name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query survey templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*survey.Template{}
+	for rows.Next() {
+		var t survey.Template
+		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Version, &t.CreatedAt, &t.UpdatedAt, &t.TeamCount); err != nil {
+			return nil, fmt.Errorf("failed to scan survey template: %w", err)
+		}
+		templates = append(templates, &t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	for _, t := range templates {
+		if t.Dimensions, err = r.findDimensionIDs(ctx, r.db, t.ID, t.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+// FindByID retrieves a template with its current dimensions
+func (r *SurveyTemplateRepository) FindByID(ctx context.Context, id string) (*survey.Template, error) {
+	var t survey.Template
+	err := r.db.QueryRowContext(ctx, `
+		SELECT st.id, st.name, COALESCE(st.description, ''), st.current_version, st.created_at, st.updated_at,
+			(SELECT COUNT(*) FROM teams t WHERE t.survey_template_id = st.id)
+		FROM survey_templates st
+		WHERE st.id = $1
+	`, id).Scan(&t.ID, &t.Name, &t.Description, &t.Version, &t.CreatedAt, &t.UpdatedAt, &t.TeamCount)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("survey template not found: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find survey template: %w", err)
+	}
+
+	if t.Dimensions, err = r.findDimensionIDs(ctx, r.db, t.ID, t.Version); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// FindVersions retrieves every version of a template, newest first
+func (r *SurveyTemplateRepository) FindVersions(ctx context.Context, templateID string) ([]*survey.Version, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT template_id, version, created_at
+		FROM survey_template_versions
+		WHERE template_id = $1
+		ORDER BY version DESC
+	`, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query survey template versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*survey.Version{}
+	for rows.Next() {
+		var v survey.Version
+		if err := rows.Scan(&v.TemplateID, &v.Version, &v.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan survey template version: %w", err)
+		}
+		versions = append(versions, &v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	for _, v := range versions {
+		if v.Dimensions, err = r.findDimensionIDs(ctx, r.db, v.TemplateID, v.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	return versions, nil
+}
+
+// FindVersion retrieves a single template version
+func (r *SurveyTemplateRepository) FindVersion(ctx context.Context, templateID string, version int) (*survey.Version, error) {
+	var v survey.Version
+	err := r.db.QueryRowContext(ctx, `
+		SELECT template_id, version, created_at
+		FROM survey_template_versions
+		WHERE template_id = $1 AND version = $2
+	`, templateID, version).Scan(&v.TemplateID, &v.Version, &v.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("survey template version not found: %s v%d", templateID, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find survey template version: %w", err)
+	}
+
+	if v.Dimensions, err = r.findDimensionIDs(ctx, r.db, v.TemplateID, v.Version); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// FindCurrentForTeam returns the current version of the team's template, or nil if none is assigned
+func (r *SurveyTemplateRepository) FindCurrentForTeam(ctx context.Context, teamID string) (*survey.Version, error) {
+	var templateID string
+	var version int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT st.id, st.current_version
+		FROM teams t
+		INNER JOIN survey_templates st ON st.id = t.survey_template_id
+		WHERE t.id = $1
+	`, teamID).Scan(&templateID, &version)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find team survey template: %w", err)
+	}
+
+	return r.FindVersion(ctx, templateID, version)
+}
+
+// Create inserts the template with its dimensions as version 1
+func (r *SurveyTemplateRepository) Create(ctx context.Context, t *survey.Template) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	t.Version = 1
+	t.CreatedAt = now
+	t.UpdatedAt = now
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO survey_templates (id, name, description, current_version, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)
+	`, t.ID, t.Name, t.Description, t.Version, t.CreatedAt, t.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save survey template: %w", err)
+	}
+
+	if err = r.insertVersionTx(ctx, tx, t.ID, t.Version, t.Dimensions, now); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves name and description, publishing a new version when the dimension set changes
+func (r *SurveyTemplateRepository) Update(ctx context.Context, t *survey.Template, dimensionIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the template row so concurrent updates cannot publish the same version twice
+	var current int
+	err = tx.QueryRowContext(ctx, `SELECT current_version FROM survey_templates WHERE id = $1 FOR UPDATE`, t.ID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("survey template not found: %s", t.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock survey template: %w", err)
+	}
+
+	currentDims, err := r.findDimensionIDs(ctx, tx, t.ID, current)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	t.Version = current
+	t.Dimensions = currentDims
+	if dimensionIDs != nil && !equalDimensionIDs(currentDims, dimensionIDs) {
+		t.Version = current + 1
+		t.Dimensions = dimensionIDs
+		if err = r.insertVersionTx(ctx, tx, t.ID, t.Version, dimensionIDs, now); err != nil {
+			return err
+		}
+	}
+
+	t.UpdatedAt = now
+	_, err = tx.ExecContext(ctx, `
+		UPDATE survey_templates SET
+			name = $1,
+			description = NULLIF($2, ''),
+			current_version = $3,
+			updated_at = $4
+		WHERE id = $5
+	`, t.Name, t.Description, t.Version, t.UpdatedAt, t.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update survey template: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a template and its versions. Teams using it fall back to all active dimensions.
+func (r *SurveyTemplateRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM survey_templates WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete survey template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("survey template not found: %s", id)
+	}
+
+	return nil
+}
+
+// CountSessions returns how many health check sessions recorded any version of the template
+func (r *SurveyTemplateRepository) CountSessions(ctx context.Context, templateID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM health_check_sessions WHERE template_id = $1
+	`, templateID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count template sessions: %w", err)
+	}
+	return count, nil
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// findDimensionIDs returns a version's dimensions in survey order
+func (r *SurveyTemplateRepository) findDimensionIDs(ctx context.Context, q queryer, templateID string, version int) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT dimension_id
+		FROM survey_template_dimensions
+		WHERE template_id = $1 AND version = $2
+		ORDER BY position
+	`, templateID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query survey template dimensions: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan survey template dimension: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// insertVersionTx publishes a version with its dimensions in the given order
+func (r *SurveyTemplateRepository) insertVersionTx(ctx context.Context, tx *sql.Tx, templateID string, version int, dimensionIDs []string, createdAt time.Time) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO survey_template_versions (template_id, version, created_at)
+		VALUES ($1, $2, $3)
+	`, templateID, version, createdAt)
+	if err != nil {
+		return fmt.Errorf("failed to save survey template version: %w", err)
+	}
+
+	for i, dimensionID := range dimensionIDs {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO survey_template_dimensions (template_id, version, dimension_id, position)
+			VALUES ($1, $2, $3, $4)
+		`, templateID, version, dimensionID, i+1)
+		if err != nil {
+			return fmt.Errorf("failed to save survey template dimension: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// equalDimensionIDs reports whether two dimension lists are identical, including order
+func equalDimensionIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
 	"github.com/agopalakrishnan/teams360/backend/domain/team"
 )
 
@@ -26,10 +27,13 @@ func (r *TeamRepository) FindByID(ctx context.Context, id string) (*team.Team, e
 	var teamLeadName sql.NullString
 	var cadence sql.NullString
 	var distributionListEmail sql.NullString
+	var nextCheckDate sql.NullTime
+	var chatWebhookURL, chatWebhookFormat sql.NullString
+	var surveyTemplateID sql.NullString
 	var createdAt, updatedAt sql.NullTime
 
 	err := r.db.QueryRowContext(ctx, `
-		SELECT t.id, t.name, t.team_lead_id, t.cadence, t.created_at, t.updated_at, t.distribution_list_email, u.full_name
+		SELECT t.id, t.name, t.team_lead_id, t.cadence, t.created_at, t.updated_at, t.distribution_list_email, u.full_name, t.next_check_date, t.chat_webhook_url, t.chat_webhook_format, t.legal_hold, t.survey_template_id, t.anonymous, t.anonymity_min_respondents
 		FROM teams t
 		LEFT JOIN users u ON t.team_lead_id = u.id
 		WHERE t.id = $1
@@ -42,6 +46,13 @@ func (r *TeamRepository) FindByID(ctx context.Context, id string) (*team.Team, e
 		&updatedAt,
 		&distributionListEmail,
 		&teamLeadName,
+		&nextCheckDate,
+		&chatWebhookURL,
+		&chatWebhookFormat,
+		&t.LegalHold,
+		&surveyTemplateID,
+		&t.Anonymous,
+		&t.AnonymityMinRespondents,
 	)
 
 	if err == sql.ErrNoRows {
@@ -66,6 +77,18 @@ func (r *TeamRepository) FindByID(ctx context.Context, id string) (*team.Team, e
 	if distributionListEmail.Valid {
 		t.DistributionListEmail = &distributionListEmail.String
 	}
+	if nextCheckDate.Valid {
+		t.NextCheckDate = nextCheckDate.Time.Format("2006-01-02")
+	}
+	if chatWebhookURL.Valid {
+		t.ChatWebhookURL = &chatWebhookURL.String
+	}
+	if chatWebhookFormat.Valid {
+		t.ChatWebhookFormat = chatWebhookFormat.String
+	}
+	if surveyTemplateID.Valid {
+		t.SurveyTemplateID = &surveyTemplateID.String
+	}
 	if createdAt.Valid {
 		t.CreatedAt = createdAt.Time
 	}
@@ -105,7 +128,7 @@ func (r *TeamRepository) FindByID(ctx context.Context, id string) (*team.Team, e
 // FindAll retrieves all teams
 func (r *TeamRepository) FindAll(ctx context.Context) ([]*team.Team, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT t.id, t.name, t.team_lead_id, t.cadence, t.created_at, t.updated_at, t.distribution_list_email, u.full_name
+		SELECT t.id, t.name, t.team_lead_id, t.cadence, t.created_at, t.updated_at, t.distribution_list_email, u.full_name, t.next_check_date, t.chat_webhook_url, t.chat_webhook_format, t.legal_hold, t.survey_template_id, t.anonymous, t.anonymity_min_respondents
 		FROM teams t
 		LEFT JOIN users u ON t.team_lead_id = u.id
 		ORDER BY t.name
@@ -137,7 +160,7 @@ func (r *TeamRepository) FindAllWithDetails(ctx context.Context) ([]team.Team, e
 // FindByLeadID retrieves all teams led by a specific user
 func (r *TeamRepository) FindByLeadID(ctx context.Context, leadID string) ([]*team.Team, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT t.id, t.name, t.team_lead_id, t.cadence, t.created_at, t.updated_at, t.distribution_list_email, u.full_name
+		SELECT t.id, t.name, t.team_lead_id, t.cadence, t.created_at, t.updated_at, t.distribution_list_email, u.full_name, t.next_check_date, t.chat_webhook_url, t.chat_webhook_format, t.legal_hold, t.survey_template_id, t.anonymous, t.anonymity_min_respondents
 		FROM teams t
 		LEFT JOIN users u ON t.team_lead_id = u.id
 		WHERE t.team_lead_id = $1
@@ -155,7 +178,7 @@ func (r *TeamRepository) FindByLeadID(ctx context.Context, leadID string) ([]*te
 // FindBySupervisorID retrieves all teams where a user is in the supervisor chain
 func (r *TeamRepository) FindBySupervisorID(ctx context.Context, supervisorID string) ([]*team.Team, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT DISTINCT t.id, t.name, t.team_lead_id, t.cadence, t.created_at, t.updated_at, t.distribution_list_email, u.full_name
+		SELECT DISTINCT t.id, t.name, t.team_lead_id, t.cadence, t.created_at, t.updated_at, t.distribution_list_email, u.full_name, t.next_check_date, t.chat_webhook_url, t.chat_webhook_format, t.legal_hold, t.survey_template_id, t.anonymous, t.anonymity_min_respondents
 		FROM teams t
 		INNER JOIN team_supervisors ts ON t.id = ts.team_id
 		LEFT JOIN users u ON t.team_lead_id = u.id
@@ -316,6 +339,20 @@ func (r *TeamRepository) Save(ctx context.Context, t *team.Team) error {
 	if t.DistributionListEmail != nil && *t.DistributionListEmail != "" {
 		distributionListEmail = sql.NullString{String: *t.DistributionListEmail, Valid: true}
 	}
+	var chatWebhookURL sql.NullString
+	if t.ChatWebhookURL != nil && *t.ChatWebhookURL != "" {
+		chatWebhookURL = sql.NullString{String: *t.ChatWebhookURL, Valid: true}
+	}
+	if t.ChatWebhookFormat == "" {
+		t.ChatWebhookFormat = "slack"
+	}
+	surveyTemplateID, err := r.surveyTemplateIDTx(ctx, tx, t.SurveyTemplateID)
+	if err != nil {
+		return err
+	}
+	if t.AnonymityMinRespondents < 1 {
+		t.AnonymityMinRespondents = healthcheck.DefaultMinRespondents
+	}
 
 	// Set timestamps
 	now := time.Now()
@@ -326,9 +363,9 @@ func (r *TeamRepository) Save(ctx context.Context, t *team.Team) error {
 
 	// Insert team
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO teams (id, name, team_lead_id, cadence, distribution_list_email, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`, t.ID, t.Name, teamLeadID, cadence, distributionListEmail, t.CreatedAt, t.UpdatedAt)
+		INSERT INTO teams (id, name, team_lead_id, cadence, distribution_list_email, chat_webhook_url, chat_webhook_format, legal_hold, survey_template_id, anonymous, anonymity_min_respondents, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, t.ID, t.Name, teamLeadID, cadence, distributionListEmail, chatWebhookURL, t.ChatWebhookFormat, t.LegalHold, surveyTemplateID, t.Anonymous, t.AnonymityMinRespondents, t.CreatedAt, t.UpdatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to save team: %w", err)
@@ -383,6 +420,25 @@ func (r *TeamRepository) Update(ctx context.Context, t *team.Team) error {
 	if t.DistributionListEmail != nil && *t.DistributionListEmail != "" {
 		distributionListEmail = sql.NullString{String: *t.DistributionListEmail, Valid: true}
 	}
+	var chatWebhookURL sql.NullString
+	if t.ChatWebhookURL != nil && *t.ChatWebhookURL != "" {
+		chatWebhookURL = sql.NullString{String: *t.ChatWebhookURL, Valid: true}
+	}
+	if t.ChatWebhookFormat == "" {
+		t.ChatWebhookFormat = "slack"
+	}
+	// An empty next check date keeps the stored one
+	var nextCheckDate sql.NullString
+	if t.NextCheckDate != "" {
+		nextCheckDate = sql.NullString{String: t.NextCheckDate, Valid: true}
+	}
+	surveyTemplateID, err := r.surveyTemplateIDTx(ctx, tx, t.SurveyTemplateID)
+	if err != nil {
+		return err
+	}
+	if t.AnonymityMinRespondents < 1 {
+		t.AnonymityMinRespondents = healthcheck.DefaultMinRespondents
+	}
 
 	// Update timestamp
 	t.UpdatedAt = time.Now()
@@ -394,9 +450,16 @@ func (r *TeamRepository) Update(ctx context.Context, t *team.Team) error {
 			team_lead_id = $2,
 			cadence = $3,
 			distribution_list_email = $4,
-			updated_at = $5
-		WHERE id = $6
-	`, t.Name, teamLeadID, cadence, distributionListEmail, t.UpdatedAt, t.ID)
+			chat_webhook_url = $5,
+			chat_webhook_format = $6,
+			legal_hold = $7,
+			survey_template_id = $8,
+			anonymous = $9,
+			anonymity_min_respondents = $10,
+			updated_at = $11,
+			next_check_date = COALESCE($13::date, next_check_date)
+		WHERE id = $12
+	`, t.Name, teamLeadID, cadence, distributionListEmail, chatWebhookURL, t.ChatWebhookFormat, t.LegalHold, surveyTemplateID, t.Anonymous, t.AnonymityMinRespondents, t.UpdatedAt, t.ID, nextCheckDate)
 
 	if err != nil {
 		return fmt.Errorf("failed to update team: %w", err)
@@ -501,6 +564,28 @@ func (r *TeamRepository) RemoveMember(ctx context.Context, teamID, userID string
 	return nil
 }
 
+// UpdateNextCheckDate records when the team's next health check is due
+func (r *TeamRepository) UpdateNextCheckDate(ctx context.Context, teamID string, nextCheckDate time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE teams SET next_check_date = $1
+		WHERE id = $2
+	`, nextCheckDate, teamID)
+
+	if err != nil {
+		return fmt.Errorf("failed to update next check date: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("team not found: %s", teamID)
+	}
+
+	return nil
+}
+
 // UpdateSupervisorChain updates the supervisor chain for a team
 func (r *TeamRepository) UpdateSupervisorChain(ctx context.Context, teamID string, chain []*team.SupervisorLink) error {
 	// Begin transaction
@@ -525,6 +610,25 @@ func (r *TeamRepository) UpdateSupervisorChain(ctx context.Context, teamID strin
 
 // Helper methods
 
+// surveyTemplateIDTx converts the team's survey template to a nullable column value,
+// returning a not-found error when the template does not exist
+func (r *TeamRepository) surveyTemplateIDTx(ctx context.Context, tx *sql.Tx, id *string) (sql.NullString, error) {
+	if id == nil || *id == "" {
+		return sql.NullString{}, nil
+	}
+
+	var exists bool
+	err := tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM survey_templates WHERE id = $1)", *id).Scan(&exists)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to check survey template: %w", err)
+	}
+	if !exists {
+		return sql.NullString{}, fmt.Errorf("survey template not found: %s", *id)
+	}
+
+	return sql.NullString{String: *id, Valid: true}, nil
+}
+
 // scanTeams is a helper function to scan query results into teams
 func (r *TeamRepository) scanTeams(ctx context.Context, rows *sql.Rows) ([]*team.Team, error) {
 	var teams []*team.Team
@@ -535,6 +639,9 @@ func (r *TeamRepository) scanTeams(ctx context.Context, rows *sql.Rows) ([]*team
 		var teamLeadName sql.NullString
 		var cadence sql.NullString
 		var distributionListEmail sql.NullString
+		var nextCheckDate sql.NullTime
+		var chatWebhookURL, chatWebhookFormat sql.NullString
+		var surveyTemplateID sql.NullString
 		var createdAt, updatedAt sql.NullTime
 
 		err := rows.Scan(
@@ -546,6 +653,13 @@ func (r *TeamRepository) scanTeams(ctx context.Context, rows *sql.Rows) ([]*team
 			&updatedAt,
 			&distributionListEmail,
 			&teamLeadName,
+			&nextCheckDate,
+			&chatWebhookURL,
+			&chatWebhookFormat,
+			&t.LegalHold,
+			&surveyTemplateID,
+			&t.Anonymous,
+			&t.AnonymityMinRespondents,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan team: %w", err)
@@ -566,6 +680,18 @@ func (r *TeamRepository) scanTeams(ctx context.Context, rows *sql.Rows) ([]*team
 		if distributionListEmail.Valid {
 			t.DistributionListEmail = &distributionListEmail.String
 		}
+		if nextCheckDate.Valid {
+			t.NextCheckDate = nextCheckDate.Time.Format("2006-01-02")
+		}
+		if chatWebhookURL.Valid {
+			t.ChatWebhookURL = &chatWebhookURL.String
+		}
+		if chatWebhookFormat.Valid {
+			t.ChatWebhookFormat = chatWebhookFormat.String
+		}
+		if surveyTemplateID.Valid {
+			t.SurveyTemplateID = &surveyTemplateID.String
+		}
 		if createdAt.Valid {
 			t.CreatedAt = createdAt.Time
 		}
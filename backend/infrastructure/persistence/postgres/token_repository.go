@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+)
+
+// TokenRepository implements services.TokenStore
+type TokenRepository struct {
+	db *sql.DB
+}
+
+// NewTokenRepository creates a new token revocation repository
+func NewTokenRepository(db *sql.DB) *TokenRepository {
+	return &TokenRepository{db: db}
+}
+
+// SaveRefreshToken records a newly issued refresh token
+func (r *TokenRepository) SaveRefreshToken(ctx context.Context, record *services.RefreshTokenRecord) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (jti, family_id, user_id, issued_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, record.JTI, record.FamilyID, record.UserID, record.IssuedAt, record.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save refresh token: %w", err)
+	}
+	return nil
+}
+
+// FindRefreshToken returns the record for a refresh token
+func (r *TokenRepository) FindRefreshToken(ctx context.Context, jti string) (*services.RefreshTokenRecord, error) {
+	var record services.RefreshTokenRecord
+	var usedAt, revokedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT jti, family_id, user_id, issued_at, expires_at, used_at, revoked_at
+		FROM refresh_tokens
+		WHERE jti = $1
+	`, jti).Scan(&record.JTI, &record.FamilyID, &record.UserID, &record.IssuedAt, &record.ExpiresAt, &usedAt, &revokedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("refresh token not found: %s", jti)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	if usedAt.Valid {
+		record.UsedAt = &usedAt.Time
+	}
+	if revokedAt.Valid {
+		record.RevokedAt = &revokedAt.Time
+	}
+
+	return &record, nil
+}
+
+// UseRefreshToken marks an unused, unrevoked refresh token as used.
+// The conditional update makes concurrent rotations of the same token race-free.
+func (r *TokenRepository) UseRefreshToken(ctx context.Context, jti string, usedAt time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE refresh_tokens SET used_at = $1
+		WHERE jti = $2 AND used_at IS NULL AND revoked_at IS NULL
+	`, usedAt, jti)
+	if err != nil {
+		return false, fmt.Errorf("failed to use refresh token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RevokeRefreshTokenFamily revokes every refresh token rotated from the same login
+func (r *TokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = $1
+		WHERE family_id = $2 AND revoked_at IS NULL
+	`, revokedAt, familyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return nil
+}
+
+// RevokeAccessToken denies an access token until it expires
+func (r *TokenRepository) RevokeAccessToken(ctx context.Context, jti, userID string, expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at, revoked_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (jti) DO NOTHING
+	`, jti, userID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+	return nil
+}
+
+// RevokeAllForUser rejects every token issued to the user up to revokedAt
+func (r *TokenRepository) RevokeAllForUser(ctx context.Context, userID string, revokedAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO user_session_revocations (user_id, revoked_before)
+		SELECT id, $2 FROM users WHERE id = $1
+		ON CONFLICT (user_id) DO UPDATE SET revoked_before = EXCLUDED.revoked_before
+	`, userID, revokedAt)
+	if err != nil {
+		return fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = $1
+		WHERE user_id = $2 AND revoked_at IS NULL
+	`, revokedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke user refresh tokens: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// IsRevoked reports whether the token was revoked individually or by a user-wide revocation,
+// or its user has been deleted.
+// issuedAt must be precise: with the second-precision JWT iat, a token issued just after a
+// user-wide revocation in the same second, such as the one from logging in again, would be rejected.
+func (r *TokenRepository) IsRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)
+			OR EXISTS (SELECT 1 FROM user_session_revocations WHERE user_id = $2 AND revoked_before >= $3)
+			OR NOT EXISTS (SELECT 1 FROM users WHERE id = $2)
+	`, jti, userID, issuedAt).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+// DeleteExpired removes records for tokens that expired before the given time
+func (r *TokenRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for _, query := range []string{
+		"DELETE FROM refresh_tokens WHERE expires_at < $1",
+		"DELETE FROM revoked_tokens WHERE expires_at < $1",
+	} {
+		result, err := r.db.ExecContext(ctx, query, before)
+		if err != nil {
+			return total, fmt.Errorf("failed to delete expired tokens: %w", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		total += n
+	}
+	return total, nil
+}
@@ -26,12 +26,12 @@ func NewUserRepository(db *sql.DB) user.Repository {
 func (r *UserRepository) FindByID(ctx context.Context, id string) (*user.User, error) {
 	var u user.User
 	var email, hierarchyLevelID, reportsTo sql.NullString
-	var createdAt, updatedAt sql.NullTime
-	var passwordHash, authType sql.NullString
+	var createdAt, updatedAt, deactivatedAt sql.NullTime
+	var passwordHash, authType, externalID sql.NullString
 
 	err := r.db.QueryRowContext(ctx, `
 		SELECT id, username, full_name, email, hierarchy_level_id, reports_to,
-		       password_hash, auth_type, created_at, updated_at
+		       password_hash, auth_type, external_id, deactivated_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`, id).Scan(
@@ -43,6 +43,8 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*user.User, e
 		&reportsTo,
 		&passwordHash,
 		&authType,
+		&externalID,
+		&deactivatedAt,
 		&createdAt,
 		&updatedAt,
 	)
@@ -79,6 +81,12 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*user.User, e
 	if updatedAt.Valid {
 		u.UpdatedAt = updatedAt.Time
 	}
+	if externalID.Valid {
+		u.ExternalID = externalID.String
+	}
+	if deactivatedAt.Valid {
+		u.DeactivatedAt = &deactivatedAt.Time
+	}
 
 	// Fetch team IDs
 	teamIDs, err := r.fetchTeamIDs(ctx, id)
@@ -97,12 +105,12 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*user.User, e
 func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*user.User, error) {
 	var u user.User
 	var email, hierarchyLevelID, reportsTo sql.NullString
-	var createdAt, updatedAt sql.NullTime
-	var passwordHash, authType sql.NullString
+	var createdAt, updatedAt, deactivatedAt sql.NullTime
+	var passwordHash, authType, externalID sql.NullString
 
 	err := r.db.QueryRowContext(ctx, `
 		SELECT id, username, full_name, email, hierarchy_level_id, reports_to,
-		       password_hash, auth_type, created_at, updated_at
+		       password_hash, auth_type, external_id, deactivated_at, created_at, updated_at
 		FROM users
 		WHERE username = $1
 	`, username).Scan(
@@ -114,6 +122,8 @@ func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*
 		&reportsTo,
 		&passwordHash,
 		&authType,
+		&externalID,
+		&deactivatedAt,
 		&createdAt,
 		&updatedAt,
 	)
@@ -150,6 +160,12 @@ func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*
 	if updatedAt.Valid {
 		u.UpdatedAt = updatedAt.Time
 	}
+	if externalID.Valid {
+		u.ExternalID = externalID.String
+	}
+	if deactivatedAt.Valid {
+		u.DeactivatedAt = &deactivatedAt.Time
+	}
 
 	// Fetch team IDs
 	teamIDs, err := r.fetchTeamIDs(ctx, u.ID)
@@ -168,12 +184,12 @@ func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*
 func (r *UserRepository) FindByEmail(ctx context.Context, emailParam string) (*user.User, error) {
 	var u user.User
 	var emailVal, hierarchyLevelID, reportsTo sql.NullString
-	var createdAt, updatedAt sql.NullTime
-	var passwordHash, authType sql.NullString
+	var createdAt, updatedAt, deactivatedAt sql.NullTime
+	var passwordHash, authType, externalID sql.NullString
 
 	err := r.db.QueryRowContext(ctx, `
 		SELECT id, username, full_name, email, hierarchy_level_id, reports_to,
-		       password_hash, auth_type, created_at, updated_at
+		       password_hash, auth_type, external_id, deactivated_at, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`, emailParam).Scan(
@@ -185,6 +201,8 @@ func (r *UserRepository) FindByEmail(ctx context.Context, emailParam string) (*u
 		&reportsTo,
 		&passwordHash,
 		&authType,
+		&externalID,
+		&deactivatedAt,
 		&createdAt,
 		&updatedAt,
 	)
@@ -221,6 +239,12 @@ func (r *UserRepository) FindByEmail(ctx context.Context, emailParam string) (*u
 	if updatedAt.Valid {
 		u.UpdatedAt = updatedAt.Time
 	}
+	if externalID.Valid {
+		u.ExternalID = externalID.String
+	}
+	if deactivatedAt.Valid {
+		u.DeactivatedAt = &deactivatedAt.Time
+	}
 
 	// Fetch team IDs
 	teamIDs, err := r.fetchTeamIDs(ctx, u.ID)
@@ -239,7 +263,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, emailParam string) (*u
 func (r *UserRepository) FindAll(ctx context.Context) ([]*user.User, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, username, full_name, email, hierarchy_level_id, reports_to,
-		       password_hash, auth_type, created_at, updated_at
+		       password_hash, auth_type, external_id, deactivated_at, created_at, updated_at
 		FROM users
 		ORDER BY username
 	`)
@@ -253,8 +277,8 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]*user.User, error) {
 	for rows.Next() {
 		var u user.User
 		var email, hierarchyLevelID, reportsTo sql.NullString
-		var createdAt, updatedAt sql.NullTime
-		var passwordHash, authType sql.NullString
+		var createdAt, updatedAt, deactivatedAt sql.NullTime
+		var passwordHash, authType, externalID sql.NullString
 
 		err := rows.Scan(
 			&u.ID,
@@ -265,6 +289,8 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]*user.User, error) {
 			&reportsTo,
 			&passwordHash,
 			&authType,
+			&externalID,
+			&deactivatedAt,
 			&createdAt,
 			&updatedAt,
 		)
@@ -297,6 +323,12 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]*user.User, error) {
 		if updatedAt.Valid {
 			u.UpdatedAt = updatedAt.Time
 		}
+		if externalID.Valid {
+			u.ExternalID = externalID.String
+		}
+		if deactivatedAt.Valid {
+			u.DeactivatedAt = &deactivatedAt.Time
+		}
 
 		// Fetch team IDs
 		teamIDs, err := r.fetchTeamIDs(ctx, u.ID)
@@ -322,7 +354,7 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]*user.User, error) {
 func (r *UserRepository) FindByHierarchyLevel(ctx context.Context, levelID string) ([]*user.User, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT id, username, full_name, email, hierarchy_level_id, reports_to,
-		       password_hash, auth_type, created_at, updated_at
+		       password_hash, auth_type, external_id, deactivated_at, created_at, updated_at
 		FROM users
 		WHERE hierarchy_level_id = $1
 		ORDER BY username
@@ -346,7 +378,7 @@ func (r *UserRepository) FindSubordinates(ctx context.Context, supervisorID stri
 		WITH RECURSIVE subordinates AS (
 			-- Base case: direct reports
 			SELECT id, username, full_name, email, hierarchy_level_id, reports_to,
-			       password_hash, auth_type, created_at, updated_at,
+			       password_hash, auth_type, external_id, deactivated_at, created_at, updated_at,
 			       1 AS depth, ARRAY[$1::text, id::text] AS visited
 			FROM users
 			WHERE reports_to = $1
@@ -356,7 +388,7 @@ func (r *UserRepository) FindSubordinates(ctx context.Context, supervisorID stri
 			-- Recursive case: reports of reports
 			-- Stops on cycle (visited array) or max depth (20 levels)
 			SELECT u.id, u.username, u.full_name, u.email, u.hierarchy_level_id, u.reports_to,
-			       u.password_hash, u.auth_type, u.created_at, u.updated_at,
+			       u.password_hash, u.auth_type, u.external_id, u.deactivated_at, u.created_at, u.updated_at,
 			       s.depth + 1, s.visited || u.id::text
 			FROM users u
 			INNER JOIN subordinates s ON u.reports_to = s.id
@@ -364,7 +396,7 @@ func (r *UserRepository) FindSubordinates(ctx context.Context, supervisorID stri
 			  AND NOT (u.id::text = ANY(s.visited))
 		)
 		SELECT id, username, full_name, email, hierarchy_level_id, reports_to,
-		       password_hash, auth_type, created_at, updated_at
+		       password_hash, auth_type, external_id, deactivated_at, created_at, updated_at
 		FROM subordinates
 		ORDER BY username
 	`, supervisorID)
@@ -379,13 +411,13 @@ func (r *UserRepository) FindSubordinates(ctx context.Context, supervisorID stri
 	for rows.Next() {
 		var u user.User
 		var email, hierarchyLevelID, reportsTo sql.NullString
-		var createdAt, updatedAt sql.NullTime
-		var passwordHash, authType sql.NullString
+		var createdAt, updatedAt, deactivatedAt sql.NullTime
+		var passwordHash, authType, externalID sql.NullString
 
 		err := rows.Scan(
 			&u.ID, &u.Username, &u.Name, &email,
 			&hierarchyLevelID, &reportsTo, &passwordHash,
-			&authType, &createdAt, &updatedAt,
+			&authType, &externalID, &deactivatedAt, &createdAt, &updatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
@@ -415,6 +447,12 @@ func (r *UserRepository) FindSubordinates(ctx context.Context, supervisorID stri
 		if updatedAt.Valid {
 			u.UpdatedAt = updatedAt.Time
 		}
+		if externalID.Valid {
+			u.ExternalID = externalID.String
+		}
+		if deactivatedAt.Valid {
+			u.DeactivatedAt = &deactivatedAt.Time
+		}
 		u.IsAdmin = u.Username == "admin"
 		u.TeamIDs = []string{} // populated below in batch
 
@@ -468,7 +506,7 @@ func (r *UserRepository) FindSupervisorChainUp(ctx context.Context, userID strin
 		WITH RECURSIVE supervisors AS (
 			-- Base case: direct supervisor of the given user
 			SELECT u.id, u.username, u.full_name, u.email, u.hierarchy_level_id, u.reports_to,
-			       u.password_hash, u.auth_type, u.created_at, u.updated_at,
+			       u.password_hash, u.auth_type, u.external_id, u.deactivated_at, u.created_at, u.updated_at,
 			       1 AS depth, ARRAY[$1::text, u.id::text] AS visited
 			FROM users u
 			WHERE u.id = (SELECT reports_to FROM users WHERE id = $1)
@@ -478,14 +516,14 @@ func (r *UserRepository) FindSupervisorChainUp(ctx context.Context, userID strin
 			-- Recursive case: supervisor's supervisor
 			-- Stops on cycle (visited array) or max depth (20 levels)
 			SELECT u.id, u.username, u.full_name, u.email, u.hierarchy_level_id, u.reports_to,
-			       u.password_hash, u.auth_type, u.created_at, u.updated_at,
+			       u.password_hash, u.auth_type, u.external_id, u.deactivated_at, u.created_at, u.updated_at,
 			       s.depth + 1, s.visited || u.id::text
 			FROM users u
 			INNER JOIN supervisors s ON u.id = s.reports_to
 			WHERE s.depth < 20 AND NOT (u.id::text = ANY(s.visited))
 		)
 		SELECT id, username, full_name, email, hierarchy_level_id, reports_to,
-		       password_hash, auth_type, created_at, updated_at
+		       password_hash, auth_type, external_id, deactivated_at, created_at, updated_at
 		FROM supervisors
 		ORDER BY depth
 	`, userID)
@@ -558,11 +596,16 @@ func (r *UserRepository) Save(ctx context.Context, u *user.User) error {
 		u.AuthType = user.AuthTypeLocal
 	}
 
+	var externalID sql.NullString
+	if u.ExternalID != "" {
+		externalID = sql.NullString{String: u.ExternalID, Valid: true}
+	}
+
 	// Insert user
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO users (id, username, full_name, email, hierarchy_level_id, reports_to, password_hash, auth_type, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	`, u.ID, u.Username, u.Name, email, hierarchyLevelID, reportsTo, passwordHash, string(u.AuthType), u.CreatedAt, u.UpdatedAt)
+		INSERT INTO users (id, username, full_name, email, hierarchy_level_id, reports_to, password_hash, auth_type, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, u.ID, u.Username, u.Name, email, hierarchyLevelID, reportsTo, passwordHash, string(u.AuthType), externalID, u.CreatedAt, u.UpdatedAt)
 
 	if err != nil {
 		log.DB("insert").
@@ -640,9 +683,10 @@ func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
 			hierarchy_level_id = $4,
 			reports_to = $5,
 			auth_type = COALESCE(NULLIF($6, ''), auth_type),
-			updated_at = $7
-		WHERE id = $8
-	`, u.Username, u.Name, email, hierarchyLevelID, reportsTo, string(u.AuthType), u.UpdatedAt, u.ID)
+			external_id = COALESCE(NULLIF($7, ''), external_id),
+			updated_at = $8
+		WHERE id = $9
+	`, u.Username, u.Name, email, hierarchyLevelID, reportsTo, string(u.AuthType), u.ExternalID, u.UpdatedAt, u.ID)
 
 	if err != nil {
 		log.DB("update").
@@ -813,8 +857,8 @@ func (r *UserRepository) scanUsers(ctx context.Context, rows *sql.Rows) ([]*user
 	for rows.Next() {
 		var u user.User
 		var email, hierarchyLevelID, reportsTo sql.NullString
-		var createdAt, updatedAt sql.NullTime
-		var passwordHash, authType sql.NullString
+		var createdAt, updatedAt, deactivatedAt sql.NullTime
+		var passwordHash, authType, externalID sql.NullString
 
 		err := rows.Scan(
 			&u.ID,
@@ -825,6 +869,8 @@ func (r *UserRepository) scanUsers(ctx context.Context, rows *sql.Rows) ([]*user
 			&reportsTo,
 			&passwordHash,
 			&authType,
+			&externalID,
+			&deactivatedAt,
 			&createdAt,
 			&updatedAt,
 		)
@@ -857,6 +903,12 @@ func (r *UserRepository) scanUsers(ctx context.Context, rows *sql.Rows) ([]*user
 		if updatedAt.Valid {
 			u.UpdatedAt = updatedAt.Time
 		}
+		if externalID.Valid {
+			u.ExternalID = externalID.String
+		}
+		if deactivatedAt.Valid {
+			u.DeactivatedAt = &deactivatedAt.Time
+		}
 
 		// Fetch team IDs
 		teamIDs, err := r.fetchTeamIDs(ctx, u.ID)
@@ -901,6 +953,43 @@ func (r *UserRepository) UpdatePassword(ctx context.Context, userID string, hash
 	return nil
 }
 
+// FindByExternalID retrieves a user by the identity provider's ID
+func (r *UserRepository) FindByExternalID(ctx context.Context, externalID string) (*user.User, error) {
+	var id string
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM users WHERE external_id = $1`, externalID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user not found with provided external ID")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// SetActive deactivates or reactivates a user. Deactivating an already deactivated user keeps the original time.
+func (r *UserRepository) SetActive(ctx context.Context, userID string, active bool) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users
+		SET deactivated_at = CASE WHEN $1 THEN NULL ELSE COALESCE(deactivated_at, $2) END,
+			updated_at = $2
+		WHERE id = $3
+	`, active, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+
+	return nil
+}
+
 // VerifyPassword checks if the provided password matches the user's password hash
 // This is a helper method, not part of the repository interface
 func (r *UserRepository) VerifyPassword(ctx context.Context, username, password string) (*user.User, error) {
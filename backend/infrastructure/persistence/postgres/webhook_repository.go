@@ -0,0 +1,305 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/lib/pq"
+)
+
+// WebhookRepository implements services.WebhookStore
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+const webhookEndpointColumns = `id, url, description, events, secret, active, created_by, created_at, updated_at`
+
+const webhookDeliveryColumns = `d.id, d.endpoint_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+	d.next_attempt_at, d.last_attempt_at, d.response_status, d.last_error, d.delivered_at, d.created_at`
+
+// CreateEndpoint stores a new endpoint
+func (r *WebhookRepository) CreateEndpoint(ctx context.Context, endpoint *services.WebhookEndpoint) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhook_endpoints (id, url, description, events, secret, active, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, endpoint.ID, endpoint.URL, endpoint.Description, pq.Array(webhookEventNames(endpoint.Events)),
+		endpoint.Secret, endpoint.Active, endpoint.CreatedBy, endpoint.CreatedAt, endpoint.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// FindEndpoint returns the endpoint with the given ID, or nil when there is none
+func (r *WebhookRepository) FindEndpoint(ctx context.Context, id string) (*services.WebhookEndpoint, error) {
+	endpoint, err := scanWebhookEndpoint(r.db.QueryRowContext(ctx, `
+		SELECT `+webhookEndpointColumns+` FROM webhook_endpoints WHERE id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+// ListEndpoints returns every endpoint, oldest first
+func (r *WebhookRepository) ListEndpoints(ctx context.Context) ([]*services.WebhookEndpoint, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+webhookEndpointColumns+` FROM webhook_endpoints ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	endpoints := []*services.WebhookEndpoint{}
+	for rows.Next() {
+		endpoint, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook endpoint: %w", err)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	return endpoints, nil
+}
+
+// UpdateEndpoint saves an endpoint's URL, description, events and active flag
+func (r *WebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *services.WebhookEndpoint) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_endpoints SET url = $2, description = $3, events = $4, active = $5, updated_at = $6
+		WHERE id = $1
+	`, endpoint.ID, endpoint.URL, endpoint.Description, pq.Array(webhookEventNames(endpoint.Events)), endpoint.Active, endpoint.UpdatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteEndpoint removes an endpoint; its deliveries are removed by the foreign key
+func (r *WebhookRepository) DeleteEndpoint(ctx context.Context, id string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_endpoints WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// EnqueueEvent queues a delivery of the payload to every active endpoint subscribed to the event
+func (r *WebhookRepository) EnqueueEvent(ctx context.Context, event services.WebhookEvent, eventID string, payload json.RawMessage, at time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (id, endpoint_id, event_id, event_type, payload, next_attempt_at, created_at)
+		SELECT gen_random_uuid()::text, e.id, $1, $2, $3, $4, $4
+		FROM webhook_endpoints e
+		WHERE e.active AND $2 = ANY(e.events)
+	`, eventID, string(event), string(payload), at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook event: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// ClaimDueDeliveries leases due pending deliveries of active endpoints.
+// SKIP LOCKED lets several API replicas run the worker without sending a delivery twice.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*services.DueWebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE webhook_deliveries d SET next_attempt_at = $2
+		FROM webhook_endpoints e
+		WHERE e.id = d.endpoint_id AND d.id IN (
+			SELECT pd.id FROM webhook_deliveries pd
+			JOIN webhook_endpoints pe ON pe.id = pd.endpoint_id
+			WHERE pd.status = 'pending' AND pd.next_attempt_at <= $1 AND pe.active
+			ORDER BY pd.next_attempt_at, pd.created_at
+			LIMIT $3
+			FOR UPDATE OF pd SKIP LOCKED
+		)
+		RETURNING `+webhookDeliveryColumns+`, e.url, e.secret
+	`, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	due := []*services.DueWebhookDelivery{}
+	for rows.Next() {
+		var d services.DueWebhookDelivery
+		if err := scanWebhookDelivery(rows, &d.WebhookDelivery, &d.URL, &d.Secret); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		due = append(due, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	return due, nil
+}
+
+// RecordAttempt saves the status, attempts, schedule and outcome of a delivery
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, delivery *services.WebhookDelivery) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries SET
+			status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5,
+			response_status = $6, last_error = $7, delivered_at = $8
+		WHERE id = $1
+	`, delivery.ID, string(delivery.Status), delivery.Attempts, delivery.NextAttemptAt, delivery.LastAttemptAt,
+		delivery.ResponseStatus, delivery.LastError, delivery.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns an endpoint's deliveries, newest first, and the total count
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, endpointID string, status services.WebhookDeliveryStatus, limit, offset int) ([]*services.WebhookDelivery, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM webhook_deliveries WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)
+	`, endpointID, string(status)).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries d
+		WHERE d.endpoint_id = $1 AND ($2 = '' OR d.status = $2)
+		ORDER BY d.created_at DESC, d.id
+		LIMIT $3 OFFSET $4
+	`, endpointID, string(status), limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*services.WebhookDelivery{}
+	for rows.Next() {
+		var d services.WebhookDelivery
+		if err := scanWebhookDelivery(rows, &d); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// FindDelivery returns an endpoint's delivery, or nil when there is none
+func (r *WebhookRepository) FindDelivery(ctx context.Context, endpointID, id string) (*services.WebhookDelivery, error) {
+	var d services.WebhookDelivery
+	err := scanWebhookDelivery(r.db.QueryRowContext(ctx, `
+		SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries d WHERE d.endpoint_id = $1 AND d.id = $2
+	`, endpointID, id), &d)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+	return &d, nil
+}
+
+// RequeueDelivery makes a failed delivery pending again with no attempts
+func (r *WebhookRepository) RequeueDelivery(ctx context.Context, id string, at time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries SET status = 'pending', attempts = 0, next_attempt_at = $2
+		WHERE id = $1 AND status = 'failed'
+	`, id, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to requeue webhook delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// scanWebhookEndpoint reads a row of webhookEndpointColumns
+func scanWebhookEndpoint(row interface{ Scan(...interface{}) error }) (*services.WebhookEndpoint, error) {
+	var endpoint services.WebhookEndpoint
+	var events []string
+
+	err := row.Scan(&endpoint.ID, &endpoint.URL, &endpoint.Description, pq.Array(&events), &endpoint.Secret,
+		&endpoint.Active, &endpoint.CreatedBy, &endpoint.CreatedAt, &endpoint.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint.Events = make([]services.WebhookEvent, len(events))
+	for i, event := range events {
+		endpoint.Events[i] = services.WebhookEvent(event)
+	}
+	return &endpoint, nil
+}
+
+// scanWebhookDelivery reads a row of webhookDeliveryColumns followed by any extra columns
+func scanWebhookDelivery(row interface{ Scan(...interface{}) error }, d *services.WebhookDelivery, extra ...interface{}) error {
+	var eventType, status string
+	var payload []byte
+	var lastAttemptAt, deliveredAt sql.NullTime
+	var responseStatus sql.NullInt64
+
+	dest := []interface{}{&d.ID, &d.EndpointID, &d.EventID, &eventType, &payload, &status, &d.Attempts,
+		&d.NextAttemptAt, &lastAttemptAt, &responseStatus, &d.LastError, &deliveredAt, &d.CreatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	d.EventType = services.WebhookEvent(eventType)
+	d.Status = services.WebhookDeliveryStatus(status)
+	d.Payload = payload
+	if lastAttemptAt.Valid {
+		d.LastAttemptAt = &lastAttemptAt.Time
+	}
+	if responseStatus.Valid {
+		code := int(responseStatus.Int64)
+		d.ResponseStatus = &code
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return nil
+}
+
+// webhookEventNames converts events for a TEXT[] column
+func webhookEventNames(events []services.WebhookEvent) []string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = string(event)
+	}
+	return names
+}
@@ -246,7 +246,13 @@ func ContentTypeValidator() gin.HandlerFunc {
 		// Only check POST, PUT, PATCH requests with body
 		if c.Request.Method == "POST" || c.Request.Method == "PUT" || c.Request.Method == "PATCH" {
 			contentType := c.GetHeader("Content-Type")
-			if contentType != "" && !strings.HasPrefix(contentType, "application/json") {
+			// The SAML Assertion Consumer Service receives the IdP's HTML form post
+			if c.Request.URL.Path == "/api/v1/auth/saml/acs" && strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+				c.Next()
+				return
+			}
+			// SCIM clients send JSON as application/scim+json
+			if contentType != "" && !strings.HasPrefix(contentType, "application/json") && !strings.HasPrefix(contentType, "application/scim+json") {
 				dto.RespondError(c, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
 				c.Abort()
 				return
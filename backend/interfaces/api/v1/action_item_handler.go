@@ -1,25 +1,45 @@
 package v1
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// actionItemSelect selects action items with their dimension and user names
+const actionItemSelect = `
+		SELECT
+			ai.id, ai.team_id, ai.dimension_id,
+			hd.name AS dimension_name,
+			ai.created_by, cu.full_name AS created_by_name,
+			ai.assigned_to, au.full_name AS assignee_name,
+			ai.title, ai.description, ai.status,
+			ai.due_date, ai.assessment_period,
+			ai.created_at, ai.updated_at
+		FROM action_items ai
+		LEFT JOIN health_dimensions hd ON hd.id = ai.dimension_id
+		LEFT JOIN users cu ON cu.id = ai.created_by
+		LEFT JOIN users au ON au.id = ai.assigned_to`
+
 // ActionItemHandler handles action item CRUD endpoints
 type ActionItemHandler struct {
-	db *sql.DB
+	db       *sql.DB
+	webhooks *services.WebhookService
 }
 
-// NewActionItemHandler creates a new ActionItemHandler
-func NewActionItemHandler(db *sql.DB) *ActionItemHandler {
-	return &ActionItemHandler{db: db}
+// NewActionItemHandler creates a new ActionItemHandler.
+// webhooks may be nil, in which case no webhook events are published.
+func NewActionItemHandler(db *sql.DB, webhooks *services.WebhookService) *ActionItemHandler {
+	return &ActionItemHandler{db: db, webhooks: webhooks}
 }
 
 // ListActionItems handles GET /api/v1/teams/:teamId/action-items
@@ -28,19 +48,7 @@ func (h *ActionItemHandler) ListActionItems(c *gin.Context) {
 	status := c.Query("status")
 	period := c.Query("period")
 
-	query := `
-		SELECT
-			ai.id, ai.team_id, ai.dimension_id,
-			hd.name AS dimension_name,
-			ai.created_by, cu.full_name AS created_by_name,
-			ai.assigned_to, au.full_name AS assignee_name,
-			ai.title, ai.description, ai.status,
-			ai.due_date, ai.assessment_period,
-			ai.created_at, ai.updated_at
-		FROM action_items ai
-		LEFT JOIN health_dimensions hd ON hd.id = ai.dimension_id
-		LEFT JOIN users cu ON cu.id = ai.created_by
-		LEFT JOIN users au ON au.id = ai.assigned_to
+	query := actionItemSelect + `
 		WHERE ai.team_id = $1`
 
 	args := []interface{}{teamID}
@@ -66,44 +74,12 @@ func (h *ActionItemHandler) ListActionItems(c *gin.Context) {
 
 	items := []dto.ActionItemResponse{}
 	for rows.Next() {
-		var item dto.ActionItemResponse
-		var dimID, dimName, assignedTo, assigneeName sql.NullString
-		var dueDate, assessmentPeriod sql.NullString
-		var createdAt, updatedAt time.Time
-
-		if err := rows.Scan(
-			&item.ID, &item.TeamID, &dimID, &dimName,
-			&item.CreatedBy, &item.CreatedByName,
-			&assignedTo, &assigneeName,
-			&item.Title, &item.Description, &item.Status,
-			&dueDate, &assessmentPeriod,
-			&createdAt, &updatedAt,
-		); err != nil {
+		item, err := scanActionItem(rows)
+		if err != nil {
 			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to scan action items", Message: err.Error()})
 			return
 		}
-		if dimID.Valid {
-			item.DimensionID = &dimID.String
-		}
-		if dimName.Valid {
-			item.DimensionName = &dimName.String
-		}
-		if assignedTo.Valid {
-			item.AssignedTo = &assignedTo.String
-		}
-		if assigneeName.Valid {
-			item.AssigneeName = &assigneeName.String
-		}
-		if dueDate.Valid {
-			item.DueDate = &dueDate.String
-		}
-		if assessmentPeriod.Valid {
-			item.AssessmentPeriod = &assessmentPeriod.String
-		}
-		item.CreatedAt = createdAt.Format(time.RFC3339)
-		item.UpdatedAt = updatedAt.Format(time.RFC3339)
-
-		items = append(items, item)
+		items = append(items, *item)
 	}
 	if err := rows.Err(); err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to read action items", Message: err.Error()})
@@ -168,6 +144,8 @@ func (h *ActionItemHandler) CreateActionItem(c *gin.Context) {
 		return
 	}
 
+	h.publishActionItem(c.Request.Context(), services.EventActionItemCreated, teamID, id)
+
 	c.JSON(http.StatusCreated, gin.H{"id": id, "status": "open", "createdAt": now.Format(time.RFC3339)})
 }
 
@@ -238,6 +216,8 @@ func (h *ActionItemHandler) UpdateActionItem(c *gin.Context) {
 		return
 	}
 
+	h.publishActionItem(c.Request.Context(), services.EventActionItemUpdated, teamID, itemID)
+
 	c.JSON(http.StatusOK, gin.H{"updated": true})
 }
 
@@ -302,6 +282,62 @@ func (h *ActionItemHandler) GetTeamsActionSummary(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.TeamsActionSummaryResponse{Teams: summaries})
 }
 
+// publishActionItem queues the action item as it now stands for webhook endpoints
+func (h *ActionItemHandler) publishActionItem(ctx context.Context, event services.WebhookEvent, teamID, itemID string) {
+	if h.webhooks == nil {
+		return
+	}
+
+	item, err := scanActionItem(h.db.QueryRowContext(ctx, actionItemSelect+`
+		WHERE ai.id = $1 AND ai.team_id = $2`, itemID, teamID))
+	if err == nil {
+		err = h.webhooks.Publish(ctx, event, item)
+	}
+	if err != nil {
+		logger.Get().WithContext(ctx).WithError(err).Warn("failed to publish action item webhook event")
+	}
+}
+
+// scanActionItem scans a row selected with actionItemSelect
+func scanActionItem(row interface{ Scan(...interface{}) error }) (*dto.ActionItemResponse, error) {
+	var item dto.ActionItemResponse
+	var dimID, dimName, assignedTo, assigneeName sql.NullString
+	var dueDate, assessmentPeriod sql.NullString
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(
+		&item.ID, &item.TeamID, &dimID, &dimName,
+		&item.CreatedBy, &item.CreatedByName,
+		&assignedTo, &assigneeName,
+		&item.Title, &item.Description, &item.Status,
+		&dueDate, &assessmentPeriod,
+		&createdAt, &updatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if dimID.Valid {
+		item.DimensionID = &dimID.String
+	}
+	if dimName.Valid {
+		item.DimensionName = &dimName.String
+	}
+	if assignedTo.Valid {
+		item.AssignedTo = &assignedTo.String
+	}
+	if assigneeName.Valid {
+		item.AssigneeName = &assigneeName.String
+	}
+	if dueDate.Valid {
+		item.DueDate = &dueDate.String
+	}
+	if assessmentPeriod.Valid {
+		item.AssessmentPeriod = &assessmentPeriod.String
+	}
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+	return &item, nil
+}
+
 // nullableString converts a *string to a value suitable for sql nullable param
 func nullableString(s *string) interface{} {
 	if s == nil {
@@ -4,18 +4,20 @@ import (
 	"database/sql"
 
 	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
 	"github.com/gin-gonic/gin"
 )
 
 // SetupActionItemRoutes registers action item routes
-func SetupActionItemRoutes(router *gin.Engine, db *sql.DB, jwtService *services.JWTService) {
-	handler := NewActionItemHandler(db)
+// Creates and updates publish webhook events unless webhooks is nil.
+func SetupActionItemRoutes(router *gin.Engine, db *sql.DB, jwtService *services.JWTService, permissions *services.PermissionService, webhooks *services.WebhookService) {
+	handler := NewActionItemHandler(db, webhooks)
 
 	// Team-scoped routes — require JWT + team membership
 	teamRoutes := router.Group("/api/v1/teams/:teamId/action-items")
 	teamRoutes.Use(middleware.JWTAuthMiddleware(jwtService))
-	teamRoutes.Use(middleware.TeamMembershipMiddleware("teamId"))
+	teamRoutes.Use(middleware.TeamMembershipMiddleware(permissions, "teamId"))
 	{
 		teamRoutes.GET("", handler.ListActionItems)
 		teamRoutes.POST("", handler.CreateActionItem)
@@ -23,10 +25,10 @@ func SetupActionItemRoutes(router *gin.Engine, db *sql.DB, jwtService *services.
 		teamRoutes.DELETE("/:id", handler.DeleteActionItem)
 	}
 
-	// Manager summary route — requires JWT + manager or above role
+	// Manager summary route — requires JWT + the reports permission
 	managerRoutes := router.Group("/api/v1/managers/:managerId/teams/action-items")
 	managerRoutes.Use(middleware.JWTAuthMiddleware(jwtService))
-	managerRoutes.Use(middleware.ManagerOrAboveMiddleware())
+	managerRoutes.Use(middleware.RequirePermission(permissions, organization.PermissionViewReports))
 	{
 		managerRoutes.GET("", handler.GetTeamsActionSummary)
 	}
@@ -77,7 +77,13 @@ var _ = Describe("Admin API", func() {
 		teamRepo := postgres.NewTeamRepository(db)
 
 		router = gin.New()
-		v1.SetupAdminRoutes(router, orgRepo, userRepo, teamRepo, jwtService)
+		v1.SetupAdminRoutes(router, v1.AdminRouteDeps{
+			OrgRepo:     orgRepo,
+			UserRepo:    userRepo,
+			TeamRepo:    teamRepo,
+			JWTService:  jwtService,
+			Permissions: services.NewPermissionService(orgRepo),
+		})
 	})
 
 	AfterEach(func() {
@@ -0,0 +1,258 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/survey"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/telemetry"
+	"github.com/gin-gonic/gin"
+)
+
+// adminAuditTarget describes what an audited admin route changes and how to load its state
+type adminAuditTarget struct {
+	Type string
+	// Param is the route parameter holding the target ID. Empty for creates and singletons.
+	Param string
+	// Create reads the new target's ID from the "id" field of the response
+	Create bool
+	// Load returns the target's current state. When nil, the response is recorded as the
+	// after state instead, for actions such as imports that have no single stored target.
+	Load func(ctx context.Context, id string) (interface{}, error)
+}
+
+// auditAdminWrite records a successful admin write in the audit log, with the target's state
+// before and after the handler runs. Failed requests are not recorded. A nil audit service
+// disables it.
+//
+// The handlers write through their own repositories, so the entry cannot share the change's
+// transaction. Instead the response is held back until the entry is recorded; if that fails,
+// the admin gets a 500 saying the change was saved but not audited, and the failure is logged
+// as a security event and counted in the audit.write_failures.total metric for alerting.
+func auditAdminWrite(audit *services.AdminAuditService, action string, target adminAuditTarget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if audit == nil {
+			c.Next()
+			return
+		}
+		ctx := c.Request.Context()
+		log := logger.Get().WithContext(ctx)
+
+		targetID := ""
+		if target.Param != "" {
+			targetID = c.Param(target.Param)
+		}
+
+		var before json.RawMessage
+		if target.Load != nil && !target.Create {
+			before = loadAuditState(ctx, target, targetID)
+		}
+
+		body := &auditBodyWriter{ResponseWriter: c.Writer}
+		c.Writer = body
+		c.Next()
+		c.Writer = body.ResponseWriter
+
+		if c.Writer.Status() >= http.StatusBadRequest {
+			body.flush()
+			return
+		}
+
+		if target.Create {
+			var created struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(body.buf.Bytes(), &created); err == nil {
+				targetID = created.ID
+			}
+		}
+
+		// A deleted target no longer loads, leaving the after state empty
+		var after json.RawMessage
+		if target.Load != nil {
+			after = loadAuditState(ctx, target, targetID)
+		} else if json.Valid(body.buf.Bytes()) {
+			after = json.RawMessage(body.buf.Bytes())
+		}
+
+		entry := &services.AdminAuditEntry{
+			Action:     action,
+			TargetType: target.Type,
+			TargetID:   targetID,
+			Before:     before,
+			After:      after,
+			IPAddress:  c.ClientIP(),
+			RequestID:  c.GetString("request_id"),
+		}
+		if claims, ok := middleware.GetClaimsFromContext(c); ok {
+			entry.ActorID = claims.UserID
+			entry.ActorUsername = claims.Username
+		}
+
+		if err := audit.Record(ctx, entry); err != nil {
+			log.WithError(err).
+				WithField("action", action).
+				WithField("target_id", targetID).
+				Error("failed to record admin audit entry")
+			logger.Get().Security("admin_audit_write_failed").
+				UserID(entry.ActorID).
+				IP(entry.IPAddress).
+				RequestID(entry.RequestID).
+				Details("Admin write " + action + " on " + target.Type + " " + targetID + " was applied but not audited").
+				Log()
+			telemetry.RecordAuditWriteFailure(ctx, action)
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error:   "Failed to record audit entry",
+				Message: "The change was saved but could not be recorded in the audit log. Contact your administrator.",
+			})
+			return
+		}
+		body.flush()
+	}
+}
+
+// loadAuditState returns the target's state as JSON, or nil if it does not exist
+func loadAuditState(ctx context.Context, target adminAuditTarget, id string) json.RawMessage {
+	state, err := target.Load(ctx, id)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			logger.Get().WithContext(ctx).WithError(err).
+				WithField("target_type", target.Type).
+				WithField("target_id", id).
+				Warn("failed to load admin audit target state")
+		}
+		return nil
+	}
+	if state == nil {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// auditBodyWriter holds back the response body until the audit entry is recorded. The status
+// is passed through; gin sends it with the first write of the body.
+type auditBodyWriter struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *auditBodyWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *auditBodyWriter) WriteString(s string) (int, error) {
+	return w.buf.WriteString(s)
+}
+
+// flush sends the held back response
+func (w *auditBodyWriter) flush() {
+	if w.buf.Len() == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	if _, err := w.ResponseWriter.Write(w.buf.Bytes()); err != nil {
+		logger.Get().WithError(err).Warn("failed to write admin response")
+	}
+}
+
+// Audit targets of the admin routes
+
+func userAuditTarget(userRepo user.Repository) adminAuditTarget {
+	return adminAuditTarget{Type: "user", Param: "id", Load: func(ctx context.Context, id string) (interface{}, error) {
+		return userRepo.FindByID(ctx, id)
+	}}
+}
+
+func teamAuditTarget(teamRepo team.Repository) adminAuditTarget {
+	return adminAuditTarget{Type: "team", Param: "id", Load: func(ctx context.Context, id string) (interface{}, error) {
+		return teamRepo.FindByID(ctx, id)
+	}}
+}
+
+func teamMembersAuditTarget(teamRepo team.Repository) adminAuditTarget {
+	return adminAuditTarget{Type: "team", Param: "id", Load: func(ctx context.Context, id string) (interface{}, error) {
+		members, err := teamRepo.FindMembers(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return gin.H{"members": members}, nil
+	}}
+}
+
+func teamSupervisorsAuditTarget(teamRepo team.Repository) adminAuditTarget {
+	return adminAuditTarget{Type: "team", Param: "id", Load: func(ctx context.Context, id string) (interface{}, error) {
+		chain, err := teamRepo.FindSupervisorChain(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return gin.H{"supervisorChain": chain}, nil
+	}}
+}
+
+func hierarchyLevelAuditTarget(orgRepo organization.Repository) adminAuditTarget {
+	return adminAuditTarget{Type: "hierarchy_level", Param: "id", Load: func(ctx context.Context, id string) (interface{}, error) {
+		return orgRepo.FindHierarchyLevelByID(ctx, id)
+	}}
+}
+
+func dimensionAuditTarget(orgRepo organization.Repository) adminAuditTarget {
+	return adminAuditTarget{Type: "dimension", Param: "id", Load: func(ctx context.Context, id string) (interface{}, error) {
+		return orgRepo.FindDimensionByID(ctx, id)
+	}}
+}
+
+func settingsAuditTarget(orgRepo organization.Repository) adminAuditTarget {
+	return adminAuditTarget{Type: "settings", Load: func(ctx context.Context, _ string) (interface{}, error) {
+		return orgRepo.GetAppSettings(ctx)
+	}}
+}
+
+func campaignAuditTarget(campaignRepo campaign.Repository) adminAuditTarget {
+	return adminAuditTarget{Type: "campaign", Param: "id", Load: func(ctx context.Context, id string) (interface{}, error) {
+		return campaignRepo.FindByID(ctx, id)
+	}}
+}
+
+func surveyTemplateAuditTarget(templateRepo survey.Repository) adminAuditTarget {
+	return adminAuditTarget{Type: "survey_template", Param: "id", Load: func(ctx context.Context, id string) (interface{}, error) {
+		return templateRepo.FindByID(ctx, id)
+	}}
+}
+
+// apiTokenAuditTarget records token metadata; the secret is only in the issue response,
+// which is not recorded because the target loads
+func apiTokenAuditTarget(tokens *services.APITokenService) adminAuditTarget {
+	return adminAuditTarget{Type: "api_token", Param: "id", Load: func(ctx context.Context, id string) (interface{}, error) {
+		return tokens.Get(ctx, id)
+	}}
+}
+
+// webhookAuditTarget records endpoints without their signing secret
+func webhookAuditTarget(webhooks *services.WebhookService) adminAuditTarget {
+	return adminAuditTarget{Type: "webhook", Param: "id", Load: func(ctx context.Context, id string) (interface{}, error) {
+		return webhooks.GetEndpoint(ctx, id)
+	}}
+}
+
+// createAudit turns an audit target into the target of a route that creates it
+func createAudit(target adminAuditTarget) adminAuditTarget {
+	target.Param = ""
+	target.Create = true
+	return target
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/domain/organization"
 	"github.com/agopalakrishnan/teams360/backend/domain/team"
 	"github.com/agopalakrishnan/teams360/backend/domain/user"
@@ -13,15 +14,23 @@ type AdminHandler struct {
 	UserHandler      *UserAdminHandler
 	TeamHandler      *TeamAdminHandler
 	SettingsHandler  *SettingsAdminHandler
+	SessionHandler   *SessionAdminHandler
+	LockoutHandler   *LockoutAdminHandler
+	MFAHandler       *MFAAdminHandler
+	AuditHandler     *AuditAdminHandler
 }
 
 // NewAdminHandler creates a new AdminHandler with all sub-handlers
-func NewAdminHandler(orgRepo organization.Repository, userRepo user.Repository, teamRepo team.Repository) *AdminHandler {
+func NewAdminHandler(orgRepo organization.Repository, userRepo user.Repository, teamRepo team.Repository, jwtService *services.JWTService, permissions *services.PermissionService, lockout *services.AccountLockoutService, mfa *services.MFAService, audit *services.AdminAuditService, webhooks *services.WebhookService) *AdminHandler {
 	return &AdminHandler{
-		HierarchyHandler: NewHierarchyAdminHandler(orgRepo),
-		UserHandler:      NewUserAdminHandler(userRepo, teamRepo),
-		TeamHandler:      NewTeamAdminHandler(teamRepo, userRepo, orgRepo),
+		HierarchyHandler: NewHierarchyAdminHandler(orgRepo, permissions),
+		UserHandler:      NewUserAdminHandler(userRepo, teamRepo, permissions),
+		TeamHandler:      NewTeamAdminHandler(teamRepo, userRepo, orgRepo, webhooks),
 		SettingsHandler:  NewSettingsAdminHandler(orgRepo),
+		SessionHandler:   NewSessionAdminHandler(jwtService),
+		LockoutHandler:   NewLockoutAdminHandler(userRepo, lockout),
+		MFAHandler:       NewMFAAdminHandler(userRepo, mfa),
+		AuditHandler:     NewAuditAdminHandler(audit),
 	}
 }
 
@@ -69,6 +78,18 @@ func (h *AdminHandler) DeleteUser(c *gin.Context) {
 	h.UserHandler.DeleteUser(c)
 }
 
+func (h *AdminHandler) RevokeUserSessions(c *gin.Context) {
+	h.SessionHandler.RevokeUserSessions(c)
+}
+
+func (h *AdminHandler) UnlockUser(c *gin.Context) {
+	h.LockoutHandler.UnlockUser(c)
+}
+
+func (h *AdminHandler) ResetUserMFA(c *gin.Context) {
+	h.MFAHandler.ResetUserMFA(c)
+}
+
 // ============================================================================
 // Teams Handlers - Delegate to TeamAdminHandler
 // ============================================================================
@@ -152,3 +173,15 @@ func (h *AdminHandler) GetRetentionPolicy(c *gin.Context) {
 func (h *AdminHandler) UpdateRetentionPolicy(c *gin.Context) {
 	h.SettingsHandler.UpdateRetentionPolicy(c)
 }
+
+// ============================================================================
+// Audit Log Handlers - Delegate to AuditAdminHandler
+// ============================================================================
+
+func (h *AdminHandler) GetAuditLog(c *gin.Context) {
+	h.AuditHandler.GetAuditLog(c)
+}
+
+func (h *AdminHandler) ExportAuditLog(c *gin.Context) {
+	h.AuditHandler.ExportAuditLog(c)
+}
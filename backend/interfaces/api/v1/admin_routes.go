@@ -9,69 +9,102 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// AdminRouteDeps holds the dependencies of the admin routes
+// Lockout, MFA, Audit and Webhooks are optional; the features they back are skipped when nil.
+type AdminRouteDeps struct {
+	OrgRepo     organization.Repository
+	UserRepo    user.Repository
+	TeamRepo    team.Repository
+	JWTService  *services.JWTService
+	Permissions *services.PermissionService
+	Lockout     *services.AccountLockoutService
+	MFA         *services.MFAService
+	Audit       *services.AdminAuditService
+	Webhooks    *services.WebhookService
+}
+
 // SetupAdminRoutes configures admin routes with repository dependency injection
-// All admin routes require JWT authentication and admin privileges (level-1)
-func SetupAdminRoutes(router *gin.Engine, orgRepo organization.Repository, userRepo user.Repository, teamRepo team.Repository, jwtService *services.JWTService) {
-	handler := NewAdminHandler(orgRepo, userRepo, teamRepo)
+// All admin routes require JWT authentication and a permission of the caller's hierarchy level:
+// CanManageUsers for users, CanEditTeams for teams and CanConfigureSystem for everything else.
+// Every write is recorded in the audit log unless deps.Audit is nil; team updates publish webhook events unless deps.Webhooks is nil.
+func SetupAdminRoutes(router *gin.Engine, deps AdminRouteDeps) {
+	orgRepo, userRepo, teamRepo := deps.OrgRepo, deps.UserRepo, deps.TeamRepo
+	jwtService, permissions, audit := deps.JWTService, deps.Permissions, deps.Audit
+	handler := NewAdminHandler(orgRepo, userRepo, teamRepo, jwtService, permissions, deps.Lockout, deps.MFA, audit, deps.Webhooks)
+	levelTarget := hierarchyLevelAuditTarget(orgRepo)
+	userTarget := userAuditTarget(userRepo)
+	teamTarget := teamAuditTarget(teamRepo)
+	dimensionTarget := dimensionAuditTarget(orgRepo)
+	settingsTarget := settingsAuditTarget(orgRepo)
 
 	admin := router.Group("/api/v1/admin")
-	// Apply JWT authentication and admin-only authorization to all admin routes
+	// Apply JWT authentication to all admin routes; each group requires its own permission
 	admin.Use(middleware.JWTAuthMiddleware(jwtService))
-	admin.Use(middleware.AdminOnlyMiddleware())
+	configureSystem := middleware.RequirePermission(permissions, organization.PermissionConfigureSystem)
 	{
 		// Hierarchy Levels CRUD
-		hierarchyLevels := admin.Group("/hierarchy-levels")
+		hierarchyLevels := admin.Group("/hierarchy-levels", configureSystem)
 		{
 			hierarchyLevels.GET("", handler.ListHierarchyLevels)
-			hierarchyLevels.POST("", handler.CreateHierarchyLevel)
-			hierarchyLevels.PUT("/:id", handler.UpdateHierarchyLevel)
-			hierarchyLevels.PUT("/:id/position", handler.UpdateHierarchyPosition)
-			hierarchyLevels.DELETE("/:id", handler.DeleteHierarchyLevel)
+			hierarchyLevels.POST("", auditAdminWrite(audit, "hierarchy_level.create", createAudit(levelTarget)), handler.CreateHierarchyLevel)
+			hierarchyLevels.PUT("/:id", auditAdminWrite(audit, "hierarchy_level.update", levelTarget), handler.UpdateHierarchyLevel)
+			hierarchyLevels.PUT("/:id/position", auditAdminWrite(audit, "hierarchy_level.reorder", levelTarget), handler.UpdateHierarchyPosition)
+			hierarchyLevels.DELETE("/:id", auditAdminWrite(audit, "hierarchy_level.delete", levelTarget), handler.DeleteHierarchyLevel)
 		}
 
 		// Users CRUD
-		users := admin.Group("/users")
+		users := admin.Group("/users", middleware.RequirePermission(permissions, organization.PermissionManageUsers))
 		{
 			users.GET("", handler.ListUsers)
-			users.POST("", handler.CreateUser)
-			users.PUT("/:id", handler.UpdateUser)
-			users.DELETE("/:id", handler.DeleteUser)
+			users.POST("", auditAdminWrite(audit, "user.create", createAudit(userTarget)), handler.CreateUser)
+			users.PUT("/:id", auditAdminWrite(audit, "user.update", userTarget), handler.UpdateUser)
+			users.DELETE("/:id", auditAdminWrite(audit, "user.delete", userTarget), handler.DeleteUser)
+			users.POST("/:id/revoke-sessions", auditAdminWrite(audit, "user.revoke_sessions", userTarget), handler.RevokeUserSessions)
+			users.POST("/:id/unlock", auditAdminWrite(audit, "user.unlock", userTarget), handler.UnlockUser)
+			users.POST("/:id/reset-mfa", auditAdminWrite(audit, "user.reset_mfa", userTarget), handler.ResetUserMFA)
 		}
 
 		// Teams CRUD
-		teams := admin.Group("/teams")
+		teams := admin.Group("/teams", middleware.RequirePermission(permissions, organization.PermissionEditTeams))
 		{
 			teams.GET("", handler.ListTeams)
-			teams.POST("", handler.CreateTeam)
-			teams.PUT("/:id", handler.UpdateTeam)
-			teams.DELETE("/:id", handler.DeleteTeam)
+			teams.POST("", auditAdminWrite(audit, "team.create", createAudit(teamTarget)), handler.CreateTeam)
+			teams.PUT("/:id", auditAdminWrite(audit, "team.update", teamTarget), handler.UpdateTeam)
+			teams.DELETE("/:id", auditAdminWrite(audit, "team.delete", teamTarget), handler.DeleteTeam)
 			teams.GET("/:id/members", handler.GetTeamMembers)
-			teams.POST("/:id/members", handler.AddTeamMember)
-			teams.DELETE("/:id/members/:userId", handler.RemoveTeamMember)
+			teams.POST("/:id/members", auditAdminWrite(audit, "team.member.add", teamMembersAuditTarget(teamRepo)), handler.AddTeamMember)
+			teams.DELETE("/:id/members/:userId", auditAdminWrite(audit, "team.member.remove", teamMembersAuditTarget(teamRepo)), handler.RemoveTeamMember)
 			teams.GET("/:id/supervisors", handler.GetTeamSupervisors)
-			teams.PUT("/:id/supervisors", handler.UpdateTeamSupervisors)
+			teams.PUT("/:id/supervisors", auditAdminWrite(audit, "team.supervisors.update", teamSupervisorsAuditTarget(teamRepo)), handler.UpdateTeamSupervisors)
 		}
 
 		// Settings
-		settings := admin.Group("/settings")
+		settings := admin.Group("/settings", configureSystem)
 		{
 			// Health Dimensions - Full CRUD
 			settings.GET("/dimensions", handler.GetDimensions)
-			settings.POST("/dimensions", handler.CreateDimension)
-			settings.PUT("/dimensions/:id", handler.UpdateDimension)
-			settings.DELETE("/dimensions/:id", handler.DeleteDimension)
+			settings.POST("/dimensions", auditAdminWrite(audit, "dimension.create", createAudit(dimensionTarget)), handler.CreateDimension)
+			settings.PUT("/dimensions/:id", auditAdminWrite(audit, "dimension.update", dimensionTarget), handler.UpdateDimension)
+			settings.DELETE("/dimensions/:id", auditAdminWrite(audit, "dimension.delete", dimensionTarget), handler.DeleteDimension)
 
 			// Branding
 			settings.GET("/branding", handler.GetBrandingSettings)
-			settings.PUT("/branding", handler.UpdateBrandingSettings)
+			settings.PUT("/branding", auditAdminWrite(audit, "settings.branding.update", settingsTarget), handler.UpdateBrandingSettings)
 
 			// Notifications
 			settings.GET("/notifications", handler.GetNotificationSettings)
-			settings.PUT("/notifications", handler.UpdateNotificationSettings)
+			settings.PUT("/notifications", auditAdminWrite(audit, "settings.notifications.update", settingsTarget), handler.UpdateNotificationSettings)
 
 			// Retention Policy
 			settings.GET("/retention", handler.GetRetentionPolicy)
-			settings.PUT("/retention", handler.UpdateRetentionPolicy)
+			settings.PUT("/retention", auditAdminWrite(audit, "settings.retention.update", settingsTarget), handler.UpdateRetentionPolicy)
+		}
+
+		// Audit log of admin writes
+		auditLog := admin.Group("/audit-log", configureSystem)
+		{
+			auditLog.GET("", handler.GetAuditLog)
+			auditLog.GET("/export", handler.ExportAuditLog)
 		}
 	}
 }
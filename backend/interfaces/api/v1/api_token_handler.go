@@ -0,0 +1,184 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// APITokenHandler handles personal and admin-issued service API token HTTP requests
+type APITokenHandler struct {
+	tokens   *services.APITokenService
+	userRepo user.Repository
+}
+
+// NewAPITokenHandler creates a new APITokenHandler
+func NewAPITokenHandler(tokens *services.APITokenService, userRepo user.Repository) *APITokenHandler {
+	return &APITokenHandler{tokens: tokens, userRepo: userRepo}
+}
+
+// ListMyAPITokens handles GET /api/v1/auth/api-tokens
+func (h *APITokenHandler) ListMyAPITokens(c *gin.Context) {
+	userID, _ := middleware.GetUserIDFromContext(c)
+	h.respondTokens(c, userID)
+}
+
+// CreateMyAPIToken handles POST /api/v1/auth/api-tokens
+// The token is returned once and cannot be retrieved again.
+func (h *APITokenHandler) CreateMyAPIToken(c *gin.Context) {
+	userID, _ := middleware.GetUserIDFromContext(c)
+
+	var req dto.CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.RespondErrorWithDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	h.issue(c, req, userID, services.APITokenKindPersonal)
+}
+
+// RevokeMyAPIToken handles DELETE /api/v1/auth/api-tokens/:id
+func (h *APITokenHandler) RevokeMyAPIToken(c *gin.Context) {
+	userID, _ := middleware.GetUserIDFromContext(c)
+	h.revoke(c, userID)
+}
+
+// ListAPITokens handles GET /api/v1/admin/api-tokens
+// Lists every user's tokens, or one user's with ?userId=
+func (h *APITokenHandler) ListAPITokens(c *gin.Context) {
+	h.respondTokens(c, c.Query("userId"))
+}
+
+// IssueServiceAPIToken handles POST /api/v1/admin/api-tokens
+// Issues a token that acts as the given user, usually a dedicated service account.
+func (h *APITokenHandler) IssueServiceAPIToken(c *gin.Context) {
+	var req dto.IssueServiceAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.RespondErrorWithDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	usr, err := h.userRepo.FindByID(c.Request.Context(), req.UserID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			dto.RespondError(c, http.StatusNotFound, "User not found")
+			return
+		}
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to issue API token", err.Error())
+		return
+	}
+	if !usr.IsActive() {
+		dto.RespondError(c, http.StatusConflict, "User is deactivated")
+		return
+	}
+
+	h.issue(c, req.CreateAPITokenRequest, usr.ID, services.APITokenKindService)
+}
+
+// RevokeAPIToken handles DELETE /api/v1/admin/api-tokens/:id
+func (h *APITokenHandler) RevokeAPIToken(c *gin.Context) {
+	h.revoke(c, "")
+}
+
+// respondTokens lists the user's tokens, or every token when userID is empty
+func (h *APITokenHandler) respondTokens(c *gin.Context, userID string) {
+	tokens, err := h.tokens.List(c.Request.Context(), userID)
+	if err != nil {
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to list API tokens", err.Error())
+		return
+	}
+
+	resp := dto.APITokensResponse{Tokens: make([]dto.APITokenDTO, len(tokens))}
+	for i, token := range tokens {
+		resp.Tokens[i] = toAPITokenDTO(token)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// issue creates a token for userID on behalf of the signed-in user
+func (h *APITokenHandler) issue(c *gin.Context, req dto.CreateAPITokenRequest, userID string, kind services.APITokenKind) {
+	createdBy, _ := middleware.GetUserIDFromContext(c)
+
+	token := &services.APIToken{
+		Name:      req.Name,
+		UserID:    userID,
+		Kind:      kind,
+		Scopes:    make([]services.APITokenScope, len(req.Scopes)),
+		CreatedBy: createdBy,
+		ExpiresAt: req.ExpiresAt,
+	}
+	for i, scope := range req.Scopes {
+		token.Scopes[i] = services.APITokenScope(scope)
+	}
+
+	secret, err := h.tokens.Issue(c.Request.Context(), token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAPITokenScope) || errors.Is(err, services.ErrInvalidAPITokenExpiry) {
+			dto.RespondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to create API token", err.Error())
+		return
+	}
+
+	logger.Get().Security("api_token_created").
+		UserID(userID).
+		IP(c.ClientIP()).
+		RequestID(c.GetString("request_id")).
+		Details("API token " + token.ID + " (" + string(kind) + ") created by " + createdBy).
+		Log()
+
+	c.JSON(http.StatusCreated, dto.CreatedAPITokenResponse{APITokenDTO: toAPITokenDTO(token), Token: secret})
+}
+
+// revoke revokes the token in the id parameter; ownerID restricts it to that user's tokens
+func (h *APITokenHandler) revoke(c *gin.Context, ownerID string) {
+	id := c.Param("id")
+
+	if err := h.tokens.Revoke(c.Request.Context(), id, ownerID); err != nil {
+		if errors.Is(err, services.ErrAPITokenNotFound) {
+			dto.RespondError(c, http.StatusNotFound, "API token not found")
+			return
+		}
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to revoke API token", err.Error())
+		return
+	}
+
+	revokedBy, _ := middleware.GetUserIDFromContext(c)
+	logger.Get().Security("api_token_revoked").
+		UserID(revokedBy).
+		IP(c.ClientIP()).
+		RequestID(c.GetString("request_id")).
+		Details("API token " + id + " revoked").
+		Log()
+
+	dto.RespondMessage(c, http.StatusOK, "API token revoked")
+}
+
+// toAPITokenDTO converts an API token to its response form
+func toAPITokenDTO(token *services.APIToken) dto.APITokenDTO {
+	scopes := make([]string, len(token.Scopes))
+	for i, scope := range token.Scopes {
+		scopes[i] = string(scope)
+	}
+	return dto.APITokenDTO{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		UserID:     token.UserID,
+		Kind:       string(token.Kind),
+		Scopes:     scopes,
+		CreatedBy:  token.CreatedBy,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		RevokedAt:  token.RevokedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAPITokenRoutes configures personal and service API token routes
+// Personal token routes require JWT authentication; service token routes also require the
+// CanManageUsers permission, and their writes are recorded in the audit log unless audit is nil.
+// API tokens themselves cannot call these routes.
+func SetupAPITokenRoutes(router *gin.Engine, tokens *services.APITokenService, userRepo user.Repository, jwtService *services.JWTService, permissions *services.PermissionService, audit *services.AdminAuditService) {
+	handler := NewAPITokenHandler(tokens, userRepo)
+	target := apiTokenAuditTarget(tokens)
+
+	personal := router.Group("/api/v1/auth/api-tokens")
+	personal.Use(middleware.JWTAuthMiddleware(jwtService))
+	{
+		personal.GET("", handler.ListMyAPITokens)
+		personal.POST("", handler.CreateMyAPIToken)
+		personal.DELETE("/:id", handler.RevokeMyAPIToken)
+	}
+
+	service := router.Group("/api/v1/admin/api-tokens")
+	service.Use(middleware.JWTAuthMiddleware(jwtService))
+	service.Use(middleware.RequirePermission(permissions, organization.PermissionManageUsers))
+	{
+		service.GET("", handler.ListAPITokens)
+		service.POST("", auditAdminWrite(audit, "api_token.issue", createAudit(target)), handler.IssueServiceAPIToken)
+		service.DELETE("/:id", auditAdminWrite(audit, "api_token.revoke", target), handler.RevokeAPIToken)
+	}
+}
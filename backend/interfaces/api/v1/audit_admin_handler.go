@@ -0,0 +1,173 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditAdminHandler serves the admin audit log
+type AuditAdminHandler struct {
+	audit *services.AdminAuditService // nil when the audit log is disabled
+}
+
+// NewAuditAdminHandler creates a new AuditAdminHandler
+func NewAuditAdminHandler(audit *services.AdminAuditService) *AuditAdminHandler {
+	return &AuditAdminHandler{audit: audit}
+}
+
+// GetAuditLog handles GET /api/v1/admin/audit-log
+// Optional query params: actorId, action, targetType, targetId, from and to (RFC 3339 or
+// YYYY-MM-DD; from is inclusive, to exclusive), limit (default 50, max 500) and offset.
+// Entries are returned newest first.
+func (h *AuditAdminHandler) GetAuditLog(c *gin.Context) {
+	if h.audit == nil {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "Audit log is not enabled"})
+		return
+	}
+
+	filter, err := auditFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	entries, total, err := h.audit.List(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAuditQuery) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch audit log",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	dtos := make([]dto.AdminAuditEntryDTO, len(entries))
+	for i, e := range entries {
+		dtos[i] = toAdminAuditEntryDTO(e)
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = services.DefaultAuditPageSize
+	}
+	c.JSON(http.StatusOK, dto.AdminAuditLogResponse{Entries: dtos, Total: total, Limit: limit, Offset: filter.Offset})
+}
+
+// ExportAuditLog handles GET /api/v1/admin/audit-log/export
+// Streams every matching entry, oldest first, as CSV (default) or XLSX with format=xlsx.
+// Takes the same filters as GetAuditLog, without paging.
+func (h *AuditAdminHandler) ExportAuditLog(c *gin.Context) {
+	if h.audit == nil {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "Audit log is not enabled"})
+		return
+	}
+	ctx := c.Request.Context()
+
+	filter, err := auditFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	format := c.DefaultQuery("format", services.ExportFormatCSV)
+
+	out := &exportResponseWriter{
+		c:           c,
+		contentType: exportContentTypes[format],
+		filename:    fmt.Sprintf("admin-audit-log-%s.%s", time.Now().UTC().Format("20060102"), format),
+	}
+
+	if err := h.audit.Export(ctx, filter, format, out); err != nil {
+		if out.started {
+			// The response is already streaming; all we can do is cut it short
+			logger.Get().WithContext(ctx).WithError(err).Error("audit log export failed while streaming")
+			c.Abort()
+			return
+		}
+		if errors.Is(err, services.ErrInvalidAuditQuery) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to export audit log",
+			Message: err.Error(),
+		})
+	}
+}
+
+// auditFilterFromQuery reads the audit log filters from the query string
+func auditFilterFromQuery(c *gin.Context) (services.AdminAuditFilter, error) {
+	filter := services.AdminAuditFilter{
+		ActorID:    c.Query("actorId"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("targetType"),
+		TargetID:   c.Query("targetId"),
+	}
+
+	var err error
+	if filter.From, err = parseAuditTime(c.Query("from"), "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseAuditTime(c.Query("to"), "to"); err != nil {
+		return filter, err
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		if filter.Limit, err = strconv.Atoi(raw); err != nil || filter.Limit < 1 {
+			return filter, fmt.Errorf("limit must be between 1 and %d", services.MaxAuditPageSize)
+		}
+	}
+	if raw := c.Query("offset"); raw != "" {
+		if filter.Offset, err = strconv.Atoi(raw); err != nil || filter.Offset < 0 {
+			return filter, fmt.Errorf("offset must be a non-negative integer")
+		}
+	}
+	return filter, nil
+}
+
+// parseAuditTime parses an RFC 3339 timestamp or a YYYY-MM-DD date (midnight UTC)
+func parseAuditTime(raw, name string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		return &t, nil
+	}
+	return nil, fmt.Errorf("%s must be an RFC 3339 timestamp or a YYYY-MM-DD date", name)
+}
+
+// toAdminAuditEntryDTO converts an audit entry to its DTO, with the changed fields
+func toAdminAuditEntryDTO(e services.AdminAuditEntry) dto.AdminAuditEntryDTO {
+	changes := services.AdminAuditChanges(e.Before, e.After)
+	changeDTOs := make([]dto.AdminAuditChangeDTO, len(changes))
+	for i, change := range changes {
+		changeDTOs[i] = dto.AdminAuditChangeDTO{Field: change.Field, Before: change.Before, After: change.After}
+	}
+	return dto.AdminAuditEntryDTO{
+		ID:            e.ID,
+		ActorID:       e.ActorID,
+		ActorUsername: e.ActorUsername,
+		Action:        e.Action,
+		TargetType:    e.TargetType,
+		TargetID:      e.TargetID,
+		Before:        e.Before,
+		After:         e.After,
+		Changes:       changeDTOs,
+		IPAddress:     e.IPAddress,
+		RequestID:     e.RequestID,
+		CreatedAt:     e.CreatedAt,
+	}
+}
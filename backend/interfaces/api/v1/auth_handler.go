@@ -2,7 +2,12 @@ package v1
 
 import (
 	"context"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/agopalakrishnan/teams360/backend/application/services"
@@ -16,19 +21,33 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// dummyPasswordHash is checked against when a login names no local account, so the response
+// takes as long as a wrong password and its timing does not reveal which accounts exist
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte("teams360-no-such-account"), bcrypt.DefaultCost)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
 // AuthHandler handles authentication-related HTTP requests
 type AuthHandler struct {
 	userRepo   user.Repository
 	orgRepo    organization.Repository
 	jwtService *services.JWTService
+	lockout    *services.AccountLockoutService // nil disables account lockout
+	mfa        *services.MFAService            // nil disables MFA
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(userRepo user.Repository, orgRepo organization.Repository, jwtService *services.JWTService) *AuthHandler {
+func NewAuthHandler(userRepo user.Repository, orgRepo organization.Repository, jwtService *services.JWTService, lockout *services.AccountLockoutService, mfa *services.MFAService) *AuthHandler {
 	return &AuthHandler{
 		userRepo:   userRepo,
 		orgRepo:    orgRepo,
 		jwtService: jwtService,
+		lockout:    lockout,
+		mfa:        mfa,
 	}
 }
 
@@ -63,9 +82,19 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("auth.username_masked", maskUsername(req.Username)))
 
+	// Every attempt is counted against the username before anything is checked, so locked
+	// or delayed usernames are refused alike whether or not the account exists, and guesses
+	// made meanwhile reveal nothing
+	attempts, reason, refused := h.reserveLogin(c, req.Username)
+	if refused {
+		telemetry.RecordLogin(ctx, false, time.Since(startTime), reason)
+		return
+	}
+
 	// Find user by username using repository
 	usr, err := h.userRepo.FindByUsername(ctx, req.Username)
 	if err != nil {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(req.Password))
 		telemetry.RecordLogin(ctx, false, time.Since(startTime), "user_not_found")
 		telemetry.SetSpanError(span, err)
 		log.Auth("login").
@@ -84,6 +113,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// SSO users cannot log in with username/password
 	if usr.AuthType == user.AuthTypeSSO {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(req.Password))
 		telemetry.RecordLogin(ctx, false, time.Since(startTime), "sso_user_local_login")
 		log.Auth("login").
 			Username(req.Username).
@@ -102,6 +132,9 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	if err := bcrypt.CompareHashAndPassword([]byte(usr.PasswordHash), []byte(req.Password)); err != nil {
 		telemetry.RecordLogin(ctx, false, time.Since(startTime), "incorrect_password")
 		telemetry.SetSpanError(span, err)
+		if h.lockout != nil {
+			h.lockout.RecordFailure(ctx, usr, attempts)
+		}
 		log.Auth("login").
 			Username(req.Username).
 			UserID(usr.ID).
@@ -115,17 +148,45 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	teamIds := collectTeamIDs(ctx, h.userRepo, usr.ID)
+	// Deactivated users cannot log in
+	if !usr.IsActive() {
+		telemetry.RecordLogin(ctx, false, time.Since(startTime), "user_deactivated")
+		log.Auth("login").
+			Username(req.Username).
+			UserID(usr.ID).
+			IP(clientIP).
+			RequestID(requestID).
+			Endpoint(endpoint).
+			Reason("user_deactivated").
+			Details("Deactivated user attempted local password login").
+			Failure()
+		dto.RespondError(c, http.StatusUnauthorized, "Invalid username or password")
+		return
+	}
 
-	// Generate JWT tokens
-	tokenPair, err := h.jwtService.GenerateTokenPair(
-		c.Request.Context(),
-		usr.ID,
-		usr.Username,
-		usr.Email,
-		usr.HierarchyLevelID,
-		teamIds,
-	)
+	// With MFA the password alone only earns a challenge token
+	if h.mfa != nil {
+		challenged, err := h.respondMFAChallenge(c, usr)
+		if err != nil {
+			telemetry.SetSpanError(span, err)
+			log.WithError(err).WithField("user_id", usr.ID).Error("failed to check MFA")
+			dto.RespondError(c, http.StatusInternalServerError, "Failed to authenticate")
+			return
+		}
+		if challenged {
+			telemetry.SetSpanOK(span)
+			log.Auth("login").
+				UserID(usr.ID).
+				IP(clientIP).
+				RequestID(requestID).
+				Endpoint(endpoint).
+				Details("Password verified, MFA challenge issued").
+				Success()
+			return
+		}
+	}
+
+	response, err := h.loginResponse(ctx, usr)
 	if err != nil {
 		log.Auth("login").
 			UserID(usr.ID).
@@ -139,6 +200,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	if h.lockout != nil {
+		if err := h.lockout.RecordSuccess(ctx, usr); err != nil {
+			log.WithError(err).WithField("user_id", usr.ID).Warn("failed to reset login attempts")
+		}
+	}
+
 	// Record successful login metrics
 	telemetry.RecordLogin(ctx, true, time.Since(startTime), "")
 	telemetry.IncrementActiveSessions(ctx)
@@ -153,16 +220,36 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		Details("User authenticated successfully, JWT tokens issued").
 		Success()
 
+	dto.RespondSuccess(c, http.StatusOK, response)
+}
+
+// loginResponse issues a token pair for a user who completed login
+func (h *AuthHandler) loginResponse(ctx context.Context, usr *user.User) (dto.LoginResponse, error) {
+	teamIds := collectTeamIDs(ctx, h.userRepo, usr.ID)
+
+	// Generate JWT tokens
+	tokenPair, err := h.jwtService.GenerateTokenPair(
+		ctx,
+		usr.ID,
+		usr.Username,
+		usr.Email,
+		usr.HierarchyLevelID,
+		teamIds,
+	)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
 	// Fetch hierarchy level permissions to include canTakeSurvey in response
 	canTakeSurvey := false
 	if usr.HierarchyLevelID != "" {
-		if level, err := h.orgRepo.FindHierarchyLevelByID(c.Request.Context(), usr.HierarchyLevelID); err == nil {
+		if level, err := h.orgRepo.FindHierarchyLevelByID(ctx, usr.HierarchyLevelID); err == nil {
 			canTakeSurvey = level.Permissions.CanTakeSurvey
 		}
 	}
 
 	// Return user info with JWT tokens
-	response := dto.LoginResponse{
+	return dto.LoginResponse{
 		User: dto.UserDTO{
 			ID:             usr.ID,
 			Username:       usr.Username,
@@ -175,9 +262,44 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
 		ExpiresIn:    tokenPair.ExpiresIn,
+	}, nil
+}
+
+// reserveLogin counts a login attempt for the username before its credentials are checked.
+// It responds 423 or 429 when the username is locked or delayed after failed logins, and 500
+// when that cannot be checked. Returns the counted attempts, or the reason and whether it responded.
+func (h *AuthHandler) reserveLogin(c *gin.Context, username string) (*services.LoginAttempts, string, bool) {
+	if h.lockout == nil {
+		return nil, "", false
 	}
 
-	dto.RespondSuccess(c, http.StatusOK, response)
+	attempts, retryAfter, err := h.lockout.ReserveLogin(c.Request.Context(), username)
+	if err == nil {
+		return attempts, "", false
+	}
+	if !errors.Is(err, services.ErrAccountLocked) && !errors.Is(err, services.ErrLoginThrottled) {
+		logger.Get().WithContext(c.Request.Context()).WithError(err).Error("failed to check login attempts")
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to authenticate")
+		return nil, "lockout_check_failed", true
+	}
+
+	reason, status, message := "account_locked", http.StatusLocked,
+		"This account is temporarily locked after too many failed login attempts. Please try again later or contact your administrator."
+	if errors.Is(err, services.ErrLoginThrottled) {
+		reason, status, message = "login_throttled", http.StatusTooManyRequests,
+			"Too many failed login attempts. Please wait before trying again."
+	}
+	logger.Get().WithContext(c.Request.Context()).Auth("login").
+		Username(username).
+		IP(c.ClientIP()).
+		RequestID(c.GetString("request_id")).
+		Endpoint(c.Request.URL.Path).
+		Reason(reason).
+		Details("Login refused after repeated failed attempts, retry after " + retryAfter.Round(time.Second).String()).
+		Failure()
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	dto.RespondError(c, status, message)
+	return nil, reason, true
 }
 
 // Refresh handles token refresh requests
@@ -249,10 +371,24 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
+	if !usr.IsActive() {
+		telemetry.RecordTokenRefresh(ctx, false, "user_deactivated")
+		log.Auth("token_refresh").
+			UserID(userID).
+			IP(clientIP).
+			RequestID(requestID).
+			Endpoint(endpoint).
+			Reason("user_deactivated").
+			Details("User from refresh token has been deactivated").
+			Failure()
+		dto.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+
 	teamIds := collectTeamIDs(ctx, h.userRepo, usr.ID)
 
-	// Generate new access token
-	newAccessToken, err := h.jwtService.RefreshAccessToken(
+	// Rotate: the presented refresh token is consumed and a new pair is issued
+	tokenPair, err := h.jwtService.RotateRefreshToken(
 		ctx,
 		req.RefreshToken,
 		usr.ID,
@@ -262,17 +398,29 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		teamIds,
 	)
 	if err != nil {
-		telemetry.RecordTokenRefresh(ctx, false, "token_generation_failed")
+		reason := "access_token_generation_failed"
+		details := "Failed to rotate valid refresh token: " + err.Error()
+		message := "Failed to refresh token"
+		if errors.Is(err, services.ErrTokenRevoked) {
+			reason = "refresh_token_revoked"
+			details = "Refresh token was revoked, or reused after rotation and its family revoked"
+			message = "Refresh token has been revoked"
+		} else if errors.Is(err, services.ErrInvalidToken) {
+			reason = "invalid_refresh_token"
+			details = "Refresh token is not known to the token store"
+			message = "Invalid or expired refresh token"
+		}
+		telemetry.RecordTokenRefresh(ctx, false, reason)
 		telemetry.SetSpanError(span, err)
 		log.Auth("token_refresh").
 			UserID(usr.ID).
 			IP(clientIP).
 			RequestID(requestID).
 			Endpoint(endpoint).
-			Reason("access_token_generation_failed").
-			Details("Failed to generate new access token from valid refresh token: " + err.Error()).
+			Reason(reason).
+			Details(details).
 			Failure()
-		dto.RespondError(c, http.StatusUnauthorized, "Failed to refresh token")
+		dto.RespondError(c, http.StatusUnauthorized, message)
 		return
 	}
 
@@ -285,12 +433,13 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		IP(clientIP).
 		RequestID(requestID).
 		Endpoint(endpoint).
-		Details("New access token issued successfully").
+		Details("Refresh token rotated, new access and refresh tokens issued").
 		Success()
 
 	response := dto.RefreshTokenResponse{
-		AccessToken: newAccessToken,
-		ExpiresIn:   900, // 15 minutes in seconds (default)
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		ExpiresIn:    tokenPair.ExpiresIn,
 	}
 
 	dto.RespondSuccess(c, http.StatusOK, response)
@@ -309,16 +458,37 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	requestID := c.GetString("request_id")
 	endpoint := "/api/v1/auth/logout"
 
-	// Try to get user ID from context if authenticated
-	userID, exists := c.Get("user_id")
-	if exists {
-		span.SetAttributes(attribute.String("user.id", userID.(string)))
+	// The access token comes from the Authorization header; the refresh token from the
+	// optional request body. Both are revoked server-side so they stop working immediately.
+	accessToken := ""
+	if parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2); len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
+		accessToken = parts[1]
+	}
+	var req dto.LogoutRequest
+	_ = c.ShouldBindJSON(&req) // body is optional
+
+	userID, err := h.jwtService.RevokeSession(ctx, accessToken, req.RefreshToken)
+	if err != nil {
+		telemetry.SetSpanError(span, err)
+		log.Auth("logout").
+			IP(clientIP).
+			RequestID(requestID).
+			Endpoint(endpoint).
+			Reason("token_revocation_failed").
+			Details("Failed to revoke tokens on logout: " + err.Error()).
+			Failure()
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to log out")
+		return
+	}
+
+	if userID != "" {
+		span.SetAttributes(attribute.String("user.id", userID))
 		log.Auth("logout").
-			UserID(userID.(string)).
+			UserID(userID).
 			IP(clientIP).
 			RequestID(requestID).
 			Endpoint(endpoint).
-			Details("User session ended, client should discard tokens").
+			Details("User session ended, tokens revoked").
 			Success()
 	} else {
 		// Log logout attempt without user context (unauthenticated logout request)
@@ -335,8 +505,6 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	telemetry.DecrementActiveSessions(ctx)
 	telemetry.SetSpanOK(span)
 
-	// For stateless JWT, logout is handled client-side by removing tokens
-	// In a production system, you would add the token to a blacklist
 	dto.RespondSuccess(c, http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
@@ -0,0 +1,348 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/telemetry"
+	"github.com/gin-gonic/gin"
+)
+
+// respondMFAChallenge answers a password login with an MFA challenge token when the user has
+// MFA enabled, or must enroll because their hierarchy level requires it.
+// Returns whether it responded.
+func (h *AuthHandler) respondMFAChallenge(c *gin.Context, usr *user.User) (bool, error) {
+	ctx := c.Request.Context()
+
+	enabled, err := h.mfa.Enabled(ctx, usr.ID)
+	if err != nil {
+		return false, err
+	}
+	required := false
+	if !enabled {
+		if required, err = h.mfa.Required(ctx, usr); err != nil {
+			return false, err
+		}
+	}
+	if !enabled && !required {
+		return false, nil
+	}
+
+	token, expiresIn, err := h.jwtService.GenerateMFAChallenge(usr.ID)
+	if err != nil {
+		return false, err
+	}
+
+	dto.RespondSuccess(c, http.StatusOK, dto.MFAChallengeResponse{
+		MFARequired:        true,
+		MFAToken:           token,
+		ExpiresIn:          expiresIn,
+		EnrollmentRequired: !enabled,
+	})
+	return true, nil
+}
+
+// VerifyMFA handles POST /api/v1/auth/mfa/verify, the second login step.
+// It exchanges the challenge token and an authenticator or recovery code for a token pair.
+// For users enrolling during login, the first code confirms the enrollment and the response
+// carries their recovery codes.
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+	startTime := time.Now()
+	log := logger.Get().WithContext(ctx)
+	clientIP := c.ClientIP()
+	requestID := c.GetString("request_id")
+	endpoint := "/api/v1/auth/mfa/verify"
+
+	var req dto.MFAVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.RespondError(c, http.StatusBadRequest, "mfaToken and code are required")
+		return
+	}
+
+	userID, err := h.jwtService.ValidateMFAChallenge(req.MFAToken)
+	if err != nil {
+		log.Auth("mfa_verify").
+			IP(clientIP).
+			RequestID(requestID).
+			Endpoint(endpoint).
+			Reason("invalid_mfa_token").
+			Details("MFA challenge token failed validation: " + err.Error()).
+			Failure()
+		dto.RespondError(c, http.StatusUnauthorized, "Your sign-in has expired. Please sign in again.")
+		return
+	}
+
+	usr, err := h.userRepo.FindByID(ctx, userID)
+	if err != nil || !usr.IsActive() {
+		dto.RespondError(c, http.StatusUnauthorized, "Invalid username or password")
+		return
+	}
+
+	// Code guesses count as failed logins
+	attempts, reason, refused := h.reserveLogin(c, usr.Username)
+	if refused {
+		telemetry.RecordLogin(ctx, false, time.Since(startTime), reason)
+		return
+	}
+
+	enabled, err := h.mfa.Enabled(ctx, usr.ID)
+	var recoveryCodes []string
+	usedRecoveryCode := false
+	if err == nil {
+		if enabled {
+			usedRecoveryCode, err = h.mfa.Verify(ctx, usr.ID, req.Code)
+		} else {
+			recoveryCodes, err = h.mfa.ConfirmEnrollment(ctx, usr.ID, req.Code)
+		}
+	}
+	switch {
+	case errors.Is(err, services.ErrInvalidMFACode):
+		telemetry.RecordLogin(ctx, false, time.Since(startTime), "invalid_mfa_code")
+		if h.lockout != nil {
+			h.lockout.RecordFailure(ctx, usr, attempts)
+		}
+		log.Auth("mfa_verify").
+			Username(usr.Username).
+			UserID(usr.ID).
+			IP(clientIP).
+			RequestID(requestID).
+			Endpoint(endpoint).
+			Reason("invalid_mfa_code").
+			Details("MFA code is wrong, expired or already used").
+			Failure()
+		dto.RespondError(c, http.StatusUnauthorized, "Invalid verification code")
+		return
+	case errors.Is(err, services.ErrMFANotEnrolling):
+		dto.RespondError(c, http.StatusBadRequest, "Set up your authenticator app before entering a code")
+		return
+	case err != nil:
+		log.WithError(err).WithField("user_id", usr.ID).Error("failed to verify MFA code")
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to verify code")
+		return
+	}
+
+	response, err := h.loginResponse(ctx, usr)
+	if err != nil {
+		log.WithError(err).WithField("user_id", usr.ID).Error("failed to generate tokens after MFA")
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to generate authentication tokens")
+		return
+	}
+
+	if h.lockout != nil {
+		if err := h.lockout.RecordSuccess(ctx, usr); err != nil {
+			log.WithError(err).WithField("user_id", usr.ID).Warn("failed to reset login attempts")
+		}
+	}
+
+	telemetry.RecordLogin(ctx, true, time.Since(startTime), "")
+	telemetry.IncrementActiveSessions(ctx)
+
+	details := "MFA code verified, JWT tokens issued"
+	if recoveryCodes != nil {
+		details = "MFA enrolled during login, JWT tokens issued"
+	}
+	log.Auth("mfa_verify").
+		UserID(usr.ID).
+		IP(clientIP).
+		RequestID(requestID).
+		Endpoint(endpoint).
+		Details(details).
+		Success()
+	if usedRecoveryCode {
+		log.Security("mfa_recovery_code_used").
+			UserID(usr.ID).
+			IP(clientIP).
+			RequestID(requestID).
+			Details("Login completed with a recovery code").
+			Log()
+	}
+
+	dto.RespondSuccess(c, http.StatusOK, dto.MFAVerifyResponse{
+		LoginResponse: response,
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+// EnrollMFA handles POST /api/v1/auth/mfa/enroll
+// Generates a new authenticator secret for the signed-in user, or during login for a user who
+// must enroll (authenticated by the challenge token in the body). The secret takes effect once
+// a code from it is entered, at /mfa/enable or /mfa/verify respectively.
+func (h *AuthHandler) EnrollMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		var req dto.MFAEnrollRequest
+		if err := c.ShouldBindJSON(&req); err != nil || req.MFAToken == "" {
+			dto.RespondError(c, http.StatusUnauthorized, "Authorization header or mfaToken is required")
+			return
+		}
+		var err error
+		if userID, err = h.jwtService.ValidateMFAChallenge(req.MFAToken); err != nil {
+			dto.RespondError(c, http.StatusUnauthorized, "Your sign-in has expired. Please sign in again.")
+			return
+		}
+	}
+
+	usr, err := h.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		dto.RespondError(c, http.StatusUnauthorized, "User not found")
+		return
+	}
+
+	enrollment, err := h.mfa.BeginEnrollment(ctx, usr)
+	switch {
+	case errors.Is(err, services.ErrMFAAlreadyEnabled):
+		dto.RespondError(c, http.StatusConflict, "MFA is already enabled")
+		return
+	case errors.Is(err, services.ErrMFANotSupported):
+		dto.RespondError(c, http.StatusBadRequest, "MFA is managed by your identity provider for SSO accounts")
+		return
+	case err != nil:
+		logger.Get().WithContext(ctx).WithError(err).WithField("user_id", usr.ID).Error("failed to start MFA enrollment")
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to start MFA enrollment")
+		return
+	}
+
+	dto.RespondSuccess(c, http.StatusOK, dto.MFAEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+// GetMFAStatus handles GET /api/v1/auth/mfa
+func (h *AuthHandler) GetMFAStatus(c *gin.Context) {
+	usr, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.mfa.Status(c.Request.Context(), usr)
+	if err != nil {
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to get MFA status")
+		return
+	}
+
+	dto.RespondSuccess(c, http.StatusOK, dto.MFAStatusResponse{
+		Enabled:                status.Enabled,
+		Required:               status.Required,
+		RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+	})
+}
+
+// EnableMFA handles POST /api/v1/auth/mfa/enable
+// Confirms the signed-in user's enrollment with a first code and returns their recovery codes.
+func (h *AuthHandler) EnableMFA(c *gin.Context) {
+	usr, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.RespondError(c, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	codes, err := h.mfa.ConfirmEnrollment(c.Request.Context(), usr.ID, req.Code)
+	if !h.respondMFAError(c, err) {
+		return
+	}
+
+	logger.Get().Security("mfa_enabled").
+		UserID(usr.ID).
+		IP(c.ClientIP()).
+		RequestID(c.GetString("request_id")).
+		Details("TOTP MFA enabled").
+		Log()
+
+	dto.RespondSuccess(c, http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableMFA handles POST /api/v1/auth/mfa/disable
+// Requires a current code, and is refused when the user's hierarchy level requires MFA.
+func (h *AuthHandler) DisableMFA(c *gin.Context) {
+	usr, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.RespondError(c, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	err := h.mfa.Disable(c.Request.Context(), usr, req.Code)
+	if !h.respondMFAError(c, err) {
+		return
+	}
+
+	logger.Get().Security("mfa_disabled").
+		UserID(usr.ID).
+		IP(c.ClientIP()).
+		RequestID(c.GetString("request_id")).
+		Details("TOTP MFA disabled by the user").
+		Log()
+
+	dto.RespondMessage(c, http.StatusOK, "MFA disabled")
+}
+
+// RegenerateRecoveryCodes handles POST /api/v1/auth/mfa/recovery-codes
+// Replaces the signed-in user's recovery codes after checking a current code.
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	usr, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.RespondError(c, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	codes, err := h.mfa.RegenerateRecoveryCodes(c.Request.Context(), usr.ID, req.Code)
+	if !h.respondMFAError(c, err) {
+		return
+	}
+
+	dto.RespondSuccess(c, http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// currentUser loads the user of the access token, responding 401 if there is none
+func (h *AuthHandler) currentUser(c *gin.Context) (*user.User, bool) {
+	userID, _ := middleware.GetUserIDFromContext(c)
+	usr, err := h.userRepo.FindByID(c.Request.Context(), userID)
+	if err != nil {
+		dto.RespondError(c, http.StatusUnauthorized, "User not found")
+		return nil, false
+	}
+	return usr, true
+}
+
+// respondMFAError responds to an error from the MFA service. Returns true if there was none.
+func (h *AuthHandler) respondMFAError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, services.ErrInvalidMFACode):
+		dto.RespondError(c, http.StatusBadRequest, "Invalid verification code")
+	case errors.Is(err, services.ErrMFANotEnrolling):
+		dto.RespondError(c, http.StatusBadRequest, "MFA enrollment has not been started")
+	case errors.Is(err, services.ErrMFANotEnabled):
+		dto.RespondError(c, http.StatusBadRequest, "MFA is not enabled")
+	case errors.Is(err, services.ErrMFAAlreadyEnabled):
+		dto.RespondError(c, http.StatusConflict, "MFA is already enabled")
+	case errors.Is(err, services.ErrMFARequired):
+		dto.RespondError(c, http.StatusForbidden, "MFA is required for your role and cannot be disabled")
+	default:
+		logger.Get().WithContext(c.Request.Context()).WithError(err).Error("MFA request failed")
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to update MFA")
+	}
+	return false
+}
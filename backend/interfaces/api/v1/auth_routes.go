@@ -4,12 +4,14 @@ import (
 	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/domain/organization"
 	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-// SetupAuthRoutes configures authentication routes with repository dependency injection
-func SetupAuthRoutes(router *gin.Engine, userRepo user.Repository, orgRepo organization.Repository, jwtService *services.JWTService) {
-	authHandler := NewAuthHandler(userRepo, orgRepo, jwtService)
+// SetupAuthRoutes configures authentication routes with repository dependency injection.
+// lockout may be nil to disable per-account lockout, and mfa nil to disable MFA.
+func SetupAuthRoutes(router *gin.Engine, userRepo user.Repository, orgRepo organization.Repository, jwtService *services.JWTService, lockout *services.AccountLockoutService, mfa *services.MFAService) {
+	authHandler := NewAuthHandler(userRepo, orgRepo, jwtService, lockout, mfa)
 
 	// Authentication routes (public - no JWT required)
 	auth := router.Group("/api/v1/auth")
@@ -18,4 +20,25 @@ func SetupAuthRoutes(router *gin.Engine, userRepo user.Repository, orgRepo organ
 		auth.POST("/refresh", authHandler.Refresh)
 		auth.POST("/logout", authHandler.Logout)
 	}
+
+	if mfa == nil {
+		return
+	}
+
+	// MFA: the second login step is authenticated by the challenge token from /login
+	mfaRoutes := router.Group("/api/v1/auth/mfa")
+	{
+		mfaRoutes.POST("/verify", authHandler.VerifyMFA)
+		mfaRoutes.POST("/enroll", middleware.OptionalJWTAuthMiddleware(jwtService), authHandler.EnrollMFA)
+	}
+
+	// MFA self-service for signed-in users
+	mfaSettings := router.Group("/api/v1/auth/mfa")
+	mfaSettings.Use(middleware.JWTAuthMiddleware(jwtService))
+	{
+		mfaSettings.GET("", authHandler.GetMFAStatus)
+		mfaSettings.POST("/enable", authHandler.EnableMFA)
+		mfaSettings.POST("/disable", authHandler.DisableMFA)
+		mfaSettings.POST("/recovery-codes", authHandler.RegenerateRecoveryCodes)
+	}
 }
@@ -0,0 +1,204 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// CampaignAdminHandler handles survey campaign admin HTTP requests
+type CampaignAdminHandler struct {
+	campaignRepo campaign.Repository
+	scheduler    *services.CampaignScheduler
+}
+
+// NewCampaignAdminHandler creates a new CampaignAdminHandler
+func NewCampaignAdminHandler(campaignRepo campaign.Repository, scheduler *services.CampaignScheduler) *CampaignAdminHandler {
+	return &CampaignAdminHandler{
+		campaignRepo: campaignRepo,
+		scheduler:    scheduler,
+	}
+}
+
+// ListCampaigns handles GET /api/v1/admin/campaigns
+// Optional query params: teamId, status (open|closed), assessmentPeriod
+func (h *CampaignAdminHandler) ListCampaigns(c *gin.Context) {
+	status := c.Query("status")
+	if status != "" && status != campaign.StatusOpen && status != campaign.StatusClosed {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "status must be one of: open closed"})
+		return
+	}
+
+	campaigns, err := h.campaignRepo.FindAll(c.Request.Context(), campaign.Filter{
+		TeamID:           c.Query("teamId"),
+		Status:           status,
+		AssessmentPeriod: c.Query("assessmentPeriod"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to query campaigns",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CampaignsResponse{
+		Campaigns: toCampaignDTOs(campaigns),
+		Total:     len(campaigns),
+	})
+}
+
+// GetCampaign handles GET /api/v1/admin/campaigns/:id
+func (h *CampaignAdminHandler) GetCampaign(c *gin.Context) {
+	cmp, err := h.campaignRepo.FindByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Campaign not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch campaign",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, toCampaignDTO(cmp))
+}
+
+// OpenCampaign handles POST /api/v1/admin/campaigns
+// Opens the campaign for the team's current period. Returns 200 if it was already open.
+func (h *CampaignAdminHandler) OpenCampaign(c *gin.Context) {
+	var req dto.OpenCampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request body", Message: err.Error()})
+		return
+	}
+
+	cmp, created, err := h.scheduler.OpenCurrent(c.Request.Context(), req.TeamID)
+	if err != nil {
+		if strings.Contains(err.Error(), "team not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Team not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to open campaign",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, toCampaignDTO(cmp))
+}
+
+// CloseCampaign handles POST /api/v1/admin/campaigns/:id/close
+func (h *CampaignAdminHandler) CloseCampaign(c *gin.Context) {
+	cmp, err := h.scheduler.Close(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Open campaign not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to close campaign",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, toCampaignDTO(cmp))
+}
+
+// UpdateCampaignAnonymity handles PUT /api/v1/admin/campaigns/:id/anonymity
+// Returns 409 once individual surveys were submitted for the campaign's period.
+func (h *CampaignAdminHandler) UpdateCampaignAnonymity(c *gin.Context) {
+	var req dto.UpdateCampaignAnonymityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request body", Message: err.Error()})
+		return
+	}
+
+	id := c.Param("id")
+	if err := h.campaignRepo.SetAnonymous(c.Request.Context(), id, *req.Anonymous); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Campaign not found"})
+			return
+		}
+		if strings.Contains(err.Error(), "already has submissions") {
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:   "Cannot change campaign anonymity",
+				Message: "Surveys have already been submitted for this campaign",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to update campaign",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	cmp, err := h.campaignRepo.FindByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch campaign",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, toCampaignDTO(cmp))
+}
+
+// RunScheduler handles POST /api/v1/admin/campaigns/run
+// Triggers a scheduler pass immediately instead of waiting for the next tick.
+func (h *CampaignAdminHandler) RunScheduler(c *gin.Context) {
+	result, err := h.scheduler.RunAt(c.Request.Context(), time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to run campaign scheduler",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CampaignRunResponse{
+		Opened: toCampaignDTOs(result.Opened),
+		Closed: toCampaignDTOs(result.Closed),
+	})
+}
+
+// toCampaignDTO converts a domain campaign to its API representation
+func toCampaignDTO(cmp *campaign.Campaign) dto.CampaignDTO {
+	return dto.CampaignDTO{
+		ID:               cmp.ID,
+		TeamID:           cmp.TeamID,
+		TeamName:         cmp.TeamName,
+		AssessmentPeriod: cmp.AssessmentPeriod,
+		Cadence:          cmp.Cadence,
+		Status:           cmp.Status,
+		StartDate:        cmp.StartDate.Format("2006-01-02"),
+		EndDate:          cmp.EndDate.Format("2006-01-02"),
+		ClosedAt:         cmp.ClosedAt,
+		Anonymous:        cmp.Anonymous,
+		CreatedAt:        cmp.CreatedAt,
+	}
+}
+
+// toCampaignDTOs converts a list of domain campaigns to API representations
+func toCampaignDTOs(campaigns []*campaign.Campaign) []dto.CampaignDTO {
+	result := make([]dto.CampaignDTO, len(campaigns))
+	for i, cmp := range campaigns {
+		result[i] = toCampaignDTO(cmp)
+	}
+	return result
+}
@@ -0,0 +1,18 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignPeriodLabels_Unit(t *testing.T) {
+	// Campaign periods must round-trip through the submission API
+	now := time.Now()
+	for _, cadence := range []string{"monthly", "quarterly", "half-yearly", "yearly"} {
+		period := campaign.PeriodFor(cadence, now)
+		assert.NoError(t, validateAssessmentPeriod(period.Label), cadence)
+	}
+}
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupCampaignRoutes configures survey campaign admin routes
+// All routes require JWT authentication and the CanConfigureSystem permission; writes are recorded in the audit log unless audit is nil
+func SetupCampaignRoutes(router *gin.Engine, campaignRepo campaign.Repository, scheduler *services.CampaignScheduler, jwtService *services.JWTService, permissions *services.PermissionService, audit *services.AdminAuditService) {
+	target := campaignAuditTarget(campaignRepo)
+	handler := NewCampaignAdminHandler(campaignRepo, scheduler)
+
+	campaigns := router.Group("/api/v1/admin/campaigns")
+	campaigns.Use(middleware.JWTAuthMiddleware(jwtService))
+	campaigns.Use(middleware.RequirePermission(permissions, organization.PermissionConfigureSystem))
+	{
+		campaigns.GET("", handler.ListCampaigns)
+		campaigns.POST("", auditAdminWrite(audit, "campaign.open", createAudit(target)), handler.OpenCampaign)
+		campaigns.POST("/run", auditAdminWrite(audit, "campaign.scheduler_run", adminAuditTarget{Type: "campaign"}), handler.RunScheduler)
+		campaigns.GET("/:id", handler.GetCampaign)
+		campaigns.POST("/:id/close", auditAdminWrite(audit, "campaign.close", target), handler.CloseCampaign)
+		campaigns.PUT("/:id/anonymity", auditAdminWrite(audit, "campaign.anonymity.update", target), handler.UpdateCampaignAnonymity)
+	}
+}
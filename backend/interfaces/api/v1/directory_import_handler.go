@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// DirectoryImportHandler handles bulk imports of users and teams
+type DirectoryImportHandler struct {
+	importService *services.DirectoryImportService
+	userHandler   *UserAdminHandler
+	teamHandler   *TeamAdminHandler
+}
+
+// NewDirectoryImportHandler creates a new DirectoryImportHandler
+func NewDirectoryImportHandler(importService *services.DirectoryImportService, userRepo user.Repository, teamRepo team.Repository, orgRepo organization.Repository) *DirectoryImportHandler {
+	return &DirectoryImportHandler{
+		importService: importService,
+		userHandler:   NewUserAdminHandler(userRepo, teamRepo, nil),
+		teamHandler:   NewTeamAdminHandler(teamRepo, userRepo, orgRepo, nil),
+	}
+}
+
+// ValidateImport handles POST /api/v1/admin/import/validate
+// Dry run: reports per-row errors and what the import would create, without writing anything.
+func (h *DirectoryImportHandler) ValidateImport(c *gin.Context) {
+	var req dto.DirectoryImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request body", Message: err.Error()})
+		return
+	}
+
+	result, err := h.importService.Validate(c.Request.Context(), c.GetString("hierarchyLevel"), strings.NewReader(req.CSV))
+	if err != nil {
+		respondImportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toDirectoryImportResponse(result))
+}
+
+// ApplyImport handles POST /api/v1/admin/import
+// The file is applied in one transaction only if every row is valid; otherwise nothing
+// is written and the row errors are returned with 422.
+func (h *DirectoryImportHandler) ApplyImport(c *gin.Context) {
+	var req dto.DirectoryImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request body", Message: err.Error()})
+		return
+	}
+
+	result, err := h.importService.Import(c.Request.Context(), c.GetString("hierarchyLevel"), strings.NewReader(req.CSV))
+	if err != nil {
+		respondImportError(c, err)
+		return
+	}
+	if !result.Applied {
+		c.JSON(http.StatusUnprocessableEntity, toDirectoryImportResponse(result))
+		return
+	}
+
+	h.deriveSupervisorChains(c.Request.Context(), result.Plan)
+
+	adminID, _ := middleware.GetUserIDFromContext(c)
+	logger.Get().WithFields(map[string]interface{}{
+		"admin_id":      adminID,
+		"rows":          result.Rows,
+		"users_created": result.UsersCreated,
+		"users_updated": result.UsersUpdated,
+		"teams_created": result.TeamsCreated,
+	}).Info("directory import applied")
+
+	c.JSON(http.StatusOK, toDirectoryImportResponse(result))
+}
+
+// deriveSupervisorChains refreshes the chains of teams given a lead by the import, and of
+// teams whose chain runs through an updated user whose level or reporting line may have changed
+func (h *DirectoryImportHandler) deriveSupervisorChains(ctx context.Context, plan *services.ImportPlan) {
+	for _, t := range plan.Teams {
+		if t.LeadID != "" {
+			h.teamHandler.deriveSupervisorChainForTeam(ctx, t.ID, t.LeadID)
+		}
+	}
+	for _, u := range plan.Users {
+		if u.Exists {
+			h.userHandler.rederiveSupervisorChains(ctx, u.ID)
+		}
+	}
+}
+
+func respondImportError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrInvalidImport) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid import file", Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to import directory", Message: err.Error()})
+}
+
+func toDirectoryImportResponse(result *services.ImportResult) dto.DirectoryImportResponse {
+	errs := make([]dto.ImportRowErrorDTO, len(result.Errors))
+	for i, e := range result.Errors {
+		errs[i] = dto.ImportRowErrorDTO{Line: e.Line, Column: e.Column, Message: e.Message}
+	}
+	return dto.DirectoryImportResponse{
+		Valid:        len(result.Errors) == 0,
+		Applied:      result.Applied,
+		Rows:         result.Rows,
+		UsersCreated: result.UsersCreated,
+		UsersUpdated: result.UsersUpdated,
+		TeamsCreated: result.TeamsCreated,
+		Memberships:  result.Memberships,
+		Errors:       errs,
+	}
+}
@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupDirectoryImportRoutes configures bulk CSV import of users and teams
+// All routes require JWT authentication and the CanManageUsers and CanEditTeams permissions, and rows
+// may only create or change users at levels with no permission the caller lacks;
+// writes are recorded in the audit log unless audit is nil
+func SetupDirectoryImportRoutes(router *gin.Engine, importService *services.DirectoryImportService, userRepo user.Repository, teamRepo team.Repository, orgRepo organization.Repository, jwtService *services.JWTService, permissions *services.PermissionService, audit *services.AdminAuditService) {
+	handler := NewDirectoryImportHandler(importService, userRepo, teamRepo, orgRepo)
+
+	imports := router.Group("/api/v1/admin/import")
+	imports.Use(middleware.JWTAuthMiddleware(jwtService))
+	imports.Use(middleware.RequirePermission(permissions, organization.PermissionManageUsers, organization.PermissionEditTeams))
+	{
+		imports.POST("", auditAdminWrite(audit, "directory.import", adminAuditTarget{Type: "directory"}), handler.ApplyImport)
+		imports.POST("/validate", handler.ValidateImport)
+	}
+}
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/telemetry"
+	"github.com/gin-gonic/gin"
+)
+
+// exportContentTypes maps export formats to their media types
+var exportContentTypes = map[string]string{
+	services.ExportFormatCSV:  "text/csv; charset=utf-8",
+	services.ExportFormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ExportHandler handles health check result exports
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+// NewExportHandler creates a new ExportHandler
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{exportService: exportService}
+}
+
+// ExportTeamHealthChecks handles GET /api/v1/exports/teams/:teamId/health-checks
+func (h *ExportHandler) ExportTeamHealthChecks(c *gin.Context) {
+	h.export(c, services.ExportFilter{Scope: services.ExportScopeTeam, TeamID: c.Param("teamId")})
+}
+
+// ExportManagerHealthChecks handles GET /api/v1/exports/managers/:managerId/health-checks
+// Exports the teams the manager supervises.
+func (h *ExportHandler) ExportManagerHealthChecks(c *gin.Context) {
+	h.export(c, services.ExportFilter{Scope: services.ExportScopeManager, ManagerID: c.Param("managerId")})
+}
+
+// ExportOrgHealthChecks handles GET /api/v1/exports/health-checks
+// Exports every team.
+func (h *ExportHandler) ExportOrgHealthChecks(c *gin.Context) {
+	h.export(c, services.ExportFilter{Scope: services.ExportScopeOrg})
+}
+
+// export streams the scope's results. Optional query parameters: format (csv or xlsx, default csv),
+// assessmentPeriod and surveyType. One row is written per dimension response; anonymous sessions
+// carry no user ID or comment and are left out below the team's minimum respondent count.
+func (h *ExportHandler) export(c *gin.Context, filter services.ExportFilter) {
+	ctx := c.Request.Context()
+
+	filter.AssessmentPeriod = c.Query("assessmentPeriod")
+	filter.SurveyType = c.Query("surveyType")
+	format := c.DefaultQuery("format", services.ExportFormatCSV)
+
+	out := &exportResponseWriter{
+		c:           c,
+		contentType: exportContentTypes[format],
+		filename:    fmt.Sprintf("health-checks-%s-%s.%s", filter.Scope, time.Now().UTC().Format("20060102"), format),
+	}
+
+	err := h.exportService.Export(ctx, filter, format, out)
+	if err != nil {
+		if out.started {
+			// The response is already streaming; all we can do is cut it short
+			logger.Get().WithContext(ctx).WithError(err).WithField("scope", filter.Scope).Error("export failed while streaming")
+			c.Abort()
+			return
+		}
+		if errors.Is(err, services.ErrInvalidExport) {
+			dto.RespondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to export health checks", err.Error())
+		return
+	}
+
+	telemetry.RecordExport(ctx, filter.Scope, format)
+}
+
+// exportResponseWriter sets the download headers on the first write,
+// so an export that fails before streaming can still respond with a JSON error
+type exportResponseWriter struct {
+	c           *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, w.filename))
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
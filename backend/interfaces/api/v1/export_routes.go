@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupExportRoutes configures health check result exports
+// All routes require JWT authentication and the CanExportData permission; exporting every team
+// also requires CanViewAllTeams
+func SetupExportRoutes(router *gin.Engine, exportService *services.ExportService, jwtService *services.JWTService, permissions *services.PermissionService) {
+	handler := NewExportHandler(exportService)
+
+	exports := router.Group("/api/v1/exports")
+	exports.Use(middleware.JWTAuthMiddleware(jwtService))
+	exports.Use(middleware.RequirePermission(permissions, organization.PermissionExportData))
+	{
+		exports.GET("/health-checks", middleware.RequirePermission(permissions, organization.PermissionViewAllTeams), handler.ExportOrgHealthChecks)
+		exports.GET("/teams/:teamId/health-checks", middleware.TeamMembershipMiddleware(permissions, "teamId"), handler.ExportTeamHealthChecks)
+		exports.GET("/managers/:managerId/health-checks",
+			middleware.SameUserOrManagerMiddleware(permissions, "managerId"),
+			handler.ExportManagerHealthChecks)
+	}
+}
@@ -0,0 +1,163 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/agopalakrishnan/teams360/backend/application/commands"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/telemetry"
+)
+
+// draftParams returns the authenticated member, team and assessment period of a draft request,
+// responding with an error and returning false when they are missing or invalid
+func draftParams(c *gin.Context) (userID, teamID, period string, ok bool) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "User not authenticated"})
+		return "", "", "", false
+	}
+
+	teamID = c.Param("teamId")
+	period = c.Param("period")
+	if err := validateAssessmentPeriod(period); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   err.Error(),
+			Message: "Assessment period must be in a valid format: 'YYYY Mon', 'YYYY Q1-Q4', 'YYYY H1/H2', 'YYYY', or 'YYYY - 1st/2nd Half'",
+		})
+		return "", "", "", false
+	}
+
+	return userID, teamID, period, true
+}
+
+// SaveDraft handles PUT /api/v1/health-checks/drafts/:teamId/:period
+// Autosaves the member's partial individual survey. Drafts are left out of every report.
+func (h *HealthCheckHandler) SaveDraft(c *gin.Context) {
+	userID, teamID, period, ok := draftParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SaveHealthCheckDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request", Message: err.Error()})
+		return
+	}
+
+	cmd := commands.SaveHealthCheckDraftCommand{
+		TeamID:           teamID,
+		UserID:           userID,
+		AssessmentPeriod: period,
+		Responses:        make([]commands.HealthCheckResponseCommand, len(req.Responses)),
+	}
+	for i, resp := range req.Responses {
+		cmd.Responses[i] = commands.HealthCheckResponseCommand{
+			DimensionID: resp.DimensionID,
+			Score:       resp.Score,
+			Trend:       resp.Trend,
+			Comment:     resp.Comment,
+		}
+	}
+
+	session, err := h.draftHandler.Save(c.Request.Context(), cmd)
+	if err != nil {
+		if strings.Contains(err.Error(), "validation failed") {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid draft", Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to save draft",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertSessionToDTO(session))
+}
+
+// GetDraft handles GET /api/v1/health-checks/drafts/:teamId/:period
+// Returns the member's draft so the survey can be resumed on any device.
+func (h *HealthCheckHandler) GetDraft(c *gin.Context) {
+	userID, teamID, period, ok := draftParams(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.draftHandler.Find(c.Request.Context(), teamID, userID, period)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Draft not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch draft",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertSessionToDTO(session))
+}
+
+// SubmitDraft handles POST /api/v1/health-checks/drafts/:teamId/:period/submit
+// Finalizes the draft with the same validation as POST /api/v1/health-checks.
+func (h *HealthCheckHandler) SubmitDraft(c *gin.Context) {
+	ctx := c.Request.Context()
+	startTime := time.Now()
+
+	ctx, span := telemetry.StartHealthCheckSpan(ctx, "submit_draft")
+	defer span.End()
+
+	userID, teamID, period, ok := draftParams(c)
+	if !ok {
+		return
+	}
+
+	session, err := h.draftHandler.Submit(ctx, teamID, userID, period)
+	if err != nil {
+		telemetry.SetSpanError(span, err)
+		if strings.Contains(err.Error(), "draft not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Draft not found"})
+			return
+		}
+		logger.Get().WithContext(ctx).WithError(err).WithField("team_id", teamID).Warn("failed to submit health check draft")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Failed to submit health check",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	telemetry.SetSpanOK(span)
+	h.recordSubmission(ctx, session, startTime)
+
+	c.JSON(http.StatusCreated, convertSessionToDTO(session))
+}
+
+// DiscardDraft handles DELETE /api/v1/health-checks/drafts/:teamId/:period
+func (h *HealthCheckHandler) DiscardDraft(c *gin.Context) {
+	userID, teamID, period, ok := draftParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.draftHandler.Discard(c.Request.Context(), teamID, userID, period); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Draft not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to discard draft",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	dto.RespondMessage(c, http.StatusOK, "Draft discarded successfully")
+}
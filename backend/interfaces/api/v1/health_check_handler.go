@@ -16,6 +16,8 @@ import (
 	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
 	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/survey"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
 	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
 	"github.com/agopalakrishnan/teams360/backend/pkg/telemetry"
@@ -38,20 +40,36 @@ var (
 // HealthCheckHandler handles health check related endpoints
 type HealthCheckHandler struct {
 	submitHandler       *commands.SubmitHealthCheckHandler
+	draftHandler        *commands.HealthCheckDraftHandler
+	amendHandler        *commands.AmendHealthCheckHandler
 	dimensionsHandler   *queries.GetHealthDimensionsHandler
 	teamSessionsHandler *queries.GetTeamSessionsHandler
 	repository          healthcheck.Repository
+	orgRepo             organization.Repository
+	templateRepo        survey.Repository
+	teamRepo            team.Repository
+	permissions         *services.PermissionService
 	notificationService *services.NotificationService
+	webhooks            *services.WebhookService
 }
 
-// NewHealthCheckHandler creates a new handler
-func NewHealthCheckHandler(repository healthcheck.Repository, orgRepo organization.Repository, notificationService *services.NotificationService) *HealthCheckHandler {
+// NewHealthCheckHandler creates a new handler.
+// templateRepo may be nil, in which case every team answers all active dimensions.
+// webhooks may be nil, in which case no webhook events are published.
+func NewHealthCheckHandler(repository healthcheck.Repository, orgRepo organization.Repository, templateRepo survey.Repository, teamRepo team.Repository, permissions *services.PermissionService, notificationService *services.NotificationService, webhooks *services.WebhookService) *HealthCheckHandler {
 	return &HealthCheckHandler{
-		submitHandler:       commands.NewSubmitHealthCheckHandler(repository),
+		submitHandler:       commands.NewSubmitHealthCheckHandler(repository, templateRepo),
+		draftHandler:        commands.NewHealthCheckDraftHandler(repository, templateRepo),
+		amendHandler:        commands.NewAmendHealthCheckHandler(repository, templateRepo),
 		dimensionsHandler:   queries.NewGetHealthDimensionsHandler(orgRepo),
 		teamSessionsHandler: queries.NewGetTeamSessionsHandler(repository),
 		repository:          repository,
+		orgRepo:             orgRepo,
+		templateRepo:        templateRepo,
+		teamRepo:            teamRepo,
+		permissions:         permissions,
 		notificationService: notificationService,
+		webhooks:            webhooks,
 	}
 }
 
@@ -153,35 +171,45 @@ func (h *HealthCheckHandler) SubmitHealthCheck(c *gin.Context) {
 		return
 	}
 
+	telemetry.SetSpanOK(span)
+	h.recordSubmission(ctx, session, startTime)
+
+	// Convert to response DTO
+	response := convertSessionToDTO(session)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// recordSubmission records metrics for a completed survey, logs it, fires notifications and publishes a webhook event
+func (h *HealthCheckHandler) recordSubmission(ctx context.Context, session *healthcheck.HealthCheckSession, startTime time.Time) {
 	// Record successful submission metrics
-	telemetry.RecordSurveySubmission(ctx, req.TeamID, req.AssessmentPeriod, len(req.Responses), time.Since(startTime))
+	telemetry.RecordSurveySubmission(ctx, session.TeamID, session.AssessmentPeriod, len(session.Responses), time.Since(startTime))
 
 	// Record individual dimension scores and comments for analytics
 	commentsCount := 0
-	for _, resp := range req.Responses {
+	for _, resp := range session.Responses {
 		telemetry.RecordDimensionScore(ctx, resp.DimensionID, float64(resp.Score), resp.Trend)
 		telemetry.RecordHealthByDimension(ctx, resp.DimensionID, float64(resp.Score))
 		if resp.Comment != "" {
-			telemetry.RecordSurveyWithComments(ctx, req.TeamID, resp.DimensionID)
+			telemetry.RecordSurveyWithComments(ctx, session.TeamID, resp.DimensionID)
 			commentsCount++
 		}
 	}
 
 	// Record comment rate for this survey
-	if len(req.Responses) > 0 {
-		commentRate := float64(commentsCount) / float64(len(req.Responses))
-		telemetry.RecordSurveyCommentRate(ctx, req.TeamID, commentRate)
+	if len(session.Responses) > 0 {
+		commentRate := float64(commentsCount) / float64(len(session.Responses))
+		telemetry.RecordSurveyCommentRate(ctx, session.TeamID, commentRate)
 	}
 
-	telemetry.SetSpanOK(span)
-	log.WithFields(map[string]interface{}{
+	logger.Get().WithContext(ctx).WithFields(map[string]interface{}{
 		"session_id":        session.ID,
-		"team_id":           req.TeamID,
-		"assessment_period": req.AssessmentPeriod,
-		"dimension_count":   len(req.Responses),
+		"team_id":           session.TeamID,
+		"assessment_period": session.AssessmentPeriod,
+		"dimension_count":   len(session.Responses),
 	}).Info("health check submitted successfully")
 
-	// Fire async email notification (never blocks the response)
+	// Fire async email and chat notifications (never blocks the response)
 	if h.notificationService != nil {
 		go func() {
 			bgCtx := context.Background()
@@ -191,13 +219,42 @@ func (h *HealthCheckHandler) SubmitHealthCheck(c *gin.Context) {
 			if session.SurveyType == "post_workshop" {
 				h.notificationService.SendPostWorkshopEmails(bgCtx, session)
 			}
+			h.notificationService.SendChatNotification(bgCtx, session)
 		}()
 	}
 
-	// Convert to response DTO
-	response := convertSessionToDTO(session)
+	h.publishSubmission(ctx, session)
+}
 
-	c.JSON(http.StatusCreated, response)
+// publishSubmission queues the submitted survey for webhook endpoints.
+// Anonymous sessions are sent without the respondent or their answers, as on the team views.
+func (h *HealthCheckHandler) publishSubmission(ctx context.Context, session *healthcheck.HealthCheckSession) {
+	if h.webhooks == nil {
+		return
+	}
+
+	event := services.EventHealthCheckSubmitted
+	if session.SurveyType == "post_workshop" {
+		event = services.EventPostWorkshopSubmitted
+	}
+
+	data := map[string]interface{}{
+		"sessionId":        session.ID,
+		"teamId":           session.TeamID,
+		"date":             session.Date,
+		"assessmentPeriod": session.AssessmentPeriod,
+		"surveyType":       session.SurveyType,
+		"anonymous":        session.Anonymous,
+		"dimensionCount":   len(session.Responses),
+	}
+	if !session.Anonymous {
+		data["userId"] = session.UserID
+		data["responses"] = session.Responses
+	}
+
+	if err := h.webhooks.Publish(ctx, event, data); err != nil {
+		logger.Get().WithContext(ctx).WithError(err).Warn("failed to publish health check webhook event")
+	}
 }
 
 // GetHealthDimensions handles GET /api/v1/health-dimensions
@@ -248,6 +305,91 @@ func (h *HealthCheckHandler) GetHealthDimensions(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetTeamSurvey handles GET /api/v1/teams/:teamId/survey
+// Returns the dimensions the team answers: its survey template's current version,
+// or all active dimensions when the team has no template.
+// Optional ?assessmentPeriod= reports whether that period's campaign is anonymous.
+func (h *HealthCheckHandler) GetTeamSurvey(c *gin.Context) {
+	ctx := c.Request.Context()
+	teamID := c.Param("teamId")
+
+	dimensions, err := h.orgRepo.FindDimensions(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch dimensions",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	policy, err := h.repository.FindAnonymityPolicy(ctx, teamID, c.Query("assessmentPeriod"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch anonymity policy",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response := dto.TeamSurveyResponse{
+		TeamID:     teamID,
+		Anonymous:  policy.Anonymous,
+		Dimensions: []dto.HealthDimensionResponse{},
+	}
+
+	var version *survey.Version
+	if h.templateRepo != nil {
+		version, err = h.templateRepo.FindCurrentForTeam(ctx, teamID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error:   "Failed to fetch survey template",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	byID := make(map[string]*organization.HealthDimension, len(dimensions))
+	for _, dim := range dimensions {
+		byID[dim.ID] = dim
+	}
+
+	var selected []*organization.HealthDimension
+	if version != nil {
+		tmpl, err := h.templateRepo.FindByID(ctx, version.TemplateID)
+		if err == nil {
+			response.TemplateName = tmpl.Name
+		}
+		response.TemplateID = version.TemplateID
+		response.TemplateVersion = version.Version
+		for _, id := range version.Dimensions {
+			if dim, ok := byID[id]; ok {
+				selected = append(selected, dim)
+			}
+		}
+	} else {
+		for _, dim := range dimensions {
+			if dim.IsActive {
+				selected = append(selected, dim)
+			}
+		}
+	}
+
+	for _, dim := range selected {
+		response.Dimensions = append(response.Dimensions, dto.HealthDimensionResponse{
+			ID:              dim.ID,
+			Name:            dim.Name,
+			Description:     dim.Description,
+			GoodDescription: dim.GoodDescription,
+			BadDescription:  dim.BadDescription,
+			IsActive:        dim.IsActive,
+			Weight:          dim.Weight,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetHealthCheckByID handles GET /api/v1/health-checks/:id
 func (h *HealthCheckHandler) GetHealthCheckByID(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -262,6 +404,13 @@ func (h *HealthCheckHandler) GetHealthCheckByID(c *gin.Context) {
 	span.SetAttributes(attribute.String("healthcheck.id", id))
 
 	session, err := h.repository.FindByID(ctx, id)
+	if err == nil && !session.Completed {
+		// Drafts are only available to their owner through the draft endpoints
+		err = fmt.Errorf("session not found: %s", id)
+	}
+	if err == nil && session.Anonymous {
+		session, err = h.findVisibleAnonymousSession(ctx, session)
+	}
 	if err != nil {
 		telemetry.SetSpanError(span, err)
 		log.WithField("session_id", id).Debug("health check session not found")
@@ -311,6 +460,16 @@ func (h *HealthCheckHandler) GetTeamHealthChecks(c *gin.Context) {
 		return
 	}
 
+	sessions, suppressed, err := redactTeamSessions(ctx, h.repository, teamID, sessions)
+	if err != nil {
+		telemetry.SetSpanError(span, err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch sessions",
+			Message: err.Error(),
+		})
+		return
+	}
+
 	// Record team health query metrics
 	telemetry.RecordTeamHealthQuery(ctx, teamID, time.Since(startTime))
 
@@ -319,8 +478,9 @@ func (h *HealthCheckHandler) GetTeamHealthChecks(c *gin.Context) {
 
 	// Convert to response DTO
 	response := dto.HealthCheckSessionsResponse{
-		Sessions: make([]dto.HealthCheckSessionResponse, len(sessions)),
-		Total:    len(sessions),
+		Sessions:   make([]dto.HealthCheckSessionResponse, len(sessions)),
+		Total:      len(sessions),
+		Suppressed: suppressed,
 	}
 
 	for i, session := range sessions {
@@ -394,6 +554,9 @@ func convertSessionToDTO(session *healthcheck.HealthCheckSession) dto.HealthChec
 		SurveyType:       session.SurveyType,
 		Responses:        make([]dto.HealthCheckResponseResponse, len(session.Responses)),
 		Completed:        session.Completed,
+		TemplateID:       session.TemplateID,
+		TemplateVersion:  session.TemplateVersion,
+		Anonymous:        session.Anonymous,
 	}
 
 	for i, resp := range session.Responses {
@@ -408,6 +571,44 @@ func convertSessionToDTO(session *healthcheck.HealthCheckSession) dto.HealthChec
 	return response
 }
 
+// redactTeamSessions applies the team's minimum respondent count to anonymous sessions
+// and strips their comments. Returns the visible sessions and how many were withheld.
+func redactTeamSessions(ctx context.Context, repo healthcheck.Repository, teamID string, sessions []*healthcheck.HealthCheckSession) ([]*healthcheck.HealthCheckSession, int, error) {
+	policy, err := repo.FindAnonymityPolicy(ctx, teamID, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	visible, suppressed := healthcheck.RedactAnonymous(sessions, policy.MinRespondents)
+	return visible, suppressed, nil
+}
+
+// findVisibleAnonymousSession returns the redacted form of an anonymous session,
+// or a not found error while its period is below the team's minimum respondent count
+func (h *HealthCheckHandler) findVisibleAnonymousSession(ctx context.Context, session *healthcheck.HealthCheckSession) (*healthcheck.HealthCheckSession, error) {
+	teamSessions, err := h.repository.FindByTeamID(ctx, session.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	periodSessions := []*healthcheck.HealthCheckSession{}
+	for _, s := range teamSessions {
+		if s.AssessmentPeriod == session.AssessmentPeriod {
+			periodSessions = append(periodSessions, s)
+		}
+	}
+
+	visible, _, err := redactTeamSessions(ctx, h.repository, session.TeamID, periodSessions)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range visible {
+		if s.ID == session.ID {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("session not found: %s", session.ID)
+}
+
 // validateAssessmentPeriod validates the assessment period format and ensures it's not in the future.
 // Valid formats:
 //   - Legacy:      "YYYY - 1st Half" or "YYYY - 2nd Half"
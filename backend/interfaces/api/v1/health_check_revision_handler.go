@@ -0,0 +1,227 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/agopalakrishnan/teams360/backend/application/commands"
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/telemetry"
+)
+
+// respondAmendError maps errors from amending or withdrawing a session to a response
+func respondAmendError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, commands.ErrNotSessionOwner):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "Access denied", Message: err.Error()})
+	case errors.Is(err, commands.ErrPeriodClosed):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{Error: failure, Message: err.Error()})
+	case strings.Contains(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid health check", Message: err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Session not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: failure, Message: err.Error()})
+	}
+}
+
+// AmendHealthCheck handles PUT /api/v1/health-checks/:id
+// Replaces the responses of the caller's submission while its assessment period is open.
+func (h *HealthCheckHandler) AmendHealthCheck(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	ctx, span := telemetry.StartHealthCheckSpan(ctx, "amend")
+	defer span.End()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	var req dto.AmendHealthCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		telemetry.SetSpanError(span, err)
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request", Message: err.Error()})
+		return
+	}
+
+	cmd := commands.AmendHealthCheckCommand{
+		SessionID: c.Param("id"),
+		UserID:    userID,
+		Responses: make([]commands.HealthCheckResponseCommand, len(req.Responses)),
+	}
+	for i, resp := range req.Responses {
+		cmd.Responses[i] = commands.HealthCheckResponseCommand{
+			DimensionID: resp.DimensionID,
+			Score:       resp.Score,
+			Trend:       resp.Trend,
+			Comment:     resp.Comment,
+		}
+	}
+
+	session, err := h.amendHandler.Amend(ctx, cmd)
+	if err != nil {
+		telemetry.SetSpanError(span, err)
+		logger.Get().WithContext(ctx).WithError(err).WithField("session_id", cmd.SessionID).Warn("failed to amend health check")
+		respondAmendError(c, err, "Failed to amend health check")
+		return
+	}
+
+	telemetry.SetSpanOK(span)
+
+	c.JSON(http.StatusOK, convertSessionToDTO(session))
+}
+
+// WithdrawHealthCheck handles DELETE /api/v1/health-checks/:id
+// Removes the caller's submission while its assessment period is open. The revision history is kept.
+func (h *HealthCheckHandler) WithdrawHealthCheck(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	ctx, span := telemetry.StartHealthCheckSpan(ctx, "withdraw")
+	defer span.End()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	id := c.Param("id")
+	if err := h.amendHandler.Withdraw(ctx, id, userID); err != nil {
+		telemetry.SetSpanError(span, err)
+		logger.Get().WithContext(ctx).WithError(err).WithField("session_id", id).Warn("failed to withdraw health check")
+		respondAmendError(c, err, "Failed to withdraw health check")
+		return
+	}
+
+	telemetry.SetSpanOK(span)
+	dto.RespondMessage(c, http.StatusOK, "Health check withdrawn successfully")
+}
+
+// GetHealthCheckRevisions handles GET /api/v1/health-checks/:id/revisions
+// Available to the session's owner and, at levels with the CanViewAllTeams permission, to the
+// supervisors of the session's team. Anonymous sessions follow the same minimum respondent count
+// as GET /api/v1/health-checks/:id and their revisions carry no responses.
+func (h *HealthCheckHandler) GetHealthCheckRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "User not authenticated"})
+		return
+	}
+
+	id := c.Param("id")
+	session, err := h.repository.FindByID(ctx, id)
+	if err == nil && !session.Completed {
+		err = fmt.Errorf("session not found: %s", id)
+	}
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to fetch session", Message: err.Error()})
+		return
+	}
+	if err != nil {
+		// Withdrawn sessions are gone, but their supervisors can still see that they were withdrawn
+		session = nil
+	}
+
+	revisions, err := h.amendHandler.Revisions(ctx, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch revisions",
+			Message: err.Error(),
+		})
+		return
+	}
+	if session == nil && len(revisions) == 0 {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Session not found"})
+		return
+	}
+
+	// The owner always sees their own history
+	if session == nil || !h.amendHandler.IsOwner(session, userID) {
+		teamID := revisions[0].TeamID
+		if session != nil {
+			teamID = session.TeamID
+		}
+		supervises, err := h.supervisesTeam(ctx, c.GetString("hierarchyLevel"), userID, teamID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to check access", Message: err.Error()})
+			return
+		}
+		switch {
+		case !supervises && session == nil:
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Session not found"})
+			return
+		case !supervises:
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "Access denied: cannot view another member's health check"})
+			return
+		case session != nil && session.Anonymous:
+			if _, err := h.findVisibleAnonymousSession(ctx, session); err != nil {
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Session not found"})
+				return
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.HealthCheckRevisionsResponse{
+		SessionID: id,
+		Revisions: convertRevisionsToDTO(revisions),
+	})
+}
+
+// supervisesTeam reports whether the user is in the team's supervisor chain at a level with
+// the CanViewAllTeams permission
+func (h *HealthCheckHandler) supervisesTeam(ctx context.Context, levelID, userID, teamID string) (bool, error) {
+	err := h.permissions.Authorize(ctx, levelID, organization.PermissionViewAllTeams)
+	if errors.Is(err, services.ErrPermissionDenied) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	chain, err := h.teamRepo.FindSupervisorChain(ctx, teamID)
+	if err != nil {
+		return false, err
+	}
+	for _, link := range chain {
+		if link.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// convertRevisionsToDTO converts revisions to their response form
+func convertRevisionsToDTO(revisions []*healthcheck.Revision) []dto.HealthCheckRevisionResponse {
+	result := make([]dto.HealthCheckRevisionResponse, len(revisions))
+	for i, rev := range revisions {
+		result[i] = dto.HealthCheckRevisionResponse{
+			Revision:  rev.Revision,
+			Action:    rev.Action,
+			CreatedAt: rev.CreatedAt.Format(time.RFC3339),
+		}
+		for _, resp := range rev.Responses {
+			result[i].Responses = append(result[i].Responses, dto.HealthCheckResponseResponse{
+				DimensionID: resp.DimensionID,
+				Score:       resp.Score,
+				Trend:       resp.Trend,
+				Comment:     resp.Comment,
+			})
+		}
+	}
+	return result
+}
@@ -6,13 +6,15 @@ import (
 	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
 	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/survey"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
 )
 
 // SetupHealthCheckRoutes registers health check routes with repository injection
 // All routes require JWT authentication
-func SetupHealthCheckRoutes(router *gin.Engine, healthCheckRepo healthcheck.Repository, orgRepo organization.Repository, jwtService *services.JWTService, notificationService *services.NotificationService) {
-	handler := NewHealthCheckHandler(healthCheckRepo, orgRepo, notificationService)
+func SetupHealthCheckRoutes(router *gin.Engine, healthCheckRepo healthcheck.Repository, orgRepo organization.Repository, templateRepo survey.Repository, teamRepo team.Repository, jwtService *services.JWTService, permissions *services.PermissionService, notificationService *services.NotificationService, webhooks *services.WebhookService) {
+	handler := NewHealthCheckHandler(healthCheckRepo, orgRepo, templateRepo, teamRepo, permissions, notificationService, webhooks)
 
 	// Health check routes - all require authentication
 	healthChecks := router.Group("/api/v1")
@@ -21,13 +23,31 @@ func SetupHealthCheckRoutes(router *gin.Engine, healthCheckRepo healthcheck.Repo
 		healthChecks.POST("/health-checks", handler.SubmitHealthCheck)
 		healthChecks.GET("/health-dimensions", handler.GetHealthDimensions)
 		healthChecks.GET("/health-checks/:id", handler.GetHealthCheckByID)
+		// Owner-only changes while the assessment period is open
+		healthChecks.PUT("/health-checks/:id", handler.AmendHealthCheck)
+		healthChecks.DELETE("/health-checks/:id", handler.WithdrawHealthCheck)
+		healthChecks.GET("/health-checks/:id/revisions", handler.GetHealthCheckRevisions)
 		// Using /health-checks/team/:id to avoid conflict with /teams/:id
 		healthChecks.GET("/health-checks/team/:id", handler.GetTeamHealthChecks)
 
 		// Team submission status for post-workshop surveys
 		healthChecks.GET("/teams/:teamId/submission-status", handler.GetTeamSubmissionStatus)
 
+		// Dimensions the team answers (survey template or all active dimensions); limited to its members
+		healthChecks.GET("/teams/:teamId/survey", middleware.TeamMembershipMiddleware(permissions, "teamId"), handler.GetTeamSurvey)
+
 		// Assessment periods (dynamic dropdown data)
 		healthChecks.GET("/assessment-periods", handler.GetAssessmentPeriods)
 	}
+
+	// Survey drafts belong to the authenticated member and are limited to their teams
+	drafts := router.Group("/api/v1/health-checks/drafts/:teamId/:period")
+	drafts.Use(middleware.JWTAuthMiddleware(jwtService))
+	drafts.Use(middleware.TeamMembershipMiddleware(permissions, "teamId"))
+	{
+		drafts.GET("", handler.GetDraft)
+		drafts.PUT("", handler.SaveDraft)
+		drafts.DELETE("", handler.DiscardDraft)
+		drafts.POST("/submit", handler.SubmitDraft)
+	}
 }
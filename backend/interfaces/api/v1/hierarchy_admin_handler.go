@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/domain/organization"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
 	"github.com/gin-gonic/gin"
@@ -11,12 +12,13 @@ import (
 
 // HierarchyAdminHandler handles hierarchy-level-related admin HTTP requests
 type HierarchyAdminHandler struct {
-	orgRepo organization.Repository
+	orgRepo     organization.Repository
+	permissions *services.PermissionService // cached level permissions, invalidated on edits
 }
 
 // NewHierarchyAdminHandler creates a new HierarchyAdminHandler
-func NewHierarchyAdminHandler(orgRepo organization.Repository) *HierarchyAdminHandler {
-	return &HierarchyAdminHandler{orgRepo: orgRepo}
+func NewHierarchyAdminHandler(orgRepo organization.Repository, permissions *services.PermissionService) *HierarchyAdminHandler {
+	return &HierarchyAdminHandler{orgRepo: orgRepo, permissions: permissions}
 }
 
 // ListHierarchyLevels handles GET /api/v1/admin/hierarchy-levels
@@ -34,18 +36,13 @@ func (h *HierarchyAdminHandler) ListHierarchyLevels(c *gin.Context) {
 	levels := make([]dto.HierarchyLevelDTO, len(hierarchyLevels))
 	for i, level := range hierarchyLevels {
 		levels[i] = dto.HierarchyLevelDTO{
-			ID:       level.ID,
-			Name:     level.Name,
-			Position: level.Position,
-			Permissions: dto.HierarchyPermissionsDTO{
-				CanViewAllTeams:  level.Permissions.CanViewAllTeams,
-				CanEditTeams:     level.Permissions.CanEditTeams,
-				CanManageUsers:   level.Permissions.CanManageUsers,
-				CanTakeSurvey:    level.Permissions.CanTakeSurvey,
-				CanViewAnalytics: level.Permissions.CanViewAnalytics,
-			},
-			CreatedAt: level.CreatedAt,
-			UpdatedAt: level.UpdatedAt,
+			ID:          level.ID,
+			Name:        level.Name,
+			Position:    level.Position,
+			Permissions: toHierarchyPermissionsDTO(level.Permissions),
+			RequireMFA:  level.RequireMFA,
+			CreatedAt:   level.CreatedAt,
+			UpdatedAt:   level.UpdatedAt,
 		}
 	}
 
@@ -76,16 +73,11 @@ func (h *HierarchyAdminHandler) CreateHierarchyLevel(c *gin.Context) {
 
 	// Create hierarchy level domain model
 	level := &organization.HierarchyLevel{
-		ID:       levelID,
-		Name:     req.Name,
-		Position: newPosition,
-		Permissions: organization.Permissions{
-			CanViewAllTeams:  req.Permissions.CanViewAllTeams,
-			CanEditTeams:     req.Permissions.CanEditTeams,
-			CanManageUsers:   req.Permissions.CanManageUsers,
-			CanTakeSurvey:    req.Permissions.CanTakeSurvey,
-			CanViewAnalytics: req.Permissions.CanViewAnalytics,
-		},
+		ID:          levelID,
+		Name:        req.Name,
+		Position:    newPosition,
+		Permissions: fromHierarchyPermissionsDTO(req.Permissions),
+		RequireMFA:  req.RequireMFA,
 	}
 
 	// Save using repository
@@ -96,21 +88,17 @@ func (h *HierarchyAdminHandler) CreateHierarchyLevel(c *gin.Context) {
 		})
 		return
 	}
+	h.permissions.Invalidate(level.ID)
 
 	// Convert to DTO and return
 	responseDTO := dto.HierarchyLevelDTO{
-		ID:       level.ID,
-		Name:     level.Name,
-		Position: level.Position,
-		Permissions: dto.HierarchyPermissionsDTO{
-			CanViewAllTeams:  level.Permissions.CanViewAllTeams,
-			CanEditTeams:     level.Permissions.CanEditTeams,
-			CanManageUsers:   level.Permissions.CanManageUsers,
-			CanTakeSurvey:    level.Permissions.CanTakeSurvey,
-			CanViewAnalytics: level.Permissions.CanViewAnalytics,
-		},
-		CreatedAt: level.CreatedAt,
-		UpdatedAt: level.UpdatedAt,
+		ID:          level.ID,
+		Name:        level.Name,
+		Position:    level.Position,
+		Permissions: toHierarchyPermissionsDTO(level.Permissions),
+		RequireMFA:  level.RequireMFA,
+		CreatedAt:   level.CreatedAt,
+		UpdatedAt:   level.UpdatedAt,
 	}
 
 	c.JSON(http.StatusCreated, responseDTO)
@@ -138,11 +126,18 @@ func (h *HierarchyAdminHandler) UpdateHierarchyLevel(c *gin.Context) {
 		existingLevel.Name = req.Name
 	}
 	if req.Permissions != nil {
-		existingLevel.Permissions.CanViewAllTeams = req.Permissions.CanViewAllTeams
-		existingLevel.Permissions.CanEditTeams = req.Permissions.CanEditTeams
-		existingLevel.Permissions.CanManageUsers = req.Permissions.CanManageUsers
-		existingLevel.Permissions.CanTakeSurvey = req.Permissions.CanTakeSurvey
-		existingLevel.Permissions.CanViewAnalytics = req.Permissions.CanViewAnalytics
+		// Admins cannot lock themselves out of the admin settings
+		if id == c.GetString("hierarchyLevel") && !req.Permissions.CanConfigureSystem {
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:   "Cannot remove permission",
+				Message: "You cannot remove Configure System from your own hierarchy level.",
+			})
+			return
+		}
+		existingLevel.Permissions = fromHierarchyPermissionsDTO(*req.Permissions)
+	}
+	if req.RequireMFA != nil {
+		existingLevel.RequireMFA = *req.RequireMFA
 	}
 
 	// Update using repository
@@ -153,21 +148,17 @@ func (h *HierarchyAdminHandler) UpdateHierarchyLevel(c *gin.Context) {
 		})
 		return
 	}
+	h.permissions.Invalidate(existingLevel.ID)
 
 	// Convert to DTO and return
 	responseDTO := dto.HierarchyLevelDTO{
-		ID:       existingLevel.ID,
-		Name:     existingLevel.Name,
-		Position: existingLevel.Position,
-		Permissions: dto.HierarchyPermissionsDTO{
-			CanViewAllTeams:  existingLevel.Permissions.CanViewAllTeams,
-			CanEditTeams:     existingLevel.Permissions.CanEditTeams,
-			CanManageUsers:   existingLevel.Permissions.CanManageUsers,
-			CanTakeSurvey:    existingLevel.Permissions.CanTakeSurvey,
-			CanViewAnalytics: existingLevel.Permissions.CanViewAnalytics,
-		},
-		CreatedAt: existingLevel.CreatedAt,
-		UpdatedAt: existingLevel.UpdatedAt,
+		ID:          existingLevel.ID,
+		Name:        existingLevel.Name,
+		Position:    existingLevel.Position,
+		Permissions: toHierarchyPermissionsDTO(existingLevel.Permissions),
+		RequireMFA:  existingLevel.RequireMFA,
+		CreatedAt:   existingLevel.CreatedAt,
+		UpdatedAt:   existingLevel.UpdatedAt,
 	}
 
 	c.JSON(http.StatusOK, responseDTO)
@@ -256,10 +247,39 @@ func (h *HierarchyAdminHandler) DeleteHierarchyLevel(c *gin.Context) {
 		})
 		return
 	}
+	h.permissions.Invalidate(id)
 
 	dto.RespondMessage(c, http.StatusOK, "Hierarchy level deleted successfully")
 }
 
+// toHierarchyPermissionsDTO converts a level's permissions to their DTO
+func toHierarchyPermissionsDTO(permissions organization.Permissions) dto.HierarchyPermissionsDTO {
+	return dto.HierarchyPermissionsDTO{
+		CanViewAllTeams:    permissions.CanViewAllTeams,
+		CanEditTeams:       permissions.CanEditTeams,
+		CanManageUsers:     permissions.CanManageUsers,
+		CanTakeSurvey:      permissions.CanTakeSurvey,
+		CanViewAnalytics:   permissions.CanViewAnalytics,
+		CanConfigureSystem: permissions.CanConfigureSystem,
+		CanViewReports:     permissions.CanViewReports,
+		CanExportData:      permissions.CanExportData,
+	}
+}
+
+// fromHierarchyPermissionsDTO converts requested permissions to the domain model
+func fromHierarchyPermissionsDTO(permissions dto.HierarchyPermissionsDTO) organization.Permissions {
+	return organization.Permissions{
+		CanViewAllTeams:    permissions.CanViewAllTeams,
+		CanEditTeams:       permissions.CanEditTeams,
+		CanManageUsers:     permissions.CanManageUsers,
+		CanTakeSurvey:      permissions.CanTakeSurvey,
+		CanViewAnalytics:   permissions.CanViewAnalytics,
+		CanConfigureSystem: permissions.CanConfigureSystem,
+		CanViewReports:     permissions.CanViewReports,
+		CanExportData:      permissions.CanExportData,
+	}
+}
+
 // generateIDFromName creates a URL-safe ID from a name
 // e.g., "Test Level 123" -> "test-level-123"
 func generateIDFromName(name string) string {
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// LockoutAdminHandler handles admin account lockout HTTP requests
+type LockoutAdminHandler struct {
+	userRepo user.Repository
+	lockout  *services.AccountLockoutService // nil when account lockout is disabled
+}
+
+// NewLockoutAdminHandler creates a new LockoutAdminHandler
+func NewLockoutAdminHandler(userRepo user.Repository, lockout *services.AccountLockoutService) *LockoutAdminHandler {
+	return &LockoutAdminHandler{userRepo: userRepo, lockout: lockout}
+}
+
+// UnlockUser handles POST /api/v1/admin/users/:id/unlock
+// Clears the user's failed logins, lifting a lockout or delay before it expires.
+func (h *LockoutAdminHandler) UnlockUser(c *gin.Context) {
+	id := c.Param("id")
+
+	if h.lockout == nil {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "Account lockout is not enabled"})
+		return
+	}
+
+	usr, err := h.userRepo.FindByID(c.Request.Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to unlock user",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	unlocked, err := h.lockout.Unlock(c.Request.Context(), usr)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to unlock user",
+			Message: err.Error(),
+		})
+		return
+	}
+	if !unlocked {
+		dto.RespondMessage(c, http.StatusOK, "User has no failed login attempts")
+		return
+	}
+
+	adminID, _ := middleware.GetUserIDFromContext(c)
+	logger.Get().Security("account_unlocked").
+		UserID(id).
+		IP(c.ClientIP()).
+		RequestID(c.GetString("request_id")).
+		Details("Failed logins cleared by admin " + adminID).
+		Log()
+
+	dto.RespondMessage(c, http.StatusOK, "User unlocked")
+}
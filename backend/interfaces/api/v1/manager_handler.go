@@ -28,6 +28,7 @@ func NewManagerHandler(healthCheckRepo healthcheck.Repository, trendsService *tr
 }
 
 // GetManagerTeamsHealth handles GET /api/v1/managers/:managerId/teams/health
+// Optional ?scoring=unweighted ignores dimension weights in overallHealth
 func (h *ManagerHandler) GetManagerTeamsHealth(c *gin.Context) {
 	ctx := c.Request.Context()
 	managerID := c.Param("managerId")
@@ -40,11 +41,16 @@ func (h *ManagerHandler) GetManagerTeamsHealth(c *gin.Context) {
 
 	assessmentPeriod := c.Query("assessmentPeriod") // Optional filter
 
+	scoring, ok := scoringFromQuery(c)
+	if !ok {
+		return
+	}
+
 	// Record manager dashboard view
 	telemetry.RecordManagerDashboardView(ctx, managerID, "teams_health")
 
 	// Use repository to fetch aggregated team health data
-	teamSummaries, err := h.healthCheckRepo.FindTeamHealthByManager(ctx, managerID, assessmentPeriod)
+	teamSummaries, err := h.healthCheckRepo.FindTeamHealthByManager(ctx, managerID, assessmentPeriod, scoring)
 	if err != nil {
 		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Database query failed", err.Error())
 		return
@@ -126,6 +132,7 @@ func (h *ManagerHandler) GetManagerAggregatedRadar(c *gin.Context) {
 
 // GetManagerTrends handles GET /api/v1/managers/:managerId/dashboard/trends
 // Returns trend data across assessment periods for all supervised teams
+// Optional ?scoring=unweighted ignores dimension weights in the overall series
 func (h *ManagerHandler) GetManagerTrends(c *gin.Context) {
 	ctx := c.Request.Context()
 	managerID := c.Param("managerId")
@@ -135,11 +142,16 @@ func (h *ManagerHandler) GetManagerTrends(c *gin.Context) {
 		return
 	}
 
+	scoring, ok := scoringFromQuery(c)
+	if !ok {
+		return
+	}
+
 	// Record manager dashboard view for trends
 	telemetry.RecordManagerDashboardView(ctx, managerID, "trends")
 	telemetry.RecordTrendReportView(ctx, managerID, "manager")
 
-	result, err := h.trendsService.GetTrendsForManager(ctx, managerID)
+	result, err := h.trendsService.GetTrendsForManager(ctx, managerID, scoring)
 	if err != nil {
 		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to fetch trend data", err.Error())
 		return
@@ -154,6 +166,7 @@ func (h *ManagerHandler) GetManagerTrends(c *gin.Context) {
 	response := dto.ManagerTrendsResponse{
 		ManagerID:  managerID,
 		Periods:    result.Periods,
+		Overall:    result.Overall,
 		Dimensions: dimensions,
 	}
 
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// MFAAdminHandler handles admin MFA management HTTP requests
+type MFAAdminHandler struct {
+	userRepo user.Repository
+	mfa      *services.MFAService // nil when MFA is disabled
+}
+
+// NewMFAAdminHandler creates a new MFAAdminHandler
+func NewMFAAdminHandler(userRepo user.Repository, mfa *services.MFAService) *MFAAdminHandler {
+	return &MFAAdminHandler{userRepo: userRepo, mfa: mfa}
+}
+
+// ResetUserMFA handles POST /api/v1/admin/users/:id/reset-mfa
+// Removes the user's authenticator and recovery codes, for users who lost both. Users whose
+// level requires MFA enroll again at their next login.
+func (h *MFAAdminHandler) ResetUserMFA(c *gin.Context) {
+	id := c.Param("id")
+
+	if h.mfa == nil {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "MFA is not enabled"})
+		return
+	}
+
+	if _, err := h.userRepo.FindByID(c.Request.Context(), id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to reset MFA",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	reset, err := h.mfa.Reset(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to reset MFA",
+			Message: err.Error(),
+		})
+		return
+	}
+	if !reset {
+		dto.RespondMessage(c, http.StatusOK, "User has no MFA")
+		return
+	}
+
+	adminID, _ := middleware.GetUserIDFromContext(c)
+	logger.Get().Security("mfa_reset").
+		UserID(id).
+		IP(c.ClientIP()).
+		RequestID(c.GetString("request_id")).
+		Details("MFA reset by admin " + adminID).
+		Log()
+
+	dto.RespondMessage(c, http.StatusOK, "MFA reset")
+}
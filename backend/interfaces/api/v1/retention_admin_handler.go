@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionAdminHandler handles data retention admin HTTP requests
+type RetentionAdminHandler struct {
+	retentionService *services.RetentionService
+}
+
+// NewRetentionAdminHandler creates a new RetentionAdminHandler
+func NewRetentionAdminHandler(retentionService *services.RetentionService) *RetentionAdminHandler {
+	return &RetentionAdminHandler{retentionService: retentionService}
+}
+
+// PreviewRetention handles GET /api/v1/admin/retention/preview
+// Dry run: reports per team/period what the next run would remove, and what legal holds protect.
+func (h *RetentionAdminHandler) PreviewRetention(c *gin.Context) {
+	preview, err := h.retentionService.Preview(c.Request.Context(), time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to preview retention",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	eligible := toRetentionCountDTOs(preview.Eligible)
+	c.JSON(http.StatusOK, dto.RetentionPreviewResponse{
+		CutoffDate:    preview.Cutoff.Format("2006-01-02"),
+		Action:        retentionActionName(preview.Archive),
+		Eligible:      eligible,
+		Held:          toRetentionCountDTOs(preview.Held),
+		TotalSessions: services.TotalRetentionSessions(preview.Eligible),
+	})
+}
+
+// RunRetention handles POST /api/v1/admin/retention/run
+func (h *RetentionAdminHandler) RunRetention(c *gin.Context) {
+	adminID, _ := middleware.GetUserIDFromContext(c)
+
+	result, err := h.retentionService.RunAt(c.Request.Context(), time.Now(), adminID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to run retention",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	total := services.TotalRetentionSessions(result.Removed)
+	logger.Get().WithFields(map[string]interface{}{
+		"run_id":   result.RunID,
+		"admin_id": adminID,
+		"sessions": total,
+	}).Info("retention: manual run completed")
+
+	c.JSON(http.StatusOK, dto.RetentionRunResponse{
+		RunID:         result.RunID,
+		CutoffDate:    result.Cutoff.Format("2006-01-02"),
+		Action:        retentionActionName(result.Archive),
+		Removed:       toRetentionCountDTOs(result.Removed),
+		TotalSessions: total,
+	})
+}
+
+// GetRetentionAuditLog handles GET /api/v1/admin/retention/audit-log
+// Optional query param: limit (default 100, max 1000)
+func (h *RetentionAdminHandler) GetRetentionAuditLog(c *gin.Context) {
+	limit := 100
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > 1000 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "limit must be between 1 and 1000"})
+			return
+		}
+		limit = parsed
+	}
+
+	entries, err := h.retentionService.AuditLog(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch retention audit log",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	dtos := make([]dto.RetentionAuditEntryDTO, len(entries))
+	for i, e := range entries {
+		dtos[i] = dto.RetentionAuditEntryDTO{
+			ID:               e.ID,
+			RunID:            e.RunID,
+			Action:           e.Action,
+			TeamID:           e.TeamID,
+			TeamName:         e.TeamName,
+			AssessmentPeriod: e.AssessmentPeriod,
+			SessionCount:     e.Sessions,
+			ResponseCount:    e.Responses,
+			CutoffDate:       e.CutoffDate.Format("2006-01-02"),
+			TriggeredBy:      e.TriggeredBy,
+			ExecutedAt:       e.ExecutedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.RetentionAuditLogResponse{Entries: dtos, Total: len(dtos)})
+}
+
+// toRetentionCountDTOs converts retention counts to DTOs
+func toRetentionCountDTOs(counts []services.RetentionCount) []dto.RetentionCountDTO {
+	dtos := make([]dto.RetentionCountDTO, len(counts))
+	for i, c := range counts {
+		dtos[i] = dto.RetentionCountDTO{
+			TeamID:           c.TeamID,
+			TeamName:         c.TeamName,
+			AssessmentPeriod: c.AssessmentPeriod,
+			SessionCount:     c.Sessions,
+			ResponseCount:    c.Responses,
+			LegalHold:        c.LegalHold,
+		}
+	}
+	return dtos
+}
+
+// retentionActionName describes what a run does with expired sessions
+func retentionActionName(archive bool) string {
+	if archive {
+		return "archive"
+	}
+	return "purge"
+}
@@ -0,0 +1,23 @@
+package v1
+
+import (
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRetentionRoutes configures data retention admin routes
+// All routes require JWT authentication and the CanConfigureSystem permission; writes are recorded in the audit log unless audit is nil
+func SetupRetentionRoutes(router *gin.Engine, retentionService *services.RetentionService, jwtService *services.JWTService, permissions *services.PermissionService, audit *services.AdminAuditService) {
+	handler := NewRetentionAdminHandler(retentionService)
+
+	retention := router.Group("/api/v1/admin/retention")
+	retention.Use(middleware.JWTAuthMiddleware(jwtService))
+	retention.Use(middleware.RequirePermission(permissions, organization.PermissionConfigureSystem))
+	{
+		retention.GET("/preview", handler.PreviewRetention)
+		retention.POST("/run", auditAdminWrite(audit, "retention.run", adminAuditTarget{Type: "retention"}), handler.RunRetention)
+		retention.GET("/audit-log", handler.GetRetentionAuditLog)
+	}
+}
@@ -0,0 +1,269 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/saml"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultSAMLEmailAttributes are the attributes IdPs commonly send the email address in,
+// tried in order when no email attribute is configured
+var defaultSAMLEmailAttributes = []string{
+	"email",
+	"mail",
+	"emailAddress",
+	"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+	"urn:oid:0.9.2342.19200300.100.1.3",
+}
+
+// samlTokenRequest is sent by the frontend to redeem the code the ACS redirected it with
+type samlTokenRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+// SAMLHandler handles SAML 2.0 service provider requests. A SAML login ends the same way as
+// an OAuth one: the user is resolved by email and receives our own JWT token pair.
+type SAMLHandler struct {
+	sp             *saml.ServiceProvider // nil when SAML is not configured
+	logins         *services.SAMLLoginService
+	userRepo       user.Repository
+	jwtService     *services.JWTService
+	provisioning   *services.SSOProvisioningService
+	userHandler    *UserAdminHandler
+	emailAttribute string // empty to try defaultSAMLEmailAttributes
+	callbackURL    string // the frontend page that redeems login codes
+}
+
+// NewSAMLHandler creates a new SAMLHandler
+func NewSAMLHandler(sp *saml.ServiceProvider, logins *services.SAMLLoginService, userRepo user.Repository, teamRepo team.Repository, jwtService *services.JWTService, provisioning *services.SSOProvisioningService, emailAttribute, callbackURL string) *SAMLHandler {
+	return &SAMLHandler{
+		sp:             sp,
+		logins:         logins,
+		userRepo:       userRepo,
+		jwtService:     jwtService,
+		provisioning:   provisioning,
+		userHandler:    NewUserAdminHandler(userRepo, teamRepo, nil),
+		emailAttribute: emailAttribute,
+		callbackURL:    callbackURL,
+	}
+}
+
+// requireSAML responds 503 when SAML is not configured
+func (h *SAMLHandler) requireSAML(c *gin.Context) bool {
+	if h.sp == nil {
+		dto.RespondError(c, http.StatusServiceUnavailable, "SAML is not configured on this server")
+		return false
+	}
+	return true
+}
+
+// Metadata serves the SP metadata for registering Team360 with the IdP
+func (h *SAMLHandler) Metadata(c *gin.Context) {
+	if !h.requireSAML(c) {
+		return
+	}
+	metadata, err := h.sp.Metadata()
+	if err != nil {
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to generate SAML metadata")
+		return
+	}
+	c.Data(http.StatusOK, "application/samlmetadata+xml", metadata)
+}
+
+// Login sends the browser to the IdP with an AuthnRequest.
+// The state query parameter is generated by the frontend and must be presented again
+// with the login code.
+func (h *SAMLHandler) Login(c *gin.Context) {
+	if !h.requireSAML(c) {
+		return
+	}
+	state := c.Query("state")
+	if state == "" || len(state) > 256 {
+		dto.RespondError(c, http.StatusBadRequest, "state is required")
+		return
+	}
+
+	requestID, err := saml.NewRequestID()
+	if err == nil {
+		err = h.logins.StartLogin(c.Request.Context(), requestID, state)
+	}
+	var redirectURL string
+	if err == nil {
+		redirectURL, err = h.sp.AuthnRequestURL(requestID, "")
+	}
+	if err != nil {
+		logger.Get().WithContext(c.Request.Context()).WithError(err).Error("SAML login: failed to start login")
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to start SAML login")
+		return
+	}
+
+	c.Redirect(http.StatusFound, redirectURL)
+}
+
+// ACS is the Assertion Consumer Service. It validates the IdP's signed Response, resolves the
+// user by the email attribute (provisioning them just in time when enabled), and redirects the
+// browser to the frontend with a one-time login code, or with an error code.
+func (h *SAMLHandler) ACS(c *gin.Context) {
+	if !h.requireSAML(c) {
+		return
+	}
+	ctx := c.Request.Context()
+	log := logger.Get().WithContext(ctx)
+
+	assertion, err := h.sp.ParseResponse(c.PostForm("SAMLResponse"))
+	if err != nil {
+		logger.Get().Auth("saml_assertion_validation").
+			IP(c.ClientIP()).
+			RequestID(c.GetString("request_id")).
+			Endpoint(c.Request.URL.Path).
+			Reason("invalid_saml_response").
+			Details(err.Error()).
+			Failure()
+		h.redirectWithError(c, "invalid_response")
+		return
+	}
+
+	// Each AuthnRequest is answered once; this also rejects replayed responses
+	if err := h.logins.ConsumeRequest(ctx, assertion.InResponseTo); err != nil {
+		if !errors.Is(err, services.ErrSAMLLoginNotFound) {
+			log.WithError(err).Error("SAML login: failed to consume request")
+			h.redirectWithError(c, "server_error")
+			return
+		}
+		log.WithField("assertion_id", assertion.ID).Warn("SAML login: response to an unknown, expired or answered request")
+		h.redirectWithError(c, "login_expired")
+		return
+	}
+
+	email := h.emailFromAssertion(assertion)
+	if email == "" {
+		log.WithField("assertion_id", assertion.ID).Warn("SAML login: assertion has no email address")
+		h.redirectWithError(c, "email_missing")
+		return
+	}
+
+	result, err := h.provisioning.ResolveUser(ctx, samlClaims(assertion, email))
+	if errors.Is(err, services.ErrSSOUserNotFound) {
+		log.WithField("email", logger.MaskEmail(email)).Warn("SAML login: no user found for email")
+		h.redirectWithError(c, "account_not_found")
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("SAML login: failed to provision user")
+		h.redirectWithError(c, "server_error")
+		return
+	}
+	usr := result.User
+
+	if usr.AuthType != user.AuthTypeSSO {
+		log.WithField("email", logger.MaskEmail(email)).Warn("SAML login: local user attempted SAML login")
+		h.redirectWithError(c, "sso_not_supported")
+		return
+	}
+	if !usr.IsActive() {
+		log.WithField("user_id", usr.ID).Warn("SAML login: deactivated user attempted SAML login")
+		h.redirectWithError(c, "account_deactivated")
+		return
+	}
+
+	if result.ReportingChanged {
+		h.userHandler.rederiveSupervisorChains(ctx, usr.ID)
+	}
+
+	code, err := h.logins.IssueCode(ctx, assertion.InResponseTo, usr.ID)
+	if err != nil {
+		log.WithError(err).Error("SAML login: failed to issue login code")
+		h.redirectWithError(c, "server_error")
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, h.callbackURL+"?"+url.Values{"code": {code}}.Encode())
+}
+
+// Token redeems the login code from the ACS redirect for our JWT token pair
+func (h *SAMLHandler) Token(c *gin.Context) {
+	if !h.requireSAML(c) {
+		return
+	}
+	ctx := c.Request.Context()
+	log := logger.Get().WithContext(ctx)
+
+	var req samlTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.RespondError(c, http.StatusBadRequest, "code and state are required")
+		return
+	}
+
+	userID, err := h.logins.RedeemCode(ctx, req.Code, req.State)
+	if errors.Is(err, services.ErrSAMLLoginNotFound) {
+		dto.RespondError(c, http.StatusUnauthorized, "The login code is invalid or has expired. Please sign in again.")
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("SAML login: failed to redeem login code")
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to complete SAML login")
+		return
+	}
+
+	usr, err := h.userRepo.FindByID(ctx, userID)
+	if err != nil || !usr.IsActive() {
+		dto.RespondError(c, http.StatusUnauthorized, "This account has been deactivated. Please contact your administrator.")
+		return
+	}
+
+	response, err := ssoLoginResponse(ctx, h.userRepo, h.jwtService, usr)
+	if err != nil {
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to generate authentication tokens")
+		return
+	}
+
+	log.WithField("user_id", usr.ID).Info("SAML login successful")
+
+	dto.RespondSuccess(c, http.StatusOK, response)
+}
+
+func (h *SAMLHandler) redirectWithError(c *gin.Context, code string) {
+	c.Redirect(http.StatusSeeOther, h.callbackURL+"?"+url.Values{"error": {code}}.Encode())
+}
+
+// emailFromAssertion reads the email from the configured attribute, or else from a commonly
+// used one, falling back to a NameID in email format
+func (h *SAMLHandler) emailFromAssertion(assertion *saml.Assertion) string {
+	if h.emailAttribute != "" {
+		return strings.TrimSpace(assertion.Attribute(h.emailAttribute))
+	}
+	for _, name := range defaultSAMLEmailAttributes {
+		if email := strings.TrimSpace(assertion.Attribute(name)); email != "" {
+			return email
+		}
+	}
+	if assertion.NameIDFormat == saml.NameIDFormatEmail {
+		return assertion.NameID
+	}
+	return ""
+}
+
+// samlClaims presents the assertion's attributes as claims, so the SSO_* claim mappings
+// apply to SAML attribute names too
+func samlClaims(assertion *saml.Assertion, email string) map[string]interface{} {
+	claims := make(map[string]interface{}, len(assertion.Attributes)+1)
+	for name, values := range assertion.Attributes {
+		if len(values) == 1 {
+			claims[name] = values[0]
+		} else {
+			claims[name] = values
+		}
+	}
+	claims["email"] = email
+	return claims
+}
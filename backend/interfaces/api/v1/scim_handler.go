@@ -0,0 +1,789 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/scim"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Page size of SCIM list responses
+const (
+	scimDefaultCount = 100
+	scimMaxCount     = 1000
+)
+
+// SCIMHandler serves SCIM 2.0 provisioning: Users map to users and Groups map to teams.
+// Provisioned users sign in with SSO; deactivating or deleting one revokes their sessions.
+// Local password accounts are outside the identity provider's reach and appear not to exist.
+type SCIMHandler struct {
+	userRepo       user.Repository
+	teamRepo       team.Repository
+	jwtService     *services.JWTService
+	defaultLevelID string
+}
+
+// NewSCIMHandler creates a new SCIMHandler. Provisioned users start at defaultLevelID.
+func NewSCIMHandler(userRepo user.Repository, teamRepo team.Repository, jwtService *services.JWTService, defaultLevelID string) *SCIMHandler {
+	return &SCIMHandler{userRepo: userRepo, teamRepo: teamRepo, jwtService: jwtService, defaultLevelID: defaultLevelID}
+}
+
+// ServiceProviderConfig handles GET /scim/v2/ServiceProviderConfig
+func (h *SCIMHandler) ServiceProviderConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"schemas":        []string{scim.SchemaServiceProviderConfig},
+		"patch":          gin.H{"supported": true},
+		"bulk":           gin.H{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         gin.H{"supported": true, "maxResults": scimMaxCount},
+		"changePassword": gin.H{"supported": false},
+		"sort":           gin.H{"supported": false},
+		"etag":           gin.H{"supported": false},
+		"authenticationSchemes": []gin.H{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "The token configured in SCIM_BEARER_TOKEN",
+		}},
+	})
+}
+
+// ResourceTypes handles GET /scim/v2/ResourceTypes
+func (h *SCIMHandler) ResourceTypes(c *gin.Context) {
+	resources := []interface{}{
+		gin.H{
+			"schemas":          []string{scim.SchemaResourceType},
+			"id":               "User",
+			"name":             "User",
+			"endpoint":         "/Users",
+			"schema":           scim.SchemaUser,
+			"schemaExtensions": []gin.H{{"schema": scim.SchemaEnterpriseUser, "required": false}},
+		},
+		gin.H{
+			"schemas":  []string{scim.SchemaResourceType},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   scim.SchemaGroup,
+		},
+	}
+	c.JSON(http.StatusOK, scim.NewListResponse(resources, 1, len(resources)))
+}
+
+// ============================================================================
+// Users
+// ============================================================================
+
+// ListUsers handles GET /scim/v2/Users
+// Query params: filter, startIndex (1-based), count
+func (h *SCIMHandler) ListUsers(c *gin.Context) {
+	filter, startIndex, count, err := scimListParams(c)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	users, err := h.userRepo.FindAll(c.Request.Context())
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	base := scimBaseURL(c)
+	var resources []interface{}
+	for _, u := range users {
+		if u.AuthType != user.AuthTypeSSO {
+			continue
+		}
+		resource := toSCIMUser(u, base)
+		matches, err := scimMatches(filter, resource)
+		if err != nil {
+			respondSCIMError(c, err)
+			return
+		}
+		if matches {
+			resources = append(resources, resource)
+		}
+	}
+
+	c.JSON(http.StatusOK, scim.NewListResponse(resources, startIndex, count))
+}
+
+// GetUser handles GET /scim/v2/Users/:id
+func (h *SCIMHandler) GetUser(c *gin.Context) {
+	u, err := h.findUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toSCIMUser(u, scimBaseURL(c)))
+}
+
+// CreateUser handles POST /scim/v2/Users
+// The user is created as an SSO user at the default hierarchy level.
+func (h *SCIMHandler) CreateUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	body, err := bindSCIMBody(c)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	in, err := scim.DecodeUser(body)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	u := &user.User{
+		HierarchyLevelID: h.defaultLevelID,
+		AuthType:         user.AuthTypeSSO,
+		TeamIDs:          []string{},
+	}
+	if err := h.applySCIMUser(ctx, u, in); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	// Usernames from identity providers are often email addresses, which do not make readable IDs
+	u.ID = user.IDFromUsername(u.Username)
+	if existing, _ := h.userRepo.FindByID(ctx, u.ID); u.ID == "" || existing != nil {
+		u.ID = uuid.NewString()
+	}
+
+	if err := h.userRepo.Save(ctx, u); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	if in.Active != nil && !*in.Active {
+		if err := h.userRepo.SetActive(ctx, u.ID, false); err != nil {
+			respondSCIMError(c, err)
+			return
+		}
+	}
+
+	logger.Get().WithField("user_id", u.ID).Info("scim: user provisioned")
+
+	created, err := h.findUser(ctx, u.ID)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	resource := toSCIMUser(created, scimBaseURL(c))
+	c.Header("Location", resource.Meta.Location)
+	c.JSON(http.StatusCreated, resource)
+}
+
+// ReplaceUser handles PUT /scim/v2/Users/:id
+func (h *SCIMHandler) ReplaceUser(c *gin.Context) {
+	body, err := bindSCIMBody(c)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	in, err := scim.DecodeUser(body)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	h.updateUser(c, in)
+}
+
+// PatchUser handles PATCH /scim/v2/Users/:id
+// Deprovisioning identity providers send `replace active false`.
+func (h *SCIMHandler) PatchUser(c *gin.Context) {
+	var req scim.PatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondSCIMError(c, scim.NewError(http.StatusBadRequest, scim.ErrorInvalidSyntax, err.Error()))
+		return
+	}
+
+	u, err := h.findUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	resource, err := scim.ToMap(toSCIMUser(u, scimBaseURL(c)))
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	if err := scim.ApplyPatch(resource, req.Operations); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	in, err := scim.DecodeUser(resource)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	h.updateUser(c, in)
+}
+
+// updateUser writes a replaced or patched user resource
+func (h *SCIMHandler) updateUser(c *gin.Context, in *scim.User) {
+	ctx := c.Request.Context()
+
+	u, err := h.findUser(ctx, c.Param("id"))
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	wasActive := u.IsActive()
+
+	if err := h.applySCIMUser(ctx, u, in); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	if err := h.userRepo.Update(ctx, u); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	if in.Active != nil && *in.Active != wasActive {
+		if err := h.userRepo.SetActive(ctx, u.ID, *in.Active); err != nil {
+			respondSCIMError(c, err)
+			return
+		}
+		if !*in.Active {
+			if err := h.jwtService.RevokeAllSessions(ctx, u.ID); err != nil {
+				logger.Get().WithError(err).WithField("user_id", u.ID).Warn("scim: failed to revoke sessions of deactivated user")
+			}
+		}
+		logger.Get().WithFields(map[string]interface{}{"user_id": u.ID, "active": *in.Active}).Info("scim: user status changed")
+	}
+
+	updated, err := h.findUser(ctx, u.ID)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toSCIMUser(updated, scimBaseURL(c)))
+}
+
+// applySCIMUser copies the resource's attributes onto u, checking that the username, email and
+// external ID are not used by another user. The manager is changed only when the resource has the
+// enterprise extension, so identity providers that do not send it leave reporting lines alone.
+func (h *SCIMHandler) applySCIMUser(ctx context.Context, u *user.User, in *scim.User) error {
+	username := strings.TrimSpace(in.UserName)
+	if username == "" {
+		return scim.NewError(http.StatusBadRequest, scim.ErrorInvalidValue, "userName is required")
+	}
+	email := strings.TrimSpace(in.PrimaryEmail())
+	if email == "" {
+		return scim.NewError(http.StatusBadRequest, scim.ErrorInvalidValue, "an email address is required")
+	}
+
+	if other, err := h.userRepo.FindByUsername(ctx, username); err == nil && other.ID != u.ID {
+		return scim.NewError(http.StatusConflict, scim.ErrorUniqueness, "userName is already in use")
+	}
+	if other, err := h.userRepo.FindByEmail(ctx, email); err == nil && other.ID != u.ID {
+		return scim.NewError(http.StatusConflict, scim.ErrorUniqueness, "email is already in use")
+	}
+	if in.ExternalID != "" {
+		if other, err := h.userRepo.FindByExternalID(ctx, in.ExternalID); err == nil && other.ID != u.ID {
+			return scim.NewError(http.StatusConflict, scim.ErrorUniqueness, "externalId is already in use")
+		}
+	}
+
+	if in.Enterprise != nil {
+		if in.Enterprise.Manager == nil || in.Enterprise.Manager.Value == "" {
+			u.ReportsTo = nil
+		} else {
+			managerID := in.Enterprise.Manager.Value
+			if managerID == u.ID {
+				return scim.NewError(http.StatusBadRequest, scim.ErrorInvalidValue, "a user cannot be their own manager")
+			}
+			if _, err := h.userRepo.FindByID(ctx, managerID); err != nil {
+				return scim.NewError(http.StatusBadRequest, scim.ErrorInvalidValue, "manager not found: "+managerID)
+			}
+			u.ReportsTo = &managerID
+		}
+	}
+
+	u.Username = username
+	u.Email = email
+	if name := strings.TrimSpace(in.FullName()); name != "" {
+		u.Name = name
+	} else if u.Name == "" {
+		u.Name = username
+	}
+	if in.ExternalID != "" {
+		u.ExternalID = in.ExternalID
+	}
+	return nil
+}
+
+// DeleteUser handles DELETE /scim/v2/Users/:id
+// Identity providers that keep history deprovision with active=false instead.
+func (h *SCIMHandler) DeleteUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	if _, err := h.findUser(ctx, id); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	// Revoke while the user still exists; once deleted, their remaining tokens are rejected as well
+	if err := h.jwtService.RevokeAllSessions(ctx, id); err != nil {
+		logger.Get().WithError(err).WithField("user_id", id).Warn("scim: failed to revoke sessions of deleted user")
+	}
+	if err := h.userRepo.Delete(ctx, id); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	logger.Get().WithField("user_id", id).Info("scim: user deleted")
+	c.Status(http.StatusNoContent)
+}
+
+// findUser returns an SSO user; local accounts are reported as not found
+func (h *SCIMHandler) findUser(ctx context.Context, id string) (*user.User, error) {
+	u, err := h.userRepo.FindByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, scim.NewError(http.StatusNotFound, "", "User not found: "+id)
+		}
+		return nil, err
+	}
+	if u.AuthType != user.AuthTypeSSO {
+		return nil, scim.NewError(http.StatusNotFound, "", "User not found: "+id)
+	}
+	return u, nil
+}
+
+func toSCIMUser(u *user.User, base string) *scim.User {
+	active := u.IsActive()
+	resource := &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          u.ID,
+		ExternalID:  u.ExternalID,
+		UserName:    u.Username,
+		Name:        &scim.Name{Formatted: u.Name},
+		DisplayName: u.Name,
+		Active:      &active,
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Location:     base + "/Users/" + u.ID,
+		},
+	}
+	if !u.CreatedAt.IsZero() {
+		resource.Meta.Created = &u.CreatedAt
+	}
+	if !u.UpdatedAt.IsZero() {
+		resource.Meta.LastModified = &u.UpdatedAt
+	}
+	if u.Email != "" {
+		resource.Emails = []scim.MultiValue{{Value: u.Email, Type: "work", Primary: true}}
+	}
+	for _, teamID := range u.TeamIDs {
+		resource.Groups = append(resource.Groups, scim.MultiValue{Value: teamID, Ref: base + "/Groups/" + teamID})
+	}
+	if u.ReportsTo != nil && *u.ReportsTo != "" {
+		resource.Schemas = append(resource.Schemas, scim.SchemaEnterpriseUser)
+		resource.Enterprise = &scim.EnterpriseUser{Manager: &scim.Manager{Value: *u.ReportsTo}}
+	}
+	return resource
+}
+
+// ============================================================================
+// Groups
+// ============================================================================
+
+// ListGroups handles GET /scim/v2/Groups
+// Query params: filter, startIndex (1-based), count, excludedAttributes=members
+func (h *SCIMHandler) ListGroups(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	filter, startIndex, count, err := scimListParams(c)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	excludeMembers := strings.Contains(strings.ToLower(c.Query("excludedAttributes")), "members")
+
+	teams, err := h.teamRepo.FindAll(ctx)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	base := scimBaseURL(c)
+	var resources []interface{}
+	for _, t := range teams {
+		var members []team.TeamMember
+		if !excludeMembers || filter != nil {
+			if members, err = h.teamRepo.FindTeamMembers(ctx, t.ID); err != nil {
+				respondSCIMError(c, err)
+				return
+			}
+		}
+		resource := toSCIMGroup(t, members, base)
+		matches, err := scimMatches(filter, resource)
+		if err != nil {
+			respondSCIMError(c, err)
+			return
+		}
+		if matches {
+			if excludeMembers {
+				resource.Members = nil
+			}
+			resources = append(resources, resource)
+		}
+	}
+
+	c.JSON(http.StatusOK, scim.NewListResponse(resources, startIndex, count))
+}
+
+// GetGroup handles GET /scim/v2/Groups/:id
+func (h *SCIMHandler) GetGroup(c *gin.Context) {
+	resource, err := h.findGroup(c.Request.Context(), c.Param("id"), scimBaseURL(c))
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	if strings.Contains(strings.ToLower(c.Query("excludedAttributes")), "members") {
+		resource.Members = nil
+	}
+	c.JSON(http.StatusOK, resource)
+}
+
+// CreateGroup handles POST /scim/v2/Groups
+// The group becomes a team with the default settings, identified by its display name.
+func (h *SCIMHandler) CreateGroup(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	body, err := bindSCIMBody(c)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	in, err := scim.DecodeGroup(body)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	name := strings.TrimSpace(in.DisplayName)
+	if name == "" {
+		respondSCIMError(c, scim.NewError(http.StatusBadRequest, scim.ErrorInvalidValue, "displayName is required"))
+		return
+	}
+	id := team.IDFromName(name)
+	if id == "" {
+		respondSCIMError(c, scim.NewError(http.StatusBadRequest, scim.ErrorInvalidValue, "displayName must contain letters or digits"))
+		return
+	}
+	if _, err := h.teamRepo.FindByID(ctx, id); err == nil {
+		respondSCIMError(c, scim.NewError(http.StatusConflict, scim.ErrorUniqueness, "a team with this displayName already exists"))
+		return
+	}
+	memberIDs, err := h.memberIDs(ctx, in.Members)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	if err := h.teamRepo.Save(ctx, &team.Team{ID: id, Name: name}); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	if err := h.syncMembers(ctx, id, nil, memberIDs); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	logger.Get().WithField("team_id", id).Info("scim: group provisioned as team")
+
+	resource, err := h.findGroup(ctx, id, scimBaseURL(c))
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	c.Header("Location", resource.Meta.Location)
+	c.JSON(http.StatusCreated, resource)
+}
+
+// ReplaceGroup handles PUT /scim/v2/Groups/:id
+func (h *SCIMHandler) ReplaceGroup(c *gin.Context) {
+	body, err := bindSCIMBody(c)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	in, err := scim.DecodeGroup(body)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	h.updateGroup(c, in)
+}
+
+// PatchGroup handles PATCH /scim/v2/Groups/:id
+// Membership changes arrive as add and remove operations on members.
+func (h *SCIMHandler) PatchGroup(c *gin.Context) {
+	var req scim.PatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondSCIMError(c, scim.NewError(http.StatusBadRequest, scim.ErrorInvalidSyntax, err.Error()))
+		return
+	}
+
+	current, err := h.findGroup(c.Request.Context(), c.Param("id"), scimBaseURL(c))
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	resource, err := scim.ToMap(current)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	if err := scim.ApplyPatch(resource, req.Operations); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	in, err := scim.DecodeGroup(resource)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	h.updateGroup(c, in)
+}
+
+// updateGroup renames the team and brings its members in line with a replaced or patched group
+func (h *SCIMHandler) updateGroup(c *gin.Context, in *scim.Group) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	t, err := h.teamRepo.FindByID(ctx, id)
+	if err != nil {
+		respondSCIMError(c, scimTeamError(err, id))
+		return
+	}
+	current, err := h.teamRepo.FindTeamMembers(ctx, id)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	name := strings.TrimSpace(in.DisplayName)
+	if name == "" {
+		respondSCIMError(c, scim.NewError(http.StatusBadRequest, scim.ErrorInvalidValue, "displayName is required"))
+		return
+	}
+	memberIDs, err := h.memberIDs(ctx, in.Members)
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	if name != t.Name {
+		t.Name = name
+		if err := h.teamRepo.Update(ctx, t); err != nil {
+			respondSCIMError(c, err)
+			return
+		}
+	}
+
+	currentIDs := make([]string, len(current))
+	for i, m := range current {
+		currentIDs[i] = m.ID
+	}
+	if err := h.syncMembers(ctx, id, currentIDs, memberIDs); err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+
+	resource, err := h.findGroup(ctx, id, scimBaseURL(c))
+	if err != nil {
+		respondSCIMError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resource)
+}
+
+// DeleteGroup handles DELETE /scim/v2/Groups/:id
+func (h *SCIMHandler) DeleteGroup(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.teamRepo.Delete(c.Request.Context(), id); err != nil {
+		respondSCIMError(c, scimTeamError(err, id))
+		return
+	}
+
+	logger.Get().WithField("team_id", id).Info("scim: group deleted")
+	c.Status(http.StatusNoContent)
+}
+
+// memberIDs checks that every member of a group is a user
+func (h *SCIMHandler) memberIDs(ctx context.Context, members []scim.MultiValue) ([]string, error) {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		if _, err := h.userRepo.FindByID(ctx, m.Value); err != nil {
+			return nil, scim.NewError(http.StatusBadRequest, scim.ErrorInvalidValue, "member not found: "+m.Value)
+		}
+		ids = append(ids, m.Value)
+	}
+	return ids, nil
+}
+
+// syncMembers adds and removes team members so the team has exactly the desired members
+func (h *SCIMHandler) syncMembers(ctx context.Context, teamID string, current, desired []string) error {
+	want := make(map[string]bool, len(desired))
+	for _, id := range desired {
+		want[id] = true
+	}
+	have := make(map[string]bool, len(current))
+	for _, id := range current {
+		have[id] = true
+		if !want[id] {
+			if err := h.teamRepo.RemoveMember(ctx, teamID, id); err != nil {
+				return err
+			}
+		}
+	}
+	for id := range want {
+		if !have[id] {
+			if err := h.teamRepo.AddMember(ctx, teamID, id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *SCIMHandler) findGroup(ctx context.Context, id, base string) (*scim.Group, error) {
+	t, err := h.teamRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, scimTeamError(err, id)
+	}
+	members, err := h.teamRepo.FindTeamMembers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSCIMGroup(t, members, base), nil
+}
+
+func scimTeamError(err error, id string) error {
+	if strings.Contains(err.Error(), "not found") {
+		return scim.NewError(http.StatusNotFound, "", "Group not found: "+id)
+	}
+	return err
+}
+
+func toSCIMGroup(t *team.Team, members []team.TeamMember, base string) *scim.Group {
+	resource := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          t.ID,
+		DisplayName: t.Name,
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Location:     base + "/Groups/" + t.ID,
+		},
+	}
+	if !t.CreatedAt.IsZero() {
+		resource.Meta.Created = &t.CreatedAt
+	}
+	if !t.UpdatedAt.IsZero() {
+		resource.Meta.LastModified = &t.UpdatedAt
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+	for _, m := range members {
+		resource.Members = append(resource.Members, scim.MultiValue{
+			Value:   m.ID,
+			Display: m.FullName,
+			Ref:     base + "/Users/" + m.ID,
+		})
+	}
+	return resource
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// scimListParams reads the filter and paging of a list request
+func scimListParams(c *gin.Context) (scim.Filter, int, int, error) {
+	var filter scim.Filter
+	if raw := c.Query("filter"); raw != "" {
+		parsed, err := scim.ParseFilter(raw)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		filter = parsed
+	}
+
+	startIndex := 1
+	if raw := c.Query("startIndex"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, 0, 0, scim.NewError(http.StatusBadRequest, scim.ErrorInvalidValue, "startIndex must be a number")
+		}
+		startIndex = parsed
+	}
+
+	count := scimDefaultCount
+	if raw := c.Query("count"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return nil, 0, 0, scim.NewError(http.StatusBadRequest, scim.ErrorInvalidValue, "count must be a non-negative number")
+		}
+		count = parsed
+	}
+	if count > scimMaxCount {
+		count = scimMaxCount
+	}
+
+	return filter, startIndex, count, nil
+}
+
+func scimMatches(filter scim.Filter, resource interface{}) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+	m, err := scim.ToMap(resource)
+	if err != nil {
+		return false, err
+	}
+	return filter.Matches(m), nil
+}
+
+func bindSCIMBody(c *gin.Context) (map[string]interface{}, error) {
+	var body map[string]interface{}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		return nil, scim.NewError(http.StatusBadRequest, scim.ErrorInvalidSyntax, err.Error())
+	}
+	return body, nil
+}
+
+// scimBaseURL is the absolute URL of the SCIM endpoint, for resource locations
+func scimBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host + "/scim/v2"
+}
+
+func respondSCIMError(c *gin.Context, err error) {
+	var scimErr *scim.Error
+	if !errors.As(err, &scimErr) {
+		logger.Get().WithError(err).Error("scim: request failed")
+		scimErr = scim.NewError(http.StatusInternalServerError, "", err.Error())
+	}
+	c.JSON(scimErr.StatusCode(), scimErr)
+}
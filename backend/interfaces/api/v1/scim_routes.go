@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"os"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupSCIMRoutes registers the SCIM 2.0 provisioning endpoints.
+// Identity providers authenticate with the bearer token in SCIM_BEARER_TOKEN; without it,
+// every request is refused. New users start at SCIM_DEFAULT_HIERARCHY_LEVEL (default level-5).
+func SetupSCIMRoutes(router *gin.Engine, userRepo user.Repository, teamRepo team.Repository, jwtService *services.JWTService) {
+	h := NewSCIMHandler(userRepo, teamRepo, jwtService, getEnvOrDefault("SCIM_DEFAULT_HIERARCHY_LEVEL", "level-5"))
+
+	scimGroup := router.Group("/scim/v2")
+	scimGroup.Use(middleware.SCIMAuthMiddleware(os.Getenv("SCIM_BEARER_TOKEN")))
+	{
+		scimGroup.GET("/ServiceProviderConfig", h.ServiceProviderConfig)
+		scimGroup.GET("/ResourceTypes", h.ResourceTypes)
+
+		scimGroup.GET("/Users", h.ListUsers)
+		scimGroup.POST("/Users", h.CreateUser)
+		scimGroup.GET("/Users/:id", h.GetUser)
+		scimGroup.PUT("/Users/:id", h.ReplaceUser)
+		scimGroup.PATCH("/Users/:id", h.PatchUser)
+		scimGroup.DELETE("/Users/:id", h.DeleteUser)
+
+		scimGroup.GET("/Groups", h.ListGroups)
+		scimGroup.POST("/Groups", h.CreateGroup)
+		scimGroup.GET("/Groups/:id", h.GetGroup)
+		scimGroup.PUT("/Groups/:id", h.ReplaceGroup)
+		scimGroup.PATCH("/Groups/:id", h.PatchGroup)
+		scimGroup.DELETE("/Groups/:id", h.DeleteGroup)
+	}
+}
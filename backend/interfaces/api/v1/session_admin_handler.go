@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// SessionAdminHandler handles admin session management HTTP requests
+type SessionAdminHandler struct {
+	jwtService *services.JWTService
+}
+
+// NewSessionAdminHandler creates a new SessionAdminHandler
+func NewSessionAdminHandler(jwtService *services.JWTService) *SessionAdminHandler {
+	return &SessionAdminHandler{jwtService: jwtService}
+}
+
+// RevokeUserSessions handles POST /api/v1/admin/users/:id/revoke-sessions
+// Every access and refresh token issued to the user stops working immediately.
+func (h *SessionAdminHandler) RevokeUserSessions(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.jwtService.RevokeAllSessions(c.Request.Context(), id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to revoke sessions",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	adminID, _ := middleware.GetUserIDFromContext(c)
+	logger.Get().Security("sessions_revoked").
+		UserID(id).
+		IP(c.ClientIP()).
+		RequestID(c.GetString("request_id")).
+		Details("All sessions revoked by admin " + adminID).
+		Log()
+
+	dto.RespondMessage(c, http.StatusOK, "All sessions revoked")
+}
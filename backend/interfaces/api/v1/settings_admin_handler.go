@@ -3,6 +3,7 @@ package v1
 import (
 	"net/http"
 	"os"
+	"sort"
 
 	"github.com/agopalakrishnan/teams360/backend/domain/organization"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
@@ -247,7 +248,8 @@ func (h *SettingsAdminHandler) GetNotificationSettings(c *gin.Context) {
 		SlackEnabled:       appSettings.SlackNotifications,
 		NotifyOnSubmission: appSettings.WeeklyDigest,
 		NotifyManagers:     false,
-		ReminderDaysBefore: 7,
+		ReminderDaysBefore: maxReminderOffset(appSettings.ReminderOffsets),
+		ReminderOffsets:    appSettings.ReminderOffsets,
 		ReminderRecipients: []string{},
 		SmtpConfigured:     os.Getenv("SMTP_HOST") != "",
 	}
@@ -263,14 +265,60 @@ func (h *SettingsAdminHandler) UpdateNotificationSettings(c *gin.Context) {
 		return
 	}
 
+	// Reminder offsets are only updated when explicitly provided
+	if settings.ReminderOffsets != nil {
+		offsets, ok := normalizeReminderOffsets(settings.ReminderOffsets)
+		if !ok {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Reminder offsets must be between 0 and 365 days"})
+			return
+		}
+		settings.ReminderOffsets = offsets
+	}
+
 	if err := h.orgRepo.UpdateNotificationSettings(c.Request.Context(), settings.EmailEnabled, settings.SlackEnabled, settings.NotifyOnSubmission); err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to save settings", Message: err.Error()})
 		return
 	}
 
+	if settings.ReminderOffsets != nil {
+		if err := h.orgRepo.UpdateReminderOffsets(c.Request.Context(), settings.ReminderOffsets); err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to save settings", Message: err.Error()})
+			return
+		}
+		settings.ReminderDaysBefore = maxReminderOffset(settings.ReminderOffsets)
+	}
+
 	c.JSON(http.StatusOK, settings)
 }
 
+// normalizeReminderOffsets validates reminder offsets and returns them de-duplicated in descending order
+func normalizeReminderOffsets(offsets []int) ([]int, bool) {
+	seen := make(map[int]bool, len(offsets))
+	result := make([]int, 0, len(offsets))
+	for _, offset := range offsets {
+		if offset < 0 || offset > 365 {
+			return nil, false
+		}
+		if !seen[offset] {
+			seen[offset] = true
+			result = append(result, offset)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(result)))
+	return result, true
+}
+
+// maxReminderOffset returns the earliest reminder (largest offset), or 0 when reminders are disabled
+func maxReminderOffset(offsets []int) int {
+	max := 0
+	for _, offset := range offsets {
+		if offset > max {
+			max = offset
+		}
+	}
+	return max
+}
+
 // GetRetentionPolicy handles GET /api/v1/admin/settings/retention
 func (h *SettingsAdminHandler) GetRetentionPolicy(c *gin.Context) {
 	appSettings, err := h.orgRepo.GetAppSettings(c.Request.Context())
@@ -281,7 +329,7 @@ func (h *SettingsAdminHandler) GetRetentionPolicy(c *gin.Context) {
 
 	policy := dto.RetentionPolicy{
 		KeepSessionsMonths: appSettings.RetentionMonths,
-		ArchiveEnabled:     false,
+		ArchiveEnabled:     appSettings.RetentionArchive,
 		AnonymizeAfterDays: appSettings.RetentionMonths * 30,
 	}
 
@@ -290,23 +338,29 @@ func (h *SettingsAdminHandler) GetRetentionPolicy(c *gin.Context) {
 
 // UpdateRetentionPolicy handles PUT /api/v1/admin/settings/retention
 func (h *SettingsAdminHandler) UpdateRetentionPolicy(c *gin.Context) {
-	var policy dto.RetentionPolicy
-	if err := c.ShouldBindJSON(&policy); err != nil {
+	var req dto.UpdateRetentionPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request body", Message: err.Error()})
 		return
 	}
 
-	if policy.KeepSessionsMonths < 1 || policy.KeepSessionsMonths > 120 {
+	if req.KeepSessionsMonths < 1 || req.KeepSessionsMonths > 120 {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Keep sessions months must be between 1 and 120"})
 		return
 	}
 
-	if err := h.orgRepo.UpdateRetentionSettings(c.Request.Context(), policy.KeepSessionsMonths); err != nil {
+	if err := h.orgRepo.UpdateRetentionSettings(c.Request.Context(), req.KeepSessionsMonths); err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to save retention policy", Message: err.Error()})
 		return
 	}
 
-	policy.AnonymizeAfterDays = policy.KeepSessionsMonths * 30
+	// Only change archive mode when explicitly provided
+	if req.ArchiveEnabled != nil {
+		if err := h.orgRepo.UpdateRetentionArchive(c.Request.Context(), *req.ArchiveEnabled); err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to save retention policy", Message: err.Error()})
+			return
+		}
+	}
 
-	c.JSON(http.StatusOK, policy)
+	h.GetRetentionPolicy(c)
 }
@@ -1,58 +1,58 @@
 package v1
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
+	"context"
+	"errors"
 	"net/http"
-	"net/url"
-	"os"
-	"strings"
-	"time"
 
 	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
 	"github.com/agopalakrishnan/teams360/backend/domain/user"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
 	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/oidc"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 // ssoCallbackRequest is sent by the frontend after the OAuth redirect.
+// Provider selects one of the configured identity providers (the default when empty), and
+// Nonce is the nonce the login was started with.
 type ssoCallbackRequest struct {
 	Code         string `json:"code" binding:"required"`
 	CodeVerifier string `json:"code_verifier" binding:"required"`
-}
-
-// oauthTokenResponse is the raw response from the provider's token endpoint.
-type oauthTokenResponse struct {
-	AccessToken string `json:"access_token"`
-	IDToken     string `json:"id_token"`
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
 }
 
 // SSOHandler handles SSO-related HTTP requests.
 type SSOHandler struct {
-	userRepo   user.Repository
-	jwtService *services.JWTService
+	userRepo     user.Repository
+	jwtService   *services.JWTService
+	providers    *oidc.Registry
+	provisioning *services.SSOProvisioningService
+	userHandler  *UserAdminHandler
 }
 
 // NewSSOHandler creates a new SSOHandler.
-func NewSSOHandler(userRepo user.Repository, jwtService *services.JWTService) *SSOHandler {
-	return &SSOHandler{userRepo: userRepo, jwtService: jwtService}
+func NewSSOHandler(userRepo user.Repository, teamRepo team.Repository, jwtService *services.JWTService, providers *oidc.Registry, provisioning *services.SSOProvisioningService) *SSOHandler {
+	return &SSOHandler{
+		userRepo:     userRepo,
+		jwtService:   jwtService,
+		providers:    providers,
+		provisioning: provisioning,
+		userHandler:  NewUserAdminHandler(userRepo, teamRepo, nil),
+	}
 }
 
 // Callback exchanges the authorization code + PKCE verifier for provider tokens,
-// extracts the email from the id_token, looks the user up in the DB, and issues
+// verifies the id_token (signature, issuer, audience, expiry and nonce), resolves
+// the user by its email (provisioning them just in time when enabled), and issues
 // our own JWT token pair — the same structure as a regular password login.
 func (h *SSOHandler) Callback(c *gin.Context) {
 	ctx := c.Request.Context()
 	log := logger.Get().WithContext(ctx)
 
-	clientID := os.Getenv("OAUTH_CLIENT_ID")
-	tokenURL := os.Getenv("OAUTH_TOKEN_URL")
-	redirectURI := os.Getenv("OAUTH_REDIRECT_URI")
-
-	if clientID == "" || tokenURL == "" {
+	if len(h.providers.Providers()) == 0 {
 		dto.RespondError(c, http.StatusServiceUnavailable, "SSO is not configured on this server")
 		return
 	}
@@ -63,31 +63,57 @@ func (h *SSOHandler) Callback(c *gin.Context) {
 		return
 	}
 
+	provider, ok := h.providers.Get(req.Provider)
+	if !ok {
+		dto.RespondError(c, http.StatusBadRequest, "Unknown SSO provider")
+		return
+	}
+
 	// Exchange code + PKCE verifier for provider tokens
-	providerTokens, err := exchangeCodeForTokens(tokenURL, clientID, redirectURI, req.Code, req.CodeVerifier)
+	providerTokens, err := provider.Exchange(ctx, req.Code, req.CodeVerifier)
 	if err != nil {
-		log.WithError(err).Error("SSO token exchange failed")
+		log.WithError(err).WithField("provider", provider.Config().ID).Error("SSO token exchange failed")
 		dto.RespondError(c, http.StatusUnauthorized, "Token exchange with OAuth provider failed")
 		return
 	}
 
-	// Extract email from id_token; fall back to access_token if needed
-	email, _ := extractEmailFromJWT(providerTokens.IDToken)
-	if email == "" {
-		email, _ = extractEmailFromJWT(providerTokens.AccessToken)
+	claims, err := provider.VerifyIDToken(ctx, providerTokens.IDToken, req.Nonce)
+	if err != nil {
+		logger.Get().Auth("sso_token_validation").
+			IP(c.ClientIP()).
+			RequestID(c.GetString("request_id")).
+			Endpoint(c.Request.URL.Path).
+			Reason("invalid_id_token").
+			Details(err.Error()).
+			Failure()
+		dto.RespondError(c, http.StatusUnauthorized, "The SSO token could not be verified")
+		return
 	}
+
+	email, _ := claims["email"].(string)
 	if email == "" {
 		dto.RespondError(c, http.StatusUnauthorized, "Could not determine email from SSO token")
 		return
 	}
+	if verified, present := claims["email_verified"].(bool); present && !verified {
+		log.WithField("email", logger.MaskEmail(email)).Warn("SSO login: provider has not verified the email address")
+		dto.RespondError(c, http.StatusUnauthorized, "Your email address has not been verified by the SSO provider")
+		return
+	}
 
-	// Look up the user by email
-	usr, err := h.userRepo.FindByEmail(ctx, email)
-	if err != nil {
+	// Look up the user by email, provisioning or refreshing them from the claims
+	result, err := h.provisioning.ResolveUser(ctx, claims)
+	if errors.Is(err, services.ErrSSOUserNotFound) {
 		log.WithField("email", email).Warn("SSO login: no user found for email")
 		dto.RespondError(c, http.StatusUnauthorized, "No account found for this email address. Please contact your administrator.")
 		return
 	}
+	if err != nil {
+		log.WithError(err).Error("SSO login: failed to provision user")
+		dto.RespondError(c, http.StatusInternalServerError, "Failed to provision SSO account")
+		return
+	}
+	usr := result.User
 
 	// Only SSO users can authenticate via SSO
 	if usr.AuthType != user.AuthTypeSSO {
@@ -96,10 +122,17 @@ func (h *SSOHandler) Callback(c *gin.Context) {
 		return
 	}
 
-	teamIds := collectTeamIDs(ctx, h.userRepo, usr.ID)
+	if !usr.IsActive() {
+		log.WithField("user_id", usr.ID).Warn("SSO login: deactivated user attempted SSO login")
+		dto.RespondError(c, http.StatusUnauthorized, "This account has been deactivated. Please contact your administrator.")
+		return
+	}
 
-	// Issue our own JWT tokens
-	tokenPair, err := h.jwtService.GenerateTokenPair(ctx, usr.ID, usr.Username, usr.Email, usr.HierarchyLevelID, teamIds)
+	if result.ReportingChanged {
+		h.userHandler.rederiveSupervisorChains(ctx, usr.ID)
+	}
+
+	response, err := ssoLoginResponse(ctx, h.userRepo, h.jwtService, usr)
 	if err != nil {
 		dto.RespondError(c, http.StatusInternalServerError, "Failed to generate authentication tokens")
 		return
@@ -107,7 +140,20 @@ func (h *SSOHandler) Callback(c *gin.Context) {
 
 	log.WithField("user_id", usr.ID).Info("SSO login successful")
 
-	dto.RespondSuccess(c, http.StatusOK, dto.LoginResponse{
+	dto.RespondSuccess(c, http.StatusOK, response)
+}
+
+// ssoLoginResponse issues our own JWT token pair for a user who signed in with an identity
+// provider, in the same structure as a regular password login
+func ssoLoginResponse(ctx context.Context, userRepo user.Repository, jwtService *services.JWTService, usr *user.User) (dto.LoginResponse, error) {
+	teamIds := collectTeamIDs(ctx, userRepo, usr.ID)
+
+	tokenPair, err := jwtService.GenerateTokenPair(ctx, usr.ID, usr.Username, usr.Email, usr.HierarchyLevelID, teamIds)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	return dto.LoginResponse{
 		User: dto.UserDTO{
 			ID:             usr.ID,
 			Username:       usr.Username,
@@ -119,54 +165,5 @@ func (h *SSOHandler) Callback(c *gin.Context) {
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
 		ExpiresIn:    tokenPair.ExpiresIn,
-	})
-}
-
-// exchangeCodeForTokens calls the provider's token endpoint using PKCE
-// (no client secret — this is a public / SPA client).
-func exchangeCodeForTokens(tokenURL, clientID, redirectURI, code, codeVerifier string) (*oauthTokenResponse, error) {
-	form := url.Values{
-		"grant_type":    {"authorization_code"},
-		"code":          {code},
-		"code_verifier": {codeVerifier},
-		"client_id":     {clientID},
-		"redirect_uri":  {redirectURI},
-	}
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Post(tokenURL, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("request to token endpoint: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading token response: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, string(body))
-	}
-
-	var tokenResp oauthTokenResponse
-	if err := json.Unmarshal(body, &tokenResp); err != nil {
-		return nil, fmt.Errorf("parsing token response: %w", err)
-	}
-	return &tokenResp, nil
-}
-
-// extractEmailFromJWT decodes a JWT and returns the "email" claim.
-// Signature is intentionally NOT verified — the token was obtained directly
-// from the provider's token endpoint (server-side), not supplied by the user.
-func extractEmailFromJWT(tokenStr string) (string, error) {
-	if tokenStr == "" {
-		return "", fmt.Errorf("empty token")
-	}
-	claims := jwt.MapClaims{}
-	_, _, err := jwt.NewParser().ParseUnverified(tokenStr, claims)
-	if err != nil {
-		return "", fmt.Errorf("parsing JWT: %w", err)
-	}
-	email, _ := claims["email"].(string)
-	return email, nil
+	}, nil
 }
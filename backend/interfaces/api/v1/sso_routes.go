@@ -2,24 +2,47 @@ package v1
 
 import (
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
 	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/oidc"
+	"github.com/agopalakrishnan/teams360/backend/pkg/saml"
 	"github.com/gin-gonic/gin"
 )
 
-// SetupSSORoutes registers the SSO/OAuth endpoints.
-func SetupSSORoutes(router *gin.Engine, userRepo user.Repository, jwtService *services.JWTService, orgRepo organization.Repository) {
-	h := NewSSOHandler(userRepo, jwtService)
+// SetupSSORoutes registers the SSO endpoints, for OAuth/OIDC and SAML identity providers.
+// OIDC providers are read from the OAUTH_* and OIDC_* environment variables (see
+// ssoProvidersFromEnv), the SAML IdP from the SAML_* ones (see samlServiceProviderFromEnv);
+// just-in-time provisioning and claim mappings, which apply to both, from the SSO_* ones.
+func SetupSSORoutes(router *gin.Engine, userRepo user.Repository, teamRepo team.Repository, jwtService *services.JWTService, orgRepo organization.Repository, samlLogins services.SAMLLoginStore) {
+	providers := ssoProvidersFromEnv()
+	provisioning := services.NewSSOProvisioningService(userRepo, teamRepo, orgRepo, services.SSOClaimMappingFromEnv())
+	h := NewSSOHandler(userRepo, teamRepo, jwtService, providers, provisioning)
 
 	sso := router.Group("/api/v1/auth/sso")
 	{
 		sso.POST("/callback", h.Callback)
 	}
 
+	sp := samlServiceProviderFromEnv()
+	samlHandler := NewSAMLHandler(sp, services.NewSAMLLoginService(samlLogins), userRepo, teamRepo, jwtService, provisioning,
+		os.Getenv("SAML_EMAIL_ATTRIBUTE"), getEnvOrDefault("SAML_CALLBACK_URL", "http://localhost:3000/auth/saml/callback"))
+
+	samlRoutes := router.Group("/api/v1/auth/saml")
+	{
+		samlRoutes.GET("/metadata", samlHandler.Metadata)
+		samlRoutes.GET("/login", samlHandler.Login)
+		samlRoutes.POST("/acs", samlHandler.ACS)
+		samlRoutes.POST("/token", samlHandler.Token)
+	}
+
 	// Public config endpoint — returns SSO settings and branding
-	// so the frontend can display the "Sign in with SSO" button and company branding
+	// so the frontend can display the "Sign in with SSO" buttons and company branding
 	// without baking values at build time.
 	router.GET("/api/v1/config", func(c *gin.Context) {
 		appEnv := getEnvOrDefault("APP_ENV", "production")
@@ -34,22 +57,144 @@ func SetupSSORoutes(router *gin.Engine, userRepo user.Repository, jwtService *se
 			}
 		}
 
-		clientID := os.Getenv("OAUTH_CLIENT_ID")
-		if clientID == "" {
-			c.JSON(200, gin.H{"sso": nil, "appEnv": appEnv, "companyName": companyName, "logoURL": logoURL})
-			return
+		// Providers whose discovery document cannot be fetched are left out until it can
+		ssoProviders := []gin.H{}
+		for _, p := range providers.Providers() {
+			authorizeURL, err := p.AuthorizeURL(c.Request.Context())
+			if err != nil {
+				logger.Get().WithError(err).WithField("provider", p.Config().ID).Warn("SSO provider discovery failed")
+				continue
+			}
+			cfg := p.Config()
+			ssoProviders = append(ssoProviders, gin.H{
+				"id":           cfg.ID,
+				"name":         cfg.Name,
+				"clientId":     cfg.ClientID,
+				"authorizeUrl": authorizeURL,
+				"redirectUri":  cfg.RedirectURI,
+				"scopes":       cfg.Scopes,
+			})
+		}
+
+		// sso is the default provider, for clients that support only one
+		var sso interface{}
+		if len(ssoProviders) > 0 {
+			sso = ssoProviders[0]
+		}
+		// The frontend sends the browser to loginUrl (on the API) to start a SAML login
+		var samlConfig interface{}
+		if sp != nil {
+			samlConfig = gin.H{
+				"name":     getEnvOrDefault("SAML_PROVIDER_NAME", "SAML"),
+				"loginUrl": "/api/v1/auth/saml/login",
+			}
 		}
+
 		c.JSON(200, gin.H{
-			"appEnv":      appEnv,
-			"companyName": companyName,
-			"logoURL":     logoURL,
-			"sso": gin.H{
-				"clientId":     clientID,
-				"authorizeUrl": os.Getenv("OAUTH_AUTHORIZE_URL"),
-				"redirectUri":  os.Getenv("OAUTH_REDIRECT_URI"),
-				"scopes":       getEnvOrDefault("OAUTH_SCOPES", "openid email profile"),
-			},
+			"appEnv":       appEnv,
+			"companyName":  companyName,
+			"logoURL":      logoURL,
+			"sso":          sso,
+			"ssoProviders": ssoProviders,
+			"saml":         samlConfig,
+		})
+	})
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// ssoProvidersFromEnv reads the configured identity providers. The OAUTH_* variables configure
+// the default provider ("default"); OIDC_PROVIDERS lists the IDs of further providers, each
+// configured by OIDC_<ID>_ISSUER, OIDC_<ID>_CLIENT_ID, OIDC_<ID>_REDIRECT_URI and so on.
+// Providers without an issuer are skipped, since their tokens cannot be verified.
+func ssoProvidersFromEnv() *oidc.Registry {
+	log := logger.Get()
+
+	var configs []oidc.Config
+	if clientID := os.Getenv("OAUTH_CLIENT_ID"); clientID != "" {
+		configs = append(configs, oidc.Config{
+			ID:           "default",
+			Name:         getEnvOrDefault("OAUTH_PROVIDER_NAME", "SSO"),
+			Issuer:       os.Getenv("OAUTH_ISSUER"),
+			ClientID:     clientID,
+			ClientSecret: os.Getenv("OAUTH_CLIENT_SECRET"),
+			RedirectURI:  os.Getenv("OAUTH_REDIRECT_URI"),
+			Scopes:       getEnvOrDefault("OAUTH_SCOPES", "openid email profile"),
+			AuthorizeURL: os.Getenv("OAUTH_AUTHORIZE_URL"),
+			TokenURL:     os.Getenv("OAUTH_TOKEN_URL"),
 		})
+	}
+	for _, id := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		prefix := "OIDC_" + nonAlphanumeric.ReplaceAllString(strings.ToUpper(id), "_") + "_"
+		configs = append(configs, oidc.Config{
+			ID:           id,
+			Name:         getEnvOrDefault(prefix+"NAME", id),
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURI:  os.Getenv(prefix + "REDIRECT_URI"),
+			Scopes:       getEnvOrDefault(prefix+"SCOPES", "openid email profile"),
+			AuthorizeURL: os.Getenv(prefix + "AUTHORIZE_URL"),
+			TokenURL:     os.Getenv(prefix + "TOKEN_URL"),
+		})
+	}
+
+	var providers []*oidc.Provider
+	for _, cfg := range configs {
+		if cfg.Issuer == "" || cfg.ClientID == "" {
+			log.WithField("provider", cfg.ID).Error("SSO provider disabled: an issuer and client ID are required to verify its tokens")
+			continue
+		}
+		providers = append(providers, oidc.NewProvider(cfg, nil))
+	}
+	return oidc.NewRegistry(providers...)
+}
+
+// samlServiceProviderFromEnv reads the SAML configuration. SAML is enabled by SAML_IDP_SSO_URL
+// and needs the IdP's entity ID and signing certificate (SAML_IDP_CERTIFICATE, PEM or base64,
+// or SAML_IDP_CERTIFICATE_FILE), and SAML_BASE_URL, the public URL of this API, from which the
+// ACS URL and (unless SAML_SP_ENTITY_ID is set) the SP entity ID are derived.
+// Returns nil when SAML is disabled or misconfigured.
+func samlServiceProviderFromEnv() *saml.ServiceProvider {
+	ssoURL := os.Getenv("SAML_IDP_SSO_URL")
+	if ssoURL == "" {
+		return nil
+	}
+	log := logger.Get()
+
+	certData := os.Getenv("SAML_IDP_CERTIFICATE")
+	if path := os.Getenv("SAML_IDP_CERTIFICATE_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.WithError(err).Error("SAML disabled: cannot read SAML_IDP_CERTIFICATE_FILE")
+			return nil
+		}
+		certData = string(data)
+	}
+	certs, err := saml.ParseCertificates(certData)
+	if err != nil {
+		log.WithError(err).Error("SAML disabled: an IdP signing certificate is required to verify its assertions")
+		return nil
+	}
+
+	idpEntityID := os.Getenv("SAML_IDP_ENTITY_ID")
+	baseURL := strings.TrimSuffix(os.Getenv("SAML_BASE_URL"), "/")
+	if idpEntityID == "" || baseURL == "" {
+		log.Error("SAML disabled: SAML_IDP_ENTITY_ID and SAML_BASE_URL are required")
+		return nil
+	}
+
+	return saml.NewServiceProvider(saml.Config{
+		EntityID:        getEnvOrDefault("SAML_SP_ENTITY_ID", baseURL+"/api/v1/auth/saml/metadata"),
+		ACSURL:          baseURL + "/api/v1/auth/saml/acs",
+		NameIDFormat:    os.Getenv("SAML_NAMEID_FORMAT"),
+		IdPEntityID:     idpEntityID,
+		IdPSSOURL:       ssoURL,
+		IdPCertificates: certs,
 	})
 }
 
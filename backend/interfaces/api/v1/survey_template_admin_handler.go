@@ -0,0 +1,305 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/survey"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// SurveyTemplateAdminHandler handles survey template admin HTTP requests
+type SurveyTemplateAdminHandler struct {
+	templateRepo survey.Repository
+	orgRepo      organization.Repository
+}
+
+// NewSurveyTemplateAdminHandler creates a new SurveyTemplateAdminHandler
+func NewSurveyTemplateAdminHandler(templateRepo survey.Repository, orgRepo organization.Repository) *SurveyTemplateAdminHandler {
+	return &SurveyTemplateAdminHandler{
+		templateRepo: templateRepo,
+		orgRepo:      orgRepo,
+	}
+}
+
+// ListSurveyTemplates handles GET /api/v1/admin/survey-templates
+func (h *SurveyTemplateAdminHandler) ListSurveyTemplates(c *gin.Context) {
+	templates, err := h.templateRepo.FindAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to query survey templates",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	templateDTOs := make([]dto.SurveyTemplateDTO, len(templates))
+	for i, t := range templates {
+		templateDTOs[i] = toSurveyTemplateDTO(t)
+	}
+
+	c.JSON(http.StatusOK, dto.SurveyTemplatesResponse{
+		Templates: templateDTOs,
+		Total:     len(templateDTOs),
+	})
+}
+
+// GetSurveyTemplate handles GET /api/v1/admin/survey-templates/:id
+// Returns the template with every published version, newest first.
+func (h *SurveyTemplateAdminHandler) GetSurveyTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	t, err := h.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Survey template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch survey template",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	versions, err := h.templateRepo.FindVersions(ctx, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch survey template versions",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response := dto.SurveyTemplateDetailResponse{
+		SurveyTemplateDTO: toSurveyTemplateDTO(t),
+		Versions:          make([]dto.SurveyTemplateVersionDTO, len(versions)),
+	}
+	for i, v := range versions {
+		response.Versions[i] = toSurveyTemplateVersionDTO(v)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetSurveyTemplateVersion handles GET /api/v1/admin/survey-templates/:id/versions/:version
+func (h *SurveyTemplateAdminHandler) GetSurveyTemplateVersion(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid version"})
+		return
+	}
+
+	v, err := h.templateRepo.FindVersion(c.Request.Context(), c.Param("id"), version)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Survey template version not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch survey template version",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, toSurveyTemplateVersionDTO(v))
+}
+
+// CreateSurveyTemplate handles POST /api/v1/admin/survey-templates
+// The dimensions become version 1 of the template.
+func (h *SurveyTemplateAdminHandler) CreateSurveyTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.CreateSurveyTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request body", Message: err.Error()})
+		return
+	}
+
+	if err := h.validateDimensionIDs(c, req.DimensionIDs); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid dimensions", Message: err.Error()})
+		return
+	}
+
+	// Auto-generate ID from name if not provided
+	templateID := req.ID
+	if templateID == "" {
+		templateID = generateIDFromName(req.Name)
+	}
+
+	if _, err := h.templateRepo.FindByID(ctx, templateID); err == nil {
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Survey template already exists",
+			Message: fmt.Sprintf("A survey template with id %q already exists", templateID),
+		})
+		return
+	}
+
+	t := &survey.Template{
+		ID:          templateID,
+		Name:        req.Name,
+		Description: req.Description,
+		Dimensions:  req.DimensionIDs,
+	}
+
+	if err := h.templateRepo.Create(ctx, t); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to create survey template",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toSurveyTemplateDTO(t))
+}
+
+// UpdateSurveyTemplate handles PUT /api/v1/admin/survey-templates/:id
+// A changed dimension list publishes a new version; sessions already submitted keep the version they answered.
+func (h *SurveyTemplateAdminHandler) UpdateSurveyTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req dto.UpdateSurveyTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request body", Message: err.Error()})
+		return
+	}
+
+	t, err := h.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Survey template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to fetch survey template",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if req.DimensionIDs != nil {
+		if err := h.validateDimensionIDs(c, req.DimensionIDs); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid dimensions", Message: err.Error()})
+			return
+		}
+	}
+
+	if req.Name != nil {
+		t.Name = *req.Name
+	}
+	if req.Description != nil {
+		t.Description = *req.Description
+	}
+
+	if err := h.templateRepo.Update(ctx, t, req.DimensionIDs); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Survey template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to update survey template",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, toSurveyTemplateDTO(t))
+}
+
+// DeleteSurveyTemplate handles DELETE /api/v1/admin/survey-templates/:id
+// Templates that recorded sessions cannot be deleted; assigned teams fall back to all active dimensions.
+func (h *SurveyTemplateAdminHandler) DeleteSurveyTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	sessionCount, err := h.templateRepo.CountSessions(ctx, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Database error"})
+		return
+	}
+
+	if sessionCount > 0 {
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Cannot delete survey template",
+			Message: "Health checks were submitted against this template. Unassign it from teams instead.",
+		})
+		return
+	}
+
+	if err := h.templateRepo.Delete(ctx, id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Survey template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Failed to delete survey template",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	dto.RespondMessage(c, http.StatusOK, "Survey template deleted successfully")
+}
+
+// validateDimensionIDs requires a non-empty list of distinct, existing, active dimensions
+func (h *SurveyTemplateAdminHandler) validateDimensionIDs(c *gin.Context, dimensionIDs []string) error {
+	if len(dimensionIDs) == 0 {
+		return fmt.Errorf("at least one dimension is required")
+	}
+
+	dimensions, err := h.orgRepo.FindDimensions(c.Request.Context())
+	if err != nil {
+		return fmt.Errorf("failed to fetch dimensions: %w", err)
+	}
+	active := make(map[string]bool, len(dimensions))
+	for _, dim := range dimensions {
+		active[dim.ID] = dim.IsActive
+	}
+
+	seen := make(map[string]bool, len(dimensionIDs))
+	for _, id := range dimensionIDs {
+		isActive, exists := active[id]
+		if !exists {
+			return fmt.Errorf("dimension not found: %s", id)
+		}
+		if !isActive {
+			return fmt.Errorf("dimension is inactive: %s", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("duplicate dimension: %s", id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// toSurveyTemplateDTO converts a domain template to its API representation
+func toSurveyTemplateDTO(t *survey.Template) dto.SurveyTemplateDTO {
+	return dto.SurveyTemplateDTO{
+		ID:           t.ID,
+		Name:         t.Name,
+		Description:  t.Description,
+		Version:      t.Version,
+		DimensionIDs: t.Dimensions,
+		TeamCount:    t.TeamCount,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+}
+
+// toSurveyTemplateVersionDTO converts a domain template version to its API representation
+func toSurveyTemplateVersionDTO(v *survey.Version) dto.SurveyTemplateVersionDTO {
+	return dto.SurveyTemplateVersionDTO{
+		Version:      v.Version,
+		DimensionIDs: v.Dimensions,
+		CreatedAt:    v.CreatedAt,
+	}
+}
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/domain/survey"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupSurveyTemplateRoutes configures survey template admin routes
+// All routes require JWT authentication and the CanConfigureSystem permission; writes are recorded in the audit log unless audit is nil
+func SetupSurveyTemplateRoutes(router *gin.Engine, templateRepo survey.Repository, orgRepo organization.Repository, jwtService *services.JWTService, permissions *services.PermissionService, audit *services.AdminAuditService) {
+	target := surveyTemplateAuditTarget(templateRepo)
+	handler := NewSurveyTemplateAdminHandler(templateRepo, orgRepo)
+
+	templates := router.Group("/api/v1/admin/survey-templates")
+	templates.Use(middleware.JWTAuthMiddleware(jwtService))
+	templates.Use(middleware.RequirePermission(permissions, organization.PermissionConfigureSystem))
+	{
+		templates.GET("", handler.ListSurveyTemplates)
+		templates.POST("", auditAdminWrite(audit, "survey_template.create", createAudit(target)), handler.CreateSurveyTemplate)
+		templates.GET("/:id", handler.GetSurveyTemplate)
+		templates.PUT("/:id", auditAdminWrite(audit, "survey_template.update", target), handler.UpdateSurveyTemplate)
+		templates.DELETE("/:id", auditAdminWrite(audit, "survey_template.delete", target), handler.DeleteSurveyTemplate)
+		templates.GET("/:id/versions/:version", handler.GetSurveyTemplateVersion)
+	}
+}
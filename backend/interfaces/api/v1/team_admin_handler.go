@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/domain/organization"
 	"github.com/agopalakrishnan/teams360/backend/domain/team"
 	"github.com/agopalakrishnan/teams360/backend/domain/user"
@@ -18,11 +19,13 @@ type TeamAdminHandler struct {
 	teamRepo team.Repository
 	userRepo user.Repository
 	orgRepo  organization.Repository
+	webhooks *services.WebhookService
 }
 
-// NewTeamAdminHandler creates a new TeamAdminHandler
-func NewTeamAdminHandler(teamRepo team.Repository, userRepo user.Repository, orgRepo organization.Repository) *TeamAdminHandler {
-	return &TeamAdminHandler{teamRepo: teamRepo, userRepo: userRepo, orgRepo: orgRepo}
+// NewTeamAdminHandler creates a new TeamAdminHandler.
+// webhooks may be nil, in which case no webhook events are published.
+func NewTeamAdminHandler(teamRepo team.Repository, userRepo user.Repository, orgRepo organization.Repository, webhooks *services.WebhookService) *TeamAdminHandler {
+	return &TeamAdminHandler{teamRepo: teamRepo, userRepo: userRepo, orgRepo: orgRepo, webhooks: webhooks}
 }
 
 // ListTeams handles GET /api/v1/admin/teams
@@ -40,15 +43,22 @@ func (h *TeamAdminHandler) ListTeams(c *gin.Context) {
 	teamDTOs := make([]dto.AdminTeamDTO, len(teams))
 	for i, tm := range teams {
 		teamDTOs[i] = dto.AdminTeamDTO{
-			ID:                    tm.ID,
-			Name:                  tm.Name,
-			TeamLeadID:            tm.TeamLeadID,
-			TeamLeadName:          tm.TeamLeadName,
-			Cadence:               tm.Cadence,
-			DistributionListEmail: tm.DistributionListEmail,
-			MemberCount:           tm.MemberCount,
-			CreatedAt:             tm.CreatedAt,
-			UpdatedAt:             tm.UpdatedAt,
+			ID:                      tm.ID,
+			Name:                    tm.Name,
+			TeamLeadID:              tm.TeamLeadID,
+			TeamLeadName:            tm.TeamLeadName,
+			Cadence:                 tm.Cadence,
+			NextCheckDate:           tm.NextCheckDate,
+			DistributionListEmail:   tm.DistributionListEmail,
+			ChatWebhookURL:          tm.ChatWebhookURL,
+			ChatWebhookFormat:       tm.ChatWebhookFormat,
+			LegalHold:               tm.LegalHold,
+			SurveyTemplateID:        tm.SurveyTemplateID,
+			Anonymous:               tm.Anonymous,
+			AnonymityMinRespondents: tm.AnonymityMinRespondents,
+			MemberCount:             tm.MemberCount,
+			CreatedAt:               tm.CreatedAt,
+			UpdatedAt:               tm.UpdatedAt,
 		}
 	}
 
@@ -69,21 +79,32 @@ func (h *TeamAdminHandler) CreateTeam(c *gin.Context) {
 	// Auto-generate ID from name if not provided
 	teamID := req.ID
 	if teamID == "" {
-		teamID = generateTeamIDFromName(req.Name)
+		teamID = team.IDFromName(req.Name)
 	}
 
 	// Create team domain model
 	tm := &team.Team{
-		ID:                    teamID,
-		Name:                  req.Name,
-		TeamLeadID:            req.TeamLeadID,
-		Cadence:               req.Cadence,
-		DistributionListEmail: req.DistributionListEmail,
-		Members:               []team.TeamMember{},
+		ID:                      teamID,
+		Name:                    req.Name,
+		TeamLeadID:              req.TeamLeadID,
+		Cadence:                 req.Cadence,
+		DistributionListEmail:   req.DistributionListEmail,
+		ChatWebhookURL:          req.ChatWebhookURL,
+		SurveyTemplateID:        req.SurveyTemplateID,
+		Anonymous:               req.Anonymous,
+		AnonymityMinRespondents: req.AnonymityMinRespondents,
+		Members:                 []team.TeamMember{},
+	}
+	if req.ChatWebhookFormat != nil {
+		tm.ChatWebhookFormat = *req.ChatWebhookFormat
 	}
 
 	// Save using repository
 	if err := h.teamRepo.Save(c.Request.Context(), tm); err != nil {
+		if strings.Contains(err.Error(), "survey template not found") {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Survey template not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "Failed to create team",
 			Message: err.Error(),
@@ -101,15 +122,20 @@ func (h *TeamAdminHandler) CreateTeam(c *gin.Context) {
 
 	// Convert to DTO and return
 	responseDTO := dto.AdminTeamDTO{
-		ID:                    tm.ID,
-		Name:                  tm.Name,
-		TeamLeadID:            tm.TeamLeadID,
-		TeamLeadName:          teamLeadName,
-		Cadence:               tm.Cadence,
-		DistributionListEmail: tm.DistributionListEmail,
-		MemberCount:           0,
-		CreatedAt:             tm.CreatedAt,
-		UpdatedAt:             tm.UpdatedAt,
+		ID:                      tm.ID,
+		Name:                    tm.Name,
+		TeamLeadID:              tm.TeamLeadID,
+		TeamLeadName:            teamLeadName,
+		Cadence:                 tm.Cadence,
+		DistributionListEmail:   tm.DistributionListEmail,
+		ChatWebhookURL:          tm.ChatWebhookURL,
+		ChatWebhookFormat:       tm.ChatWebhookFormat,
+		SurveyTemplateID:        tm.SurveyTemplateID,
+		Anonymous:               tm.Anonymous,
+		AnonymityMinRespondents: tm.AnonymityMinRespondents,
+		MemberCount:             0,
+		CreatedAt:               tm.CreatedAt,
+		UpdatedAt:               tm.UpdatedAt,
 	}
 
 	// Auto-derive supervisor chain from team lead's reports_to hierarchy
@@ -156,9 +182,31 @@ func (h *TeamAdminHandler) UpdateTeam(c *gin.Context) {
 	if req.DistributionListEmail != nil {
 		tm.DistributionListEmail = req.DistributionListEmail
 	}
+	if req.ChatWebhookURL != nil {
+		tm.ChatWebhookURL = req.ChatWebhookURL
+	}
+	if req.ChatWebhookFormat != nil {
+		tm.ChatWebhookFormat = *req.ChatWebhookFormat
+	}
+	if req.LegalHold != nil {
+		tm.LegalHold = *req.LegalHold
+	}
+	if req.SurveyTemplateID != nil {
+		tm.SurveyTemplateID = req.SurveyTemplateID
+	}
+	if req.Anonymous != nil {
+		tm.Anonymous = *req.Anonymous
+	}
+	if req.AnonymityMinRespondents != nil {
+		tm.AnonymityMinRespondents = *req.AnonymityMinRespondents
+	}
 
 	// Update using repository
 	if err := h.teamRepo.Update(c.Request.Context(), tm); err != nil {
+		if strings.Contains(err.Error(), "survey template not found") {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Survey template not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "Failed to update team",
 			Message: err.Error(),
@@ -189,20 +237,29 @@ func (h *TeamAdminHandler) UpdateTeam(c *gin.Context) {
 		updatedTm = tm
 	}
 
+	publishTeamUpdated(c.Request.Context(), h.webhooks, updatedTm)
+
 	// Get member count
 	memberCount, _ := h.teamRepo.CountTeamMembers(c.Request.Context(), tm.ID)
 
 	// Convert to DTO and return
 	responseDTO := dto.AdminTeamDTO{
-		ID:                    updatedTm.ID,
-		Name:                  updatedTm.Name,
-		TeamLeadID:            updatedTm.TeamLeadID,
-		TeamLeadName:          updatedTm.TeamLeadName,
-		Cadence:               updatedTm.Cadence,
-		DistributionListEmail: updatedTm.DistributionListEmail,
-		MemberCount:           memberCount,
-		CreatedAt:             updatedTm.CreatedAt,
-		UpdatedAt:             updatedTm.UpdatedAt,
+		ID:                      updatedTm.ID,
+		Name:                    updatedTm.Name,
+		TeamLeadID:              updatedTm.TeamLeadID,
+		TeamLeadName:            updatedTm.TeamLeadName,
+		Cadence:                 updatedTm.Cadence,
+		NextCheckDate:           updatedTm.NextCheckDate,
+		DistributionListEmail:   updatedTm.DistributionListEmail,
+		ChatWebhookURL:          updatedTm.ChatWebhookURL,
+		ChatWebhookFormat:       updatedTm.ChatWebhookFormat,
+		LegalHold:               updatedTm.LegalHold,
+		SurveyTemplateID:        updatedTm.SurveyTemplateID,
+		Anonymous:               updatedTm.Anonymous,
+		AnonymityMinRespondents: updatedTm.AnonymityMinRespondents,
+		MemberCount:             memberCount,
+		CreatedAt:               updatedTm.CreatedAt,
+		UpdatedAt:               updatedTm.UpdatedAt,
 	}
 
 	c.JSON(http.StatusOK, responseDTO)
@@ -398,20 +455,28 @@ func (h *TeamAdminHandler) UpdateSupervisorChain(c *gin.Context) {
 	h.GetSupervisorChain(c)
 }
 
-// generateTeamIDFromName creates a URL-safe ID from a team name
-// e.g., "Test Team 123" -> "test-team-123"
-func generateTeamIDFromName(name string) string {
-	// Convert to lowercase and replace spaces with hyphens
-	id := strings.ToLower(name)
-	id = strings.ReplaceAll(id, " ", "-")
-	// Remove any characters that aren't alphanumeric or hyphens
-	var result strings.Builder
-	for _, r := range id {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
-			result.WriteRune(r)
-		}
+// publishTeamUpdated queues a team's settings for webhook endpoints; the chat webhook URL is left out
+func publishTeamUpdated(ctx context.Context, webhooks *services.WebhookService, tm *team.Team) {
+	if webhooks == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"teamId":                  tm.ID,
+		"name":                    tm.Name,
+		"teamLeadId":              tm.TeamLeadID,
+		"cadence":                 tm.Cadence,
+		"nextCheckDate":           tm.NextCheckDate,
+		"distributionListEmail":   tm.DistributionListEmail,
+		"legalHold":               tm.LegalHold,
+		"surveyTemplateId":        tm.SurveyTemplateID,
+		"anonymous":               tm.Anonymous,
+		"anonymityMinRespondents": tm.AnonymityMinRespondents,
+		"updatedAt":               tm.UpdatedAt,
+	}
+	if err := webhooks.Publish(ctx, services.EventTeamUpdated, data); err != nil {
+		logger.Get().WithContext(ctx).WithError(err).Warn("failed to publish team webhook event")
 	}
-	return result.String()
 }
 
 // deriveSupervisorChainForTeam walks up the team lead's reports_to hierarchy
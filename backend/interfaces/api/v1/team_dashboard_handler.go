@@ -4,8 +4,10 @@ import (
 	"database/sql"
 	"encoding/json"
 	"net/http"
+	"sort"
 
 	"github.com/agopalakrishnan/teams360/backend/application/trends"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
 	"github.com/agopalakrishnan/teams360/backend/pkg/telemetry"
 	"github.com/gin-gonic/gin"
@@ -18,15 +20,16 @@ type TeamDashboardHandler struct {
 }
 
 // NewTeamDashboardHandler creates a new team dashboard handler
-func NewTeamDashboardHandler(db *sql.DB) *TeamDashboardHandler {
+func NewTeamDashboardHandler(db *sql.DB, trendsService *trends.Service) *TeamDashboardHandler {
 	return &TeamDashboardHandler{
 		db:            db,
-		trendsService: trends.NewService(db),
+		trendsService: trendsService,
 	}
 }
 
 // GetHealthSummary handles GET /api/v1/teams/:teamId/dashboard/health-summary
 // Returns radar chart data (avg score per dimension)
+// Optional ?scoring=unweighted ignores dimension weights in overallHealth
 func (h *TeamDashboardHandler) GetHealthSummary(c *gin.Context) {
 	ctx := c.Request.Context()
 	teamID := c.Param("teamId")
@@ -42,22 +45,46 @@ func (h *TeamDashboardHandler) GetHealthSummary(c *gin.Context) {
 
 	assessmentPeriod := c.Query("assessmentPeriod") // Optional filter
 
+	scoring, ok := scoringFromQuery(c)
+	if !ok {
+		return
+	}
+
 	// Record team lead dashboard view
 	telemetry.RecordTeamLeadDashboardView(ctx, teamID, "health_summary")
 
-	// Query to get team info, overall health, and dimension averages
+	// Query to get team info, overall health, and dimension averages.
+	// Overall health counts each response by its dimension's weight unless scoring is unweighted.
+	// Responses count only for dimensions in the template version their session answered.
 	query := `
 		WITH team_info AS (
 			SELECT id, name
 			FROM teams
 			WHERE id = $1
 		),
+		dimension_weights AS (
+			SELECT id as dimension_id, CASE WHEN $3 THEN COALESCE(weight, 1) ELSE 1 END as weight
+			FROM health_dimensions
+		),
+		team_responses AS (
+			SELECT hcr.id, hcr.session_id, hcr.dimension_id, hcr.score
+			FROM health_check_responses hcr
+			INNER JOIN health_check_sessions hcs ON hcr.session_id = hcs.id
+			LEFT JOIN survey_template_dimensions std
+				ON std.template_id = hcs.template_id AND std.version = hcs.template_version
+				AND std.dimension_id = hcr.dimension_id
+			WHERE hcs.team_id = $1
+				AND hcs.completed = true
+				AND ($2 = '' OR hcs.assessment_period = $2)
+				AND (hcs.template_id IS NULL OR std.dimension_id IS NOT NULL)
+		),
 		session_stats AS (
 			SELECT
 				COUNT(DISTINCT hcs.id) as submission_count,
-				AVG(hcr.score) as overall_health
+				SUM(hcr.score * dw.weight) / NULLIF(SUM(dw.weight), 0) as overall_health
 			FROM health_check_sessions hcs
-			LEFT JOIN health_check_responses hcr ON hcs.id = hcr.session_id
+			LEFT JOIN team_responses hcr ON hcs.id = hcr.session_id
+			LEFT JOIN dimension_weights dw ON dw.dimension_id = hcr.dimension_id
 			WHERE hcs.team_id = $1
 				AND hcs.completed = true
 				AND ($2 = '' OR hcs.assessment_period = $2)
@@ -67,11 +94,7 @@ func (h *TeamDashboardHandler) GetHealthSummary(c *gin.Context) {
 				hcr.dimension_id,
 				AVG(hcr.score) as avg_score,
 				COUNT(hcr.id) as response_count
-			FROM health_check_responses hcr
-			INNER JOIN health_check_sessions hcs ON hcr.session_id = hcs.id
-			WHERE hcs.team_id = $1
-				AND hcs.completed = true
-				AND ($2 = '' OR hcs.assessment_period = $2)
+			FROM team_responses hcr
 			GROUP BY hcr.dimension_id
 		)
 		SELECT
@@ -101,7 +124,7 @@ func (h *TeamDashboardHandler) GetHealthSummary(c *gin.Context) {
 	var overallHealth float64
 	var dimensionsJSON []byte
 
-	err := h.db.QueryRowContext(ctx, query, teamID, assessmentPeriod).Scan(
+	err := h.db.QueryRowContext(ctx, query, teamID, assessmentPeriod, scoring.IsWeighted()).Scan(
 		&teamID_result,
 		&teamName,
 		&submissionCount,
@@ -171,7 +194,7 @@ func (h *TeamDashboardHandler) GetResponseDistribution(c *gin.Context) {
 	// Record team lead dashboard view
 	telemetry.RecordTeamLeadDashboardView(ctx, teamID, "response_distribution")
 
-	// Query to count red/yellow/green scores per dimension
+	// Query to count red/yellow/green scores per dimension of each session's template version
 	query := `
 		SELECT
 			hcr.dimension_id,
@@ -180,9 +203,13 @@ func (h *TeamDashboardHandler) GetResponseDistribution(c *gin.Context) {
 			COUNT(CASE WHEN hcr.score = 3 THEN 1 END) as green
 		FROM health_check_responses hcr
 		INNER JOIN health_check_sessions hcs ON hcr.session_id = hcs.id
+		LEFT JOIN survey_template_dimensions std
+			ON std.template_id = hcs.template_id AND std.version = hcs.template_version
+			AND std.dimension_id = hcr.dimension_id
 		WHERE hcs.team_id = $1
 			AND hcs.completed = true
 			AND ($2 = '' OR hcs.assessment_period = $2)
+			AND (hcs.template_id IS NULL OR std.dimension_id IS NOT NULL)
 		GROUP BY hcr.dimension_id
 		ORDER BY hcr.dimension_id
 	`
@@ -220,7 +247,9 @@ func (h *TeamDashboardHandler) GetResponseDistribution(c *gin.Context) {
 }
 
 // GetIndividualResponses handles GET /api/v1/teams/:teamId/dashboard/individual-responses
-// Returns individual team member responses with comments
+// Returns individual team member responses with comments.
+// Anonymous sessions are reported only for periods that reach the team's minimum respondent count,
+// and their comments are returned separately without attribution.
 func (h *TeamDashboardHandler) GetIndividualResponses(c *gin.Context) {
 	ctx := c.Request.Context()
 	teamID := c.Param("teamId")
@@ -244,9 +273,11 @@ func (h *TeamDashboardHandler) GetIndividualResponses(c *gin.Context) {
 			SELECT
 				hcs.id as session_id,
 				hcs.user_id,
-				u.full_name as user_name,
+				COALESCE(u.full_name, '') as user_name,
 				hcs.date,
 				hcs.survey_type,
+				hcs.anonymous,
+				COALESCE(hcs.assessment_period, '') as assessment_period,
 				json_agg(
 					json_build_object(
 						'dimensionId', hcr.dimension_id,
@@ -256,12 +287,12 @@ func (h *TeamDashboardHandler) GetIndividualResponses(c *gin.Context) {
 					) ORDER BY hcr.dimension_id
 				) as dimensions
 			FROM health_check_sessions hcs
-			INNER JOIN users u ON hcs.user_id = u.id
+			LEFT JOIN users u ON hcs.user_id = u.id AND NOT hcs.anonymous
 			INNER JOIN health_check_responses hcr ON hcs.id = hcr.session_id
 			WHERE hcs.team_id = $1
 				AND hcs.completed = true
 				AND ($2 = '' OR hcs.assessment_period = $2)
-			GROUP BY hcs.id, hcs.user_id, u.full_name, hcs.date, hcs.survey_type
+			GROUP BY hcs.id, hcs.user_id, u.full_name, hcs.date, hcs.survey_type, hcs.anonymous, hcs.assessment_period
 		)
 		SELECT
 			session_id,
@@ -269,6 +300,8 @@ func (h *TeamDashboardHandler) GetIndividualResponses(c *gin.Context) {
 			user_name,
 			date,
 			survey_type,
+			anonymous,
+			assessment_period,
 			dimensions
 		FROM session_responses
 		ORDER BY date DESC
@@ -285,8 +318,11 @@ func (h *TeamDashboardHandler) GetIndividualResponses(c *gin.Context) {
 	defer rows.Close()
 
 	responses := []dto.IndividualUserResponse{}
+	periods := []string{}
+	respondents := make(map[string]map[string]bool) // period -> anonymous respondent tokens
 	for rows.Next() {
 		var resp dto.IndividualUserResponse
+		var period string
 		var dimensionsJSON []byte
 
 		err := rows.Scan(
@@ -295,6 +331,8 @@ func (h *TeamDashboardHandler) GetIndividualResponses(c *gin.Context) {
 			&resp.UserName,
 			&resp.Date,
 			&resp.SurveyType,
+			&resp.Anonymous,
+			&period,
 			&dimensionsJSON,
 		)
 		if err != nil {
@@ -319,19 +357,73 @@ func (h *TeamDashboardHandler) GetIndividualResponses(c *gin.Context) {
 			resp.Dimensions = []dto.IndividualDimensionResp{}
 		}
 
+		if resp.Anonymous {
+			if respondents[period] == nil {
+				respondents[period] = make(map[string]bool)
+			}
+			respondents[period][resp.UserID] = true
+		}
+
 		responses = append(responses, resp)
+		periods = append(periods, period)
+	}
+
+	minRespondents := healthcheck.DefaultMinRespondents
+	err = h.db.QueryRowContext(ctx, `SELECT anonymity_min_respondents FROM teams WHERE id = $1`, teamID).Scan(&minRespondents)
+	if err != nil && err != sql.ErrNoRows {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Database query failed",
+			Message: err.Error(),
+		})
+		return
 	}
 
 	result := dto.IndividualResponses{
-		TeamID:    teamID,
-		Responses: responses,
+		TeamID:            teamID,
+		Responses:         []dto.IndividualUserResponse{},
+		MinRespondents:    minRespondents,
+		AnonymousComments: []dto.AnonymousComment{},
 	}
+	for i, resp := range responses {
+		if !resp.Anonymous {
+			result.Responses = append(result.Responses, resp)
+			continue
+		}
+		if len(respondents[periods[i]]) < minRespondents {
+			result.SuppressedResponses++
+			continue
+		}
+		for j, dim := range resp.Dimensions {
+			if dim.Comment != "" {
+				result.AnonymousComments = append(result.AnonymousComments, dto.AnonymousComment{
+					DimensionID:      dim.DimensionID,
+					AssessmentPeriod: periods[i],
+					Comment:          dim.Comment,
+				})
+				resp.Dimensions[j].Comment = ""
+			}
+		}
+		result.Responses = append(result.Responses, resp)
+	}
+
+	// Order comments by content rather than by session so they cannot be matched back to a respondent
+	sort.Slice(result.AnonymousComments, func(a, b int) bool {
+		ca, cb := result.AnonymousComments[a], result.AnonymousComments[b]
+		if ca.AssessmentPeriod != cb.AssessmentPeriod {
+			return ca.AssessmentPeriod < cb.AssessmentPeriod
+		}
+		if ca.DimensionID != cb.DimensionID {
+			return ca.DimensionID < cb.DimensionID
+		}
+		return ca.Comment < cb.Comment
+	})
 
 	c.JSON(http.StatusOK, result)
 }
 
 // GetTrends handles GET /api/v1/teams/:teamId/dashboard/trends
 // Returns trend data across assessment periods
+// Optional ?scoring=unweighted ignores dimension weights in the overall series
 func (h *TeamDashboardHandler) GetTrends(c *gin.Context) {
 	ctx := c.Request.Context()
 	teamID := c.Param("teamId")
@@ -344,11 +436,16 @@ func (h *TeamDashboardHandler) GetTrends(c *gin.Context) {
 		return
 	}
 
+	scoring, ok := scoringFromQuery(c)
+	if !ok {
+		return
+	}
+
 	// Record team lead dashboard view for trends
 	telemetry.RecordTeamLeadDashboardView(ctx, teamID, "trends")
 	telemetry.RecordTrendReportView(ctx, teamID, "team_lead")
 
-	result, err := h.trendsService.GetTrendsForTeam(ctx, teamID)
+	result, err := h.trendsService.GetTrendsForTeam(ctx, teamID, scoring)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "Failed to fetch trend data",
@@ -360,8 +457,23 @@ func (h *TeamDashboardHandler) GetTrends(c *gin.Context) {
 	response := dto.TrendData{
 		TeamID:     teamID,
 		Periods:    result.Periods,
+		Overall:    result.Overall,
 		Dimensions: result.Dimensions,
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// scoringFromQuery reads the optional scoring query parameter (weighted or unweighted).
+// It responds with 400 and returns false when the value is invalid.
+func scoringFromQuery(c *gin.Context) (healthcheck.Scoring, bool) {
+	scoring, err := healthcheck.ParseScoring(c.Query("scoring"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid scoring",
+			Message: err.Error(),
+		})
+		return "", false
+	}
+	return scoring, true
+}
@@ -4,19 +4,20 @@ import (
 	"database/sql"
 
 	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/application/trends"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
 	"github.com/gin-gonic/gin"
 )
 
 // SetupTeamDashboardRoutes registers team lead dashboard routes
 // All routes require JWT authentication and team membership
-func SetupTeamDashboardRoutes(router *gin.Engine, db *sql.DB, jwtService *services.JWTService) {
-	handler := NewTeamDashboardHandler(db)
+func SetupTeamDashboardRoutes(router *gin.Engine, db *sql.DB, trendsService *trends.Service, jwtService *services.JWTService, permissions *services.PermissionService) {
+	handler := NewTeamDashboardHandler(db, trendsService)
 
 	// Team Lead Dashboard routes - require authentication + team membership
 	dashboard := router.Group("/api/v1/teams/:teamId/dashboard")
 	dashboard.Use(middleware.JWTAuthMiddleware(jwtService))
-	dashboard.Use(middleware.TeamMembershipMiddleware("teamId"))
+	dashboard.Use(middleware.TeamMembershipMiddleware(permissions, "teamId"))
 	{
 		dashboard.GET("/health-summary", handler.GetHealthSummary)
 		dashboard.GET("/response-distribution", handler.GetResponseDistribution)
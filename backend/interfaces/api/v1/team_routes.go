@@ -67,10 +67,17 @@ func (h *TeamHandler) GetTeamSessions(c *gin.Context) {
 		return
 	}
 
+	sessions, suppressed, err := redactTeamSessions(c.Request.Context(), h.healthCheckRepo, teamID, sessions)
+	if err != nil {
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to fetch team sessions", err.Error())
+		return
+	}
+
 	// Convert to response DTO
 	response := dto.HealthCheckSessionsResponse{
-		Sessions: make([]dto.HealthCheckSessionResponse, len(sessions)),
-		Total:    len(sessions),
+		Sessions:   make([]dto.HealthCheckSessionResponse, len(sessions)),
+		Total:      len(sessions),
+		Suppressed: suppressed,
 	}
 
 	for i, session := range sessions {
@@ -171,8 +178,8 @@ func (h *TeamHandler) ListTeams(c *gin.Context) {
 
 // SetupTeamRoutes registers team-related routes
 // All team routes require JWT authentication
-// Team-specific routes require team membership or manager+ privileges
-func SetupTeamRoutes(router *gin.Engine, healthCheckRepo healthcheck.Repository, teamRepo team.Repository, jwtService *services.JWTService) {
+// Team-specific routes require team membership or the CanViewAllTeams permission
+func SetupTeamRoutes(router *gin.Engine, healthCheckRepo healthcheck.Repository, teamRepo team.Repository, jwtService *services.JWTService, permissions *services.PermissionService) {
 	handler := NewTeamHandler(healthCheckRepo, teamRepo)
 
 	// Team routes - require authentication
@@ -182,9 +189,9 @@ func SetupTeamRoutes(router *gin.Engine, healthCheckRepo healthcheck.Repository,
 		// List all teams (authenticated users only)
 		teams.GET("", handler.ListTeams)
 
-		// Team-specific routes require team membership or manager+ privileges
+		// Team-specific routes require team membership or the CanViewAllTeams permission
 		teamSpecific := teams.Group("/:teamId")
-		teamSpecific.Use(middleware.TeamMembershipMiddleware("teamId"))
+		teamSpecific.Use(middleware.TeamMembershipMiddleware(permissions, "teamId"))
 		{
 			teamSpecific.GET("/sessions", handler.GetTeamSessions)
 			teamSpecific.GET("/info", handler.GetTeamInfo)
@@ -0,0 +1,172 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// TeamSettingsHandler lets team leads and supervisors manage their own team's roster,
+// cadence and distribution list without admin privileges
+type TeamSettingsHandler struct {
+	teamRepo team.Repository
+	userRepo user.Repository
+	webhooks *services.WebhookService
+}
+
+// NewTeamSettingsHandler creates a new TeamSettingsHandler.
+// webhooks may be nil, in which case no webhook events are published.
+func NewTeamSettingsHandler(teamRepo team.Repository, userRepo user.Repository, webhooks *services.WebhookService) *TeamSettingsHandler {
+	return &TeamSettingsHandler{teamRepo: teamRepo, userRepo: userRepo, webhooks: webhooks}
+}
+
+// GetTeamSettings handles GET /api/v1/teams/:teamId/settings
+func (h *TeamSettingsHandler) GetTeamSettings(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	tm, err := h.teamRepo.FindByID(ctx, c.Param("teamId"))
+	if err != nil {
+		dto.RespondError(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	members, err := h.teamRepo.FindTeamMembers(ctx, tm.ID)
+	if err != nil {
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to fetch team members", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, toTeamSettingsResponse(tm, members))
+}
+
+// UpdateTeamSettings handles PUT /api/v1/teams/:teamId/settings
+// Only the cadence and distribution list email can be changed here; everything else is admin-only.
+// Changing the cadence moves the team's next check date onto the new cadence at once.
+func (h *TeamSettingsHandler) UpdateTeamSettings(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req dto.UpdateTeamSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.RespondErrorWithDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	tm, err := h.teamRepo.FindByID(ctx, c.Param("teamId"))
+	if err != nil {
+		dto.RespondError(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	if req.Cadence != nil && *req.Cadence != tm.Cadence {
+		// Move the next check onto the new schedule now rather than after the current cycle
+		tm.Cadence = *req.Cadence
+		tm.NextCheckDate = campaign.NextCheckDate(tm.Cadence, time.Now()).Format("2006-01-02")
+	}
+	if req.DistributionListEmail != nil {
+		if *req.DistributionListEmail == "" {
+			tm.DistributionListEmail = nil
+		} else {
+			tm.DistributionListEmail = req.DistributionListEmail
+		}
+	}
+
+	if err := h.teamRepo.Update(ctx, tm); err != nil {
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to update team settings", err.Error())
+		return
+	}
+
+	updated, err := h.teamRepo.FindByID(ctx, tm.ID)
+	if err != nil {
+		updated = tm
+	}
+	publishTeamUpdated(ctx, h.webhooks, updated)
+	members, err := h.teamRepo.FindTeamMembers(ctx, tm.ID)
+	if err != nil {
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to fetch team members", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, toTeamSettingsResponse(updated, members))
+}
+
+// AddTeamMember handles POST /api/v1/teams/:teamId/settings/members
+func (h *TeamSettingsHandler) AddTeamMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	teamID := c.Param("teamId")
+
+	var req dto.AddTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		dto.RespondErrorWithDetails(c, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if _, err := h.userRepo.FindByID(ctx, req.UserID); err != nil {
+		dto.RespondError(c, http.StatusNotFound, "User not found")
+		return
+	}
+
+	if err := h.teamRepo.AddMember(ctx, teamID, req.UserID); err != nil {
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to add team member", err.Error())
+		return
+	}
+
+	dto.RespondMessage(c, http.StatusCreated, "Member added successfully")
+}
+
+// RemoveTeamMember handles DELETE /api/v1/teams/:teamId/settings/members/:userId
+// The team lead cannot be removed here; changing the lead is admin-only.
+func (h *TeamSettingsHandler) RemoveTeamMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	teamID := c.Param("teamId")
+	userID := c.Param("userId")
+
+	tm, err := h.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		dto.RespondError(c, http.StatusNotFound, "Team not found")
+		return
+	}
+	if tm.TeamLeadID != nil && *tm.TeamLeadID == userID {
+		dto.RespondError(c, http.StatusConflict, "The team lead cannot be removed from the team")
+		return
+	}
+
+	if err := h.teamRepo.RemoveMember(ctx, teamID, userID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			dto.RespondError(c, http.StatusNotFound, "Team member not found")
+			return
+		}
+		dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to remove team member", err.Error())
+		return
+	}
+
+	dto.RespondMessage(c, http.StatusOK, "Member removed successfully")
+}
+
+// toTeamSettingsResponse converts a team and its roster to the settings response
+func toTeamSettingsResponse(tm *team.Team, members []team.TeamMember) dto.TeamSettingsResponse {
+	memberDTOs := make([]dto.TeamMemberAdminDTO, len(members))
+	for i, m := range members {
+		memberDTOs[i] = dto.TeamMemberAdminDTO{
+			UserID:   m.ID,
+			UserName: m.FullName,
+			Email:    m.Email,
+		}
+	}
+
+	return dto.TeamSettingsResponse{
+		TeamID:                tm.ID,
+		TeamName:              tm.Name,
+		TeamLeadID:            tm.TeamLeadID,
+		Cadence:               tm.Cadence,
+		NextCheckDate:         tm.NextCheckDate,
+		DistributionListEmail: tm.DistributionListEmail,
+		Members:               memberDTOs,
+	}
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"database/sql"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/domain/user"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupTeamSettingsRoutes registers delegated team administration routes
+// All routes require JWT authentication and that the user leads the team or is in its supervisor chain.
+// Writes are recorded in the audit log unless audit is nil, and publish webhook events unless webhooks is nil.
+func SetupTeamSettingsRoutes(router *gin.Engine, db *sql.DB, teamRepo team.Repository, userRepo user.Repository, jwtService *services.JWTService, audit *services.AdminAuditService, webhooks *services.WebhookService) {
+	handler := NewTeamSettingsHandler(teamRepo, userRepo, webhooks)
+	actionItems := NewActionItemHandler(db, webhooks)
+
+	teamTarget := teamAuditTarget(teamRepo)
+	teamTarget.Param = "teamId"
+	membersTarget := teamMembersAuditTarget(teamRepo)
+	membersTarget.Param = "teamId"
+
+	settings := router.Group("/api/v1/teams/:teamId/settings")
+	settings.Use(middleware.JWTAuthMiddleware(jwtService))
+	settings.Use(middleware.TeamLeadOrSupervisorMiddleware(teamRepo, "teamId"))
+	{
+		settings.GET("", handler.GetTeamSettings)
+		settings.PUT("", auditAdminWrite(audit, "team.settings.update", teamTarget), handler.UpdateTeamSettings)
+
+		// Roster; GET "" lists the members
+		settings.POST("/members", auditAdminWrite(audit, "team.member.add", membersTarget), handler.AddTeamMember)
+		settings.DELETE("/members/:userId", auditAdminWrite(audit, "team.member.remove", membersTarget), handler.RemoveTeamMember)
+
+		// Action items, authorized like the rest of the settings rather than by team membership
+		settings.GET("/action-items", actionItems.ListActionItems)
+		settings.POST("/action-items", actionItems.CreateActionItem)
+		settings.PATCH("/action-items/:id", actionItems.UpdateActionItem)
+		settings.DELETE("/action-items/:id", actionItems.DeleteActionItem)
+	}
+}
@@ -2,9 +2,10 @@ package v1
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"strings"
 
+	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/domain/team"
 	"github.com/agopalakrishnan/teams360/backend/domain/user"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
@@ -15,13 +16,30 @@ import (
 
 // UserAdminHandler handles user-related admin HTTP requests
 type UserAdminHandler struct {
-	userRepo user.Repository
-	teamRepo team.Repository
+	userRepo    user.Repository
+	teamRepo    team.Repository
+	permissions *services.PermissionService
 }
 
-// NewUserAdminHandler creates a new UserAdminHandler
-func NewUserAdminHandler(userRepo user.Repository, teamRepo team.Repository) *UserAdminHandler {
-	return &UserAdminHandler{userRepo: userRepo, teamRepo: teamRepo}
+// NewUserAdminHandler creates a new UserAdminHandler.
+// permissions may be nil when the handler is only used to re-derive supervisor chains.
+func NewUserAdminHandler(userRepo user.Repository, teamRepo team.Repository, permissions *services.PermissionService) *UserAdminHandler {
+	return &UserAdminHandler{userRepo: userRepo, teamRepo: teamRepo, permissions: permissions}
+}
+
+// authorizeLevel refuses a hierarchy level with permissions the caller lacks.
+// It writes the response and returns false when the level is refused.
+func (h *UserAdminHandler) authorizeLevel(c *gin.Context, levelID string, refusal string) bool {
+	err := h.permissions.AuthorizeLevel(c.Request.Context(), c.GetString("hierarchyLevel"), levelID)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, services.ErrPermissionDenied):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: refusal, Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to check permissions", Message: err.Error()})
+	}
+	return false
 }
 
 // ListUsers handles GET /api/v1/admin/users
@@ -72,6 +90,10 @@ func (h *UserAdminHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
+	if !h.authorizeLevel(c, req.HierarchyLevel, "Cannot assign a hierarchy level with permissions you do not have") {
+		return
+	}
+
 	// Determine auth type (default to local)
 	authType := user.AuthTypeLocal
 	if req.AuthType == "sso" {
@@ -97,7 +119,7 @@ func (h *UserAdminHandler) CreateUser(c *gin.Context) {
 	// Auto-generate ID from username if not provided
 	userID := req.ID
 	if userID == "" {
-		userID = generateUserIDFromUsername(req.Username)
+		userID = user.IDFromUsername(req.Username)
 	}
 
 	// Create user domain model
@@ -156,6 +178,15 @@ func (h *UserAdminHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	// Users at a level with permissions the caller lacks are out of reach, and so are such levels
+	if !h.authorizeLevel(c, usr.HierarchyLevelID, "Cannot manage a user whose hierarchy level has permissions you do not have") {
+		return
+	}
+	if req.HierarchyLevel != nil && *req.HierarchyLevel != usr.HierarchyLevelID &&
+		!h.authorizeLevel(c, *req.HierarchyLevel, "Cannot assign a hierarchy level with permissions you do not have") {
+		return
+	}
+
 	// Capture pre-update values for change detection
 	oldReportsTo := usr.ReportsTo
 	oldHierarchyLevel := usr.HierarchyLevelID
@@ -265,6 +296,11 @@ func (h *UserAdminHandler) UpdateUser(c *gin.Context) {
 func (h *UserAdminHandler) DeleteUser(c *gin.Context) {
 	id := c.Param("id")
 
+	if usr, err := h.userRepo.FindByID(c.Request.Context(), id); err == nil &&
+		!h.authorizeLevel(c, usr.HierarchyLevelID, "Cannot manage a user whose hierarchy level has permissions you do not have") {
+		return
+	}
+
 	if err := h.userRepo.Delete(c.Request.Context(), id); err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "Failed to delete user",
@@ -276,23 +312,6 @@ func (h *UserAdminHandler) DeleteUser(c *gin.Context) {
 	dto.RespondMessage(c, http.StatusOK, "User deleted successfully")
 }
 
-// generateUserIDFromUsername creates a URL-safe ID from a username
-// e.g., "test_user" -> "test-user"
-func generateUserIDFromUsername(username string) string {
-	// Convert to lowercase, replace underscores and spaces with hyphens
-	id := strings.ToLower(username)
-	id = strings.ReplaceAll(id, "_", "-")
-	id = strings.ReplaceAll(id, " ", "-")
-	// Remove any characters that aren't alphanumeric or hyphens
-	var result strings.Builder
-	for _, r := range id {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
-			result.WriteRune(r)
-		}
-	}
-	return result.String()
-}
-
 // ptrStrEqual compares two *string values for equality (nil-safe).
 func ptrStrEqual(a, b *string) bool {
 	if a == nil && b == nil {
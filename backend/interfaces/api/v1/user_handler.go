@@ -67,6 +67,7 @@ func SetupProtectedUserRoutes(router *gin.Engine, db *sql.DB, jwtService *servic
 }
 
 // GetUserSurveyHistory handles GET /api/v1/users/:userId/survey-history
+// Optional ?scoring=unweighted ignores dimension weights in each session's avgScore
 func (h *UserHandler) GetUserSurveyHistory(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID := c.Param("userId")
@@ -89,7 +90,14 @@ func (h *UserHandler) GetUserSurveyHistory(c *gin.Context) {
 		}
 	}
 
-	// Build query to get user's survey history
+	scoring, ok := scoringFromQuery(c)
+	if !ok {
+		return
+	}
+
+	// Build query to get user's survey history.
+	// avg_score counts each response by its dimension's weight unless scoring is unweighted,
+	// and only responses to dimensions in the template version the session answered.
 	query := `
 		SELECT
 			hcs.id as session_id,
@@ -98,19 +106,33 @@ func (h *UserHandler) GetUserSurveyHistory(c *gin.Context) {
 			hcs.date,
 			COALESCE(hcs.assessment_period, '') as assessment_period,
 			hcs.completed,
-			COALESCE(AVG(hcr.score), 0) as avg_score,
+			COALESCE(SUM(hcr.score * dw.weight) / NULLIF(SUM(dw.weight), 0), 0) as avg_score,
 			COUNT(hcr.id) as response_count
 		FROM health_check_sessions hcs
 		JOIN teams t ON hcs.team_id = t.id
-		LEFT JOIN health_check_responses hcr ON hcs.id = hcr.session_id
+		LEFT JOIN (
+			SELECT r.id, r.session_id, r.dimension_id, r.score
+			FROM health_check_responses r
+			INNER JOIN health_check_sessions s ON r.session_id = s.id
+			LEFT JOIN survey_template_dimensions std
+				ON std.template_id = s.template_id AND std.version = s.template_version
+				AND std.dimension_id = r.dimension_id
+			WHERE s.user_id = $1
+				AND (s.template_id IS NULL OR std.dimension_id IS NOT NULL)
+		) hcr ON hcs.id = hcr.session_id
+		LEFT JOIN (
+			SELECT id, CASE WHEN $4 THEN COALESCE(weight, 1) ELSE 1 END as weight
+			FROM health_dimensions
+		) dw ON dw.id = hcr.dimension_id
 		WHERE hcs.user_id = $1
+			AND hcs.completed = true
 			AND ($2 = '' OR hcs.assessment_period = $2)
 		GROUP BY hcs.id, hcs.team_id, t.name, hcs.date, hcs.assessment_period, hcs.completed
 		ORDER BY hcs.date DESC
 		LIMIT $3
 	`
 
-	rows, err := h.db.QueryContext(ctx, query, userID, assessmentPeriod, limit)
+	rows, err := h.db.QueryContext(ctx, query, userID, assessmentPeriod, limit, scoring.IsWeighted())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "Database query failed",
@@ -191,6 +213,7 @@ func (h *UserHandler) GetUserSurveyHistory(c *gin.Context) {
 		SELECT COUNT(DISTINCT hcs.id)
 		FROM health_check_sessions hcs
 		WHERE hcs.user_id = $1
+			AND hcs.completed = true
 			AND ($2 = '' OR hcs.assessment_period = $2)
 	`
 
@@ -9,14 +9,14 @@ import (
 )
 
 // SetupUserRoutes registers user-related routes
-// All routes require JWT authentication
-func SetupUserRoutes(router *gin.Engine, db *sql.DB, jwtService *services.JWTService) {
+// All routes require JWT authentication; other users' data requires CanViewAllTeams
+func SetupUserRoutes(router *gin.Engine, db *sql.DB, jwtService *services.JWTService, permissions *services.PermissionService) {
 	handler := NewUserHandler(db)
 
 	// User routes - require authentication + same user or manager
 	userRoutes := router.Group("/api/v1/users/:userId")
 	userRoutes.Use(middleware.JWTAuthMiddleware(jwtService))
-	userRoutes.Use(middleware.SameUserOrManagerMiddleware("userId"))
+	userRoutes.Use(middleware.SameUserOrManagerMiddleware(permissions, "userId"))
 	{
 		userRoutes.GET("/survey-history", handler.GetUserSurveyHistory)
 	}
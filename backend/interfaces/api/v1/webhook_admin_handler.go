@@ -0,0 +1,244 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// Page sizes of the webhook delivery log
+const (
+	defaultWebhookDeliveryPageSize = 50
+	maxWebhookDeliveryPageSize     = 200
+)
+
+// WebhookAdminHandler handles webhook endpoint administration HTTP requests
+type WebhookAdminHandler struct {
+	webhooks *services.WebhookService
+}
+
+// NewWebhookAdminHandler creates a new WebhookAdminHandler
+func NewWebhookAdminHandler(webhooks *services.WebhookService) *WebhookAdminHandler {
+	return &WebhookAdminHandler{webhooks: webhooks}
+}
+
+// ListWebhooks handles GET /api/v1/admin/webhooks
+func (h *WebhookAdminHandler) ListWebhooks(c *gin.Context) {
+	endpoints, err := h.webhooks.ListEndpoints(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "Failed to fetch webhooks", Message: err.Error()})
+		return
+	}
+
+	dtos := make([]dto.WebhookEndpointDTO, len(endpoints))
+	for i, endpoint := range endpoints {
+		dtos[i] = toWebhookEndpointDTO(endpoint)
+	}
+	c.JSON(http.StatusOK, dto.WebhookEndpointsResponse{Endpoints: dtos})
+}
+
+// GetWebhook handles GET /api/v1/admin/webhooks/:id
+func (h *WebhookAdminHandler) GetWebhook(c *gin.Context) {
+	endpoint, err := h.webhooks.GetEndpoint(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondWebhookError(c, err, "Failed to fetch webhook")
+		return
+	}
+	c.JSON(http.StatusOK, toWebhookEndpointDTO(endpoint))
+}
+
+// CreateWebhook handles POST /api/v1/admin/webhooks
+// The signing secret is returned once and cannot be retrieved again.
+func (h *WebhookAdminHandler) CreateWebhook(c *gin.Context) {
+	var req dto.CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request body", Message: err.Error()})
+		return
+	}
+
+	createdBy, _ := middleware.GetUserIDFromContext(c)
+	endpoint := &services.WebhookEndpoint{
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      toWebhookEvents(req.Events),
+		Active:      req.Active == nil || *req.Active,
+		CreatedBy:   createdBy,
+	}
+	if err := h.webhooks.CreateEndpoint(c.Request.Context(), endpoint); err != nil {
+		respondWebhookError(c, err, "Failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreatedWebhookEndpointResponse{
+		WebhookEndpointDTO: toWebhookEndpointDTO(endpoint),
+		Secret:             endpoint.Secret,
+	})
+}
+
+// UpdateWebhook handles PUT /api/v1/admin/webhooks/:id
+func (h *WebhookAdminHandler) UpdateWebhook(c *gin.Context) {
+	var req dto.UpdateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid request body", Message: err.Error()})
+		return
+	}
+
+	endpoint, err := h.webhooks.GetEndpoint(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondWebhookError(c, err, "Failed to update webhook")
+		return
+	}
+
+	if req.URL != nil {
+		endpoint.URL = *req.URL
+	}
+	if req.Description != nil {
+		endpoint.Description = *req.Description
+	}
+	if req.Events != nil {
+		endpoint.Events = toWebhookEvents(req.Events)
+	}
+	if req.Active != nil {
+		endpoint.Active = *req.Active
+	}
+
+	if err := h.webhooks.UpdateEndpoint(c.Request.Context(), endpoint); err != nil {
+		respondWebhookError(c, err, "Failed to update webhook")
+		return
+	}
+	c.JSON(http.StatusOK, toWebhookEndpointDTO(endpoint))
+}
+
+// DeleteWebhook handles DELETE /api/v1/admin/webhooks/:id
+// Deliveries still queued for the endpoint are dropped with its delivery log.
+func (h *WebhookAdminHandler) DeleteWebhook(c *gin.Context) {
+	if err := h.webhooks.DeleteEndpoint(c.Request.Context(), c.Param("id")); err != nil {
+		respondWebhookError(c, err, "Failed to delete webhook")
+		return
+	}
+	dto.RespondMessage(c, http.StatusOK, "Webhook deleted successfully")
+}
+
+// ListWebhookDeliveries handles GET /api/v1/admin/webhooks/:id/deliveries
+// Optional query parameters: status (pending, delivered or failed), limit (default 50, max 200) and offset.
+func (h *WebhookAdminHandler) ListWebhookDeliveries(c *gin.Context) {
+	status := services.WebhookDeliveryStatus(c.Query("status"))
+	switch status {
+	case "", services.WebhookDeliveryPending, services.WebhookDeliveryDelivered, services.WebhookDeliveryFailed:
+	default:
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid status", Message: "status must be pending, delivered or failed"})
+		return
+	}
+
+	limit, offset, err := webhookPageFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "Invalid paging", Message: err.Error()})
+		return
+	}
+
+	deliveries, total, err := h.webhooks.Deliveries(c.Request.Context(), c.Param("id"), status, limit, offset)
+	if err != nil {
+		respondWebhookError(c, err, "Failed to fetch webhook deliveries")
+		return
+	}
+
+	dtos := make([]dto.WebhookDeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		dtos[i] = toWebhookDeliveryDTO(delivery)
+	}
+	c.JSON(http.StatusOK, dto.WebhookDeliveriesResponse{Deliveries: dtos, Total: total, Limit: limit, Offset: offset})
+}
+
+// RetryWebhookDelivery handles POST /api/v1/admin/webhooks/:id/deliveries/:deliveryId/retry
+// Queues a failed delivery again with a fresh set of attempts.
+func (h *WebhookAdminHandler) RetryWebhookDelivery(c *gin.Context) {
+	err := h.webhooks.RetryDelivery(c.Request.Context(), c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		respondWebhookError(c, err, "Failed to retry webhook delivery")
+		return
+	}
+	dto.RespondMessage(c, http.StatusAccepted, "Webhook delivery queued")
+}
+
+// respondWebhookError maps webhook service errors to responses
+func respondWebhookError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "Webhook not found"})
+	case errors.Is(err, services.ErrInvalidWebhookURL), errors.Is(err, services.ErrInvalidWebhookEvent):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, services.ErrWebhookDeliveryNotFailed):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: message, Message: err.Error()})
+	}
+}
+
+// webhookPageFromQuery reads limit and offset for the delivery log
+func webhookPageFromQuery(c *gin.Context) (int, int, error) {
+	limit, offset := defaultWebhookDeliveryPageSize, 0
+	var err error
+	if raw := c.Query("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > maxWebhookDeliveryPageSize {
+			return 0, 0, fmt.Errorf("limit must be between 1 and %d", maxWebhookDeliveryPageSize)
+		}
+	}
+	if raw := c.Query("offset"); raw != "" {
+		if offset, err = strconv.Atoi(raw); err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("offset must be a non-negative integer")
+		}
+	}
+	return limit, offset, nil
+}
+
+func toWebhookEvents(names []string) []services.WebhookEvent {
+	events := make([]services.WebhookEvent, len(names))
+	for i, name := range names {
+		events[i] = services.WebhookEvent(name)
+	}
+	return events
+}
+
+func toWebhookEndpointDTO(endpoint *services.WebhookEndpoint) dto.WebhookEndpointDTO {
+	events := make([]string, len(endpoint.Events))
+	for i, event := range endpoint.Events {
+		events[i] = string(event)
+	}
+	return dto.WebhookEndpointDTO{
+		ID:          endpoint.ID,
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		Events:      events,
+		Active:      endpoint.Active,
+		CreatedBy:   endpoint.CreatedBy,
+		CreatedAt:   endpoint.CreatedAt,
+		UpdatedAt:   endpoint.UpdatedAt,
+	}
+}
+
+func toWebhookDeliveryDTO(delivery *services.WebhookDelivery) dto.WebhookDeliveryDTO {
+	result := dto.WebhookDeliveryDTO{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.Status == services.WebhookDeliveryPending {
+		next := delivery.NextAttemptAt
+		result.NextAttemptAt = &next
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWebhookRoutes configures outbound webhook admin routes
+// All routes require JWT authentication and the CanConfigureSystem permission; writes are recorded in the audit log unless audit is nil
+func SetupWebhookRoutes(router *gin.Engine, webhooks *services.WebhookService, jwtService *services.JWTService, permissions *services.PermissionService, audit *services.AdminAuditService) {
+	handler := NewWebhookAdminHandler(webhooks)
+	target := webhookAuditTarget(webhooks)
+
+	endpoints := router.Group("/api/v1/admin/webhooks")
+	endpoints.Use(middleware.JWTAuthMiddleware(jwtService))
+	endpoints.Use(middleware.RequirePermission(permissions, organization.PermissionConfigureSystem))
+	{
+		endpoints.GET("", handler.ListWebhooks)
+		endpoints.POST("", auditAdminWrite(audit, "webhook.create", createAudit(target)), handler.CreateWebhook)
+		endpoints.GET("/:id", handler.GetWebhook)
+		endpoints.PUT("/:id", auditAdminWrite(audit, "webhook.update", target), handler.UpdateWebhook)
+		endpoints.DELETE("/:id", auditAdminWrite(audit, "webhook.delete", target), handler.DeleteWebhook)
+
+		// Delivery log
+		endpoints.GET("/:id/deliveries", handler.ListWebhookDeliveries)
+		endpoints.POST("/:id/deliveries/:deliveryId/retry", auditAdminWrite(audit, "webhook.delivery.retry", target), handler.RetryWebhookDelivery)
+	}
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // ============================================================================
 // Hierarchy Levels DTOs
@@ -12,17 +15,21 @@ type HierarchyLevelDTO struct {
 	Name        string                  `json:"name"`
 	Position    int                     `json:"position"`
 	Permissions HierarchyPermissionsDTO `json:"permissions"`
+	RequireMFA  bool                    `json:"requireMfa"`
 	CreatedAt   time.Time               `json:"createdAt"`
 	UpdatedAt   time.Time               `json:"updatedAt"`
 }
 
 // HierarchyPermissionsDTO represents permissions for a hierarchy level
 type HierarchyPermissionsDTO struct {
-	CanViewAllTeams  bool `json:"canViewAllTeams"`
-	CanEditTeams     bool `json:"canEditTeams"`
-	CanManageUsers   bool `json:"canManageUsers"`
-	CanTakeSurvey    bool `json:"canTakeSurvey"`
-	CanViewAnalytics bool `json:"canViewAnalytics"`
+	CanViewAllTeams    bool `json:"canViewAllTeams"`
+	CanEditTeams       bool `json:"canEditTeams"`
+	CanManageUsers     bool `json:"canManageUsers"`
+	CanTakeSurvey      bool `json:"canTakeSurvey"`
+	CanViewAnalytics   bool `json:"canViewAnalytics"`
+	CanConfigureSystem bool `json:"canConfigureSystem"`
+	CanViewReports     bool `json:"canViewReports"`
+	CanExportData      bool `json:"canExportData"`
 }
 
 // CreateHierarchyLevelRequest represents request to create a hierarchy level
@@ -30,12 +37,14 @@ type CreateHierarchyLevelRequest struct {
 	ID          string                  `json:"id"`                      // Optional - will be auto-generated from name if not provided
 	Name        string                  `json:"name" binding:"required"` // Required - used to generate ID if not provided
 	Permissions HierarchyPermissionsDTO `json:"permissions"`
+	RequireMFA  bool                    `json:"requireMfa"` // Local users at this level must use TOTP MFA
 }
 
 // UpdateHierarchyLevelRequest represents request to update a hierarchy level
 type UpdateHierarchyLevelRequest struct {
 	Name        string                   `json:"name"`
 	Permissions *HierarchyPermissionsDTO `json:"permissions"`
+	RequireMFA  *bool                    `json:"requireMfa"`
 }
 
 // UpdateHierarchyPositionRequest represents request to reorder hierarchy levels
@@ -101,32 +110,50 @@ type UsersResponse struct {
 
 // AdminTeamDTO represents detailed team information for admin
 type AdminTeamDTO struct {
-	ID                    string    `json:"id"`
-	Name                  string    `json:"name"`
-	TeamLeadID            *string   `json:"teamLeadId"`
-	TeamLeadName          *string   `json:"teamLeadName"`
-	Cadence               string    `json:"cadence"`
-	DistributionListEmail *string   `json:"distributionListEmail,omitempty"`
-	MemberCount           int       `json:"memberCount"`
-	CreatedAt             time.Time `json:"createdAt"`
-	UpdatedAt             time.Time `json:"updatedAt"`
+	ID                      string    `json:"id"`
+	Name                    string    `json:"name"`
+	TeamLeadID              *string   `json:"teamLeadId"`
+	TeamLeadName            *string   `json:"teamLeadName"`
+	Cadence                 string    `json:"cadence"`
+	NextCheckDate           string    `json:"nextCheckDate,omitempty"`
+	DistributionListEmail   *string   `json:"distributionListEmail,omitempty"`
+	ChatWebhookURL          *string   `json:"chatWebhookUrl,omitempty"`
+	ChatWebhookFormat       string    `json:"chatWebhookFormat,omitempty"`
+	LegalHold               bool      `json:"legalHold"`
+	SurveyTemplateID        *string   `json:"surveyTemplateId,omitempty"`
+	Anonymous               bool      `json:"anonymous"`
+	AnonymityMinRespondents int       `json:"anonymityMinRespondents"`
+	MemberCount             int       `json:"memberCount"`
+	CreatedAt               time.Time `json:"createdAt"`
+	UpdatedAt               time.Time `json:"updatedAt"`
 }
 
 // CreateTeamRequest represents request to create a team
 type CreateTeamRequest struct {
-	ID                    string  `json:"id"`                      // Optional - will be auto-generated from name if not provided
-	Name                  string  `json:"name" binding:"required"` // Required - used to generate ID if not provided
-	TeamLeadID            *string `json:"teamLeadId"`
-	Cadence               string  `json:"cadence" binding:"required,oneof=monthly quarterly half-yearly yearly"`
-	DistributionListEmail *string `json:"distributionListEmail" binding:"omitempty,email"`
+	ID                      string  `json:"id"`                      // Optional - will be auto-generated from name if not provided
+	Name                    string  `json:"name" binding:"required"` // Required - used to generate ID if not provided
+	TeamLeadID              *string `json:"teamLeadId"`
+	Cadence                 string  `json:"cadence" binding:"required,oneof=monthly quarterly half-yearly yearly"`
+	DistributionListEmail   *string `json:"distributionListEmail" binding:"omitempty,email"`
+	ChatWebhookURL          *string `json:"chatWebhookUrl" binding:"omitempty,url"`
+	ChatWebhookFormat       *string `json:"chatWebhookFormat" binding:"omitempty,oneof=slack generic"`
+	SurveyTemplateID        *string `json:"surveyTemplateId"`
+	Anonymous               bool    `json:"anonymous"`
+	AnonymityMinRespondents int     `json:"anonymityMinRespondents" binding:"omitempty,min=1,max=100"`
 }
 
 // UpdateTeamRequest represents request to update a team
 type UpdateTeamRequest struct {
-	Name                  *string `json:"name"`
-	TeamLeadID            *string `json:"teamLeadId"`
-	Cadence               *string `json:"cadence" binding:"omitempty,oneof=monthly quarterly half-yearly yearly"`
-	DistributionListEmail *string `json:"distributionListEmail" binding:"omitempty,email"`
+	Name                    *string `json:"name"`
+	TeamLeadID              *string `json:"teamLeadId"`
+	Cadence                 *string `json:"cadence" binding:"omitempty,oneof=monthly quarterly half-yearly yearly"`
+	DistributionListEmail   *string `json:"distributionListEmail" binding:"omitempty,email"`
+	ChatWebhookURL          *string `json:"chatWebhookUrl" binding:"omitempty,url"`
+	ChatWebhookFormat       *string `json:"chatWebhookFormat" binding:"omitempty,oneof=slack generic"`
+	LegalHold               *bool   `json:"legalHold"`
+	SurveyTemplateID        *string `json:"surveyTemplateId"` // empty string unassigns the template
+	Anonymous               *bool   `json:"anonymous"`        // applies to surveys submitted from now on
+	AnonymityMinRespondents *int    `json:"anonymityMinRespondents" binding:"omitempty,min=1,max=100"`
 }
 
 // TeamsResponse represents response with list of teams
@@ -234,6 +261,7 @@ type NotificationSettings struct {
 	NotifyOnSubmission bool     `json:"notifyOnSubmission"`
 	NotifyManagers     bool     `json:"notifyManagers"`
 	ReminderDaysBefore int      `json:"reminderDaysBefore"`
+	ReminderOffsets    []int    `json:"reminderOffsets"` // days before a campaign closes; omitted = unchanged
 	ReminderRecipients []string `json:"reminderRecipients"`
 	SmtpConfigured     bool     `json:"smtpConfigured"`
 }
@@ -244,3 +272,279 @@ type RetentionPolicy struct {
 	ArchiveEnabled     bool `json:"archiveEnabled"`
 	AnonymizeAfterDays int  `json:"anonymizeAfterDays"`
 }
+
+// UpdateRetentionPolicyRequest represents request to update the retention policy.
+// ArchiveEnabled is left unchanged when omitted.
+type UpdateRetentionPolicyRequest struct {
+	KeepSessionsMonths int   `json:"keepSessionsMonths"`
+	ArchiveEnabled     *bool `json:"archiveEnabled"`
+}
+
+// RetentionCountDTO reports sessions past the retention window for one team and period
+type RetentionCountDTO struct {
+	TeamID           string `json:"teamId"`
+	TeamName         string `json:"teamName"`
+	AssessmentPeriod string `json:"assessmentPeriod"`
+	SessionCount     int    `json:"sessionCount"`
+	ResponseCount    int    `json:"responseCount"`
+	LegalHold        bool   `json:"legalHold"`
+}
+
+// RetentionPreviewResponse represents a dry run of the retention worker
+type RetentionPreviewResponse struct {
+	CutoffDate    string              `json:"cutoffDate"`
+	Action        string              `json:"action"` // archive or purge
+	Eligible      []RetentionCountDTO `json:"eligible"`
+	Held          []RetentionCountDTO `json:"held"`
+	TotalSessions int                 `json:"totalSessions"`
+}
+
+// RetentionRunResponse represents the result of a retention run
+type RetentionRunResponse struct {
+	RunID         string              `json:"runId"`
+	CutoffDate    string              `json:"cutoffDate"`
+	Action        string              `json:"action"`
+	Removed       []RetentionCountDTO `json:"removed"`
+	TotalSessions int                 `json:"totalSessions"`
+}
+
+// RetentionAuditEntryDTO represents one row of the retention audit log
+type RetentionAuditEntryDTO struct {
+	ID               int64     `json:"id"`
+	RunID            string    `json:"runId"`
+	Action           string    `json:"action"`
+	TeamID           string    `json:"teamId"`
+	TeamName         string    `json:"teamName"`
+	AssessmentPeriod string    `json:"assessmentPeriod"`
+	SessionCount     int       `json:"sessionCount"`
+	ResponseCount    int       `json:"responseCount"`
+	CutoffDate       string    `json:"cutoffDate"`
+	TriggeredBy      string    `json:"triggeredBy"`
+	ExecutedAt       time.Time `json:"executedAt"`
+}
+
+// RetentionAuditLogResponse represents response with the retention audit log
+type RetentionAuditLogResponse struct {
+	Entries []RetentionAuditEntryDTO `json:"entries"`
+	Total   int                      `json:"total"`
+}
+
+// ============================================================================
+// Campaigns DTOs
+// ============================================================================
+
+// CampaignDTO represents a scheduled survey campaign for a team
+type CampaignDTO struct {
+	ID               string     `json:"id"`
+	TeamID           string     `json:"teamId"`
+	TeamName         string     `json:"teamName"`
+	AssessmentPeriod string     `json:"assessmentPeriod"`
+	Cadence          string     `json:"cadence"`
+	Status           string     `json:"status"`
+	StartDate        string     `json:"startDate"`
+	EndDate          string     `json:"endDate"`
+	ClosedAt         *time.Time `json:"closedAt,omitempty"`
+	Anonymous        bool       `json:"anonymous"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// CampaignsResponse represents response with list of campaigns
+type CampaignsResponse struct {
+	Campaigns []CampaignDTO `json:"campaigns"`
+	Total     int           `json:"total"`
+}
+
+// OpenCampaignRequest represents request to open the current-period campaign for a team
+type OpenCampaignRequest struct {
+	TeamID string `json:"teamId" binding:"required"`
+}
+
+// UpdateCampaignAnonymityRequest represents request to turn anonymous mode on or off for a campaign
+type UpdateCampaignAnonymityRequest struct {
+	Anonymous *bool `json:"anonymous" binding:"required"`
+}
+
+// CampaignRunResponse represents the outcome of a manual scheduler run
+type CampaignRunResponse struct {
+	Opened []CampaignDTO `json:"opened"`
+	Closed []CampaignDTO `json:"closed"`
+}
+
+// ============================================================================
+// Survey Template DTOs
+// ============================================================================
+
+// SurveyTemplateDTO represents a survey template and its current version
+type SurveyTemplateDTO struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	Version      int       `json:"version"`
+	DimensionIDs []string  `json:"dimensionIds"`
+	TeamCount    int       `json:"teamCount"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// SurveyTemplateVersionDTO represents an immutable version of a survey template
+type SurveyTemplateVersionDTO struct {
+	Version      int       `json:"version"`
+	DimensionIDs []string  `json:"dimensionIds"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// SurveyTemplateDetailResponse represents a survey template with its version history
+type SurveyTemplateDetailResponse struct {
+	SurveyTemplateDTO
+	Versions []SurveyTemplateVersionDTO `json:"versions"`
+}
+
+// SurveyTemplatesResponse represents response with list of survey templates
+type SurveyTemplatesResponse struct {
+	Templates []SurveyTemplateDTO `json:"templates"`
+	Total     int                 `json:"total"`
+}
+
+// CreateSurveyTemplateRequest represents request to create a survey template
+type CreateSurveyTemplateRequest struct {
+	ID           string   `json:"id"` // Optional - will be auto-generated from name if not provided
+	Name         string   `json:"name" binding:"required"`
+	Description  string   `json:"description"`
+	DimensionIDs []string `json:"dimensionIds" binding:"required,min=1"`
+}
+
+// UpdateSurveyTemplateRequest represents request to update a survey template.
+// Changing dimensionIds publishes a new version; earlier versions are kept unchanged.
+type UpdateSurveyTemplateRequest struct {
+	Name         *string  `json:"name"`
+	Description  *string  `json:"description"`
+	DimensionIDs []string `json:"dimensionIds" binding:"omitempty,min=1"`
+}
+
+// DirectoryImportRequest carries a CSV file of users and teams to import
+type DirectoryImportRequest struct {
+	CSV string `json:"csv" binding:"required"`
+}
+
+// ImportRowErrorDTO reports a problem with one row of an import
+type ImportRowErrorDTO struct {
+	Line    int    `json:"line"` // line in the file; the header is line 1
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// DirectoryImportResponse reports what an import did, or on a dry run, what it would do
+type DirectoryImportResponse struct {
+	Valid        bool                `json:"valid"`
+	Applied      bool                `json:"applied"`
+	Rows         int                 `json:"rows"`
+	UsersCreated int                 `json:"usersCreated"`
+	UsersUpdated int                 `json:"usersUpdated"`
+	TeamsCreated int                 `json:"teamsCreated"`
+	Memberships  int                 `json:"memberships"`
+	Errors       []ImportRowErrorDTO `json:"errors"`
+}
+
+// ============================================================================
+// Admin Audit Log DTOs
+// ============================================================================
+
+// AdminAuditEntryDTO represents one admin write in the audit log
+type AdminAuditEntryDTO struct {
+	ID            int64                 `json:"id"`
+	ActorID       string                `json:"actorId"`
+	ActorUsername string                `json:"actorUsername"`
+	Action        string                `json:"action"`
+	TargetType    string                `json:"targetType"`
+	TargetID      string                `json:"targetId"`
+	Before        json.RawMessage       `json:"before"`
+	After         json.RawMessage       `json:"after"`
+	Changes       []AdminAuditChangeDTO `json:"changes"` // top-level fields that differ between before and after
+	IPAddress     string                `json:"ipAddress"`
+	RequestID     string                `json:"requestId"`
+	CreatedAt     time.Time             `json:"createdAt"`
+}
+
+// AdminAuditChangeDTO is one field changed by an admin write
+type AdminAuditChangeDTO struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// AdminAuditLogResponse represents a page of the admin audit log
+type AdminAuditLogResponse struct {
+	Entries []AdminAuditEntryDTO `json:"entries"`
+	Total   int                  `json:"total"` // matching entries across all pages
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+}
+
+// ============================================================================
+// Webhooks DTOs
+// ============================================================================
+
+// WebhookEndpointDTO represents a webhook endpoint; the secret is only returned on creation
+type WebhookEndpointDTO struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	Events      []string  `json:"events"`
+	Active      bool      `json:"active"`
+	CreatedBy   string    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// WebhookEndpointsResponse represents response with all webhook endpoints
+type WebhookEndpointsResponse struct {
+	Endpoints []WebhookEndpointDTO `json:"endpoints"`
+}
+
+// CreateWebhookEndpointRequest represents request to register a webhook endpoint
+type CreateWebhookEndpointRequest struct {
+	URL         string   `json:"url" binding:"required,url"`
+	Description string   `json:"description" binding:"max=255"`
+	Events      []string `json:"events" binding:"required,min=1"`
+	Active      *bool    `json:"active"` // defaults to true
+}
+
+// UpdateWebhookEndpointRequest represents request to update a webhook endpoint
+// Omitted fields are left unchanged.
+type UpdateWebhookEndpointRequest struct {
+	URL         *string  `json:"url" binding:"omitempty,url"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Events      []string `json:"events" binding:"omitempty,min=1"`
+	Active      *bool    `json:"active"`
+}
+
+// CreatedWebhookEndpointResponse is returned once when an endpoint is registered, with the
+// secret its deliveries are signed with
+type CreatedWebhookEndpointResponse struct {
+	WebhookEndpointDTO
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryDTO represents one event sent, or still to be sent, to an endpoint
+type WebhookDeliveryDTO struct {
+	ID             string          `json:"id"`
+	EventID        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"` // pending, delivered or failed
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"` // pending deliveries only
+	LastAttemptAt  *time.Time      `json:"lastAttemptAt,omitempty"`
+	ResponseStatus *int            `json:"responseStatus,omitempty"`
+	LastError      string          `json:"lastError,omitempty"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// WebhookDeliveriesResponse represents a page of an endpoint's delivery log
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryDTO `json:"deliveries"`
+	Total      int                  `json:"total"` // matching deliveries across all pages
+	Limit      int                  `json:"limit"`
+	Offset     int                  `json:"offset"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// APITokenDTO describes an API token; the secret is never included
+type APITokenDTO struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // first characters of the token, to tell tokens apart
+	UserID     string     `json:"userId"`
+	Kind       string     `json:"kind"` // personal or service
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"createdBy"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// APITokensResponse lists API tokens, newest first
+type APITokensResponse struct {
+	Tokens []APITokenDTO `json:"tokens"`
+}
+
+// CreateAPITokenRequest is the request body for POST /api/v1/auth/api-tokens
+// Tokens without expiresAt do not expire.
+type CreateAPITokenRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// IssueServiceAPITokenRequest is the request body for POST /api/v1/admin/api-tokens
+type IssueServiceAPITokenRequest struct {
+	CreateAPITokenRequest
+	UserID string `json:"userId" binding:"required"`
+}
+
+// CreatedAPITokenResponse is returned once when a token is created, with its secret
+type CreatedAPITokenResponse struct {
+	APITokenDTO
+	Token string `json:"token"`
+}
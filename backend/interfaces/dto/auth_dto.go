@@ -19,10 +19,17 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refreshToken" binding:"required"`
 }
 
-// RefreshTokenResponse represents a token refresh response
+// RefreshTokenResponse represents a token refresh response.
+// The presented refresh token is consumed; clients must store the new one.
 type RefreshTokenResponse struct {
-	AccessToken string `json:"accessToken"`
-	ExpiresIn   int64  `json:"expiresIn"` // Access token expiry in seconds
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"` // Access token expiry in seconds
+}
+
+// LogoutRequest represents an optional logout body carrying the refresh token to revoke
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
 }
 
 // UserDTO represents user data transfer object
@@ -46,3 +53,52 @@ type ResetPasswordRequest struct {
 	Token       string `json:"token" binding:"required"`
 	NewPassword string `json:"newPassword" binding:"required"`
 }
+
+// MFAChallengeResponse is returned by login instead of tokens while an MFA code is needed.
+// The challenge token is exchanged for tokens at POST /api/v1/auth/mfa/verify.
+type MFAChallengeResponse struct {
+	MFARequired        bool   `json:"mfaRequired"`
+	MFAToken           string `json:"mfaToken"`
+	ExpiresIn          int64  `json:"expiresIn"`          // Challenge token expiry in seconds
+	EnrollmentRequired bool   `json:"enrollmentRequired"` // the user must set up MFA first
+}
+
+// MFAVerifyRequest completes a login with an authenticator or recovery code
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfaToken" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// MFAVerifyResponse is a login response, with recovery codes when the login completed enrollment
+type MFAVerifyResponse struct {
+	LoginResponse
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
+
+// MFAEnrollRequest starts enrollment during login; signed-in users send no body
+type MFAEnrollRequest struct {
+	MFAToken string `json:"mfaToken"`
+}
+
+// MFAEnrollmentResponse is what an authenticator app needs to be set up
+type MFAEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"` // otpauth:// URI, usually shown as a QR code
+}
+
+// MFACodeRequest carries a current authenticator or recovery code
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFAStatusResponse describes the signed-in user's MFA state
+type MFAStatusResponse struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+}
+
+// RecoveryCodesResponse lists newly issued recovery codes, shown once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
@@ -20,6 +20,26 @@ type HealthCheckResponseRequest struct {
 	Comment     string `json:"comment,omitempty"`
 }
 
+// SaveHealthCheckDraftRequest represents the autosaved answers of an individual survey in progress.
+// Responses may omit the score or trend of a dimension not fully answered yet.
+type SaveHealthCheckDraftRequest struct {
+	Responses []HealthCheckDraftResponseRequest `json:"responses" binding:"dive"`
+}
+
+// HealthCheckDraftResponseRequest represents a possibly partial dimension response
+type HealthCheckDraftResponseRequest struct {
+	DimensionID string `json:"dimensionId" binding:"required"`
+	Score       int    `json:"score,omitempty" binding:"omitempty,min=1,max=3"`
+	Trend       string `json:"trend,omitempty" binding:"omitempty,oneof=improving stable declining"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+// AmendHealthCheckRequest represents the corrected responses of a submitted health check.
+// The responses replace the submitted ones entirely.
+type AmendHealthCheckRequest struct {
+	Responses []HealthCheckResponseRequest `json:"responses" binding:"required,min=1,dive"`
+}
+
 // HealthCheckSessionResponse represents the response after creating/fetching a session
 type HealthCheckSessionResponse struct {
 	ID               string                        `json:"id"`
@@ -30,6 +50,9 @@ type HealthCheckSessionResponse struct {
 	SurveyType       string                        `json:"surveyType,omitempty"`
 	Responses        []HealthCheckResponseResponse `json:"responses"`
 	Completed        bool                          `json:"completed"`
+	TemplateID       string                        `json:"templateId,omitempty"`
+	TemplateVersion  int                           `json:"templateVersion,omitempty"`
+	Anonymous        bool                          `json:"anonymous,omitempty"`
 	CreatedAt        string                        `json:"createdAt,omitempty"`
 }
 
@@ -67,10 +90,37 @@ type HealthDimensionsResponse struct {
 	Dimensions []HealthDimensionResponse `json:"dimensions"`
 }
 
+// TeamSurveyResponse is the dimension set a team answers.
+// Template fields are omitted when the team has no survey template.
+type TeamSurveyResponse struct {
+	TeamID          string                    `json:"teamId"`
+	TemplateID      string                    `json:"templateId,omitempty"`
+	TemplateName    string                    `json:"templateName,omitempty"`
+	TemplateVersion int                       `json:"templateVersion,omitempty"`
+	Anonymous       bool                      `json:"anonymous"` // responses will not be attributed to the respondent
+	Dimensions      []HealthDimensionResponse `json:"dimensions"`
+}
+
 // HealthCheckSessionsResponse is the response containing multiple sessions
 type HealthCheckSessionsResponse struct {
-	Sessions []HealthCheckSessionResponse `json:"sessions"`
-	Total    int                          `json:"total,omitempty"`
+	Sessions   []HealthCheckSessionResponse `json:"sessions"`
+	Total      int                          `json:"total,omitempty"`
+	Suppressed int                          `json:"suppressed,omitempty"` // anonymous sessions withheld below the minimum respondent count
+}
+
+// HealthCheckRevisionResponse represents one entry of a session's revision history.
+// Responses are omitted for anonymous sessions and withdrawals.
+type HealthCheckRevisionResponse struct {
+	Revision  int                           `json:"revision"`
+	Action    string                        `json:"action"` // submitted, amended or withdrawn
+	Responses []HealthCheckResponseResponse `json:"responses,omitempty"`
+	CreatedAt string                        `json:"createdAt"`
+}
+
+// HealthCheckRevisionsResponse is the revision history of a session, oldest first
+type HealthCheckRevisionsResponse struct {
+	SessionID string                        `json:"sessionId"`
+	Revisions []HealthCheckRevisionResponse `json:"revisions"`
 }
 
 // ErrorResponse represents an error response
@@ -36,13 +36,16 @@ type ManagerRadarResponse struct {
 type ManagerTrendsResponse struct {
 	ManagerID  string                  `json:"managerId"`
 	Periods    []string                `json:"periods"`
+	Overall    []*float64              `json:"overall"` // overall health per period, matches periods array order
 	Dimensions []ManagerDimensionTrend `json:"dimensions"`
 }
 
 // ManagerDimensionTrend represents trend scores for a dimension across periods
 type ManagerDimensionTrend struct {
-	DimensionID string    `json:"dimensionId"`
-	Scores      []float64 `json:"scores"` // matches periods array order
+	DimensionID string     `json:"dimensionId"`
+	Name        string     `json:"name"`
+	IsActive    bool       `json:"isActive"`
+	Scores      []*float64 `json:"scores"` // matches periods array order; null where no data exists
 }
 
 // SubordinateDTO represents a user in the subordinate tree
@@ -24,10 +24,22 @@ type DimensionDistribution struct {
 	Green       int    `json:"green"`  // score = 3
 }
 
-// IndividualResponses represents individual team member responses
+// IndividualResponses represents individual team member responses.
+// Anonymous sessions are omitted (and counted in SuppressedResponses) for any period with fewer
+// than MinRespondents respondents; otherwise their comments are listed in AnonymousComments only.
 type IndividualResponses struct {
-	TeamID    string                   `json:"teamId"`
-	Responses []IndividualUserResponse `json:"responses"`
+	TeamID              string                   `json:"teamId"`
+	Responses           []IndividualUserResponse `json:"responses"`
+	MinRespondents      int                      `json:"minRespondents"`
+	SuppressedResponses int                      `json:"suppressedResponses"`
+	AnonymousComments   []AnonymousComment       `json:"anonymousComments"`
+}
+
+// AnonymousComment is a comment from an anonymous session, shown without attribution
+type AnonymousComment struct {
+	DimensionID      string `json:"dimensionId"`
+	AssessmentPeriod string `json:"assessmentPeriod,omitempty"`
+	Comment          string `json:"comment"`
 }
 
 // IndividualUserResponse represents one user's full health check session
@@ -37,6 +49,7 @@ type IndividualUserResponse struct {
 	UserName   string                    `json:"userName"`
 	Date       string                    `json:"date"`
 	SurveyType string                    `json:"surveyType"`
+	Anonymous  bool                      `json:"anonymous,omitempty"` // UserID is a respondent token and UserName is empty
 	Dimensions []IndividualDimensionResp `json:"dimensions"`
 }
 
@@ -52,13 +65,16 @@ type IndividualDimensionResp struct {
 type TrendData struct {
 	TeamID     string           `json:"teamId"`
 	Periods    []string         `json:"periods"`
+	Overall    []*float64       `json:"overall"` // overall health per period, matches periods array order
 	Dimensions []DimensionTrend `json:"dimensions"`
 }
 
 // DimensionTrend represents trend scores for a dimension across periods
 type DimensionTrend struct {
-	DimensionID string    `json:"dimensionId"`
-	Scores      []float64 `json:"scores"` // matches periods array order
+	DimensionID string     `json:"dimensionId"`
+	Name        string     `json:"name"`
+	IsActive    bool       `json:"isActive"`
+	Scores      []*float64 `json:"scores"` // matches periods array order; null where no data exists
 }
 
 // TeamInfoResponse represents detailed team information
@@ -0,0 +1,19 @@
+package dto
+
+// TeamSettingsResponse is the response body for GET /api/v1/teams/:teamId/settings
+type TeamSettingsResponse struct {
+	TeamID                string               `json:"teamId"`
+	TeamName              string               `json:"teamName"`
+	TeamLeadID            *string              `json:"teamLeadId,omitempty"`
+	Cadence               string               `json:"cadence"`
+	NextCheckDate         string               `json:"nextCheckDate,omitempty"`
+	DistributionListEmail *string              `json:"distributionListEmail,omitempty"`
+	Members               []TeamMemberAdminDTO `json:"members"`
+}
+
+// UpdateTeamSettingsRequest is the request body for PUT /api/v1/teams/:teamId/settings
+// Omitted fields are left unchanged; an empty distributionListEmail removes it.
+type UpdateTeamSettingsRequest struct {
+	Cadence               *string `json:"cadence" binding:"omitempty,oneof=monthly quarterly half-yearly yearly"`
+	DistributionListEmail *string `json:"distributionListEmail" binding:"omitempty,email"`
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/gin-gonic/gin"
+)
+
+// apiTokenRoute grants API tokens with any of scopes access to the routes under prefix.
+// An empty method matches every method; no scopes keeps API tokens out.
+type apiTokenRoute struct {
+	method string
+	prefix string
+	scopes []services.APITokenScope
+}
+
+// apiTokenRoutes decides which routes an API token may call, by route path. The first match
+// wins and routes that match nothing are closed to API tokens, so new routes stay closed
+// until they are added here. Hierarchy level permissions and team checks still apply.
+var apiTokenRoutes = []apiTokenRoute{
+	// Team administration is for signed-in team leads and supervisors
+	{"", "/api/v1/teams/:teamId/settings", nil},
+
+	{http.MethodGet, "/api/v1/teams/:teamId/action-items", []services.APITokenScope{services.ScopeReadDashboards, services.ScopeWriteActionItems}},
+	{"", "/api/v1/teams/:teamId/action-items", []services.APITokenScope{services.ScopeWriteActionItems}},
+
+	{http.MethodGet, "/api/v1/teams", []services.APITokenScope{services.ScopeReadDashboards}},
+	{http.MethodGet, "/api/v1/managers/", []services.APITokenScope{services.ScopeReadDashboards}},
+	{http.MethodGet, "/api/v1/users/", []services.APITokenScope{services.ScopeReadDashboards}},
+	{http.MethodGet, "/api/v1/health-checks/team/", []services.APITokenScope{services.ScopeReadDashboards}},
+	{http.MethodGet, "/api/v1/health-dimensions", []services.APITokenScope{services.ScopeReadDashboards}},
+
+	{http.MethodGet, "/api/v1/exports/", []services.APITokenScope{services.ScopeReadExports}},
+
+	// Account recovery is for signed-in user admins
+	{"", "/api/v1/admin/users/:id/revoke-sessions", nil},
+	{"", "/api/v1/admin/users/:id/unlock", nil},
+	{"", "/api/v1/admin/users/:id/reset-mfa", nil},
+	{"", "/api/v1/admin/users", []services.APITokenScope{services.ScopeAdminUsers}},
+}
+
+// apiTokenScopesFor returns the scopes that allow an API token to call the matched route,
+// or nil when API tokens cannot call it
+func apiTokenScopesFor(c *gin.Context) []services.APITokenScope {
+	path := c.FullPath()
+	for _, route := range apiTokenRoutes {
+		if route.method != "" && route.method != c.Request.Method {
+			continue
+		}
+		if strings.HasPrefix(path, route.prefix) {
+			return route.scopes
+		}
+	}
+	return nil
+}
+
+// apiTokenAllowed reports whether the API token's claims carry a scope for the matched route.
+// Also returns the scopes that would allow it.
+func apiTokenAllowed(c *gin.Context, claims *services.TokenClaims) (bool, []services.APITokenScope) {
+	scopes := apiTokenScopesFor(c)
+	for _, scope := range scopes {
+		if claims.HasScope(scope) {
+			return true, scopes
+		}
+	}
+	return false, scopes
+}
+
+// scopeNames lists scopes for an error message
+func scopeNames(scopes []services.APITokenScope) string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	return strings.Join(names, " or ")
+}
@@ -1,16 +1,19 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/organization"
 	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
 	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
-// JWTAuthMiddleware creates a middleware that validates JWT tokens
+// JWTAuthMiddleware creates a middleware that validates JWT tokens.
+// API tokens are accepted too when the JWT service accepts them, on the routes their scopes allow.
 func JWTAuthMiddleware(jwtService *services.JWTService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		log := logger.Get()
@@ -50,8 +53,8 @@ func JWTAuthMiddleware(jwtService *services.JWTService) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		// Validate token
-		claims, err := jwtService.ValidateAccessToken(tokenString)
+		// Validate token and check it has not been revoked
+		claims, err := jwtService.VerifyAccessToken(c.Request.Context(), tokenString)
 		if err != nil {
 			var reason, details string
 			switch err {
@@ -63,6 +66,10 @@ func JWTAuthMiddleware(jwtService *services.JWTService) gin.HandlerFunc {
 				reason = "access_token_invalid"
 				details = "JWT access token is malformed, tampered with, or signed with wrong key"
 				dto.RespondError(c, http.StatusUnauthorized, "Invalid token")
+			case services.ErrTokenRevoked:
+				reason = "access_token_revoked"
+				details = "JWT access token was revoked by logout or an administrator"
+				dto.RespondError(c, http.StatusUnauthorized, "Token has been revoked")
 			default:
 				reason = "token_validation_error"
 				details = "Unexpected error during token validation: " + err.Error()
@@ -79,6 +86,28 @@ func JWTAuthMiddleware(jwtService *services.JWTService) gin.HandlerFunc {
 			return
 		}
 
+		if claims.TokenType == services.APITokenType {
+			if allowed, scopes := apiTokenAllowed(c, claims); !allowed {
+				details := "API token used on an endpoint API tokens cannot call"
+				message := "Access denied: API tokens cannot be used for this endpoint"
+				if len(scopes) > 0 {
+					details = "API token lacks a scope the endpoint requires"
+					message = "Access denied: API token requires the " + scopeNames(scopes) + " scope"
+				}
+				log.Auth("authorization").
+					UserID(claims.UserID).
+					IP(clientIP).
+					RequestID(requestID).
+					Endpoint(endpoint).
+					Reason("api_token_scope_denied").
+					Details(details).
+					Failure()
+				dto.RespondError(c, http.StatusForbidden, message)
+				c.Abort()
+				return
+			}
+		}
+
 		// Store user info in context for downstream handlers
 		c.Set("userID", claims.UserID)
 		c.Set("username", claims.Username)
@@ -107,11 +136,17 @@ func OptionalJWTAuthMiddleware(jwtService *services.JWTService) gin.HandlerFunc
 		}
 
 		tokenString := parts[1]
-		claims, err := jwtService.ValidateAccessToken(tokenString)
+		claims, err := jwtService.VerifyAccessToken(c.Request.Context(), tokenString)
 		if err != nil {
 			c.Next()
 			return
 		}
+		if claims.TokenType == services.APITokenType {
+			if allowed, _ := apiTokenAllowed(c, claims); !allowed {
+				c.Next()
+				return
+			}
+		}
 
 		// Store user info in context
 		c.Set("userID", claims.UserID)
@@ -143,9 +178,10 @@ func GetClaimsFromContext(c *gin.Context) (*services.TokenClaims, bool) {
 	return claims.(*services.TokenClaims), true
 }
 
-// AdminOnlyMiddleware ensures only admin users (level-1) can access the route
+// RequirePermission ensures the user's hierarchy level has every required permission.
+// Permissions come from the level's configuration, so editing a level changes what its users can access.
 // Must be used AFTER JWTAuthMiddleware
-func AdminOnlyMiddleware() gin.HandlerFunc {
+func RequirePermission(permissions *services.PermissionService, required ...organization.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		log := logger.Get()
 		clientIP := c.ClientIP()
@@ -166,84 +202,64 @@ func AdminOnlyMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Admin is level-1 or level-admin (special admin account)
-		level := hierarchyLevel.(string)
-		if level != "level-1" && level != "level-admin" {
-			userID, _ := c.Get("userID")
-			log.Auth("authorization").
-				UserID(userID.(string)).
-				IP(clientIP).
-				RequestID(requestID).
-				Endpoint(endpoint).
-				Reason("insufficient_privileges").
-				Details("User attempted to access admin-only endpoint without admin privileges").
-				Failure()
-			dto.RespondError(c, http.StatusForbidden, "Access denied: admin privileges required")
-			c.Abort()
+		err := permissions.Authorize(c.Request.Context(), hierarchyLevel.(string), required...)
+		if err == nil {
+			c.Next()
 			return
 		}
 
-		c.Next()
-	}
-}
-
-// ManagerOrAboveMiddleware ensures only manager level users (level-3 or above) can access the route
-// Hierarchy levels: level-1 (VP/Admin), level-2 (Director), level-3 (Manager)
-// Must be used AFTER JWTAuthMiddleware
-func ManagerOrAboveMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		log := logger.Get()
-		clientIP := c.ClientIP()
-		requestID := c.GetString("request_id")
-		endpoint := c.Request.URL.Path
-
-		hierarchyLevel, exists := c.Get("hierarchyLevel")
-		if !exists {
-			log.Auth("authorization").
-				IP(clientIP).
-				RequestID(requestID).
-				Endpoint(endpoint).
-				Reason("missing_hierarchy_level").
-				Details("User hierarchy level not found in context - ensure JWTAuthMiddleware runs first").
-				Failure()
-			dto.RespondError(c, http.StatusForbidden, "Access denied: unable to determine user role")
+		if !errors.Is(err, services.ErrPermissionDenied) {
+			log.WithContext(c.Request.Context()).WithError(err).Error("failed to resolve hierarchy level permissions")
+			dto.RespondError(c, http.StatusInternalServerError, "Failed to check permissions")
 			c.Abort()
 			return
 		}
 
-		level := hierarchyLevel.(string)
-		// Manager or above: level-1 (VP/Admin), level-2 (Director), level-3 (Manager), level-admin (System Admin)
-		allowedLevels := map[string]bool{
-			"level-1":     true, // VP/Admin
-			"level-2":     true, // Director
-			"level-3":     true, // Manager
-			"level-admin": true, // System Admin
-		}
+		userID, _ := c.Get("userID")
+		log.Auth("authorization").
+			UserID(userID.(string)).
+			IP(clientIP).
+			RequestID(requestID).
+			Endpoint(endpoint).
+			Reason("insufficient_privileges").
+			Details("User's hierarchy level lacks a permission the endpoint requires: " + err.Error()).
+			Failure()
+		dto.RespondError(c, http.StatusForbidden, "Access denied: "+permissionNames(required)+" permission required")
+		c.Abort()
+	}
+}
 
-		if !allowedLevels[level] {
-			userID, _ := c.Get("userID")
-			log.Auth("authorization").
-				UserID(userID.(string)).
-				IP(clientIP).
-				RequestID(requestID).
-				Endpoint(endpoint).
-				Reason("insufficient_privileges").
-				Details("User attempted to access manager endpoint without manager or above privileges").
-				Failure()
-			dto.RespondError(c, http.StatusForbidden, "Access denied: manager or above privileges required")
-			c.Abort()
-			return
-		}
+// permissionNames lists permissions for an error message
+func permissionNames(permissions []organization.Permission) string {
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = string(permission)
+	}
+	return strings.Join(names, " and ")
+}
 
-		c.Next()
+// callerHasPermission reports whether the caller's hierarchy level has a permission.
+// It responds with 500 and returns ok=false when the permissions cannot be resolved.
+func callerHasPermission(c *gin.Context, permissions *services.PermissionService, level string, permission organization.Permission) (allowed bool, ok bool) {
+	err := permissions.Authorize(c.Request.Context(), level, permission)
+	if err == nil {
+		return true, true
 	}
+	if errors.Is(err, services.ErrPermissionDenied) {
+		return false, true
+	}
+
+	logger.Get().WithContext(c.Request.Context()).WithError(err).Error("failed to resolve hierarchy level permissions")
+	dto.RespondError(c, http.StatusInternalServerError, "Failed to check permissions")
+	c.Abort()
+	return false, false
 }
 
 // SameUserOrManagerMiddleware ensures the requesting user is accessing their own data
-// OR has manager or above privileges to view subordinate data
-// The managerId path parameter must match the authenticated user's ID
+// OR their hierarchy level has the CanViewAllTeams permission
+// The paramName path parameter must match the authenticated user's ID
 // Must be used AFTER JWTAuthMiddleware
-func SameUserOrManagerMiddleware(paramName string) gin.HandlerFunc {
+func SameUserOrManagerMiddleware(permissions *services.PermissionService, paramName string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		log := logger.Get()
 		clientIP := c.ClientIP()
@@ -272,18 +288,12 @@ func SameUserOrManagerMiddleware(paramName string) gin.HandlerFunc {
 			return
 		}
 
-		// Only directors (level-2) and above (level-1, level-admin) can access other users' data
-		// Managers (level-3) should only access their own data, not other managers' data
-		higherLevelRoles := map[string]bool{
-			"level-1":     true, // VP
-			"level-2":     true, // Director
-			"level-admin": true, // System Admin
+		// Levels that can view every team can also view any user's results from those teams
+		allowed, ok := callerHasPermission(c, permissions, level, organization.PermissionViewAllTeams)
+		if !ok {
+			return
 		}
-
-		if higherLevelRoles[level] {
-			// Directors and above can view any manager's data (they oversee managers)
-			// TODO: In a full implementation, we'd verify the target user is actually
-			// a subordinate of the requesting user in the org hierarchy
+		if allowed {
 			c.Next()
 			return
 		}
@@ -302,10 +312,10 @@ func SameUserOrManagerMiddleware(paramName string) gin.HandlerFunc {
 }
 
 // TeamMembershipMiddleware ensures the user is a member of the team being accessed
-// OR has manager or above privileges
-// The teamId path parameter is used to check membership
+// OR their hierarchy level has the CanViewAllTeams permission
+// The paramName path parameter is used to check membership
 // Must be used AFTER JWTAuthMiddleware
-func TeamMembershipMiddleware(paramName string) gin.HandlerFunc {
+func TeamMembershipMiddleware(permissions *services.PermissionService, paramName string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		log := logger.Get()
 		clientIP := c.ClientIP()
@@ -333,15 +343,12 @@ func TeamMembershipMiddleware(paramName string) gin.HandlerFunc {
 		}
 		level := hierarchyLevel.(string)
 
-		// Manager or above can access any team (in their hierarchy)
-		allowedLevels := map[string]bool{
-			"level-1":     true, // VP/Admin
-			"level-2":     true, // Director
-			"level-3":     true, // Manager
-			"level-admin": true, // System Admin
+		// Levels that can view all teams can access any team
+		allowed, ok := callerHasPermission(c, permissions, level, organization.PermissionViewAllTeams)
+		if !ok {
+			return
 		}
-
-		if allowedLevels[level] {
+		if allowed {
 			c.Next()
 			return
 		}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/agopalakrishnan/teams360/backend/pkg/scim"
+	"github.com/gin-gonic/gin"
+)
+
+// SCIMAuthMiddleware authenticates an identity provider by the bearer token configured for SCIM
+// provisioning. An empty token disables provisioning.
+func SCIMAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", scim.ContentType)
+
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable,
+				scim.NewError(http.StatusServiceUnavailable, "", "SCIM provisioning is not configured on this server"))
+			return
+		}
+
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" ||
+			subtle.ConstantTimeCompare([]byte(parts[1]), []byte(token)) != 1 {
+			logger.Get().Auth("scim_token_validation").
+				IP(c.ClientIP()).
+				RequestID(c.GetString("request_id")).
+				Endpoint(c.Request.URL.Path).
+				Reason("invalid_scim_token").
+				Details("SCIM request with a missing or invalid bearer token").
+				Failure()
+			c.AbortWithStatusJSON(http.StatusUnauthorized, scim.NewError(http.StatusUnauthorized, "", "Invalid or missing bearer token"))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/agopalakrishnan/teams360/backend/domain/team"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// TeamLeadOrSupervisorMiddleware ensures the user leads the team being accessed or is in its
+// supervisor chain. Admin privileges alone do not grant access; admins use the admin routes.
+// The paramName path parameter holds the team ID
+// Must be used AFTER JWTAuthMiddleware
+func TeamLeadOrSupervisorMiddleware(teamRepo team.Repository, paramName string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		teamID := c.Param(paramName)
+
+		userID, exists := GetUserIDFromContext(c)
+		if !exists {
+			dto.RespondError(c, http.StatusForbidden, "Access denied: user not authenticated")
+			c.Abort()
+			return
+		}
+
+		tm, err := teamRepo.FindByID(ctx, teamID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				dto.RespondError(c, http.StatusNotFound, "Team not found")
+			} else {
+				dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to load team", err.Error())
+			}
+			c.Abort()
+			return
+		}
+
+		if tm.TeamLeadID != nil && *tm.TeamLeadID == userID {
+			c.Next()
+			return
+		}
+
+		chain, err := teamRepo.FindSupervisorChain(ctx, teamID)
+		if err != nil {
+			dto.RespondErrorWithDetails(c, http.StatusInternalServerError, "Failed to load supervisor chain", err.Error())
+			c.Abort()
+			return
+		}
+		for _, link := range chain {
+			if link.UserID == userID {
+				c.Next()
+				return
+			}
+		}
+
+		logger.Get().Auth("authorization").
+			UserID(userID).
+			IP(c.ClientIP()).
+			RequestID(c.GetString("request_id")).
+			Endpoint(c.Request.URL.Path).
+			Reason("team_management_denied").
+			Details("User attempted to manage a team they neither lead nor supervise").
+			Failure()
+		dto.RespondError(c, http.StatusForbidden, "Access denied: only the team lead or a supervisor can manage this team")
+		c.Abort()
+	}
+}
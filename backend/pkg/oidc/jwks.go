@@ -0,0 +1,198 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Key cache lifetimes. Keys are refetched when the cache expires, and when a token is signed with
+// an unknown key ID (the provider rotated its keys), but for unknown key IDs no more often than
+// minKeyRefresh, so tokens with made-up key IDs cannot make us hammer the provider.
+const (
+	defaultKeyCacheTTL = time.Hour
+	maxKeyCacheTTL     = 24 * time.Hour
+	minKeyRefresh      = 30 * time.Second
+)
+
+// jsonWebKey is one key of a JWK set (RFC 7517)
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// KeySet caches the signing keys a provider publishes at its jwks_uri
+type KeySet struct {
+	uri    string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey // by key ID
+	expiresAt time.Time
+	missedAt  time.Time // last refresh for an unknown key ID
+
+	now func() time.Time
+}
+
+// NewKeySet creates a key set for the JWK set at uri; keys are fetched on first use
+func NewKeySet(uri string, client *http.Client) *KeySet {
+	return &KeySet{uri: uri, client: client, now: time.Now}
+}
+
+// Key returns the signing key with the key ID. An empty key ID matches the only key of the set.
+func (k *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	key, found := k.lookup(kid)
+	if found && now.Before(k.expiresAt) {
+		return key, nil
+	}
+
+	// Refresh when the cache expired, or when the key is unknown and the keys may have rotated
+	expired := !now.Before(k.expiresAt)
+	if expired || (!found && now.Sub(k.missedAt) >= minKeyRefresh) {
+		if !expired {
+			k.missedAt = now
+		}
+		if err := k.refresh(ctx, now); err != nil {
+			if found {
+				// Keep using the cached key while the provider is unreachable
+				return key, nil
+			}
+			return nil, err
+		}
+		key, found = k.lookup(kid)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: no signing key with kid %q", ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+func (k *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" {
+		if len(k.keys) != 1 {
+			return nil, false
+		}
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// refresh fetches the key set; the caller holds k.mu
+func (k *KeySet) refresh(ctx context.Context, now time.Time) error {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	var header http.Header
+	if err := getJSON(ctx, k.client, k.uri, &set, &header); err != nil {
+		return fmt.Errorf("fetching signing keys: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Skip keys of types this package does not verify rather than failing the whole set
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	k.keys = keys
+	k.expiresAt = now.Add(cacheTTL(header))
+	return nil
+}
+
+// cacheTTL honours the max-age of the key set's Cache-Control header
+func cacheTTL(header http.Header) time.Duration {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(directive), "=")
+		if !ok || !strings.EqualFold(name, "max-age") {
+			continue
+		}
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds <= 0 {
+			break
+		}
+		if ttl := time.Duration(seconds) * time.Second; ttl < maxKeyCacheTTL {
+			return ttl
+		}
+		return maxKeyCacheTTL
+	}
+	return defaultKeyCacheTTL
+}
+
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,219 @@
+// Package oidc is a minimal OpenID Connect relying party: it reads a provider's discovery
+// document, exchanges authorization codes (with PKCE) for tokens, and verifies ID tokens
+// against the provider's published signing keys.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidToken is returned (wrapped) when an ID token fails verification
+var ErrInvalidToken = errors.New("invalid ID token")
+
+// Config configures one identity provider
+type Config struct {
+	ID           string // identifies the provider in login requests
+	Name         string // shown on the login page
+	Issuer       string // discovery is read from Issuer + "/.well-known/openid-configuration"
+	ClientID     string
+	ClientSecret string // empty for public (PKCE) clients
+	RedirectURI  string
+	Scopes       string
+
+	// Override the endpoints from the discovery document when set
+	AuthorizeURL string
+	TokenURL     string
+}
+
+// Discovery is the part of a provider's discovery document this package uses
+type Discovery struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	SigningAlgorithms     []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// TokenResponse is the response of the provider's token endpoint
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Provider is a configured identity provider. Its discovery document and signing keys are
+// fetched on first use and cached.
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *Discovery
+	keys      *KeySet
+
+	now func() time.Time
+}
+
+// NewProvider creates a provider. A nil client uses one with a 10 second timeout.
+func NewProvider(config Config, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	config.Issuer = strings.TrimSuffix(config.Issuer, "/")
+	return &Provider{config: config, client: client, now: time.Now}
+}
+
+// Config returns the provider's configuration
+func (p *Provider) Config() Config {
+	return p.config
+}
+
+// Discover returns the provider's discovery document. A failed fetch is retried on the next call.
+func (p *Provider) Discover(ctx context.Context) (*Discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var doc Discovery
+	if err := getJSON(ctx, p.client, p.config.Issuer+"/.well-known/openid-configuration", &doc, nil); err != nil {
+		return nil, fmt.Errorf("fetching discovery document: %w", err)
+	}
+	// The document must be for the configured issuer (OIDC Discovery section 4.3)
+	if strings.TrimSuffix(doc.Issuer, "/") != p.config.Issuer {
+		return nil, fmt.Errorf("discovery document is for issuer %q, expected %q", doc.Issuer, p.config.Issuer)
+	}
+	if doc.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document has no jwks_uri")
+	}
+
+	p.discovery = &doc
+	p.keys = NewKeySet(doc.JWKSURI, p.client)
+	return p.discovery, nil
+}
+
+// AuthorizeURL returns the provider's authorization endpoint
+func (p *Provider) AuthorizeURL(ctx context.Context) (string, error) {
+	if p.config.AuthorizeURL != "" {
+		return p.config.AuthorizeURL, nil
+	}
+	doc, err := p.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return doc.AuthorizationEndpoint, nil
+}
+
+// Exchange exchanges an authorization code and its PKCE verifier for tokens
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*TokenResponse, error) {
+	tokenURL := p.config.TokenURL
+	if tokenURL == "" {
+		doc, err := p.Discover(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tokenURL = doc.TokenEndpoint
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"code_verifier": {codeVerifier},
+		"client_id":     {p.config.ClientID},
+		"redirect_uri":  {p.config.RedirectURI},
+	}
+	if p.config.ClientSecret != "" {
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("building token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var tokens TokenResponse
+	if err := doJSON(p.client, req, &tokens, nil); err != nil {
+		return nil, fmt.Errorf("token exchange: %w", err)
+	}
+	return &tokens, nil
+}
+
+// getJSON fetches a JSON document, returning the response headers through header when not nil
+func getJSON(ctx context.Context, client *http.Client, u string, out interface{}, header *http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	return doJSON(client, req, out, header)
+}
+
+func doJSON(client *http.Client, req *http.Request, out interface{}, header *http.Header) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s: %w", req.URL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("reading response from %s: %w", req.URL.Redacted(), err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d: %s", req.URL.Redacted(), resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("parsing response from %s: %w", req.URL.Redacted(), err)
+	}
+	if header != nil {
+		*header = resp.Header
+	}
+	return nil
+}
+
+// Registry holds the configured providers in order
+type Registry struct {
+	providers []*Provider
+	byID      map[string]*Provider
+}
+
+// NewRegistry creates a registry of providers; the first is the default
+func NewRegistry(providers ...*Provider) *Registry {
+	r := &Registry{byID: make(map[string]*Provider, len(providers))}
+	for _, p := range providers {
+		r.providers = append(r.providers, p)
+		r.byID[p.config.ID] = p
+	}
+	return r
+}
+
+// Get returns the provider with the ID, or the default provider for an empty ID
+func (r *Registry) Get(id string) (*Provider, bool) {
+	if id == "" {
+		if len(r.providers) == 0 {
+			return nil, false
+		}
+		return r.providers[0], true
+	}
+	p, ok := r.byID[id]
+	return p, ok
+}
+
+// Providers returns the providers in order
+func (r *Registry) Providers() []*Provider {
+	return r.providers
+}
@@ -0,0 +1,85 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningAlgorithms are the ID token signature algorithms accepted
+var SigningAlgorithms = []string{"RS256", "ES256"}
+
+// clockSkew is the leeway allowed on exp, iat and nbf
+const clockSkew = time.Minute
+
+// VerifyIDToken verifies an ID token's signature against the provider's keys and its standard
+// claims (OIDC Core section 3.1.3.7): iss, aud, azp, exp, iat and nonce. nonce is the value the
+// login was started with; when empty, tokens carrying a nonce are rejected.
+// Failures wrap ErrInvalidToken.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (map[string]interface{}, error) {
+	if rawIDToken == "" {
+		return nil, fmt.Errorf("%w: empty token", ErrInvalidToken)
+	}
+	if _, err := p.Discover(ctx); err != nil {
+		return nil, err
+	}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(SigningAlgorithms),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(p.now),
+	)
+
+	claims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := p.keys.Key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		// The key must be of the type the algorithm needs
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA:
+			if _, ok := key.(*rsa.PublicKey); !ok {
+				return nil, fmt.Errorf("key %q is not an RSA key", kid)
+			}
+		case *jwt.SigningMethodECDSA:
+			if _, ok := key.(*ecdsa.PublicKey); !ok {
+				return nil, fmt.Errorf("key %q is not an EC key", kid)
+			}
+		}
+		return key, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if _, ok := claims["iat"]; !ok {
+		return nil, fmt.Errorf("%w: missing iat", ErrInvalidToken)
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidToken)
+	}
+
+	// With several audiences the token must be authorized for this client
+	if aud, _ := claims.GetAudience(); len(aud) > 1 {
+		if azp, _ := claims["azp"].(string); azp != p.config.ClientID {
+			return nil, fmt.Errorf("%w: azp %q does not match the client", ErrInvalidToken, azp)
+		}
+	}
+
+	tokenNonce, _ := claims["nonce"].(string)
+	if tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,65 @@
+// Package outbound builds HTTP clients for requests to URLs that users configure, such as
+// webhooks, so those URLs cannot be used to reach the API's own network.
+package outbound
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrAddressNotAllowed is returned when a URL resolves to a loopback, private or link-local address
+var ErrAddressNotAllowed = errors.New("address is not publicly routable")
+
+// NewPublicClient returns a client with a 10 second timeout that only connects to publicly
+// routable addresses. The check runs on the address actually dialed, after DNS resolution,
+// and no proxy is used.
+func NewPublicClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrAddressNotAllowed, host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// WithoutRedirects returns a copy of client that returns redirect responses instead of following them
+func WithoutRedirects(client *http.Client) *http.Client {
+	noRedirects := *client
+	noRedirects.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &noRedirects
+}
+
+// IsPublicIP reports whether requests may be sent to ip: not loopback, private
+// (RFC 1918, RFC 4193), link-local, multicast, unspecified or carrier-grade NAT
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	return !sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace is the RFC 6598 carrier-grade NAT range
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
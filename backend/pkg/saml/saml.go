@@ -0,0 +1,471 @@
+// Package saml is a minimal SAML 2.0 service provider: it publishes SP metadata, sends
+// AuthnRequests with the HTTP-Redirect binding, and validates signed Responses received
+// with the HTTP-POST binding at the Assertion Consumer Service.
+//
+// Only SP-initiated logins are accepted: every Response must answer an AuthnRequest the
+// caller issued, which the caller checks against Assertion.InResponseTo. Encrypted
+// assertions are not supported.
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+)
+
+// ErrInvalidResponse is returned (wrapped) when a SAML Response fails validation
+var ErrInvalidResponse = errors.New("invalid SAML response")
+
+// SAML identifiers
+const (
+	NameIDFormatEmail       = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	NameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+
+	bindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	statusSuccess       = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	confirmationBearer  = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	maxResponseSize     = 1 << 20
+	clockSkew           = 2 * time.Minute
+	samlVersion         = "2.0"
+	samlTimestampFormat = "2006-01-02T15:04:05Z"
+)
+
+// Config configures the service provider and the identity provider it trusts
+type Config struct {
+	EntityID     string // the SP's entity ID, expected as the assertions' audience
+	ACSURL       string // where the IdP posts Responses
+	NameIDFormat string // requested in AuthnRequests; defaults to NameIDFormatEmail
+
+	IdPEntityID     string
+	IdPSSOURL       string              // the IdP's SingleSignOnService (HTTP-Redirect binding)
+	IdPCertificates []*x509.Certificate // signing certificates; several while the IdP rotates
+}
+
+// Assertion is the validated content of a SAML Response
+type Assertion struct {
+	ID           string
+	InResponseTo string // the ID of the AuthnRequest the Response answers
+	NameID       string
+	NameIDFormat string
+	// Attributes by Name, and by FriendlyName when the IdP sends one
+	Attributes map[string][]string
+}
+
+// Attribute returns the first value of an attribute, or ""
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// ServiceProvider is a configured SAML service provider
+type ServiceProvider struct {
+	config Config
+	now    func() time.Time
+}
+
+// NewServiceProvider creates a service provider
+func NewServiceProvider(config Config) *ServiceProvider {
+	if config.NameIDFormat == "" {
+		config.NameIDFormat = NameIDFormatEmail
+	}
+	return &ServiceProvider{config: config, now: time.Now}
+}
+
+// Config returns the service provider's configuration
+func (sp *ServiceProvider) Config() Config {
+	return sp.config
+}
+
+// NewRequestID returns a random ID for an AuthnRequest. XML IDs may not start with a digit.
+func NewRequestID() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate request ID: %w", err)
+	}
+	return "_" + hex.EncodeToString(b), nil
+}
+
+type metadataEntityDescriptor struct {
+	XMLName         xml.Name                `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string                  `xml:"entityID,attr"`
+	SPSSODescriptor metadataSPSSODescriptor `xml:"SPSSODescriptor"`
+}
+
+type metadataSPSSODescriptor struct {
+	AuthnRequestsSigned        bool                `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string              `xml:"protocolSupportEnumeration,attr"`
+	NameIDFormat               string              `xml:"NameIDFormat"`
+	AssertionConsumerService   metadataACSEndpoint `xml:"AssertionConsumerService"`
+}
+
+type metadataACSEndpoint struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     int    `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr"`
+}
+
+// Metadata returns the SP's metadata document, for registering the SP with the IdP
+func (sp *ServiceProvider) Metadata() ([]byte, error) {
+	doc := metadataEntityDescriptor{
+		EntityID: sp.config.EntityID,
+		SPSSODescriptor: metadataSPSSODescriptor{
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: nsProtocol,
+			NameIDFormat:               sp.config.NameIDFormat,
+			AssertionConsumerService: metadataACSEndpoint{
+				Binding:   bindingHTTPPost,
+				Location:  sp.config.ACSURL,
+				IsDefault: true,
+			},
+		},
+	}
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal SP metadata: %w", err)
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+type authnRequest struct {
+	XMLName                     xml.Name           `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string             `xml:"ID,attr"`
+	Version                     string             `xml:"Version,attr"`
+	IssueInstant                string             `xml:"IssueInstant,attr"`
+	Destination                 string             `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string             `xml:"AssertionConsumerServiceURL,attr"`
+	ProtocolBinding             string             `xml:"ProtocolBinding,attr"`
+	Issuer                      authnRequestIssuer `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy                authnNameIDPolicy  `xml:"NameIDPolicy"`
+}
+
+type authnRequestIssuer struct {
+	Value string `xml:",chardata"`
+}
+
+type authnNameIDPolicy struct {
+	Format      string `xml:"Format,attr"`
+	AllowCreate bool   `xml:"AllowCreate,attr"`
+}
+
+// AuthnRequestURL returns the URL that sends the user to the IdP with an AuthnRequest of
+// the given ID (HTTP-Redirect binding). relayState is returned by the IdP with the Response.
+func (sp *ServiceProvider) AuthnRequestURL(requestID, relayState string) (string, error) {
+	request := authnRequest{
+		ID:                          requestID,
+		Version:                     samlVersion,
+		IssueInstant:                sp.now().UTC().Format(samlTimestampFormat),
+		Destination:                 sp.config.IdPSSOURL,
+		AssertionConsumerServiceURL: sp.config.ACSURL,
+		ProtocolBinding:             bindingHTTPPost,
+		Issuer:                      authnRequestIssuer{Value: sp.config.EntityID},
+		NameIDPolicy:                authnNameIDPolicy{Format: sp.config.NameIDFormat, AllowCreate: true},
+	}
+	out, err := xml.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal AuthnRequest: %w", err)
+	}
+
+	// The HTTP-Redirect binding sends the request DEFLATE-compressed and base64-encoded
+	var compressed bytes.Buffer
+	w, err := flate.NewWriter(&compressed, flate.BestCompression)
+	if err != nil {
+		return "", fmt.Errorf("failed to compress AuthnRequest: %w", err)
+	}
+	if _, err := w.Write(out); err != nil {
+		return "", fmt.Errorf("failed to compress AuthnRequest: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress AuthnRequest: %w", err)
+	}
+
+	u, err := url.Parse(sp.config.IdPSSOURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid IdP SSO URL: %w", err)
+	}
+	query := u.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(compressed.Bytes()))
+	if relayState != "" {
+		query.Set("RelayState", relayState)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// ParseResponse validates a base64-encoded Response as posted to the ACS: the signature of
+// the Response or of its assertion against the IdP certificates, the issuer, status,
+// audience, recipient and validity window. The caller must check that InResponseTo is the
+// ID of an AuthnRequest it issued and has not seen answered before.
+func (sp *ServiceProvider) ParseResponse(encoded string) (*Assertion, error) {
+	if len(encoded) > maxResponseSize {
+		return nil, fmt.Errorf("%w: response too large", ErrInvalidResponse)
+	}
+	data, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: not base64 encoded", ErrInvalidResponse)
+	}
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	assertion, err := sp.validateResponse(root)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	return assertion, nil
+}
+
+func (sp *ServiceProvider) validateResponse(response *etree.Element) (*Assertion, error) {
+	if !is(response, nsProtocol, "Response") {
+		return nil, errors.New("not a SAML Response")
+	}
+	if child(response, nsAssertion, "EncryptedAssertion") != nil {
+		return nil, errors.New("encrypted assertions are not supported")
+	}
+	assertion, err := onlyAssertion(response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Either the whole Response or the assertion must carry a valid signature. What is read
+	// from here on is the signed content as verified, not the document as received.
+	verifiedResponse, responseErr := verifySignature(response, sp.config.IdPCertificates)
+	if responseErr != nil && !errors.Is(responseErr, errNotSigned) {
+		return nil, fmt.Errorf("response signature: %v", responseErr)
+	}
+	if responseErr == nil {
+		response = verifiedResponse
+		if assertion, err = onlyAssertion(response); err != nil {
+			return nil, err
+		}
+	}
+	verifiedAssertion, assertionErr := verifySignature(assertion, sp.config.IdPCertificates)
+	if assertionErr != nil && !errors.Is(assertionErr, errNotSigned) {
+		return nil, fmt.Errorf("assertion signature: %v", assertionErr)
+	}
+	if responseErr != nil && assertionErr != nil {
+		return nil, errors.New("neither the response nor the assertion is signed")
+	}
+	if assertionErr == nil {
+		assertion = verifiedAssertion
+	}
+
+	if attr(response, "Version") != samlVersion {
+		return nil, errors.New("unsupported SAML version")
+	}
+	if destination := attr(response, "Destination"); destination != "" && destination != sp.config.ACSURL {
+		return nil, fmt.Errorf("response is destined for %q", destination)
+	}
+	if issuer := child(response, nsAssertion, "Issuer"); issuer != nil && text(issuer) != sp.config.IdPEntityID {
+		return nil, fmt.Errorf("response issued by unexpected IdP %q", text(issuer))
+	}
+	inResponseTo := attr(response, "InResponseTo")
+	if inResponseTo == "" {
+		return nil, errors.New("unsolicited responses are not accepted")
+	}
+
+	if err := checkStatus(response); err != nil {
+		return nil, err
+	}
+
+	return sp.validateAssertion(assertion, inResponseTo)
+}
+
+func onlyAssertion(response *etree.Element) (*etree.Element, error) {
+	assertions := childrenNamed(response, nsAssertion, "Assertion")
+	if len(assertions) != 1 {
+		return nil, errors.New("response must contain exactly one assertion")
+	}
+	return assertions[0], nil
+}
+
+func checkStatus(response *etree.Element) error {
+	status := child(response, nsProtocol, "Status")
+	if status == nil {
+		return errors.New("response has no status")
+	}
+	code := child(status, nsProtocol, "StatusCode")
+	if code == nil {
+		return errors.New("response has no status code")
+	}
+	if attr(code, "Value") == statusSuccess {
+		return nil
+	}
+	// The second-level status code says why, e.g. AuthnFailed or RequestDenied
+	detail := attr(code, "Value")
+	if sub := child(code, nsProtocol, "StatusCode"); sub != nil {
+		detail += " (" + attr(sub, "Value") + ")"
+	}
+	return fmt.Errorf("IdP returned status %s", detail)
+}
+
+func (sp *ServiceProvider) validateAssertion(assertion *etree.Element, inResponseTo string) (*Assertion, error) {
+	now := sp.now()
+
+	if attr(assertion, "Version") != samlVersion {
+		return nil, errors.New("unsupported assertion version")
+	}
+	issuer := child(assertion, nsAssertion, "Issuer")
+	if issuer == nil || text(issuer) != sp.config.IdPEntityID {
+		return nil, errors.New("assertion issued by unexpected IdP")
+	}
+
+	subject := child(assertion, nsAssertion, "Subject")
+	if subject == nil {
+		return nil, errors.New("assertion has no subject")
+	}
+	if err := sp.checkSubjectConfirmation(subject, inResponseTo, now); err != nil {
+		return nil, err
+	}
+
+	conditions := child(assertion, nsAssertion, "Conditions")
+	if conditions == nil {
+		return nil, errors.New("assertion has no conditions")
+	}
+	if err := sp.checkConditions(conditions, now); err != nil {
+		return nil, err
+	}
+
+	result := &Assertion{
+		ID:           attr(assertion, "ID"),
+		InResponseTo: inResponseTo,
+		Attributes:   map[string][]string{},
+	}
+	if nameID := child(subject, nsAssertion, "NameID"); nameID != nil {
+		result.NameID = text(nameID)
+		result.NameIDFormat = attr(nameID, "Format")
+	}
+	for _, statement := range childrenNamed(assertion, nsAssertion, "AttributeStatement") {
+		for _, attribute := range childrenNamed(statement, nsAssertion, "Attribute") {
+			var values []string
+			for _, value := range childrenNamed(attribute, nsAssertion, "AttributeValue") {
+				if v := text(value); v != "" {
+					values = append(values, v)
+				}
+			}
+			for _, name := range []string{attr(attribute, "Name"), attr(attribute, "FriendlyName")} {
+				if name != "" {
+					result.Attributes[name] = append(result.Attributes[name], values...)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// checkSubjectConfirmation requires a bearer confirmation for this SP, this request and now
+func (sp *ServiceProvider) checkSubjectConfirmation(subject *etree.Element, inResponseTo string, now time.Time) error {
+	for _, confirmation := range childrenNamed(subject, nsAssertion, "SubjectConfirmation") {
+		if attr(confirmation, "Method") != confirmationBearer {
+			continue
+		}
+		data := child(confirmation, nsAssertion, "SubjectConfirmationData")
+		if data == nil || attr(data, "Recipient") != sp.config.ACSURL || attr(data, "InResponseTo") != inResponseTo {
+			continue
+		}
+		notOnOrAfter, err := parseTime(attr(data, "NotOnOrAfter"))
+		if err != nil || !now.Before(notOnOrAfter.Add(clockSkew)) {
+			continue
+		}
+		return nil
+	}
+	return errors.New("assertion has no valid bearer subject confirmation for this service provider")
+}
+
+// checkConditions checks the validity window and that the SP is in every audience restriction
+func (sp *ServiceProvider) checkConditions(conditions *etree.Element, now time.Time) error {
+	if v := attr(conditions, "NotBefore"); v != "" {
+		notBefore, err := parseTime(v)
+		if err != nil || now.Add(clockSkew).Before(notBefore) {
+			return errors.New("assertion is not yet valid")
+		}
+	}
+	if v := attr(conditions, "NotOnOrAfter"); v != "" {
+		notOnOrAfter, err := parseTime(v)
+		if err != nil || !now.Before(notOnOrAfter.Add(clockSkew)) {
+			return errors.New("assertion has expired")
+		}
+	}
+
+	restrictions := childrenNamed(conditions, nsAssertion, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return errors.New("assertion has no audience restriction")
+	}
+	for _, restriction := range restrictions {
+		found := false
+		for _, audience := range childrenNamed(restriction, nsAssertion, "Audience") {
+			if text(audience) == sp.config.EntityID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New("assertion is not intended for this service provider")
+		}
+	}
+	return nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339, value)
+}
+
+// ParseCertificates reads X.509 certificates from PEM blocks, or from a single base64-encoded
+// DER certificate as IdP admin consoles and metadata often show them
+func ParseCertificates(data string) ([]*x509.Certificate, error) {
+	data = strings.TrimSpace(data)
+	if !strings.Contains(data, "-----BEGIN") {
+		der, err := decodeBase64(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %w", err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %w", err)
+		}
+		return []*x509.Certificate{cert}, nil
+	}
+
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+	return certs, nil
+}
+
+// decodeBase64 decodes base64 that may be wrapped across lines
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
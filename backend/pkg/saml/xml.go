@@ -0,0 +1,74 @@
+package saml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// XML namespaces
+const (
+	nsDSig      = "http://www.w3.org/2000/09/xmldsig#"
+	nsAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	nsProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+)
+
+// parseXML parses a document into its root element. DTDs are rejected.
+func parseXML(data []byte) (*etree.Element, error) {
+	doc := etree.NewDocument()
+	doc.ReadSettings.ValidateInput = true
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, fmt.Errorf("malformed XML: %w", err)
+	}
+	for _, token := range doc.Child {
+		if _, ok := token.(*etree.Directive); ok {
+			return nil, errors.New("XML documents with a DTD are not accepted")
+		}
+	}
+	root := doc.Root()
+	if root == nil {
+		return nil, errors.New("malformed XML: no root element")
+	}
+	return root, nil
+}
+
+func is(el *etree.Element, namespace, local string) bool {
+	return el.Tag == local && el.NamespaceURI() == namespace
+}
+
+// attr returns the value of an unqualified attribute
+// (etree's SelectAttr would also match a prefixed one).
+func attr(el *etree.Element, name string) string {
+	for _, a := range el.Attr {
+		if a.Space == "" && a.Key == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// childrenNamed returns the child elements with the name
+func childrenNamed(el *etree.Element, namespace, local string) []*etree.Element {
+	var children []*etree.Element
+	for _, child := range el.ChildElements() {
+		if is(child, namespace, local) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// child returns the first child element with the name, or nil
+func child(el *etree.Element, namespace, local string) *etree.Element {
+	if children := childrenNamed(el, namespace, local); len(children) > 0 {
+		return children[0]
+	}
+	return nil
+}
+
+// text returns the element's text content, trimmed
+func text(el *etree.Element) string {
+	return strings.TrimSpace(el.Text())
+}
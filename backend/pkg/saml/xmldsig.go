@@ -0,0 +1,90 @@
+package saml
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+// Signature and digest algorithms accepted on top of goxmldsig's checks. SHA-1 based
+// algorithms are not accepted.
+var (
+	signatureAlgorithms = map[string]bool{
+		dsig.RSASHA256SignatureMethod:   true,
+		dsig.RSASHA384SignatureMethod:   true,
+		dsig.RSASHA512SignatureMethod:   true,
+		dsig.ECDSASHA256SignatureMethod: true,
+		dsig.ECDSASHA384SignatureMethod: true,
+		dsig.ECDSASHA512SignatureMethod: true,
+	}
+	digestAlgorithms = map[string]bool{
+		"http://www.w3.org/2001/04/xmlenc#sha256":       true,
+		"http://www.w3.org/2001/04/xmldsig-more#sha384": true,
+		"http://www.w3.org/2001/04/xmlenc#sha512":       true,
+	}
+)
+
+var errNotSigned = errors.New("not signed")
+
+// verifySignature checks the enveloped signature of el against the trusted certificates and
+// returns the signed content, as verified, for the caller to read instead of el. The signature
+// must be a direct child of el and reference el itself. Returns errNotSigned when el carries
+// no signature.
+func verifySignature(el *etree.Element, certs []*x509.Certificate) (*etree.Element, error) {
+	signatures := childrenNamed(el, nsDSig, "Signature")
+	if len(signatures) == 0 {
+		return nil, errNotSigned
+	}
+	if len(signatures) > 1 {
+		return nil, errors.New("more than one signature")
+	}
+	if err := checkAlgorithms(signatures[0]); err != nil {
+		return nil, err
+	}
+
+	// Validate a copy of el that declares the namespaces it inherits from its ancestors
+	nsCtx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return nil, err
+	}
+	detached, err := etreeutils.NSDetatch(nsCtx, el)
+	if err != nil {
+		return nil, err
+	}
+
+	// One certificate per validation, so a signature without KeyInfo is still tried against
+	// each certificate while the IdP rotates
+	for _, cert := range certs {
+		ctx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: []*x509.Certificate{cert}})
+		var verified *etree.Element
+		if verified, err = ctx.Validate(detached); err == nil {
+			return verified, nil
+		}
+	}
+	if err == nil {
+		err = errors.New("no IdP certificate configured")
+	}
+	return nil, fmt.Errorf("signature does not match a trusted IdP certificate: %v", err)
+}
+
+func checkAlgorithms(signature *etree.Element) error {
+	signedInfo := child(signature, nsDSig, "SignedInfo")
+	if signedInfo == nil {
+		return errors.New("signature has no SignedInfo")
+	}
+	method := child(signedInfo, nsDSig, "SignatureMethod")
+	if method == nil || !signatureAlgorithms[attr(method, "Algorithm")] {
+		return errors.New("unsupported signature method")
+	}
+	for _, reference := range childrenNamed(signedInfo, nsDSig, "Reference") {
+		digest := child(reference, nsDSig, "DigestMethod")
+		if digest == nil || !digestAlgorithms[attr(digest, "Algorithm")] {
+			return errors.New("unsupported digest method")
+		}
+	}
+	return nil
+}
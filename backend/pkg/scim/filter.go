@@ -0,0 +1,466 @@
+package scim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Filter is a parsed filter expression (RFC 7644 section 3.4.2.2)
+type Filter interface {
+	// Matches reports whether the JSON form of a resource satisfies the filter
+	Matches(resource map[string]interface{}) bool
+}
+
+// ParseFilter parses a filter such as `userName eq "jdoe" and active eq true`.
+// Attribute names and string comparisons are case-insensitive. Failures are returned as
+// an *Error with scimType invalidFilter.
+func ParseFilter(expr string) (Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, invalidFilter("unexpected %q", p.tokens[p.pos].text)
+	}
+	return f, nil
+}
+
+func invalidFilter(format string, args ...interface{}) *Error {
+	return NewError(400, ErrorInvalidFilter, "invalid filter: "+fmt.Sprintf(format, args...))
+}
+
+type token struct {
+	text   string
+	quoted bool // a string literal
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			tokens = append(tokens, token{text: string(c)})
+			i++
+		case c == '"':
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(expr) {
+				if expr[i] == '\\' && i+1 < len(expr) {
+					sb.WriteByte(expr[i+1])
+					i += 2
+					continue
+				}
+				if expr[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteByte(expr[i])
+				i++
+			}
+			if !closed {
+				return nil, invalidFilter("unterminated string")
+			}
+			tokens = append(tokens, token{text: sb.String(), quoted: true})
+		default:
+			start := i
+			for i < len(expr) && !strings.ContainsRune(" \t\n\r()[]\"", rune(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, token{text: expr[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// keyword reports whether the next token is the unquoted word, and consumes it if so
+func (p *filterParser) keyword(word string) bool {
+	t, ok := p.peek()
+	if ok && !t.quoted && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) expect(text string) error {
+	if !p.keyword(text) {
+		return invalidFilter("expected %q", text)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	if p.keyword("not") {
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return notFilter{f}, nil
+	}
+
+	if p.keyword("(") {
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	return p.parseAttribute()
+}
+
+func (p *filterParser) parseAttribute() (Filter, error) {
+	t, ok := p.peek()
+	if !ok || t.quoted || strings.ContainsAny(t.text, "()[]") {
+		return nil, invalidFilter("expected an attribute")
+	}
+	p.pos++
+	path := parseAttrPath(t.text)
+
+	// Value path: emails[type eq "work"]
+	if p.keyword("[") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		return valuePathFilter{path: path, filter: inner}, nil
+	}
+
+	opToken, ok := p.peek()
+	if !ok || opToken.quoted {
+		return nil, invalidFilter("expected an operator after %q", t.text)
+	}
+	p.pos++
+	op := strings.ToLower(opToken.text)
+
+	switch op {
+	case "pr":
+		return presentFilter{path: path}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, invalidFilter("unknown operator %q", opToken.text)
+	}
+
+	valueToken, ok := p.peek()
+	if !ok {
+		return nil, invalidFilter("expected a value after %q", opToken.text)
+	}
+	p.pos++
+
+	var value interface{}
+	if valueToken.quoted {
+		value = valueToken.text
+	} else {
+		switch strings.ToLower(valueToken.text) {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			n, err := strconv.ParseFloat(valueToken.text, 64)
+			if err != nil {
+				return nil, invalidFilter("invalid value %q", valueToken.text)
+			}
+			value = n
+		}
+	}
+
+	return compareFilter{path: path, op: op, value: value}, nil
+}
+
+// attrPath is an attribute reference: an optional extension schema, a name and a sub-attribute
+type attrPath struct {
+	schema string // extension schema URN; empty for core attributes
+	name   string
+	sub    string
+}
+
+// parseAttrPath splits `urn:...:User:manager.value` into schema, name and sub-attribute.
+// Core schema URNs are dropped, as core attributes are top level.
+func parseAttrPath(text string) attrPath {
+	var path attrPath
+	if strings.HasPrefix(strings.ToLower(text), "urn:") {
+		path.schema, text = splitSchema(text)
+		if strings.Contains(strings.ToLower(path.schema), ":core:") {
+			path.schema = ""
+		}
+	}
+	if i := strings.Index(text, "."); i >= 0 {
+		path.name, path.sub = text[:i], text[i+1:]
+	} else {
+		path.name = text
+	}
+	return path
+}
+
+// splitSchema splits a URN-qualified attribute into its schema and attribute. Known schemas are
+// matched whole, since their URNs may end in what looks like an attribute (`...:2.0:User`).
+func splitSchema(text string) (string, string) {
+	for _, schema := range []string{SchemaUser, SchemaGroup, SchemaEnterpriseUser} {
+		if strings.EqualFold(text, schema) {
+			return schema, ""
+		}
+		if len(text) > len(schema) && strings.EqualFold(text[:len(schema)+1], schema+":") {
+			return schema, text[len(schema)+1:]
+		}
+	}
+	if i := strings.LastIndex(text, ":"); i > 0 {
+		return text[:i], text[i+1:]
+	}
+	return "", text
+}
+
+// container returns the map holding the attribute: the resource, or its extension object
+func (a attrPath) container(resource map[string]interface{}) (map[string]interface{}, bool) {
+	if a.schema == "" {
+		return resource, true
+	}
+	key, ok := findKey(resource, a.schema)
+	if !ok {
+		return nil, false
+	}
+	m, ok := resource[key].(map[string]interface{})
+	return m, ok
+}
+
+// values returns every value the path refers to. The elements of multi-valued attributes are
+// returned individually, and complex values compared without a sub-attribute use their "value".
+func (a attrPath) values(resource map[string]interface{}) []interface{} {
+	container, ok := a.container(resource)
+	if !ok {
+		return nil
+	}
+	key, ok := findKey(container, a.name)
+	if !ok {
+		return nil
+	}
+
+	var values []interface{}
+	var collect func(v interface{})
+	collect = func(v interface{}) {
+		switch typed := v.(type) {
+		case []interface{}:
+			for _, item := range typed {
+				collect(item)
+			}
+		case map[string]interface{}:
+			sub := a.sub
+			if sub == "" {
+				sub = "value"
+			}
+			if subKey, ok := findKey(typed, sub); ok {
+				values = append(values, typed[subKey])
+			}
+		default:
+			if a.sub == "" && v != nil {
+				values = append(values, v)
+			}
+		}
+	}
+	collect(container[key])
+	return values
+}
+
+type andFilter struct{ left, right Filter }
+
+func (f andFilter) Matches(r map[string]interface{}) bool {
+	return f.left.Matches(r) && f.right.Matches(r)
+}
+
+type orFilter struct{ left, right Filter }
+
+func (f orFilter) Matches(r map[string]interface{}) bool {
+	return f.left.Matches(r) || f.right.Matches(r)
+}
+
+type notFilter struct{ inner Filter }
+
+func (f notFilter) Matches(r map[string]interface{}) bool { return !f.inner.Matches(r) }
+
+type presentFilter struct{ path attrPath }
+
+func (f presentFilter) Matches(r map[string]interface{}) bool {
+	for _, v := range f.path.values(r) {
+		if s, ok := v.(string); !ok || s != "" {
+			return true
+		}
+	}
+	return false
+}
+
+type valuePathFilter struct {
+	path   attrPath
+	filter Filter
+}
+
+func (f valuePathFilter) Matches(r map[string]interface{}) bool {
+	for _, element := range f.path.elements(r) {
+		if f.filter.Matches(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// elements returns the complex values of a multi-valued attribute
+func (a attrPath) elements(resource map[string]interface{}) []map[string]interface{} {
+	container, ok := a.container(resource)
+	if !ok {
+		return nil
+	}
+	key, ok := findKey(container, a.name)
+	if !ok {
+		return nil
+	}
+	items, _ := container[key].([]interface{})
+	var elements []map[string]interface{}
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			elements = append(elements, m)
+		}
+	}
+	return elements
+}
+
+type compareFilter struct {
+	path  attrPath
+	op    string
+	value interface{}
+}
+
+func (f compareFilter) Matches(r map[string]interface{}) bool {
+	values := f.path.values(r)
+	if f.op == "ne" {
+		for _, v := range values {
+			if compareValues(v, "eq", f.value) {
+				return false
+			}
+		}
+		return f.value != nil || len(values) > 0
+	}
+	if f.value == nil && f.op == "eq" {
+		return len(values) == 0
+	}
+	for _, v := range values {
+		if compareValues(v, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareValues(actual interface{}, op string, expected interface{}) bool {
+	switch want := expected.(type) {
+	case string:
+		got, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		got, want = strings.ToLower(got), strings.ToLower(want)
+		switch op {
+		case "eq":
+			return got == want
+		case "co":
+			return strings.Contains(got, want)
+		case "sw":
+			return strings.HasPrefix(got, want)
+		case "ew":
+			return strings.HasSuffix(got, want)
+		case "gt":
+			return got > want
+		case "ge":
+			return got >= want
+		case "lt":
+			return got < want
+		case "le":
+			return got <= want
+		}
+	case bool:
+		got, ok := actual.(bool)
+		return ok && op == "eq" && got == want
+	case float64:
+		got, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return got == want
+		case "gt":
+			return got > want
+		case "ge":
+			return got >= want
+		case "lt":
+			return got < want
+		case "le":
+			return got <= want
+		}
+	}
+	return false
+}
@@ -0,0 +1,255 @@
+package scim
+
+import (
+	"reflect"
+	"strings"
+)
+
+// patchPath is a PATCH target: an attribute, optionally narrowed to the elements of a
+// multi-valued attribute matching a filter, and optionally a sub-attribute of those
+// (`emails[type eq "work"].value`)
+type patchPath struct {
+	attrPath
+	filter Filter
+}
+
+func parsePatchPath(path string) (*patchPath, error) {
+	open := strings.Index(path, "[")
+	if open < 0 {
+		return &patchPath{attrPath: parseAttrPath(path)}, nil
+	}
+
+	closing := strings.LastIndex(path, "]")
+	if closing < open {
+		return nil, NewError(400, ErrorInvalidPath, "invalid path: "+path)
+	}
+	filter, err := ParseFilter(path[open+1 : closing])
+	if err != nil {
+		return nil, NewError(400, ErrorInvalidPath, "invalid path: "+path)
+	}
+
+	p := &patchPath{attrPath: parseAttrPath(path[:open]), filter: filter}
+	if rest := path[closing+1:]; rest != "" {
+		if !strings.HasPrefix(rest, ".") || p.sub != "" {
+			return nil, NewError(400, ErrorInvalidPath, "invalid path: "+path)
+		}
+		p.sub = rest[1:]
+	}
+	return p, nil
+}
+
+// ApplyPatch applies PATCH operations in order to the JSON form of a resource.
+// Failures are returned as an *Error with the scimType to report.
+func ApplyPatch(resource map[string]interface{}, operations []Operation) error {
+	for _, op := range operations {
+		if err := applyOperation(resource, strings.ToLower(op.Op), op.Path, op.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyOperation(resource map[string]interface{}, op, path string, value interface{}) error {
+	if op != "add" && op != "replace" && op != "remove" {
+		return NewError(400, ErrorInvalidSyntax, "unsupported operation: "+op)
+	}
+
+	// Without a path the value holds the attributes to add or replace, keyed by path
+	if path == "" {
+		if op == "remove" {
+			return NewError(400, ErrorNoTarget, "remove requires a path")
+		}
+		attributes, ok := value.(map[string]interface{})
+		if !ok {
+			return NewError(400, ErrorInvalidValue, "value must be an object when no path is given")
+		}
+		for key, v := range attributes {
+			if err := applyOperation(resource, op, key, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	target, err := parsePatchPath(path)
+	if err != nil {
+		return err
+	}
+	if target.name == "" {
+		// A path naming only an extension schema targets the extension object
+		target.name, target.schema = target.schema, ""
+	}
+
+	container, ok := target.container(resource)
+	if !ok {
+		if op == "remove" {
+			return nil
+		}
+		container = map[string]interface{}{}
+		resource[target.schema] = container
+	}
+
+	key, exists := findKey(container, target.name)
+	if !exists {
+		key = target.name
+	}
+
+	if target.filter != nil {
+		return applyToElements(container, key, op, target, value)
+	}
+
+	if target.sub != "" {
+		switch current := container[key].(type) {
+		case []interface{}:
+			// Without a filter a sub-attribute path applies to every element
+			for _, item := range current {
+				if element, ok := item.(map[string]interface{}); ok {
+					setAttribute(element, op, target.sub, value)
+				}
+			}
+		case map[string]interface{}:
+			setAttribute(current, op, target.sub, value)
+		default:
+			if op != "remove" {
+				container[key] = map[string]interface{}{target.sub: value}
+			}
+		}
+		return nil
+	}
+
+	if op == "remove" {
+		current, isList := container[key].([]interface{})
+		if removals, ok := value.([]interface{}); ok && isList {
+			// Some identity providers remove members by value: {"path": "members", "value": [{"value": "id"}]}
+			container[key] = withoutItems(current, removals)
+			return nil
+		}
+		delete(container, key)
+		return nil
+	}
+
+	setAttribute(container, op, key, value)
+	return nil
+}
+
+// setAttribute adds, replaces or removes one attribute of a complex value
+func setAttribute(m map[string]interface{}, op, name string, value interface{}) {
+	key, exists := findKey(m, name)
+	if !exists {
+		key = name
+	}
+
+	if op == "remove" {
+		delete(m, key)
+		return
+	}
+
+	switch current := m[key].(type) {
+	case []interface{}:
+		if op == "add" {
+			m[key] = withItems(current, value)
+			return
+		}
+	case map[string]interface{}:
+		// Sub-attributes not in the value are left unchanged
+		if values, ok := value.(map[string]interface{}); ok {
+			for k, v := range values {
+				setAttribute(current, op, k, v)
+			}
+			return
+		}
+	}
+	m[key] = value
+}
+
+// applyToElements applies an operation to the elements of a multi-valued attribute matching the path's filter
+func applyToElements(container map[string]interface{}, key, op string, target *patchPath, value interface{}) error {
+	items, _ := container[key].([]interface{})
+
+	var kept []interface{}
+	matched := false
+	for _, item := range items {
+		element, ok := item.(map[string]interface{})
+		if !ok || !target.filter.Matches(element) {
+			kept = append(kept, item)
+			continue
+		}
+		matched = true
+
+		switch {
+		case op == "remove" && target.sub == "":
+			continue
+		case target.sub != "":
+			setAttribute(element, op, target.sub, value)
+		default:
+			if values, ok := value.(map[string]interface{}); ok {
+				for k, v := range values {
+					setAttribute(element, op, k, v)
+				}
+			}
+		}
+		kept = append(kept, element)
+	}
+
+	if !matched && op != "remove" {
+		return NewError(400, ErrorNoTarget, "no value matches the path filter")
+	}
+	if kept == nil {
+		kept = []interface{}{}
+	}
+	container[key] = kept
+	return nil
+}
+
+// withItems appends the value (one item or a list) to a multi-valued attribute, skipping items already present
+func withItems(items []interface{}, value interface{}) []interface{} {
+	additions, ok := value.([]interface{})
+	if !ok {
+		additions = []interface{}{value}
+	}
+	for _, addition := range additions {
+		present := false
+		for _, item := range items {
+			if sameItem(item, addition) {
+				present = true
+				break
+			}
+		}
+		if !present {
+			items = append(items, addition)
+		}
+	}
+	return items
+}
+
+// withoutItems removes the given items from a multi-valued attribute
+func withoutItems(items []interface{}, removals []interface{}) []interface{} {
+	kept := []interface{}{}
+	for _, item := range items {
+		remove := false
+		for _, removal := range removals {
+			if sameItem(item, removal) {
+				remove = true
+				break
+			}
+		}
+		if !remove {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// sameItem compares complex values by their "value" sub-attribute, and other values as a whole
+func sameItem(a, b interface{}) bool {
+	am, aok := a.(map[string]interface{})
+	bm, bok := b.(map[string]interface{})
+	if aok && bok {
+		ak, aHas := findKey(am, "value")
+		bk, bHas := findKey(bm, "value")
+		if aHas && bHas {
+			return reflect.DeepEqual(am[ak], bm[bk])
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -76,6 +76,9 @@ var (
 	// --- Rate Limiting Metrics ---
 	rateLimitExceeded metric.Int64Counter
 
+	// --- Audit Metrics ---
+	auditWriteFailures metric.Int64Counter // Admin writes whose audit entry could not be recorded
+
 	// --- General HTTP Metrics (supplemental to otelgin) ---
 	httpRequestsInflight metric.Int64UpDownCounter
 
@@ -284,6 +287,15 @@ func initBusinessMetrics() error {
 		return err
 	}
 
+	// --- Audit Metrics ---
+	auditWriteFailures, err = meter.Int64Counter("audit.write_failures.total",
+		metric.WithDescription("Total number of admin writes whose audit entry could not be recorded"),
+		metric.WithUnit("{entries}"),
+	)
+	if err != nil {
+		return err
+	}
+
 	// --- HTTP Metrics ---
 	httpRequestsInflight, err = meter.Int64UpDownCounter("http.requests.inflight",
 		metric.WithDescription("Number of HTTP requests currently being processed"),
@@ -742,6 +754,19 @@ func RecordRateLimitExceeded(ctx context.Context, clientIP, endpoint string) {
 	))
 }
 
+// --- Audit Metric Recording ---
+
+// RecordAuditWriteFailure records an admin write whose audit entry could not be recorded
+func RecordAuditWriteFailure(ctx context.Context, action string) {
+	// Skip if metrics not initialized (e.g., in tests)
+	if auditWriteFailures == nil {
+		return
+	}
+	auditWriteFailures.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("action", action),
+	))
+}
+
 // --- HTTP Metric Recording ---
 
 // IncrementInflightRequests increments inflight HTTP requests
@@ -0,0 +1,152 @@
+// Package xlsx writes single-sheet Office Open XML workbooks as a stream.
+//
+// Rows are written straight into the zip archive as they arrive, so a workbook of any size
+// is produced without holding it in memory. Cells are written as inline strings or numbers;
+// there are no shared strings, styles or formulas.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const packageRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
+
+const workbookTemplate = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const sheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const sheetFooter = `</sheetData></worksheet>`
+
+// Writer streams the rows of a single worksheet
+type Writer struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	rows  int
+}
+
+// NewWriter starts a workbook with one sheet of the given name (at most 31 characters)
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	zw := zip.NewWriter(w)
+
+	var name []byte
+	if err := xmlEscape(&name, sheetName); err != nil {
+		return nil, err
+	}
+
+	parts := []struct {
+		path    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", packageRels},
+		{"xl/workbook.xml", fmt.Sprintf(workbookTemplate, name)},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", part.path, err)
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", part.path, err)
+		}
+	}
+
+	// The worksheet is the last part so its rows can be streamed until Close
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worksheet: %w", err)
+	}
+	if _, err := io.WriteString(sheet, sheetHeader); err != nil {
+		return nil, fmt.Errorf("failed to write worksheet: %w", err)
+	}
+
+	return &Writer{zip: zw, sheet: sheet}, nil
+}
+
+// WriteRow appends a row. Integers and floats become numeric cells; every other value
+// is written as text using its default format.
+func (w *Writer) WriteRow(values ...interface{}) error {
+	w.rows++
+	row := strconv.Itoa(w.rows)
+
+	buf := make([]byte, 0, 64*len(values))
+	buf = append(buf, `<row r="`+row+`">`...)
+	for i, value := range values {
+		ref := columnName(i) + row
+		switch v := value.(type) {
+		case int:
+			buf = append(buf, `<c r="`+ref+`"><v>`+strconv.Itoa(v)+`</v></c>`...)
+		case int64:
+			buf = append(buf, `<c r="`+ref+`"><v>`+strconv.FormatInt(v, 10)+`</v></c>`...)
+		case float64:
+			buf = append(buf, `<c r="`+ref+`"><v>`+strconv.FormatFloat(v, 'f', -1, 64)+`</v></c>`...)
+		default:
+			buf = append(buf, `<c r="`+ref+`" t="inlineStr"><is><t xml:space="preserve">`...)
+			if err := xmlEscape(&buf, fmt.Sprint(v)); err != nil {
+				return err
+			}
+			buf = append(buf, `</t></is></c>`...)
+		}
+	}
+	buf = append(buf, `</row>`...)
+
+	if _, err := w.sheet.Write(buf); err != nil {
+		return fmt.Errorf("failed to write row %d: %w", w.rows, err)
+	}
+	return nil
+}
+
+// Close finishes the worksheet and the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if _, err := io.WriteString(w.sheet, sheetFooter); err != nil {
+		return fmt.Errorf("failed to write worksheet: %w", err)
+	}
+	return w.zip.Close()
+}
+
+// byteWriter lets xml.EscapeText append to a byte slice
+type byteWriter struct{ buf *[]byte }
+
+func (b byteWriter) Write(p []byte) (int, error) {
+	*b.buf = append(*b.buf, p...)
+	return len(p), nil
+}
+
+// xmlEscape appends s to buf as XML text. Characters not allowed in XML are replaced.
+func xmlEscape(buf *[]byte, s string) error {
+	return xml.EscapeText(byteWriter{buf}, []byte(s))
+}
+
+// columnName returns the spreadsheet column letters for a zero-based index (0 = A, 26 = AA)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
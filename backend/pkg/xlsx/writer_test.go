@@ -0,0 +1,94 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sheetXML struct {
+	Rows []struct {
+		R     string `xml:"r,attr"`
+		Cells []struct {
+			R      string `xml:"r,attr"`
+			T      string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type workbookXML struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// readPart returns the content of one part of the archive
+func readPart(t *testing.T, archive []byte, path string) []byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	f, err := zr.Open(path)
+	require.NoError(t, err, path)
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	return content
+}
+
+func TestWriter_RoundTrip_Unit(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(&out, `Q3 <"R&D">`)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow("Team", "Score", "Responses"))
+	require.NoError(t, w.WriteRow(`Platform <&"core">`, 2.5, 7))
+	require.NoError(t, w.WriteRow("tab\tnew\nline", int64(12), "bell\x07end"))
+	require.NoError(t, w.Close())
+
+	var workbook workbookXML
+	require.NoError(t, xml.Unmarshal(readPart(t, out.Bytes(), "xl/workbook.xml"), &workbook))
+	require.Len(t, workbook.Sheets, 1)
+	assert.Equal(t, `Q3 <"R&D">`, workbook.Sheets[0].Name)
+
+	var sheet sheetXML
+	require.NoError(t, xml.Unmarshal(readPart(t, out.Bytes(), "xl/worksheets/sheet1.xml"), &sheet))
+	require.Len(t, sheet.Rows, 3)
+
+	header := sheet.Rows[0]
+	assert.Equal(t, "1", header.R)
+	require.Len(t, header.Cells, 3)
+	assert.Equal(t, "A1", header.Cells[0].R)
+	assert.Equal(t, "inlineStr", header.Cells[0].T)
+	assert.Equal(t, "Team", header.Cells[0].Inline)
+	assert.Equal(t, "C1", header.Cells[2].R)
+
+	row := sheet.Rows[1]
+	require.Len(t, row.Cells, 3)
+	assert.Equal(t, `Platform <&"core">`, row.Cells[0].Inline)
+	assert.Equal(t, "", row.Cells[1].T)
+	assert.Equal(t, "2.5", row.Cells[1].Value)
+	assert.Equal(t, "7", row.Cells[2].Value)
+
+	// Whitespace survives; characters XML cannot carry are replaced
+	row = sheet.Rows[2]
+	require.Len(t, row.Cells, 3)
+	assert.Equal(t, "tab\tnew\nline", row.Cells[0].Inline)
+	assert.Equal(t, "12", row.Cells[1].Value)
+	assert.Equal(t, "bell\uFFFDend", row.Cells[2].Inline)
+
+	for _, path := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels"} {
+		assert.NotEmpty(t, readPart(t, out.Bytes(), path), path)
+	}
+}
+
+func TestColumnName_Unit(t *testing.T) {
+	for index, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, name, columnName(index), index)
+	}
+}
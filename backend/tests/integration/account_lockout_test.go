@@ -0,0 +1,321 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ = Describe("Integration: Account Lockout", func() {
+	var (
+		db           *sql.DB
+		cleanup      func()
+		jwtService   *services.JWTService
+		emailService *testhelpers.MockEmailService
+		router       *gin.Engine
+	)
+
+	// newReplica builds an API instance with its own lockout service over the shared database
+	newReplica := func(policy services.AccountLockoutPolicy) *gin.Engine {
+		orgRepo := postgres.NewOrganizationRepository(db)
+		userRepo := postgres.NewUserRepository(db)
+		teamRepo := postgres.NewTeamRepository(db)
+		lockout := services.NewAccountLockoutService(postgres.NewLoginAttemptRepository(db), emailService, policy)
+
+		r := gin.New()
+		v1.SetupAuthRoutes(r, userRepo, orgRepo, jwtService, lockout, nil)
+		v1.SetupAdminRoutes(r, v1.AdminRouteDeps{
+			OrgRepo:     orgRepo,
+			UserRepo:    userRepo,
+			TeamRepo:    teamRepo,
+			JWTService:  jwtService,
+			Permissions: services.NewPermissionService(orgRepo),
+			Lockout:     lockout,
+		})
+		return r
+	}
+
+	loginAs := func(r *gin.Engine, username, password string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]string{"username": username, "password": password})
+		req, _ := http.NewRequest("POST", "/api/v1/auth/login", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	login := func(r *gin.Engine, password string) *httptest.ResponseRecorder {
+		return loginAs(r, "lockout_user", password)
+	}
+
+	failLogins := func(r *gin.Engine, n int) {
+		for i := 0; i < n; i++ {
+			Expect(login(r, "wrong-password").Code).To(Equal(http.StatusUnauthorized))
+		}
+	}
+
+	// lockoutPolicy locks after 3 failures without delays in between
+	lockoutPolicy := services.AccountLockoutPolicy{
+		MaxFailures:     3,
+		LockoutDuration: 15 * time.Minute,
+		FailureWindow:   time.Hour,
+		DelayAfter:      3,
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		gin.SetMode(gin.TestMode)
+
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.DefaultCost)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash)
+			VALUES ('lockout_user', 'lockout_user', 'lockout_user@test.com', 'Lockout User', 'level-5', $1)
+		`, string(hashedPassword))
+		Expect(err).NotTo(HaveOccurred())
+
+		jwtService = services.NewJWTService()
+		emailService = testhelpers.NewMockEmailService()
+		router = newReplica(lockoutPolicy)
+	})
+
+	AfterEach(func() {
+		cleanup()
+		os.Unsetenv("JWT_SECRET")
+	})
+
+	Describe("POST /api/v1/auth/login", func() {
+		It("should lock the account after too many failed logins, even for the correct password", func() {
+			failLogins(router, 3)
+
+			w := login(router, "correct-password")
+			Expect(w.Code).To(Equal(http.StatusLocked))
+			retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retryAfter).To(BeNumerically("~", 15*60, 5))
+		})
+
+		It("should email the user once when the account is locked", func() {
+			failLogins(router, 3)
+
+			Expect(emailService.SentHTMLEmails).To(HaveLen(1))
+			Expect(emailService.SentHTMLEmails[0].To).To(Equal("lockout_user@test.com"))
+			Expect(emailService.SentHTMLEmails[0].Subject).To(ContainSubstring("locked"))
+			Expect(emailService.SentHTMLEmails[0].Body).To(ContainSubstring("lockout_user"))
+
+			// Guesses while locked are refused and do not send more emails
+			Expect(login(router, "wrong-password").Code).To(Equal(http.StatusLocked))
+			Expect(emailService.SentHTMLEmails).To(HaveLen(1))
+		})
+
+		It("should reset the failure count after a successful login", func() {
+			failLogins(router, 2)
+			Expect(login(router, "correct-password").Code).To(Equal(http.StatusOK))
+
+			failLogins(router, 2)
+			Expect(login(router, "correct-password").Code).To(Equal(http.StatusOK))
+
+			var count int
+			Expect(db.QueryRow("SELECT COUNT(*) FROM login_attempts").Scan(&count)).To(Succeed())
+			Expect(count).To(Equal(0))
+		})
+
+		It("should start a new count when the previous failure is outside the window", func() {
+			failLogins(router, 2)
+			_, err := db.Exec("UPDATE login_attempts SET last_failed_at = NOW() - INTERVAL '2 hours'")
+			Expect(err).NotTo(HaveOccurred())
+
+			failLogins(router, 2)
+			Expect(login(router, "correct-password").Code).To(Equal(http.StatusOK))
+		})
+
+		It("should unlock the account once the lockout expires", func() {
+			failLogins(router, 3)
+			_, err := db.Exec("UPDATE login_attempts SET locked_until = NOW() - INTERVAL '1 second'")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(login(router, "correct-password").Code).To(Equal(http.StatusOK))
+		})
+
+		It("should delay attempts progressively before the lockout", func() {
+			router = newReplica(services.AccountLockoutPolicy{
+				MaxFailures:     10,
+				LockoutDuration: 15 * time.Minute,
+				FailureWindow:   time.Hour,
+				DelayAfter:      1,
+				BaseDelay:       time.Minute,
+				MaxDelay:        10 * time.Minute,
+			})
+
+			failLogins(router, 2)
+
+			w := login(router, "correct-password")
+			Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+			retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retryAfter).To(BeNumerically("~", 60, 5))
+
+			// The delay doubles with the next failure
+			_, err = db.Exec("UPDATE login_attempts SET next_attempt_at = NULL")
+			Expect(err).NotTo(HaveOccurred())
+			failLogins(router, 1)
+			retryAfter, err = strconv.Atoi(login(router, "correct-password").Header().Get("Retry-After"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retryAfter).To(BeNumerically("~", 120, 5))
+		})
+
+		It("should share failures across replicas", func() {
+			other := newReplica(lockoutPolicy)
+
+			failLogins(router, 2)
+			failLogins(other, 1)
+
+			Expect(login(router, "correct-password").Code).To(Equal(http.StatusLocked))
+			Expect(login(other, "correct-password").Code).To(Equal(http.StatusLocked))
+		})
+
+		It("should count concurrent guesses one after another", func() {
+			codes := make([]int, 10)
+			var wg sync.WaitGroup
+			for i := range codes {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					defer GinkgoRecover()
+					codes[i] = login(router, "wrong-password").Code
+				}(i)
+			}
+			wg.Wait()
+
+			// Only the attempts before the lockout reach the password check
+			Expect(codes).To(ContainElements(http.StatusUnauthorized, http.StatusLocked))
+			unauthorized := 0
+			for _, code := range codes {
+				if code == http.StatusUnauthorized {
+					unauthorized++
+				}
+			}
+			Expect(unauthorized).To(Equal(3))
+
+			var failed int
+			Expect(db.QueryRow("SELECT failed_count FROM login_attempts WHERE username = 'lockout_user'").Scan(&failed)).To(Succeed())
+			Expect(failed).To(Equal(3))
+		})
+
+		It("should match usernames regardless of case and surrounding spaces", func() {
+			failLogins(router, 2)
+			Expect(loginAs(router, " LOCKOUT_USER ", "wrong-password").Code).To(Equal(http.StatusUnauthorized))
+
+			Expect(login(router, "correct-password").Code).To(Equal(http.StatusLocked))
+		})
+
+		It("should throttle unknown usernames like existing accounts", func() {
+			for i := 0; i < 3; i++ {
+				Expect(loginAs(router, "nobody", "wrong-password").Code).To(Equal(http.StatusUnauthorized))
+			}
+
+			w := loginAs(router, "nobody", "wrong-password")
+			Expect(w.Code).To(Equal(http.StatusLocked))
+			retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retryAfter).To(BeNumerically("~", 15*60, 5))
+			Expect(emailService.SentHTMLEmails).To(BeEmpty())
+
+			// A locked account answers the same way
+			failLogins(router, 3)
+			Expect(login(router, "wrong-password").Body.String()).To(Equal(w.Body.String()))
+		})
+	})
+
+	Describe("Cleanup", func() {
+		It("should delete failed logins that no longer count and keep the rest", func() {
+			Expect(loginAs(router, "stale_guess", "wrong-password").Code).To(Equal(http.StatusUnauthorized))
+			Expect(loginAs(router, "recent_guess", "wrong-password").Code).To(Equal(http.StatusUnauthorized))
+			failLogins(router, 3)
+			_, err := db.Exec("UPDATE login_attempts SET last_failed_at = NOW() - INTERVAL '2 hours' WHERE username IN ('stale_guess', 'lockout_user')")
+			Expect(err).NotTo(HaveOccurred())
+
+			lockout := services.NewAccountLockoutService(postgres.NewLoginAttemptRepository(db), nil, lockoutPolicy)
+			deleted, err := lockout.RunAt(context.Background(), time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(int64(1)))
+
+			// The locked account stays locked until its lockout ends
+			rows, err := db.Query("SELECT username FROM login_attempts ORDER BY username")
+			Expect(err).NotTo(HaveOccurred())
+			defer rows.Close()
+			var usernames []string
+			for rows.Next() {
+				var username string
+				Expect(rows.Scan(&username)).To(Succeed())
+				usernames = append(usernames, username)
+			}
+			Expect(usernames).To(Equal([]string{"lockout_user", "recent_guess"}))
+			Expect(login(router, "correct-password").Code).To(Equal(http.StatusLocked))
+		})
+	})
+
+	Describe("POST /api/v1/admin/users/:id/unlock", func() {
+		var adminToken string
+
+		BeforeEach(func() {
+			adminTokens, err := jwtService.GenerateTokenPair(context.Background(), "admin", "admin", "admin@test.com", "level-admin", nil)
+			Expect(err).NotTo(HaveOccurred())
+			adminToken = adminTokens.AccessToken
+		})
+
+		unlock := func(userID string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("POST", "/api/v1/admin/users/"+userID+"/unlock", nil)
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		It("should lift the lockout immediately", func() {
+			failLogins(router, 3)
+			Expect(login(router, "correct-password").Code).To(Equal(http.StatusLocked))
+
+			w := unlock("lockout_user")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp map[string]interface{}
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp["message"]).To(Equal("User unlocked"))
+
+			Expect(login(router, "correct-password").Code).To(Equal(http.StatusOK))
+		})
+
+		It("should succeed for a user without failed logins", func() {
+			Expect(unlock("lockout_user").Code).To(Equal(http.StatusOK))
+		})
+
+		It("should return 404 for an unknown user", func() {
+			Expect(unlock("no_such_user").Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should require admin privileges", func() {
+			userTokens, err := jwtService.GenerateTokenPair(context.Background(), "lockout_user", "lockout_user", "lockout_user@test.com", "level-5", nil)
+			Expect(err).NotTo(HaveOccurred())
+			adminToken = userTokens.AccessToken
+			Expect(unlock("lockout_user").Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
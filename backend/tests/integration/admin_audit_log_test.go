@@ -0,0 +1,253 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// failingAuditRepository refuses new entries, as an unavailable audit log table would
+type failingAuditRepository struct {
+	services.AdminAuditRepository
+}
+
+func (failingAuditRepository) AppendAuditEntry(context.Context, *services.AdminAuditEntry) error {
+	return errors.New("audit log unavailable")
+}
+
+var _ = Describe("Integration: Admin Audit Log", func() {
+	var (
+		db         *sql.DB
+		cleanup    func()
+		router     *gin.Engine
+		jwtService *services.JWTService
+		adminToken string
+	)
+
+	send := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		var body *bytes.Buffer
+		if payload != nil {
+			data, _ := json.Marshal(payload)
+			body = bytes.NewBuffer(data)
+		} else {
+			body = &bytes.Buffer{}
+		}
+		req := httptest.NewRequest(method, path, body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	auditLog := func(query string) dto.AdminAuditLogResponse {
+		w := send("GET", "/api/v1/admin/audit-log"+query, adminToken, nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp dto.AdminAuditLogResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		return resp
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		jwtService = services.NewJWTService()
+		tokenPair, err := jwtService.GenerateTokenPair(context.Background(), "admin", "admin", "admin@test.com", "level-admin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		adminToken = tokenPair.AccessToken
+
+		orgRepo := postgres.NewOrganizationRepository(db)
+		userRepo := postgres.NewUserRepository(db)
+		teamRepo := postgres.NewTeamRepository(db)
+		audit := services.NewAdminAuditService(postgres.NewAdminAuditRepository(db))
+
+		router = gin.New()
+		v1.SetupAdminRoutes(router, v1.AdminRouteDeps{
+			OrgRepo:     orgRepo,
+			UserRepo:    userRepo,
+			TeamRepo:    teamRepo,
+			JWTService:  jwtService,
+			Permissions: services.NewPermissionService(orgRepo),
+			Audit:       audit,
+		})
+
+		_, err = db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash)
+			VALUES ('audited_user', 'audited_user', 'audited@test.com', 'Audited User', 'level-5', 'unused')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		cleanup()
+	})
+
+	Describe("Recording admin writes", func() {
+		It("should record the actor, target and changed fields of an update", func() {
+			w := send("PUT", "/api/v1/admin/users/audited_user", adminToken, map[string]string{"fullName": "Renamed User"})
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			resp := auditLog("")
+			Expect(resp.Total).To(Equal(1))
+			entry := resp.Entries[0]
+			Expect(entry.ActorID).To(Equal("admin"))
+			Expect(entry.ActorUsername).To(Equal("admin"))
+			Expect(entry.Action).To(Equal("user.update"))
+			Expect(entry.TargetType).To(Equal("user"))
+			Expect(entry.TargetID).To(Equal("audited_user"))
+			Expect(string(entry.Before)).To(ContainSubstring("Audited User"))
+			Expect(string(entry.After)).To(ContainSubstring("Renamed User"))
+
+			fields := []string{}
+			for _, change := range entry.Changes {
+				fields = append(fields, change.Field)
+			}
+			Expect(fields).To(ContainElement("name"))
+			Expect(fields).NotTo(ContainElement("email"))
+		})
+
+		It("should record the ID and state of a created target", func() {
+			w := send("POST", "/api/v1/admin/teams", adminToken, map[string]string{"id": "audit_team", "name": "Audit Team", "cadence": "monthly"})
+			Expect(w.Code).To(Equal(http.StatusCreated))
+
+			entry := auditLog("").Entries[0]
+			Expect(entry.Action).To(Equal("team.create"))
+			Expect(entry.TargetID).To(Equal("audit_team"))
+			Expect(string(entry.Before)).To(Equal("null"))
+			Expect(string(entry.After)).To(ContainSubstring("Audit Team"))
+		})
+
+		It("should record the state of a deleted target", func() {
+			w := send("POST", "/api/v1/admin/hierarchy-levels", adminToken, map[string]interface{}{"id": "level-audit", "name": "Audit Level"})
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			w = send("DELETE", "/api/v1/admin/hierarchy-levels/level-audit", adminToken, nil)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			entry := auditLog("?action=hierarchy_level.delete").Entries[0]
+			Expect(entry.TargetID).To(Equal("level-audit"))
+			Expect(string(entry.Before)).To(ContainSubstring("Audit Level"))
+			Expect(string(entry.After)).To(Equal("null"))
+		})
+
+		It("should not record failed writes or reads", func() {
+			Expect(send("PUT", "/api/v1/admin/users/no_such_user", adminToken, map[string]string{"fullName": "Nobody"}).Code).To(BeNumerically(">=", 400))
+			Expect(send("GET", "/api/v1/admin/users", adminToken, nil).Code).To(Equal(http.StatusOK))
+
+			Expect(auditLog("").Total).To(Equal(0))
+		})
+
+		It("should report a write that could not be audited", func() {
+			orgRepo := postgres.NewOrganizationRepository(db)
+			audit := services.NewAdminAuditService(failingAuditRepository{postgres.NewAdminAuditRepository(db)})
+			router = gin.New()
+			v1.SetupAdminRoutes(router, v1.AdminRouteDeps{
+				OrgRepo:     orgRepo,
+				UserRepo:    postgres.NewUserRepository(db),
+				TeamRepo:    postgres.NewTeamRepository(db),
+				JWTService:  jwtService,
+				Permissions: services.NewPermissionService(orgRepo),
+				Audit:       audit,
+			})
+
+			w := send("PUT", "/api/v1/admin/users/audited_user", adminToken, map[string]string{"fullName": "Unaudited Rename"})
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			var resp dto.ErrorResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Error).To(Equal("Failed to record audit entry"))
+			Expect(resp.Message).To(ContainSubstring("saved"))
+
+			var name string
+			Expect(db.QueryRow("SELECT full_name FROM users WHERE id = 'audited_user'").Scan(&name)).To(Succeed())
+			Expect(name).To(Equal("Unaudited Rename"))
+		})
+	})
+
+	Describe("GET /api/v1/admin/audit-log", func() {
+		BeforeEach(func() {
+			for _, name := range []string{"One", "Two", "Three"} {
+				w := send("PUT", "/api/v1/admin/users/audited_user", adminToken, map[string]string{"fullName": name})
+				Expect(w.Code).To(Equal(http.StatusOK))
+			}
+			w := send("PUT", "/api/v1/admin/settings/branding", adminToken, map[string]string{"companyName": "Audit Corp"})
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should filter by target and page newest first", func() {
+			resp := auditLog("?targetType=user&targetId=audited_user&limit=2")
+			Expect(resp.Total).To(Equal(3))
+			Expect(resp.Entries).To(HaveLen(2))
+			Expect(string(resp.Entries[0].After)).To(ContainSubstring("Three"))
+
+			resp = auditLog("?targetType=user&limit=2&offset=2")
+			Expect(resp.Entries).To(HaveLen(1))
+			Expect(string(resp.Entries[0].After)).To(ContainSubstring("One"))
+		})
+
+		It("should filter by action and actor", func() {
+			Expect(auditLog("?action=settings.branding.update").Total).To(Equal(1))
+			Expect(auditLog("?actorId=admin").Total).To(Equal(4))
+			Expect(auditLog("?actorId=someone_else").Total).To(Equal(0))
+		})
+
+		It("should filter by time range", func() {
+			Expect(auditLog("?from=2000-01-01").Total).To(Equal(4))
+			Expect(auditLog("?to=2000-01-01").Total).To(Equal(0))
+		})
+
+		It("should reject invalid filters", func() {
+			Expect(send("GET", "/api/v1/admin/audit-log?limit=0", adminToken, nil).Code).To(Equal(http.StatusBadRequest))
+			Expect(send("GET", "/api/v1/admin/audit-log?limit=501", adminToken, nil).Code).To(Equal(http.StatusBadRequest))
+			Expect(send("GET", "/api/v1/admin/audit-log?from=yesterday", adminToken, nil).Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should export matching entries as CSV, oldest first", func() {
+			w := send("GET", "/api/v1/admin/audit-log/export?targetType=user", adminToken, nil)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get("Content-Type")).To(ContainSubstring("text/csv"))
+			Expect(w.Header().Get("Content-Disposition")).To(ContainSubstring("admin-audit-log-"))
+
+			records, err := csv.NewReader(w.Body).ReadAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(4))
+			Expect(records[0][4]).To(Equal("Action"))
+			Expect(records[1][4]).To(Equal("user.update"))
+			Expect(records[1][9]).To(ContainSubstring("One"))
+		})
+
+		It("should require admin privileges", func() {
+			tokens, err := jwtService.GenerateTokenPair(context.Background(), "audited_user", "audited_user", "audited@test.com", "level-5", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(send("GET", "/api/v1/admin/audit-log", tokens.AccessToken, nil).Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("Immutability", func() {
+		It("should reject changes to recorded entries", func() {
+			Expect(send("PUT", "/api/v1/admin/users/audited_user", adminToken, map[string]string{"fullName": "Changed"}).Code).To(Equal(http.StatusOK))
+
+			_, err := db.Exec("UPDATE admin_audit_log SET actor_id = 'someone_else'")
+			Expect(err).To(HaveOccurred())
+			_, err = db.Exec("DELETE FROM admin_audit_log")
+			Expect(err).To(HaveOccurred())
+			_, err = db.Exec("TRUNCATE admin_audit_log")
+			Expect(err).To(HaveOccurred())
+
+			Expect(auditLog("").Entries[0].ActorID).To(Equal("admin"))
+		})
+	})
+})
@@ -0,0 +1,231 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/commands"
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/application/trends"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: Anonymous Surveys", func() {
+	const period = "2024 - 2nd Half"
+
+	var (
+		db              *sql.DB
+		cleanup         func()
+		router          *gin.Engine
+		healthCheckRepo healthcheck.Repository
+		managerToken    string
+		adminToken      string
+	)
+
+	submit := func(teamID, userID, comment string) *healthcheck.HealthCheckSession {
+		session, err := commands.NewSubmitHealthCheckHandler(healthCheckRepo, nil).Handle(commands.SubmitHealthCheckCommand{
+			ID:               "anon_" + teamID + "_" + userID,
+			TeamID:           teamID,
+			UserID:           userID,
+			Date:             time.Now().Format("2006-01-02"),
+			AssessmentPeriod: period,
+			SurveyType:       healthcheck.SurveyTypeIndividual,
+			Completed:        true,
+			Responses: []commands.HealthCheckResponseCommand{
+				{DimensionID: "mission", Score: 3, Trend: "stable", Comment: comment},
+				{DimensionID: "speed", Score: 2, Trend: "improving"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return session
+	}
+
+	individualResponses := func(teamID string) dto.IndividualResponses {
+		req, _ := http.NewRequest("GET", "/api/v1/teams/"+teamID+"/dashboard/individual-responses?assessmentPeriod="+period, nil)
+		req.Header.Set("Authorization", "Bearer "+managerToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var resp dto.IndividualResponses
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		return resp
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+		healthCheckRepo = postgres.NewHealthCheckRepository(db)
+
+		jwtService := services.NewJWTService()
+		tokenPair, err := jwtService.GenerateTokenPair(context.Background(), "anon_manager", "anon_manager", "manager@test.com", "level-3", nil)
+		Expect(err).NotTo(HaveOccurred())
+		managerToken = tokenPair.AccessToken
+		tokenPair, err = jwtService.GenerateTokenPair(context.Background(), "admin", "admin", "admin@test.com", "level-admin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		adminToken = tokenPair.AccessToken
+
+		campaignRepo := postgres.NewCampaignRepository(db)
+		router = gin.New()
+		v1.SetupTeamDashboardRoutes(router, db, trends.NewService(db, postgres.NewOrganizationRepository(db)), jwtService, services.NewPermissionService(postgres.NewOrganizationRepository(db)))
+		v1.SetupCampaignRoutes(router, campaignRepo, services.NewCampaignScheduler(campaignRepo, postgres.NewTeamRepository(db)), jwtService, services.NewPermissionService(postgres.NewOrganizationRepository(db)), nil)
+
+		_, err = db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id) VALUES
+			('anon_m1', 'anon_m1', 'anon_m1@test.com', 'Member One', 'level-5'),
+			('anon_m2', 'anon_m2', 'anon_m2@test.com', 'Member Two', 'level-5'),
+			('anon_m3', 'anon_m3', 'anon_m3@test.com', 'Member Three', 'level-5'),
+			('anon_m4', 'anon_m4', 'anon_m4@test.com', 'Member Four', 'level-5')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO teams (id, name, anonymous, anonymity_min_respondents) VALUES
+			('anon_team', 'Anonymous Team', true, 3),
+			('anon_open', 'Open Team', false, 3)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO team_members (team_id, user_id) VALUES
+			('anon_team', 'anon_m1'), ('anon_team', 'anon_m2'), ('anon_team', 'anon_m3'), ('anon_team', 'anon_m4'),
+			('anon_open', 'anon_m1')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("JWT_SECRET")
+		cleanup()
+	})
+
+	It("should store respondent tokens and still track who submitted", func() {
+		session := submit("anon_team", "anon_m1", "")
+		Expect(session.Anonymous).To(BeTrue())
+		Expect(session.UserID).To(HavePrefix("anon_"))
+		Expect(session.UserID).NotTo(ContainSubstring("anon_m1"))
+		submit("anon_team", "anon_m2", "")
+
+		var identified int
+		Expect(db.QueryRow(`
+			SELECT COUNT(*) FROM health_check_sessions
+			WHERE team_id = 'anon_team' AND user_id IN ('anon_m1', 'anon_m2')
+		`).Scan(&identified)).To(Succeed())
+		Expect(identified).To(Equal(0))
+
+		status, err := healthCheckRepo.GetTeamSubmissionStatus(context.Background(), "anon_team", period)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.TotalMembers).To(Equal(4))
+		Expect(status.SubmittedMembers).To(Equal(2))
+
+		nonSubmitters, err := healthCheckRepo.FindNonSubmitters(context.Background(), "anon_team", period)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nonSubmitters).To(Equal([]string{"anon_m3", "anon_m4"}))
+
+		// Resubmitting counts the same respondent once
+		submit("anon_team", "anon_m1", "")
+		status, err = healthCheckRepo.GetTeamSubmissionStatus(context.Background(), "anon_team", period)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.SubmittedMembers).To(Equal(2))
+	})
+
+	It("should withhold individual responses until the minimum respondent count is reached", func() {
+		submit("anon_team", "anon_m1", "first comment")
+		submit("anon_team", "anon_m2", "second comment")
+
+		resp := individualResponses("anon_team")
+		Expect(resp.Responses).To(BeEmpty())
+		Expect(resp.SuppressedResponses).To(Equal(2))
+		Expect(resp.AnonymousComments).To(BeEmpty())
+
+		submit("anon_team", "anon_m3", "")
+
+		resp = individualResponses("anon_team")
+		Expect(resp.SuppressedResponses).To(Equal(0))
+		Expect(resp.Responses).To(HaveLen(3))
+		for _, r := range resp.Responses {
+			Expect(r.Anonymous).To(BeTrue())
+			Expect(r.UserName).To(BeEmpty())
+			for _, dim := range r.Dimensions {
+				Expect(dim.Comment).To(BeEmpty())
+			}
+		}
+		Expect(resp.AnonymousComments).To(HaveLen(2))
+		Expect(resp.AnonymousComments[0].Comment).To(Equal("first comment"))
+	})
+
+	It("should leave non-anonymous teams attributed", func() {
+		session := submit("anon_open", "anon_m1", "signed comment")
+		Expect(session.Anonymous).To(BeFalse())
+		Expect(session.UserID).To(Equal("anon_m1"))
+
+		resp := individualResponses("anon_open")
+		Expect(resp.Responses).To(HaveLen(1))
+		Expect(resp.Responses[0].UserName).To(Equal("Member One"))
+		Expect(resp.Responses[0].Dimensions[0].Comment).To(Equal("signed comment"))
+	})
+
+	It("should make a campaign anonymous until surveys are submitted", func() {
+		_, err := db.Exec(`
+			INSERT INTO survey_campaigns (id, team_id, assessment_period, cadence, start_date, end_date)
+			VALUES ('anon_campaign', 'anon_open', $1, 'half-yearly', '2024-07-01', '2024-12-31')
+		`, period)
+		Expect(err).NotTo(HaveOccurred())
+
+		setAnonymity := func(anonymous bool) int {
+			body, _ := json.Marshal(map[string]bool{"anonymous": anonymous})
+			req, _ := http.NewRequest("PUT", "/api/v1/admin/campaigns/anon_campaign/anonymity", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w.Code
+		}
+
+		Expect(setAnonymity(true)).To(Equal(http.StatusOK))
+
+		session := submit("anon_open", "anon_m1", "")
+		Expect(session.Anonymous).To(BeTrue())
+
+		Expect(setAnonymity(false)).To(Equal(http.StatusConflict))
+	})
+
+	It("should not count drafts as campaign submissions", func() {
+		_, err := db.Exec(`
+			INSERT INTO survey_campaigns (id, team_id, assessment_period, cadence, start_date, end_date)
+			VALUES ('anon_campaign', 'anon_open', $1, 'half-yearly', '2024-07-01', '2024-12-31')
+		`, period)
+		Expect(err).NotTo(HaveOccurred())
+
+		draft, err := commands.NewHealthCheckDraftHandler(healthCheckRepo, nil).Save(context.Background(), commands.SaveHealthCheckDraftCommand{
+			TeamID:           "anon_open",
+			UserID:           "anon_m1",
+			AssessmentPeriod: period,
+			Responses:        []commands.HealthCheckResponseCommand{{DimensionID: "mission", Score: 3, Trend: "stable"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(draft.Completed).To(BeFalse())
+
+		body, _ := json.Marshal(map[string]bool{"anonymous": true})
+		req, _ := http.NewRequest("PUT", "/api/v1/admin/campaigns/anon_campaign/anonymity", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,266 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: API Tokens", func() {
+	var (
+		db         *sql.DB
+		cleanup    func()
+		router     *gin.Engine
+		jwtService *services.JWTService
+	)
+
+	tokenFor := func(userID, levelID string) string {
+		tokens, err := jwtService.GenerateTokenPair(context.Background(), userID, userID, userID+"@test.com", levelID, nil)
+		Expect(err).NotTo(HaveOccurred())
+		return tokens.AccessToken
+	}
+
+	send := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		if payload != nil {
+			data, _ := json.Marshal(payload)
+			body = bytes.NewBuffer(data)
+		}
+		req := httptest.NewRequest(method, path, body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	createToken := func(path, jwt string, payload map[string]interface{}) dto.CreatedAPITokenResponse {
+		w := send("POST", path, jwt, payload)
+		Expect(w.Code).To(Equal(http.StatusCreated), w.Body.String())
+		var created dto.CreatedAPITokenResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		return created
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		orgRepo := postgres.NewOrganizationRepository(db)
+		userRepo := postgres.NewUserRepository(db)
+		teamRepo := postgres.NewTeamRepository(db)
+		permissions := services.NewPermissionService(orgRepo)
+		audit := services.NewAdminAuditService(postgres.NewAdminAuditRepository(db))
+		apiTokens := services.NewAPITokenService(postgres.NewAPITokenRepository(db), userRepo)
+		jwtService = services.NewJWTServiceWithStore(postgres.NewTokenRepository(db))
+		jwtService.UseAPITokens(apiTokens)
+
+		router = gin.New()
+		v1.SetupAPITokenRoutes(router, apiTokens, userRepo, jwtService, permissions, audit)
+		v1.SetupTeamRoutes(router, postgres.NewHealthCheckRepository(db), teamRepo, jwtService, permissions)
+		v1.SetupActionItemRoutes(router, db, jwtService, permissions, nil)
+		v1.SetupAdminRoutes(router, v1.AdminRouteDeps{
+			OrgRepo:     orgRepo,
+			UserRepo:    userRepo,
+			TeamRepo:    teamRepo,
+			JWTService:  jwtService,
+			Permissions: permissions,
+			Audit:       audit,
+		})
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id) VALUES
+			('at_owner', 'at_owner', 'at_owner@test.com', 'Token Owner', 'level-5'),
+			('at_other', 'at_other', 'at_other@test.com', 'Other User', 'level-5'),
+			('at_bot', 'at_bot', 'at_bot@test.com', 'Reporting Bot', 'level-3')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO teams (id, name, cadence) VALUES ('at_team', 'Token Team', 'quarterly');
+			INSERT INTO team_members (team_id, user_id) VALUES ('at_team', 'at_owner');
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		cleanup()
+	})
+
+	Describe("personal tokens", func() {
+		It("should authenticate as the owner and store only a hash", func() {
+			created := createToken("/api/v1/auth/api-tokens", tokenFor("at_owner", "level-5"),
+				map[string]interface{}{"name": "weekly report", "scopes": []string{"read:dashboards"}})
+			Expect(created.Token).To(HavePrefix("t360_"))
+			Expect(created.Kind).To(Equal("personal"))
+			Expect(created.Token).To(HavePrefix(created.Prefix))
+
+			var stored string
+			Expect(db.QueryRow(`SELECT token_hash FROM api_tokens WHERE id = $1`, created.ID).Scan(&stored)).To(Succeed())
+			Expect(stored).NotTo(ContainSubstring(strings.TrimPrefix(created.Token, "t360_")))
+
+			// Team membership comes from the owner, not the token
+			Expect(send("GET", "/api/v1/teams/at_team/info", created.Token, nil).Code).To(Equal(http.StatusOK))
+
+			w := send("GET", "/api/v1/auth/api-tokens", tokenFor("at_owner", "level-5"), nil)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).NotTo(ContainSubstring(created.Token))
+			var list dto.APITokensResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &list)).To(Succeed())
+			Expect(list.Tokens).To(HaveLen(1))
+			Expect(list.Tokens[0].LastUsedAt).NotTo(BeNil())
+		})
+
+		It("should reject unknown scopes and expiries in the past", func() {
+			jwt := tokenFor("at_owner", "level-5")
+			Expect(send("POST", "/api/v1/auth/api-tokens", jwt,
+				map[string]interface{}{"name": "bad", "scopes": []string{"write:everything"}}).Code).To(Equal(http.StatusBadRequest))
+			Expect(send("POST", "/api/v1/auth/api-tokens", jwt,
+				map[string]interface{}{"name": "bad", "scopes": []string{}}).Code).To(Equal(http.StatusBadRequest))
+			Expect(send("POST", "/api/v1/auth/api-tokens", jwt,
+				map[string]interface{}{"name": "bad", "scopes": []string{"read:dashboards"}, "expiresAt": time.Now().Add(-time.Hour)}).Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should reject revoked and expired tokens", func() {
+			jwt := tokenFor("at_owner", "level-5")
+			revoked := createToken("/api/v1/auth/api-tokens", jwt,
+				map[string]interface{}{"name": "old", "scopes": []string{"read:dashboards"}})
+			expiring := createToken("/api/v1/auth/api-tokens", jwt,
+				map[string]interface{}{"name": "short", "scopes": []string{"read:dashboards"}, "expiresAt": time.Now().Add(time.Hour)})
+
+			Expect(send("DELETE", "/api/v1/auth/api-tokens/"+revoked.ID, tokenFor("at_other", "level-5"), nil).Code).To(Equal(http.StatusNotFound))
+			Expect(send("DELETE", "/api/v1/auth/api-tokens/"+revoked.ID, jwt, nil).Code).To(Equal(http.StatusOK))
+			Expect(send("GET", "/api/v1/teams/at_team/info", revoked.Token, nil).Code).To(Equal(http.StatusUnauthorized))
+
+			Expect(send("GET", "/api/v1/teams/at_team/info", expiring.Token, nil).Code).To(Equal(http.StatusOK))
+			_, err := db.Exec(`UPDATE api_tokens SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, expiring.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(send("GET", "/api/v1/teams/at_team/info", expiring.Token, nil).Code).To(Equal(http.StatusUnauthorized))
+
+			Expect(send("GET", "/api/v1/teams/at_team/info", "t360_not-a-real-token", nil).Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Describe("scopes", func() {
+		It("should only allow the routes the token's scopes cover", func() {
+			readOnly := createToken("/api/v1/auth/api-tokens", tokenFor("at_owner", "level-5"),
+				map[string]interface{}{"name": "reader", "scopes": []string{"read:dashboards"}})
+			writer := createToken("/api/v1/auth/api-tokens", tokenFor("at_owner", "level-5"),
+				map[string]interface{}{"name": "writer", "scopes": []string{"write:action-items"}})
+			item := map[string]string{"title": "Automate the report", "assignedTo": "at_owner"}
+
+			Expect(send("GET", "/api/v1/teams/at_team/action-items", readOnly.Token, nil).Code).To(Equal(http.StatusOK))
+			Expect(send("POST", "/api/v1/teams/at_team/action-items", readOnly.Token, item).Code).To(Equal(http.StatusForbidden))
+			Expect(send("POST", "/api/v1/teams/at_team/action-items", writer.Token, item).Code).To(Equal(http.StatusCreated))
+			Expect(send("GET", "/api/v1/teams/at_team/info", writer.Token, nil).Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should keep account recovery out of reach of user admin tokens", func() {
+			admin := tokenFor("admin", "level-admin")
+			adminBot := createToken("/api/v1/admin/api-tokens", admin,
+				map[string]interface{}{"userId": "admin", "name": "directory sync", "scopes": []string{"admin:users"}})
+
+			Expect(send("PUT", "/api/v1/admin/users/at_other", adminBot.Token,
+				map[string]interface{}{"fullName": "Renamed User"}).Code).To(Equal(http.StatusOK))
+			for _, action := range []string{"revoke-sessions", "unlock", "reset-mfa"} {
+				Expect(send("POST", "/api/v1/admin/users/at_other/"+action, adminBot.Token, nil).Code).To(Equal(http.StatusForbidden), action)
+			}
+
+			// A signed-in admin still can
+			Expect(send("POST", "/api/v1/admin/users/at_other/revoke-sessions", admin, nil).Code).To(Equal(http.StatusOK))
+		})
+
+		It("should not let API tokens manage API tokens", func() {
+			created := createToken("/api/v1/auth/api-tokens", tokenFor("at_owner", "level-5"),
+				map[string]interface{}{"name": "all", "scopes": []string{"read:dashboards", "read:exports", "write:action-items", "admin:users"}})
+
+			Expect(send("GET", "/api/v1/auth/api-tokens", created.Token, nil).Code).To(Equal(http.StatusForbidden))
+			Expect(send("POST", "/api/v1/auth/api-tokens", created.Token,
+				map[string]interface{}{"name": "more", "scopes": []string{"read:dashboards"}}).Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("service tokens", func() {
+		It("should be issued by user admins and act within the user's permissions", func() {
+			admin := tokenFor("admin", "level-admin")
+			Expect(send("POST", "/api/v1/admin/api-tokens", tokenFor("at_owner", "level-5"),
+				map[string]interface{}{"userId": "at_bot", "name": "sync", "scopes": []string{"admin:users"}}).Code).To(Equal(http.StatusForbidden))
+			Expect(send("POST", "/api/v1/admin/api-tokens", admin,
+				map[string]interface{}{"userId": "nobody", "name": "sync", "scopes": []string{"admin:users"}}).Code).To(Equal(http.StatusNotFound))
+
+			bot := createToken("/api/v1/admin/api-tokens", admin,
+				map[string]interface{}{"userId": "at_bot", "name": "sync", "scopes": []string{"admin:users"}})
+			Expect(bot.Kind).To(Equal("service"))
+			Expect(bot.CreatedBy).To(Equal("admin"))
+
+			// The scope allows the route but managers cannot manage users
+			Expect(send("GET", "/api/v1/admin/users", bot.Token, nil).Code).To(Equal(http.StatusForbidden))
+
+			adminBot := createToken("/api/v1/admin/api-tokens", admin,
+				map[string]interface{}{"userId": "admin", "name": "directory sync", "scopes": []string{"admin:users"}})
+			Expect(send("GET", "/api/v1/admin/users", adminBot.Token, nil).Code).To(Equal(http.StatusOK))
+			Expect(send("GET", "/api/v1/admin/teams", adminBot.Token, nil).Code).To(Equal(http.StatusForbidden))
+
+			w := send("GET", "/api/v1/admin/api-tokens?userId=at_bot", admin, nil)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var list dto.APITokensResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &list)).To(Succeed())
+			Expect(list.Tokens).To(HaveLen(1))
+			Expect(list.Tokens[0].ID).To(Equal(bot.ID))
+
+			Expect(send("DELETE", "/api/v1/admin/api-tokens/"+adminBot.ID, admin, nil).Code).To(Equal(http.StatusOK))
+			Expect(send("GET", "/api/v1/admin/users", adminBot.Token, nil).Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should audit issuing without recording the secret", func() {
+			bot := createToken("/api/v1/admin/api-tokens", tokenFor("admin", "level-admin"),
+				map[string]interface{}{"userId": "at_bot", "name": "sync", "scopes": []string{"read:exports"}})
+
+			var action, after string
+			Expect(db.QueryRow(`SELECT action, after_state::text FROM admin_audit_log WHERE target_id = $1`, bot.ID).Scan(&action, &after)).To(Succeed())
+			Expect(action).To(Equal("api_token.issue"))
+			Expect(after).To(ContainSubstring(bot.Prefix))
+			Expect(after).NotTo(ContainSubstring(bot.Token))
+		})
+
+		It("should be revoked with the user's sessions", func() {
+			admin := tokenFor("admin", "level-admin")
+			personal := createToken("/api/v1/auth/api-tokens", tokenFor("at_owner", "level-5"),
+				map[string]interface{}{"name": "laptop", "scopes": []string{"read:dashboards"}})
+			service := createToken("/api/v1/admin/api-tokens", admin,
+				map[string]interface{}{"userId": "at_owner", "name": "sync", "scopes": []string{"read:dashboards"}})
+			other := createToken("/api/v1/admin/api-tokens", admin,
+				map[string]interface{}{"userId": "at_bot", "name": "sync", "scopes": []string{"read:dashboards"}})
+
+			Expect(send("POST", "/api/v1/admin/users/at_owner/revoke-sessions", admin, nil).Code).To(Equal(http.StatusOK))
+
+			Expect(send("GET", "/api/v1/teams", personal.Token, nil).Code).To(Equal(http.StatusUnauthorized))
+			Expect(send("GET", "/api/v1/teams", service.Token, nil).Code).To(Equal(http.StatusUnauthorized))
+			Expect(send("GET", "/api/v1/teams", other.Token, nil).Code).To(Equal(http.StatusOK))
+		})
+
+		It("should stop working when its user is deactivated", func() {
+			bot := createToken("/api/v1/admin/api-tokens", tokenFor("admin", "level-admin"),
+				map[string]interface{}{"userId": "at_bot", "name": "sync", "scopes": []string{"read:dashboards"}})
+			Expect(send("GET", "/api/v1/teams", bot.Token, nil).Code).To(Equal(http.StatusOK))
+
+			_, err := db.Exec(`UPDATE users SET deactivated_at = NOW() WHERE id = 'at_bot'`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(send("GET", "/api/v1/teams", bot.Token, nil).Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"time"
 
 	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
@@ -41,7 +42,7 @@ var _ = Describe("Authentication API Integration Tests", func() {
 		userRepo := postgres.NewUserRepository(db)
 		orgRepo := postgres.NewOrganizationRepository(db)
 		jwtService = services.NewJWTService()
-		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService)
+		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService, nil, nil)
 	})
 
 	AfterEach(func() {
@@ -121,6 +122,35 @@ var _ = Describe("Authentication API Integration Tests", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(response["error"]).To(Equal("Invalid username or password"))
 			})
+
+			It("should take as long as a wrong password for an existing account", func() {
+				hashedPassword, err := bcrypt.GenerateFromPassword([]byte("testpass"), bcrypt.DefaultCost)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = db.Exec(`
+					INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash)
+					VALUES ('timingtest', 'timinguser', 'timinguser@test.com', 'Timing Test User', 'level-4', $1)
+				`, string(hashedPassword))
+				Expect(err).NotTo(HaveOccurred())
+
+				timeLogin := func(username string) time.Duration {
+					jsonData, _ := json.Marshal(map[string]string{"username": username, "password": "wrongpass"})
+					req, _ := http.NewRequest("POST", "/api/v1/auth/login", bytes.NewBuffer(jsonData))
+					req.Header.Set("Content-Type", "application/json")
+					w := httptest.NewRecorder()
+					start := time.Now()
+					router.ServeHTTP(w, req)
+					elapsed := time.Since(start)
+					Expect(w.Code).To(Equal(http.StatusUnauthorized))
+					return elapsed
+				}
+
+				timeLogin("nonexistentuser") // the first unknown username prepares the comparison hash
+				wrongPassword := timeLogin("timinguser")
+				unknownUser := timeLogin("nonexistentuser")
+
+				// Both check a bcrypt hash, which dwarfs the user lookup
+				Expect(unknownUser).To(BeNumerically(">", wrongPassword/2))
+			})
 		})
 
 		Context("when password is incorrect", func() {
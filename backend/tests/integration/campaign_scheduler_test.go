@@ -0,0 +1,180 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+)
+
+var _ = Describe("Integration: Survey Campaign Scheduler", func() {
+	var (
+		db           *sql.DB
+		router       *gin.Engine
+		cleanup      func()
+		adminToken   string
+		campaignRepo campaign.Repository
+		scheduler    *services.CampaignScheduler
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		jwtService := services.NewJWTService()
+		tokenPair, err := jwtService.GenerateTokenPair(context.Background(), "admin", "admin", "admin@test.com", "level-admin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		adminToken = tokenPair.AccessToken
+
+		campaignRepo = postgres.NewCampaignRepository(db)
+		teamRepo := postgres.NewTeamRepository(db)
+		scheduler = services.NewCampaignScheduler(campaignRepo, teamRepo)
+
+		router = gin.New()
+		v1.SetupCampaignRoutes(router, campaignRepo, scheduler, jwtService, services.NewPermissionService(postgres.NewOrganizationRepository(db)), nil)
+
+		_, err = db.Exec(`
+			INSERT INTO teams (id, name, cadence) VALUES
+			('cmp_monthly', 'Campaign Monthly Team', 'monthly'),
+			('cmp_quarterly', 'Campaign Quarterly Team', 'quarterly')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		cleanup()
+	})
+
+	Describe("scheduler run", func() {
+		It("should open one campaign per team for the current cadence period", func() {
+			at := time.Date(2025, time.May, 20, 12, 0, 0, 0, time.UTC)
+
+			_, err := scheduler.RunAt(context.Background(), at)
+			Expect(err).NotTo(HaveOccurred())
+
+			monthly, err := campaignRepo.FindByTeamAndPeriod(context.Background(), "cmp_monthly", "2025 May")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(monthly.Status).To(Equal(campaign.StatusOpen))
+			Expect(monthly.EndDate.Format("2006-01-02")).To(Equal("2025-05-31"))
+
+			quarterly, err := campaignRepo.FindByTeamAndPeriod(context.Background(), "cmp_quarterly", "2025 Q2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quarterly.StartDate.Format("2006-01-02")).To(Equal("2025-04-01"))
+
+			var nextCheck time.Time
+			err = db.QueryRow("SELECT next_check_date FROM teams WHERE id = 'cmp_quarterly'").Scan(&nextCheck)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nextCheck.Format("2006-01-02")).To(Equal("2025-07-01"))
+		})
+
+		It("should be idempotent across repeated runs", func() {
+			at := time.Date(2025, time.May, 20, 12, 0, 0, 0, time.UTC)
+
+			_, err := scheduler.RunAt(context.Background(), at)
+			Expect(err).NotTo(HaveOccurred())
+			second, err := scheduler.RunAt(context.Background(), at)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.Opened).To(BeEmpty())
+
+			var count int
+			err = db.QueryRow("SELECT COUNT(*) FROM survey_campaigns WHERE team_id = 'cmp_monthly'").Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+
+		It("should close campaigns once their period has ended", func() {
+			_, err := scheduler.RunAt(context.Background(), time.Date(2025, time.May, 20, 0, 0, 0, 0, time.UTC))
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := scheduler.RunAt(context.Background(), time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC))
+			Expect(err).NotTo(HaveOccurred())
+
+			closedIDs := []string{}
+			for _, c := range result.Closed {
+				closedIDs = append(closedIDs, c.TeamID+"/"+c.AssessmentPeriod)
+			}
+			Expect(closedIDs).To(ContainElement("cmp_monthly/2025 May"))
+			Expect(closedIDs).NotTo(ContainElement("cmp_quarterly/2025 Q2"))
+
+			june, err := campaignRepo.FindByTeamAndPeriod(context.Background(), "cmp_monthly", "2025 Jun")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(june.IsOpen()).To(BeTrue())
+		})
+	})
+
+	Describe("/api/v1/admin/campaigns", func() {
+		It("should open, list and close a campaign", func() {
+			body, _ := json.Marshal(dto.OpenCampaignRequest{TeamID: "cmp_monthly"})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/campaigns", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusCreated))
+
+			var opened dto.CampaignDTO
+			Expect(json.Unmarshal(w.Body.Bytes(), &opened)).To(Succeed())
+			Expect(opened.Status).To(Equal("open"))
+			Expect(opened.AssessmentPeriod).To(Equal(campaign.PeriodFor("monthly", time.Now()).Label))
+
+			req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/campaigns?teamId=cmp_monthly&status=open", nil)
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var list dto.CampaignsResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &list)).To(Succeed())
+			Expect(list.Total).To(Equal(1))
+
+			req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/campaigns/"+opened.ID+"/close", nil)
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var closed dto.CampaignDTO
+			Expect(json.Unmarshal(w.Body.Bytes(), &closed)).To(Succeed())
+			Expect(closed.Status).To(Equal("closed"))
+			Expect(closed.ClosedAt).NotTo(BeNil())
+		})
+
+		It("should return 404 when opening a campaign for an unknown team", func() {
+			body, _ := json.Marshal(dto.OpenCampaignRequest{TeamID: "cmp_missing"})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/campaigns", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should reject non-admin users", func() {
+			jwtService := services.NewJWTService()
+			tokenPair, err := jwtService.GenerateTokenPair(context.Background(), "member", "member", "member@test.com", "level-5", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/campaigns", nil)
+			req.Header.Set("Authorization", "Bearer "+tokenPair.AccessToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
@@ -0,0 +1,187 @@
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/chat"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	"github.com/agopalakrishnan/teams360/backend/pkg/outbound"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+)
+
+var _ = Describe("Integration: Chat Webhook Notifications", func() {
+	var (
+		db       *sql.DB
+		cleanup  func()
+		server   *httptest.Server
+		mu       sync.Mutex
+		payloads []map[string]interface{}
+		service  *services.NotificationService
+	)
+
+	received := func() []map[string]interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]map[string]interface{}{}, payloads...)
+	}
+
+	BeforeEach(func() {
+		db, cleanup = testhelpers.SetupTestDatabase()
+		payloads = nil
+
+		// Local stand-in for the Slack / Teams webhook endpoint
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var payload map[string]interface{}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			mu.Lock()
+			payloads = append(payloads, payload)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		service = services.NewNotificationService(
+			nil,
+			chat.NewChannels(server.Client()),
+			postgres.NewTeamRepository(db),
+			postgres.NewUserRepository(db),
+			postgres.NewOrganizationRepository(db),
+		)
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id)
+			VALUES ('chat_lead', 'chat_lead', 'chat_lead@test.com', 'Chat Lead', 'level-4')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO teams (id, name, cadence, chat_webhook_url, chat_webhook_format)
+			VALUES ('chat_team', 'Chat Team', 'monthly', $1, 'slack')
+		`, server.URL)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`UPDATE app_settings SET slack_notifications = true WHERE id = 1`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		cleanup()
+	})
+
+	newSession := func(surveyType string) *healthcheck.HealthCheckSession {
+		return &healthcheck.HealthCheckSession{
+			ID:               "chat_sess",
+			TeamID:           "chat_team",
+			UserID:           "chat_lead",
+			AssessmentPeriod: "2025 Q2",
+			SurveyType:       surveyType,
+			Completed:        true,
+			Responses: []healthcheck.HealthCheckResponse{
+				{DimensionID: "mission", Score: 3, Trend: "improving"},
+				{DimensionID: "speed", Score: 1, Trend: "declining"},
+			},
+		}
+	}
+
+	It("should post a post-workshop summary with dimension scores in Slack format", func() {
+		service.SendChatNotification(context.Background(), newSession("post_workshop"))
+
+		Expect(received()).To(HaveLen(1))
+		payload := received()[0]
+		Expect(payload["text"]).To(ContainSubstring("Chat Team"))
+		Expect(payload).To(HaveKey("blocks"))
+
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring("Chat Lead"))
+		Expect(string(body)).To(ContainSubstring("declining"))
+	})
+
+	It("should weight the overall health by dimension weights", func() {
+		_, err := db.Exec(`UPDATE health_dimensions SET weight = 3 WHERE id = 'mission'`)
+		Expect(err).NotTo(HaveOccurred())
+
+		service.SendChatNotification(context.Background(), newSession("post_workshop"))
+
+		Expect(received()).To(HaveLen(1))
+		body, err := json.Marshal(received()[0])
+		Expect(err).NotTo(HaveOccurred())
+		// (3×3 + 1×1) / 4, not the plain average of 2.00
+		Expect(string(body)).To(ContainSubstring("overall health 2.50"))
+		Expect(string(body)).To(ContainSubstring("Green 3/3"))
+	})
+
+	It("should announce individual submissions without scores or the submitter's name", func() {
+		service.SendChatNotification(context.Background(), newSession("individual"))
+
+		Expect(received()).To(HaveLen(1))
+		body, err := json.Marshal(received()[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring("2025 Q2"))
+		Expect(string(body)).NotTo(ContainSubstring("Chat Lead"))
+		Expect(string(body)).NotTo(ContainSubstring("declining"))
+	})
+
+	It("should use MessageCard JSON for generic webhooks", func() {
+		_, err := db.Exec(`UPDATE teams SET chat_webhook_format = 'generic' WHERE id = 'chat_team'`)
+		Expect(err).NotTo(HaveOccurred())
+
+		service.SendChatNotification(context.Background(), newSession("post_workshop"))
+
+		Expect(received()).To(HaveLen(1))
+		payload := received()[0]
+		Expect(payload["@type"]).To(Equal("MessageCard"))
+		Expect(payload["text"]).To(ContainSubstring("Chat Team"))
+		Expect(payload).To(HaveKey("sections"))
+	})
+
+	It("should not post when Slack notifications are disabled", func() {
+		_, err := db.Exec(`UPDATE app_settings SET slack_notifications = false WHERE id = 1`)
+		Expect(err).NotTo(HaveOccurred())
+
+		service.SendChatNotification(context.Background(), newSession("post_workshop"))
+		Expect(received()).To(BeEmpty())
+	})
+
+	It("should not post when the team has no webhook URL", func() {
+		_, err := db.Exec(`UPDATE teams SET chat_webhook_url = NULL WHERE id = 'chat_team'`)
+		Expect(err).NotTo(HaveOccurred())
+
+		service.SendChatNotification(context.Background(), newSession("post_workshop"))
+		Expect(received()).To(BeEmpty())
+	})
+
+	It("should refuse to connect to loopback addresses by default", func() {
+		err := chat.NewChannels(nil)[chat.FormatSlack].Send(context.Background(), server.URL, chat.Message{Title: "Test"})
+		Expect(err).To(MatchError(ContainSubstring(outbound.ErrAddressNotAllowed.Error())))
+		Expect(received()).To(BeEmpty())
+	})
+
+	It("should not follow redirects or report the response body", func() {
+		redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", server.URL)
+			w.WriteHeader(http.StatusTemporaryRedirect)
+			_, _ = w.Write([]byte("internal-secret-detail"))
+		}))
+		defer redirecting.Close()
+
+		err := chat.NewChannels(redirecting.Client())[chat.FormatSlack].Send(context.Background(), redirecting.URL, chat.Message{Title: "Test"})
+		Expect(err).To(MatchError("webhook returned status 307"))
+		Expect(received()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,169 @@
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: Data Retention", func() {
+	var (
+		db      *sql.DB
+		cleanup func()
+		service *services.RetentionService
+		now     time.Time
+	)
+
+	countRows := func(query string, args ...interface{}) int {
+		var n int
+		Expect(db.QueryRow(query, args...).Scan(&n)).To(Succeed())
+		return n
+	}
+
+	BeforeEach(func() {
+		db, cleanup = testhelpers.SetupTestDatabase()
+		now = time.Now()
+		service = services.NewRetentionService(postgres.NewOrganizationRepository(db), postgres.NewRetentionRepository(db))
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id)
+			VALUES ('ret_member', 'ret_member', 'ret_member@test.com', 'Retention Member', 'level-5')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO teams (id, name, cadence, legal_hold) VALUES
+			('ret_team', 'Retention Team', 'monthly', false),
+			('ret_held', 'Held Team', 'monthly', true)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		old := now.AddDate(-2, 0, 0).Format("2006-01-02")
+		recent := now.AddDate(0, -1, 0).Format("2006-01-02")
+		_, err = db.Exec(`
+			INSERT INTO health_check_sessions (id, team_id, user_id, date, assessment_period, survey_type, completed) VALUES
+			('ret_old1', 'ret_team', 'ret_member', $1, 'Old Period', 'individual', true),
+			('ret_old2', 'ret_team', 'ret_member', $1, 'Old Period', 'post_workshop', true),
+			('ret_recent', 'ret_team', 'ret_member', $2, 'Recent Period', 'individual', true),
+			('ret_held_old', 'ret_held', 'ret_member', $1, 'Old Period', 'individual', true)
+		`, old, recent)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO health_check_responses (session_id, dimension_id, score, trend, comment) VALUES
+			('ret_old1', 'mission', 3, 'stable', 'kept in archive'), ('ret_old1', 'speed', 2, 'stable', NULL),
+			('ret_old2', 'mission', 1, 'declining', NULL),
+			('ret_recent', 'mission', 2, 'improving', NULL),
+			('ret_held_old', 'mission', 2, 'stable', NULL)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`UPDATE app_settings SET retention_months = 12, retention_archive = true WHERE id = 1`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		cleanup()
+	})
+
+	It("should preview expired sessions per team and period without changing data", func() {
+		preview, err := service.Preview(context.Background(), now)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(preview.Eligible).To(HaveLen(1))
+		Expect(preview.Eligible[0].TeamID).To(Equal("ret_team"))
+		Expect(preview.Eligible[0].AssessmentPeriod).To(Equal("Old Period"))
+		Expect(preview.Eligible[0].Sessions).To(Equal(2))
+		Expect(preview.Eligible[0].Responses).To(Equal(3))
+
+		Expect(preview.Held).To(HaveLen(1))
+		Expect(preview.Held[0].TeamID).To(Equal("ret_held"))
+
+		Expect(countRows(`SELECT COUNT(*) FROM health_check_sessions WHERE id LIKE 'ret_%'`)).To(Equal(4))
+	})
+
+	It("should archive expired sessions, skip legal holds and write the audit log", func() {
+		result, err := service.RunAt(context.Background(), now, services.RetentionTriggeredBySystem)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(services.TotalRetentionSessions(result.Removed)).To(Equal(2))
+
+		// Expired sessions and their responses are gone from the live tables
+		Expect(countRows(`SELECT COUNT(*) FROM health_check_sessions WHERE id IN ('ret_old1', 'ret_old2')`)).To(Equal(0))
+		Expect(countRows(`SELECT COUNT(*) FROM health_check_responses WHERE session_id IN ('ret_old1', 'ret_old2')`)).To(Equal(0))
+
+		// Recent and held sessions are untouched
+		Expect(countRows(`SELECT COUNT(*) FROM health_check_sessions WHERE id IN ('ret_recent', 'ret_held_old')`)).To(Equal(2))
+
+		// Archived copies keep the responses
+		var responses string
+		Expect(db.QueryRow(`SELECT responses::text FROM archived_health_check_sessions WHERE id = 'ret_old1'`).Scan(&responses)).To(Succeed())
+		Expect(responses).To(ContainSubstring("kept in archive"))
+		Expect(countRows(`SELECT COUNT(*) FROM archived_health_check_sessions WHERE retention_run_id = $1`, result.RunID)).To(Equal(2))
+
+		entries, err := service.AuditLog(context.Background(), 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Action).To(Equal(services.RetentionActionArchive))
+		Expect(entries[0].TeamID).To(Equal("ret_team"))
+		Expect(entries[0].Sessions).To(Equal(2))
+		Expect(entries[0].Responses).To(Equal(3))
+		Expect(entries[0].TriggeredBy).To(Equal(services.RetentionTriggeredBySystem))
+
+		// A second run has nothing left to do
+		result, err = service.RunAt(context.Background(), now, services.RetentionTriggeredBySystem)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Removed).To(BeEmpty())
+	})
+
+	It("should delete without archiving when archiving is disabled", func() {
+		_, err := db.Exec(`UPDATE app_settings SET retention_archive = false WHERE id = 1`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = service.RunAt(context.Background(), now, "admin")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(countRows(`SELECT COUNT(*) FROM health_check_sessions WHERE id IN ('ret_old1', 'ret_old2')`)).To(Equal(0))
+		Expect(countRows(`SELECT COUNT(*) FROM archived_health_check_sessions`)).To(Equal(0))
+		Expect(countRows(`SELECT COUNT(*) FROM retention_audit_log WHERE action = 'purged' AND triggered_by = 'admin'`)).To(Equal(1))
+	})
+
+	Describe("GET /api/v1/admin/retention/preview", func() {
+		It("should return the dry run for admins", func() {
+			os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+			defer os.Unsetenv("JWT_SECRET")
+			gin.SetMode(gin.TestMode)
+
+			jwtService := services.NewJWTService()
+			tokenPair, err := jwtService.GenerateTokenPair(context.Background(), "admin", "admin", "admin@test.com", "level-admin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			router := gin.New()
+			v1.SetupRetentionRoutes(router, service, jwtService, services.NewPermissionService(postgres.NewOrganizationRepository(db)), nil)
+
+			req, _ := http.NewRequest("GET", "/api/v1/admin/retention/preview", nil)
+			req.Header.Set("Authorization", "Bearer "+tokenPair.AccessToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp dto.RetentionPreviewResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Action).To(Equal("archive"))
+			Expect(resp.TotalSessions).To(Equal(2))
+			Expect(resp.Held).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,226 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: Directory Import", func() {
+	const header = "username,email,full_name,hierarchy_level,reports_to,teams,leads,auth_type,password\n"
+
+	var (
+		db         *sql.DB
+		cleanup    func()
+		router     *gin.Engine
+		jwtService *services.JWTService
+		adminToken string
+	)
+
+	postAs := func(token, path, csv string) (*httptest.ResponseRecorder, dto.DirectoryImportResponse) {
+		body, _ := json.Marshal(dto.DirectoryImportRequest{CSV: csv})
+		req, _ := http.NewRequest("POST", path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var resp dto.DirectoryImportResponse
+		if w.Code == http.StatusOK || w.Code == http.StatusUnprocessableEntity {
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		}
+		return w, resp
+	}
+
+	post := func(path, csv string) (*httptest.ResponseRecorder, dto.DirectoryImportResponse) {
+		return postAs(adminToken, path, csv)
+	}
+
+	countUsers := func(prefix string) int {
+		var count int
+		Expect(db.QueryRow("SELECT COUNT(*) FROM users WHERE username LIKE $1", prefix+"%").Scan(&count)).To(Succeed())
+		return count
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		jwtService = services.NewJWTService()
+		tokenPair, err := jwtService.GenerateTokenPair(context.Background(), "admin", "admin", "admin@test.com", "level-admin", []string{})
+		Expect(err).NotTo(HaveOccurred())
+		adminToken = tokenPair.AccessToken
+
+		userRepo := postgres.NewUserRepository(db)
+		teamRepo := postgres.NewTeamRepository(db)
+		orgRepo := postgres.NewOrganizationRepository(db)
+		permissions := services.NewPermissionService(orgRepo)
+		importService := services.NewDirectoryImportService(userRepo, teamRepo, orgRepo, postgres.NewDirectoryImportRepository(db), permissions)
+
+		router = gin.New()
+		v1.SetupDirectoryImportRoutes(router, importService, userRepo, teamRepo, orgRepo, jwtService, permissions, nil)
+
+		_, err = db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash) VALUES
+			('imp_vp', 'imp_vp', 'imp_vp@test.com', 'Import VP', 'level-1', '')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("JWT_SECRET")
+		cleanup()
+	})
+
+	It("should report what an import would do without writing anything", func() {
+		w, resp := post("/api/v1/admin/import/validate", header+
+			"imp_lead,imp_lead@test.com,Import Lead,level-4,imp_vp,Import Team,Import Team,,secret1\n"+
+			"imp_dev,imp_dev@test.com,Import Dev,level-5,imp_lead,Import Team,,sso,\n")
+
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(resp.Valid).To(BeTrue())
+		Expect(resp.Applied).To(BeFalse())
+		Expect(resp.Rows).To(Equal(2))
+		Expect(resp.UsersCreated).To(Equal(2))
+		Expect(resp.TeamsCreated).To(Equal(1))
+		Expect(resp.Memberships).To(Equal(2))
+		Expect(countUsers("imp_")).To(Equal(1))
+	})
+
+	It("should apply users, reporting lines, teams and leads, then derive supervisor chains", func() {
+		// The lead reports to a manager later in the file
+		w, resp := post("/api/v1/admin/import", header+
+			"imp_lead,imp_lead@test.com,Import Lead,level-4,imp_mgr,Import Team,Import Team,,secret1\n"+
+			"imp_mgr,imp_mgr@test.com,Import Manager,Manager,imp_vp,,,sso,\n"+
+			"imp_dev,imp_dev@test.com,Import Dev,level-5,imp_lead,Import Team;Second Team,,sso,\n")
+
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		Expect(resp.Applied).To(BeTrue())
+		Expect(resp.UsersCreated).To(Equal(3))
+		Expect(resp.TeamsCreated).To(Equal(2))
+
+		var reportsTo, levelID string
+		Expect(db.QueryRow("SELECT reports_to, hierarchy_level_id FROM users WHERE id = 'imp-lead'").Scan(&reportsTo, &levelID)).To(Succeed())
+		Expect(reportsTo).To(Equal("imp-mgr"))
+		Expect(levelID).To(Equal("level-4"))
+
+		var leadID string
+		Expect(db.QueryRow("SELECT team_lead_id FROM teams WHERE id = 'import-team'").Scan(&leadID)).To(Succeed())
+		Expect(leadID).To(Equal("imp-lead"))
+
+		var members int
+		Expect(db.QueryRow("SELECT COUNT(*) FROM team_members WHERE team_id = 'import-team'").Scan(&members)).To(Succeed())
+		Expect(members).To(Equal(2))
+
+		rows, err := db.Query("SELECT user_id FROM team_supervisors WHERE team_id = 'import-team' ORDER BY position")
+		Expect(err).NotTo(HaveOccurred())
+		defer rows.Close()
+		var chain []string
+		for rows.Next() {
+			var userID string
+			Expect(rows.Scan(&userID)).To(Succeed())
+			chain = append(chain, userID)
+		}
+		Expect(chain).To(Equal([]string{"imp-mgr", "imp_vp"}))
+
+		// Importing again updates the same users instead of creating them
+		w, resp = post("/api/v1/admin/import", header+
+			"imp_dev,imp_dev@test.com,Import Developer,level-5,imp_mgr,Import Team,,,\n")
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		Expect(resp.UsersUpdated).To(Equal(1))
+		Expect(resp.UsersCreated).To(Equal(0))
+		Expect(db.QueryRow("SELECT reports_to FROM users WHERE id = 'imp-dev'").Scan(&reportsTo)).To(Succeed())
+		Expect(reportsTo).To(Equal("imp-mgr"))
+	})
+
+	It("should report every row error and write nothing", func() {
+		w, resp := post("/api/v1/admin/import", header+
+			"imp_a,imp_a@test.com,Import A,level-9,imp_b,Team A,Team A,,secret1\n"+
+			"imp_b,not-an-email,Import B,level-5,imp_a,,Team A,,secret1\n"+
+			"imp_c,imp_vp@test.com,Import C,level-5,nobody,,,ldap,\n"+
+			"imp_d,imp_d@test.com,Import D,level-5,imp_e,,,,secret1\n"+
+			"imp_e,imp_e@test.com,Import E,level-5,imp_d,,,,secret1\n")
+
+		Expect(w.Code).To(Equal(http.StatusUnprocessableEntity))
+		Expect(resp.Valid).To(BeFalse())
+		Expect(resp.Applied).To(BeFalse())
+
+		errs := map[int][]string{}
+		for _, e := range resp.Errors {
+			errs[e.Line] = append(errs[e.Line], e.Column)
+		}
+		Expect(errs[2]).To(ContainElements("hierarchy_level", "reports_to"))
+		Expect(errs[3]).To(ContainElements("email", "leads", "reports_to"))
+		Expect(errs[4]).To(ContainElements("email", "reports_to", "auth_type"))
+		Expect(errs[5]).To(ContainElement("reports_to"))
+		Expect(errs[6]).To(ContainElement("reports_to"))
+		Expect(countUsers("imp_")).To(Equal(1))
+	})
+
+	It("should reject files without the required columns", func() {
+		w, _ := post("/api/v1/admin/import/validate", "username,email\nimp_a,imp_a@test.com\n")
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should not let importers hand out or take over access they lack", func() {
+		// Team leads may import users and teams, but hold none of the other admin permissions
+		_, err := db.Exec(`UPDATE hierarchy_levels SET can_manage_users = true, can_edit_teams = true WHERE id = 'level-4'`)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash) VALUES
+			('imp_admin', 'imp_admin', 'imp_admin@test.com', 'Import Admin', 'level-admin', ''),
+			('imp_member', 'imp_member', 'imp_member@test.com', 'Import Member', 'level-5', '')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+		tokenPair, err := jwtService.GenerateTokenPair(context.Background(), "imp_importer", "imp_importer", "imp_importer@test.com", "level-4", nil)
+		Expect(err).NotTo(HaveOccurred())
+		importerToken := tokenPair.AccessToken
+
+		csv := header +
+			"imp_new_admin,imp_new_admin@test.com,Import New Admin,level-admin,,,,,secret1\n" +
+			"imp_member,imp_member@test.com,Import Member,level-1,,,,,\n" +
+			"imp_admin,imp_taken@test.com,Import Admin,level-5,,,,,\n" +
+			"imp_dev,imp_dev@test.com,Import Dev,level-5,,,,,secret1\n"
+
+		for _, path := range []string{"/api/v1/admin/import/validate", "/api/v1/admin/import"} {
+			w, resp := postAs(importerToken, path, csv)
+			Expect(resp.Valid).To(BeFalse(), path)
+			Expect(resp.Applied).To(BeFalse(), path)
+			if path == "/api/v1/admin/import" {
+				Expect(w.Code).To(Equal(http.StatusUnprocessableEntity))
+			}
+
+			lines := map[int]string{}
+			for _, e := range resp.Errors {
+				lines[e.Line] = e.Column
+			}
+			Expect(lines).To(Equal(map[int]string{2: "hierarchy_level", 3: "hierarchy_level", 4: "hierarchy_level"}), path)
+		}
+
+		var email, levelID string
+		Expect(db.QueryRow("SELECT email FROM users WHERE id = 'imp_admin'").Scan(&email)).To(Succeed())
+		Expect(email).To(Equal("imp_admin@test.com"))
+		Expect(db.QueryRow("SELECT hierarchy_level_id FROM users WHERE id = 'imp_member'").Scan(&levelID)).To(Succeed())
+		Expect(levelID).To(Equal("level-5"))
+		Expect(countUsers("imp_")).To(Equal(3))
+
+		// Rows within the importer's own access are accepted
+		w, resp := postAs(importerToken, "/api/v1/admin/import", header+"imp_dev,imp_dev@test.com,Import Dev,level-5,,,,,secret1\n")
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		Expect(resp.UsersCreated).To(Equal(1))
+	})
+})
@@ -0,0 +1,189 @@
+package integration_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/commands"
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: Health Check Export", func() {
+	const period = "2024 Q2"
+
+	var (
+		db              *sql.DB
+		cleanup         func()
+		router          *gin.Engine
+		healthCheckRepo healthcheck.Repository
+		tokens          map[string]string
+	)
+
+	get := func(user, path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+tokens[user])
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	readCSV := func(w *httptest.ResponseRecorder) [][]string {
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(HavePrefix("text/csv"))
+		records, err := csv.NewReader(w.Body).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		return records
+	}
+
+	submit := func(teamID, userID, surveyType, comment string) {
+		_, err := commands.NewSubmitHealthCheckHandler(healthCheckRepo, nil).Handle(commands.SubmitHealthCheckCommand{
+			ID:               "exp_" + teamID + "_" + userID + "_" + surveyType,
+			TeamID:           teamID,
+			UserID:           userID,
+			Date:             time.Now().Format("2006-01-02"),
+			AssessmentPeriod: period,
+			SurveyType:       surveyType,
+			Completed:        true,
+			Responses: []commands.HealthCheckResponseCommand{
+				{DimensionID: "mission", Score: 3, Trend: "improving", Comment: comment},
+				{DimensionID: "speed", Score: 1, Trend: "declining"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+		healthCheckRepo = postgres.NewHealthCheckRepository(db)
+
+		jwtService := services.NewJWTService()
+		tokens = map[string]string{}
+		for user, level := range map[string]string{
+			"exp_member": "level-5", "exp_manager": "level-3", "exp_director": "level-2", "admin": "level-admin",
+		} {
+			tokenPair, err := jwtService.GenerateTokenPair(context.Background(), user, user, user+"@test.com", level, []string{"exp_team"})
+			Expect(err).NotTo(HaveOccurred())
+			tokens[user] = tokenPair.AccessToken
+		}
+
+		router = gin.New()
+		exportService := services.NewExportService(postgres.NewExportRepository(db))
+		v1.SetupExportRoutes(router, exportService, jwtService, services.NewPermissionService(postgres.NewOrganizationRepository(db)))
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id) VALUES
+			('exp_member', 'exp_member', 'exp_member@test.com', 'Export Member', 'level-5'),
+			('exp_m2', 'exp_m2', 'exp_m2@test.com', 'Second Member', 'level-5'),
+			('exp_director', 'exp_director', 'exp_director@test.com', 'Export Director', 'level-2')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO teams (id, name, anonymous, anonymity_min_respondents) VALUES
+			('exp_team', 'Export Team', false, 3),
+			('exp_hidden', 'Hidden Team', true, 3),
+			('exp_anon', 'Anonymous Team', true, 1)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO team_supervisors (team_id, user_id, hierarchy_level_id, position) VALUES
+			('exp_team', 'exp_director', 'level-2', 1),
+			('exp_hidden', 'exp_director', 'level-2', 1),
+			('exp_anon', 'exp_director', 'level-2', 1)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		submit("exp_team", "exp_member", healthcheck.SurveyTypeIndividual, "=needs focus")
+		submit("exp_team", "exp_director", healthcheck.SurveyTypePostWorkshop, "")
+		submit("exp_hidden", "exp_member", healthcheck.SurveyTypeIndividual, "hidden")
+		submit("exp_hidden", "exp_m2", healthcheck.SurveyTypeIndividual, "hidden")
+		submit("exp_anon", "exp_member", healthcheck.SurveyTypeIndividual, "private")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("JWT_SECRET")
+		cleanup()
+	})
+
+	It("should export a team's responses as CSV with period and survey type filters", func() {
+		records := readCSV(get("exp_director", "/api/v1/exports/teams/exp_team/health-checks?assessmentPeriod=2024%20Q2"))
+		Expect(records).To(HaveLen(5))
+		Expect(records[0][0]).To(Equal("Team ID"))
+		Expect(records[1][1]).To(Equal("Export Team"))
+
+		records = readCSV(get("exp_director", "/api/v1/exports/teams/exp_team/health-checks?surveyType=individual"))
+		Expect(records).To(HaveLen(3))
+		Expect(records[1][5]).To(Equal("exp_member"))
+		Expect(records[1][10]).To(Equal("3"))
+		Expect(records[1][11]).To(Equal("improving"))
+		// Comments are kept from being read as spreadsheet formulas
+		Expect(records[1][12]).To(Equal("'=needs focus"))
+
+		records = readCSV(get("exp_director", "/api/v1/exports/teams/exp_team/health-checks?assessmentPeriod=2023%20Q1"))
+		Expect(records).To(HaveLen(1))
+	})
+
+	It("should leave out identities, comments and teams below the minimum respondent count", func() {
+		records := readCSV(get("exp_director", "/api/v1/exports/managers/exp_director/health-checks?surveyType=individual"))
+
+		teams := map[string]int{}
+		for _, record := range records[1:] {
+			teams[record[0]]++
+			if record[0] == "exp_anon" {
+				Expect(record[5]).To(BeEmpty())
+				Expect(record[7]).To(Equal("true"))
+				Expect(record[12]).To(BeEmpty())
+			}
+		}
+		Expect(teams).To(Equal(map[string]int{"exp_team": 2, "exp_anon": 2}))
+	})
+
+	It("should stream XLSX workbooks", func() {
+		w := get("exp_director", "/api/v1/exports/teams/exp_team/health-checks?format=xlsx")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(Equal("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"))
+		Expect(w.Header().Get("Content-Disposition")).To(ContainSubstring(".xlsx"))
+
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		Expect(err).NotTo(HaveOccurred())
+		var sheet []byte
+		for _, f := range archive.File {
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				r, err := f.Open()
+				Expect(err).NotTo(HaveOccurred())
+				sheet, err = io.ReadAll(r)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+		Expect(string(sheet)).To(ContainSubstring("Export Team"))
+		Expect(string(sheet)).To(ContainSubstring("=needs focus"))
+	})
+
+	It("should require the export permission", func() {
+		Expect(get("exp_member", "/api/v1/exports/teams/exp_team/health-checks").Code).To(Equal(http.StatusForbidden))
+		Expect(get("exp_manager", "/api/v1/exports/teams/exp_team/health-checks").Code).To(Equal(http.StatusForbidden))
+		Expect(get("exp_director", "/api/v1/exports/managers/exp_manager/health-checks").Code).To(Equal(http.StatusOK))
+		Expect(get("exp_director", "/api/v1/exports/teams/exp_team/health-checks?format=pdf").Code).To(Equal(http.StatusBadRequest))
+
+		records := readCSV(get("admin", "/api/v1/exports/health-checks?assessmentPeriod=2024%20Q2"))
+		Expect(len(records)).To(BeNumerically(">=", 7))
+	})
+})
@@ -0,0 +1,234 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/commands"
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: Health Check Revisions", func() {
+	const period = "2024 Q1"
+
+	var (
+		db              *sql.DB
+		cleanup         func()
+		router          *gin.Engine
+		healthCheckRepo healthcheck.Repository
+		tokens          map[string]string
+	)
+
+	doRequest := func(user, method, path string, body interface{}) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokens[user])
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	submit := func(teamID, userID string) *healthcheck.HealthCheckSession {
+		session, err := commands.NewSubmitHealthCheckHandler(healthCheckRepo, nil).Handle(commands.SubmitHealthCheckCommand{
+			ID:               "rev_" + teamID + "_" + userID,
+			TeamID:           teamID,
+			UserID:           userID,
+			Date:             time.Now().Format("2006-01-02"),
+			AssessmentPeriod: period,
+			SurveyType:       healthcheck.SurveyTypeIndividual,
+			Completed:        true,
+			Responses: []commands.HealthCheckResponseCommand{
+				{DimensionID: "mission", Score: 1, Trend: "declining", Comment: "mis-click"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return session
+	}
+
+	amended := map[string]interface{}{
+		"responses": []map[string]interface{}{
+			{"dimensionId": "mission", "score": 3, "trend": "improving"},
+		},
+	}
+
+	revisionsOf := func(user, sessionID string) dto.HealthCheckRevisionsResponse {
+		w := doRequest(user, "GET", "/api/v1/health-checks/"+sessionID+"/revisions", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp dto.HealthCheckRevisionsResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		return resp
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+		healthCheckRepo = postgres.NewHealthCheckRepository(db)
+
+		jwtService := services.NewJWTService()
+		tokens = map[string]string{}
+		for user, level := range map[string]string{"rev_m1": "level-5", "rev_m2": "level-5", "rev_manager": "level-3", "rev_other_manager": "level-3"} {
+			tokenPair, err := jwtService.GenerateTokenPair(context.Background(), user, user, user+"@test.com", level, []string{"rev_team", "rev_closed", "rev_anon"})
+			Expect(err).NotTo(HaveOccurred())
+			tokens[user] = tokenPair.AccessToken
+		}
+
+		router = gin.New()
+		v1.SetupHealthCheckRoutes(router, healthCheckRepo, postgres.NewOrganizationRepository(db), postgres.NewSurveyTemplateRepository(db), postgres.NewTeamRepository(db), jwtService, services.NewPermissionService(postgres.NewOrganizationRepository(db)), nil, nil)
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id) VALUES
+			('rev_m1', 'rev_m1', 'rev_m1@test.com', 'Member One', 'level-5'),
+			('rev_m2', 'rev_m2', 'rev_m2@test.com', 'Member Two', 'level-5'),
+			('rev_manager', 'rev_manager', 'rev_manager@test.com', 'Manager', 'level-3'),
+			('rev_other_manager', 'rev_other_manager', 'rev_other_manager@test.com', 'Other Manager', 'level-3')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO teams (id, name, cadence, anonymous, anonymity_min_respondents) VALUES
+			('rev_team', 'Revision Team', 'quarterly', false, 3),
+			('rev_closed', 'Closed Team', 'quarterly', false, 3),
+			('rev_anon', 'Anonymous Team', 'quarterly', true, 1);
+			INSERT INTO team_supervisors (team_id, user_id, hierarchy_level_id, position) VALUES
+			('rev_team', 'rev_manager', 'level-3', 1),
+			('rev_closed', 'rev_manager', 'level-3', 1),
+			('rev_anon', 'rev_manager', 'level-3', 1)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO survey_campaigns (id, team_id, assessment_period, cadence, status, start_date, end_date) VALUES
+			('rev_c1', 'rev_team', $1, 'quarterly', 'open', '2024-01-01', '2024-03-31'),
+			('rev_c2', 'rev_closed', $1, 'quarterly', 'closed', '2024-01-01', '2024-03-31'),
+			('rev_c3', 'rev_anon', $1, 'quarterly', 'open', '2024-01-01', '2024-03-31')
+		`, period)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("JWT_SECRET")
+		cleanup()
+	})
+
+	It("should amend the member's own submission and keep the original as a revision", func() {
+		session := submit("rev_team", "rev_m1")
+
+		w := doRequest("rev_m2", "PUT", "/api/v1/health-checks/"+session.ID, amended)
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+
+		w = doRequest("rev_m1", "PUT", "/api/v1/health-checks/"+session.ID, amended)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		stored, err := healthCheckRepo.FindByID(context.Background(), session.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.Responses).To(HaveLen(1))
+		Expect(stored.Responses[0].Score).To(Equal(3))
+
+		revisions := revisionsOf("rev_manager", session.ID)
+		Expect(revisions.Revisions).To(HaveLen(2))
+		Expect(revisions.Revisions[0].Action).To(Equal(healthcheck.RevisionSubmitted))
+		Expect(revisions.Revisions[0].Responses[0].Score).To(Equal(1))
+		Expect(revisions.Revisions[0].Responses[0].Comment).To(Equal("mis-click"))
+		Expect(revisions.Revisions[1].Action).To(Equal(healthcheck.RevisionAmended))
+		Expect(revisions.Revisions[1].Responses[0].Score).To(Equal(3))
+
+		Expect(revisionsOf("rev_m1", session.ID).Revisions).To(HaveLen(2))
+		w = doRequest("rev_m2", "GET", "/api/v1/health-checks/"+session.ID+"/revisions", nil)
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+
+		// Revisions are immutable
+		_, err = db.Exec(`UPDATE health_check_revisions SET action = 'amended' WHERE session_id = $1`, session.ID)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject changes once the period is closed", func() {
+		session := submit("rev_closed", "rev_m1")
+
+		w := doRequest("rev_m1", "PUT", "/api/v1/health-checks/"+session.ID, amended)
+		Expect(w.Code).To(Equal(http.StatusConflict))
+
+		w = doRequest("rev_m1", "DELETE", "/api/v1/health-checks/"+session.ID, nil)
+		Expect(w.Code).To(Equal(http.StatusConflict))
+
+		Expect(revisionsOf("rev_m1", session.ID).Revisions).To(BeEmpty())
+	})
+
+	It("should withdraw a submission and record the withdrawal", func() {
+		session := submit("rev_team", "rev_m1")
+
+		w := doRequest("rev_m1", "DELETE", "/api/v1/health-checks/"+session.ID, nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		w = doRequest("rev_m1", "GET", "/api/v1/health-checks/"+session.ID, nil)
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+
+		revisions := revisionsOf("rev_manager", session.ID)
+		Expect(revisions.Revisions).To(HaveLen(2))
+		Expect(revisions.Revisions[1].Action).To(Equal(healthcheck.RevisionWithdrawn))
+		Expect(revisions.Revisions[1].Responses).To(BeEmpty())
+
+		w = doRequest("rev_m1", "DELETE", "/api/v1/health-checks/"+session.ID, nil)
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should only show revisions to supervisors of the session's team", func() {
+		session := submit("rev_team", "rev_m1")
+		Expect(doRequest("rev_m1", "PUT", "/api/v1/health-checks/"+session.ID, amended).Code).To(Equal(http.StatusOK))
+
+		Expect(revisionsOf("rev_manager", session.ID).Revisions).To(HaveLen(2))
+		w := doRequest("rev_other_manager", "GET", "/api/v1/health-checks/"+session.ID+"/revisions", nil)
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+
+		// Nor do withdrawn sessions of other teams show up
+		Expect(doRequest("rev_m1", "DELETE", "/api/v1/health-checks/"+session.ID, nil).Code).To(Equal(http.StatusOK))
+		Expect(revisionsOf("rev_manager", session.ID).Revisions).To(HaveLen(3))
+		w = doRequest("rev_other_manager", "GET", "/api/v1/health-checks/"+session.ID+"/revisions", nil)
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should not record responses of anonymous submissions", func() {
+		session := submit("rev_anon", "rev_m1")
+		Expect(session.Anonymous).To(BeTrue())
+
+		w := doRequest("rev_m2", "PUT", "/api/v1/health-checks/"+session.ID, amended)
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+
+		w = doRequest("rev_m1", "PUT", "/api/v1/health-checks/"+session.ID, amended)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resp dto.HealthCheckSessionResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		Expect(resp.UserID).To(Equal(session.UserID))
+
+		revisions := revisionsOf("rev_manager", session.ID)
+		Expect(revisions.Revisions).To(HaveLen(2))
+		for _, rev := range revisions.Revisions {
+			Expect(rev.Responses).To(BeEmpty())
+		}
+
+		w = doRequest("rev_m1", "DELETE", "/api/v1/health-checks/"+session.ID, nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var participants int
+		Expect(db.QueryRow(`SELECT COUNT(*) FROM survey_participation WHERE team_id = 'rev_anon'`).Scan(&participants)).To(Succeed())
+		Expect(participants).To(Equal(0))
+	})
+})
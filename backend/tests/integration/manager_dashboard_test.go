@@ -47,11 +47,11 @@ var _ = Describe("Integration: Manager Dashboard API", func() {
 		router = gin.New()
 		healthCheckRepo := postgres.NewHealthCheckRepository(db)
 		orgRepo := postgres.NewOrganizationRepository(db)
-		trendsService := trends.NewService(db)
+		trendsService := trends.NewService(db, orgRepo)
 
-		v1.SetupHealthCheckRoutes(router, healthCheckRepo, orgRepo, jwtService, nil)
+		v1.SetupHealthCheckRoutes(router, healthCheckRepo, orgRepo, postgres.NewSurveyTemplateRepository(db), postgres.NewTeamRepository(db), jwtService, services.NewPermissionService(orgRepo), nil, nil)
 		userRepo := postgres.NewUserRepository(db)
-		v1.SetupManagerRoutes(router, healthCheckRepo, trendsService, jwtService, userRepo)
+		v1.SetupManagerRoutes(router, healthCheckRepo, trendsService, jwtService, services.NewPermissionService(orgRepo), userRepo)
 	})
 
 	AfterEach(func() {
@@ -335,6 +335,201 @@ var _ = Describe("Integration: Manager Dashboard API", func() {
 		})
 	})
 
+	Describe("Weighted overall health", func() {
+		BeforeEach(func() {
+			_, err := db.Exec(`
+				INSERT INTO users (id, username, email, full_name, hierarchy_level_id, reports_to)
+				VALUES
+					('wt_manager', 'wt_manager', 'wt_manager@test.com', 'Weight Manager', 'level-3', NULL),
+					('wt_member', 'wt_member', 'wt_member@test.com', 'Weight Member', 'level-5', 'wt_manager')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`INSERT INTO teams (id, name) VALUES ('wt_team', 'Weighted Team')`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO team_supervisors (team_id, user_id, hierarchy_level_id, position)
+				VALUES ('wt_team', 'wt_manager', 'level-3', 1)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO health_check_sessions (id, team_id, user_id, date, assessment_period, completed)
+				VALUES ('wt_session', 'wt_team', 'wt_member', $1, '2024 - 2nd Half', true)
+			`, time.Now().Format("2006-01-02"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO health_check_responses (session_id, dimension_id, score, trend)
+				VALUES ('wt_session', 'mission', 3, 'stable'), ('wt_session', 'value', 1, 'stable')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Mission counts three times as much as value
+			_, err = db.Exec(`UPDATE health_dimensions SET weight = 3 WHERE id = 'mission'`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		getJSON := func(path string) map[string]interface{} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("Authorization", "Bearer "+managerToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+
+			var response map[string]interface{}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			return response
+		}
+
+		It("should weight overallHealth by dimension weight by default", func() {
+			response := getJSON("/api/v1/managers/wt_manager/teams/health")
+			team := response["teams"].([]interface{})[0].(map[string]interface{})
+
+			// (3*3 + 1*1) / (3 + 1) = 2.5
+			Expect(team["overallHealth"].(float64)).To(BeNumerically("~", 2.5, 0.001))
+		})
+
+		It("should return the plain average with scoring=unweighted", func() {
+			response := getJSON("/api/v1/managers/wt_manager/teams/health?scoring=unweighted")
+			team := response["teams"].([]interface{})[0].(map[string]interface{})
+
+			// (3 + 1) / 2 = 2.0
+			Expect(team["overallHealth"].(float64)).To(BeNumerically("~", 2.0, 0.001))
+		})
+
+		It("should weight the overall trend series the same way", func() {
+			weighted := getJSON("/api/v1/managers/wt_manager/dashboard/trends")
+			Expect(weighted["overall"]).To(HaveLen(1))
+			Expect(weighted["overall"].([]interface{})[0]).To(BeNumerically("~", 2.5, 0.001))
+
+			unweighted := getJSON("/api/v1/managers/wt_manager/dashboard/trends?scoring=unweighted")
+			Expect(unweighted["overall"].([]interface{})[0]).To(BeNumerically("~", 2.0, 0.001))
+		})
+
+		It("should reject an unknown scoring mode", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/managers/wt_manager/teams/health?scoring=median", nil)
+			req.Header.Set("Authorization", "Bearer "+managerToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("GET /api/v1/managers/:managerId/dashboard/trends dimension set", func() {
+		BeforeEach(func() {
+			_, err := db.Exec(`
+				INSERT INTO users (id, username, email, full_name, hierarchy_level_id)
+				VALUES
+					('tr_manager', 'tr_manager', 'tr_manager@test.com', 'Trend Manager', 'level-3'),
+					('tr_member', 'tr_member', 'tr_member@test.com', 'Trend Member', 'level-5')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`INSERT INTO teams (id, name) VALUES ('tr_team', 'Trend Team')`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO team_supervisors (team_id, user_id, hierarchy_level_id, position)
+				VALUES ('tr_team', 'tr_manager', 'level-3', 1)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			// A custom dimension added by an admin
+			_, err = db.Exec(`
+				INSERT INTO health_dimensions (id, name, description, good_description, bad_description, is_active, weight)
+				VALUES ('autonomy', 'Autonomy', 'Team decides how to work', 'We decide', 'Others decide', true, 1.0)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			currentDate := time.Now().Format("2006-01-02")
+			_, err = db.Exec(`
+				INSERT INTO health_check_sessions (id, team_id, user_id, date, assessment_period, completed)
+				VALUES
+					('tr_session1', 'tr_team', 'tr_member', $1, '2024 - 1st Half', true),
+					('tr_session2', 'tr_team', 'tr_member', $1, '2024 - 2nd Half', true)
+			`, currentDate)
+			Expect(err).NotTo(HaveOccurred())
+
+			// fun has data only in the first period and is retired afterwards; autonomy only in the second
+			_, err = db.Exec(`
+				INSERT INTO health_check_responses (session_id, dimension_id, score, trend)
+				VALUES
+					('tr_session1', 'mission', 3, 'stable'),
+					('tr_session1', 'fun', 2, 'stable'),
+					('tr_session2', 'mission', 2, 'declining'),
+					('tr_session2', 'autonomy', 3, 'improving')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`UPDATE health_dimensions SET is_active = false WHERE id IN ('fun', 'speed')`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should include custom and historical dimensions and report gaps as null", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/managers/tr_manager/dashboard/trends", nil)
+			req.Header.Set("Authorization", "Bearer "+managerToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var response map[string]interface{}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response["periods"]).To(Equal([]interface{}{"2024 - 1st Half", "2024 - 2nd Half"}))
+
+			dimensions := response["dimensions"].([]interface{})
+
+			autonomy := findDimensionById(dimensions, "autonomy")
+			Expect(autonomy).NotTo(BeNil())
+			Expect(autonomy["name"]).To(Equal("Autonomy"))
+			Expect(autonomy["scores"]).To(Equal([]interface{}{nil, 3.0}))
+
+			// Inactive but has data in the requested periods
+			fun := findDimensionById(dimensions, "fun")
+			Expect(fun).NotTo(BeNil())
+			Expect(fun["isActive"]).To(BeFalse())
+			Expect(fun["scores"]).To(Equal([]interface{}{2.0, nil}))
+
+			// Inactive without data is left out
+			Expect(findDimensionById(dimensions, "speed")).To(BeNil())
+
+			// Active without data is present with nulls
+			value := findDimensionById(dimensions, "value")
+			Expect(value).NotTo(BeNil())
+			Expect(value["scores"]).To(Equal([]interface{}{nil, nil}))
+		})
+
+		It("should report null, not 0, for a period without responses for a dimension", func() {
+			// A later period in which only autonomy was answered
+			_, err := db.Exec(`
+				INSERT INTO health_check_sessions (id, team_id, user_id, date, assessment_period, completed)
+				VALUES ('tr_session3', 'tr_team', 'tr_member', $1, '2025 - 1st Half', true)
+			`, time.Now().Format("2006-01-02"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = db.Exec(`
+				INSERT INTO health_check_responses (session_id, dimension_id, score, trend)
+				VALUES ('tr_session3', 'autonomy', 1, 'declining')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/managers/tr_manager/dashboard/trends", nil)
+			req.Header.Set("Authorization", "Bearer "+managerToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var response map[string]interface{}
+			Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(Succeed())
+			Expect(response["periods"]).To(Equal([]interface{}{"2024 - 1st Half", "2024 - 2nd Half", "2025 - 1st Half"}))
+
+			mission := findDimensionById(response["dimensions"].([]interface{}), "mission")
+			Expect(mission).NotTo(BeNil())
+			Expect(mission["scores"]).To(Equal([]interface{}{3.0, 2.0, nil}))
+		})
+	})
+
 	Describe("GET /api/v1/managers/:managerId/subordinates", func() {
 		Context("when a manager requests their subordinate tree", func() {
 			It("should return direct and indirect reports with team memberships", func() {
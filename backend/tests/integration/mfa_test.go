@@ -0,0 +1,268 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/pkg/totp"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ = Describe("Integration: Multi-Factor Authentication", func() {
+	var (
+		db         *sql.DB
+		cleanup    func()
+		jwtService *services.JWTService
+		router     *gin.Engine
+	)
+
+	// secret is the RFC 6238 test key, base32 encoded
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	currentCode := func(secret string) string {
+		code, err := totp.Code(secret, totp.Step(time.Now()))
+		Expect(err).NotTo(HaveOccurred())
+		return code
+	}
+
+	post := func(path, token string, payload interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("POST", path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	decode := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		var resp map[string]interface{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		return resp
+	}
+
+	login := func() *httptest.ResponseRecorder {
+		return post("/api/v1/auth/login", "", map[string]string{"username": "mfa_user", "password": "correct-password"})
+	}
+
+	// challenge logs in and returns the MFA challenge token
+	challenge := func() string {
+		w := login()
+		Expect(w.Code).To(Equal(http.StatusOK))
+		resp := decode(w)
+		Expect(resp["mfaRequired"]).To(BeTrue())
+		Expect(resp).NotTo(HaveKey("accessToken"))
+		return resp["mfaToken"].(string)
+	}
+
+	enableMFA := func() {
+		_, err := db.Exec(`INSERT INTO user_mfa (user_id, secret, enabled_at) VALUES ('mfa_user', $1, NOW())`, secret)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	userToken := func() string {
+		tokens, err := jwtService.GenerateTokenPair(context.Background(), "mfa_user", "mfa_user", "mfa_user@test.com", "level-5", nil)
+		Expect(err).NotTo(HaveOccurred())
+		return tokens.AccessToken
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		gin.SetMode(gin.TestMode)
+
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.DefaultCost)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash)
+			VALUES ('mfa_user', 'mfa_user', 'mfa_user@test.com', 'MFA User', 'level-5', $1)
+		`, string(hashedPassword))
+		Expect(err).NotTo(HaveOccurred())
+
+		orgRepo := postgres.NewOrganizationRepository(db)
+		userRepo := postgres.NewUserRepository(db)
+		teamRepo := postgres.NewTeamRepository(db)
+		jwtService = services.NewJWTService()
+		mfa := services.NewMFAService(postgres.NewMFARepository(db), orgRepo, "Teams360")
+
+		router = gin.New()
+		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService, nil, mfa)
+		v1.SetupAdminRoutes(router, v1.AdminRouteDeps{
+			OrgRepo:     orgRepo,
+			UserRepo:    userRepo,
+			TeamRepo:    teamRepo,
+			JWTService:  jwtService,
+			Permissions: services.NewPermissionService(orgRepo),
+			MFA:         mfa,
+		})
+	})
+
+	AfterEach(func() {
+		cleanup()
+		os.Unsetenv("JWT_SECRET")
+	})
+
+	Describe("Login", func() {
+		It("should issue tokens directly for users without MFA", func() {
+			w := login()
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(decode(w)).To(HaveKey("accessToken"))
+		})
+
+		It("should issue tokens only after a valid code", func() {
+			enableMFA()
+			mfaToken := challenge()
+
+			w := post("/api/v1/auth/mfa/verify", "", map[string]string{"mfaToken": mfaToken, "code": "000000"})
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+
+			w = post("/api/v1/auth/mfa/verify", "", map[string]string{"mfaToken": mfaToken, "code": currentCode(secret)})
+			Expect(w.Code).To(Equal(http.StatusOK))
+			resp := decode(w)
+			Expect(resp["accessToken"]).NotTo(BeEmpty())
+			Expect(resp["refreshToken"]).NotTo(BeEmpty())
+		})
+
+		It("should reject a code that was already used", func() {
+			enableMFA()
+			code := currentCode(secret)
+
+			Expect(post("/api/v1/auth/mfa/verify", "", map[string]string{"mfaToken": challenge(), "code": code}).Code).To(Equal(http.StatusOK))
+			Expect(post("/api/v1/auth/mfa/verify", "", map[string]string{"mfaToken": challenge(), "code": code}).Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should not accept the challenge token as an access token", func() {
+			enableMFA()
+			mfaToken := challenge()
+
+			req, _ := http.NewRequest("GET", "/api/v1/auth/mfa", nil)
+			req.Header.Set("Authorization", "Bearer "+mfaToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should require users of a level that requires MFA to enroll", func() {
+			_, err := db.Exec("UPDATE hierarchy_levels SET require_mfa = true WHERE id = 'level-5'")
+			Expect(err).NotTo(HaveOccurred())
+
+			w := login()
+			Expect(w.Code).To(Equal(http.StatusOK))
+			resp := decode(w)
+			Expect(resp["mfaRequired"]).To(BeTrue())
+			Expect(resp["enrollmentRequired"]).To(BeTrue())
+			mfaToken := resp["mfaToken"].(string)
+
+			w = post("/api/v1/auth/mfa/enroll", "", map[string]string{"mfaToken": mfaToken})
+			Expect(w.Code).To(Equal(http.StatusOK))
+			enrollment := decode(w)
+			Expect(enrollment["provisioningUri"]).To(HavePrefix("otpauth://totp/Teams360:mfa_user?"))
+			enrolledSecret := enrollment["secret"].(string)
+
+			w = post("/api/v1/auth/mfa/verify", "", map[string]string{"mfaToken": mfaToken, "code": currentCode(enrolledSecret)})
+			Expect(w.Code).To(Equal(http.StatusOK))
+			resp = decode(w)
+			Expect(resp["accessToken"]).NotTo(BeEmpty())
+			Expect(resp["recoveryCodes"]).To(HaveLen(10))
+
+			// The next login is challenged for a code rather than enrollment
+			resp = decode(login())
+			Expect(resp["enrollmentRequired"]).To(BeFalse())
+		})
+
+		It("should accept each recovery code once", func() {
+			_, err := db.Exec("UPDATE hierarchy_levels SET require_mfa = true WHERE id = 'level-5'")
+			Expect(err).NotTo(HaveOccurred())
+
+			mfaToken := challenge()
+			enrolledSecret := decode(post("/api/v1/auth/mfa/enroll", "", map[string]string{"mfaToken": mfaToken}))["secret"].(string)
+			resp := decode(post("/api/v1/auth/mfa/verify", "", map[string]string{"mfaToken": mfaToken, "code": currentCode(enrolledSecret)}))
+			recoveryCode := resp["recoveryCodes"].([]interface{})[0].(string)
+
+			Expect(post("/api/v1/auth/mfa/verify", "", map[string]string{"mfaToken": challenge(), "code": recoveryCode}).Code).To(Equal(http.StatusOK))
+			Expect(post("/api/v1/auth/mfa/verify", "", map[string]string{"mfaToken": challenge(), "code": recoveryCode}).Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Describe("Self-service MFA", func() {
+		It("should enroll, confirm and disable MFA", func() {
+			token := userToken()
+
+			w := post("/api/v1/auth/mfa/enroll", token, nil)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			enrolledSecret := decode(w)["secret"].(string)
+
+			// Not enabled until confirmed
+			Expect(decode(login())).To(HaveKey("accessToken"))
+
+			w = post("/api/v1/auth/mfa/enable", token, map[string]string{"code": currentCode(enrolledSecret)})
+			Expect(w.Code).To(Equal(http.StatusOK))
+			recoveryCodes := decode(w)["recoveryCodes"].([]interface{})
+			Expect(recoveryCodes).To(HaveLen(10))
+
+			Expect(decode(login())["mfaRequired"]).To(BeTrue())
+			Expect(post("/api/v1/auth/mfa/enroll", token, nil).Code).To(Equal(http.StatusConflict))
+
+			w = post("/api/v1/auth/mfa/disable", token, map[string]string{"code": recoveryCodes[0].(string)})
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(decode(login())).To(HaveKey("accessToken"))
+		})
+
+		It("should report the MFA status", func() {
+			enableMFA()
+
+			req, _ := http.NewRequest("GET", "/api/v1/auth/mfa", nil)
+			req.Header.Set("Authorization", "Bearer "+userToken())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			resp := decode(w)
+			Expect(resp["enabled"]).To(BeTrue())
+			Expect(resp["required"]).To(BeFalse())
+		})
+
+		It("should not let users disable MFA their level requires", func() {
+			enableMFA()
+			_, err := db.Exec("UPDATE hierarchy_levels SET require_mfa = true WHERE id = 'level-5'")
+			Expect(err).NotTo(HaveOccurred())
+
+			w := post("/api/v1/auth/mfa/disable", userToken(), map[string]string{"code": currentCode(secret)})
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("POST /api/v1/admin/users/:id/reset-mfa", func() {
+		It("should remove the user's MFA", func() {
+			enableMFA()
+			adminTokens, err := jwtService.GenerateTokenPair(context.Background(), "admin", "admin", "admin@test.com", "level-admin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			w := post("/api/v1/admin/users/mfa_user/reset-mfa", adminTokens.AccessToken, nil)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(decode(w)["message"]).To(Equal("MFA reset"))
+			Expect(decode(login())).To(HaveKey("accessToken"))
+
+			Expect(post("/api/v1/admin/users/no_such_user/reset-mfa", adminTokens.AccessToken, nil).Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should require admin privileges", func() {
+			Expect(post("/api/v1/admin/users/mfa_user/reset-mfa", userToken(), nil).Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
@@ -50,7 +50,7 @@ var _ = Describe("Password Reset API Integration Tests", func() {
 
 		// Setup auth routes with password reset
 		orgRepo := postgres.NewOrganizationRepository(db)
-		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService)
+		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService, nil, nil)
 		v1.SetupPasswordResetRoutes(router, passwordResetService, userRepo)
 	})
 
@@ -0,0 +1,183 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/application/trends"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: Hierarchy Level Permissions", func() {
+	var (
+		db         *sql.DB
+		cleanup    func()
+		router     *gin.Engine
+		jwtService *services.JWTService
+	)
+
+	tokenFor := func(userID, levelID string) string {
+		tokens, err := jwtService.GenerateTokenPair(context.Background(), userID, userID, userID+"@test.com", levelID, nil)
+		Expect(err).NotTo(HaveOccurred())
+		return tokens.AccessToken
+	}
+
+	send := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		if payload != nil {
+			data, _ := json.Marshal(payload)
+			body = bytes.NewBuffer(data)
+		}
+		req := httptest.NewRequest(method, path, body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	setPermissions := func(levelID string, permissions map[string]bool) {
+		w := send("PUT", "/api/v1/admin/hierarchy-levels/"+levelID, tokenFor("admin", "level-admin"),
+			map[string]interface{}{"permissions": permissions})
+		Expect(w.Code).To(Equal(http.StatusOK))
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+		jwtService = services.NewJWTService()
+
+		orgRepo := postgres.NewOrganizationRepository(db)
+		userRepo := postgres.NewUserRepository(db)
+		teamRepo := postgres.NewTeamRepository(db)
+		healthCheckRepo := postgres.NewHealthCheckRepository(db)
+		permissions := services.NewPermissionService(orgRepo)
+
+		router = gin.New()
+		v1.SetupAdminRoutes(router, v1.AdminRouteDeps{
+			OrgRepo:     orgRepo,
+			UserRepo:    userRepo,
+			TeamRepo:    teamRepo,
+			JWTService:  jwtService,
+			Permissions: permissions,
+		})
+		v1.SetupManagerRoutes(router, healthCheckRepo, trends.NewService(db, orgRepo), jwtService, permissions, userRepo)
+	})
+
+	AfterEach(func() {
+		cleanup()
+	})
+
+	It("should keep the access each seeded level had", func() {
+		Expect(send("GET", "/api/v1/admin/settings/dimensions", tokenFor("vp", "level-1"), nil).Code).To(Equal(http.StatusOK))
+		Expect(send("GET", "/api/v1/admin/users", tokenFor("vp", "level-1"), nil).Code).To(Equal(http.StatusOK))
+		Expect(send("GET", "/api/v1/admin/users", tokenFor("director", "level-2"), nil).Code).To(Equal(http.StatusForbidden))
+		Expect(send("GET", "/api/v1/admin/teams", tokenFor("director", "level-2"), nil).Code).To(Equal(http.StatusForbidden))
+		Expect(send("GET", "/api/v1/managers/manager/subordinates", tokenFor("manager", "level-3"), nil).Code).To(Equal(http.StatusOK))
+		Expect(send("GET", "/api/v1/managers/lead/subordinates", tokenFor("lead", "level-4"), nil).Code).To(Equal(http.StatusForbidden))
+		Expect(send("GET", "/api/v1/admin/users", tokenFor("member", "level-5"), nil).Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should grant and revoke access when a level's permissions are edited", func() {
+		leadToken := tokenFor("lead", "level-4")
+		Expect(send("GET", "/api/v1/admin/users", leadToken, nil).Code).To(Equal(http.StatusForbidden))
+		Expect(send("GET", "/api/v1/managers/lead/subordinates", leadToken, nil).Code).To(Equal(http.StatusForbidden))
+
+		setPermissions("level-4", map[string]bool{"canTakeSurvey": true, "canManageUsers": true, "canViewReports": true})
+		Expect(send("GET", "/api/v1/admin/users", leadToken, nil).Code).To(Equal(http.StatusOK))
+		Expect(send("GET", "/api/v1/managers/lead/subordinates", leadToken, nil).Code).To(Equal(http.StatusOK))
+		// Other permissions are still required
+		Expect(send("GET", "/api/v1/admin/teams", leadToken, nil).Code).To(Equal(http.StatusForbidden))
+		Expect(send("GET", "/api/v1/admin/settings/dimensions", leadToken, nil).Code).To(Equal(http.StatusForbidden))
+
+		setPermissions("level-4", map[string]bool{"canTakeSurvey": true})
+		Expect(send("GET", "/api/v1/admin/users", leadToken, nil).Code).To(Equal(http.StatusForbidden))
+		Expect(send("GET", "/api/v1/managers/lead/subordinates", leadToken, nil).Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should let levels that view all teams see other managers' data", func() {
+		Expect(send("GET", "/api/v1/managers/other_manager/subordinates", tokenFor("manager", "level-3"), nil).Code).To(Equal(http.StatusOK))
+
+		leadToken := tokenFor("lead", "level-4")
+		setPermissions("level-4", map[string]bool{"canTakeSurvey": true, "canViewReports": true})
+		Expect(send("GET", "/api/v1/managers/lead/subordinates", leadToken, nil).Code).To(Equal(http.StatusOK))
+		Expect(send("GET", "/api/v1/managers/manager/subordinates", leadToken, nil).Code).To(Equal(http.StatusForbidden))
+
+		setPermissions("level-4", map[string]bool{"canTakeSurvey": true, "canViewReports": true, "canViewAllTeams": true})
+		Expect(send("GET", "/api/v1/managers/manager/subordinates", leadToken, nil).Code).To(Equal(http.StatusOK))
+	})
+
+	It("should not let user managers hand out or take over access they lack", func() {
+		setPermissions("level-4", map[string]bool{"canTakeSurvey": true, "canManageUsers": true})
+		leadToken := tokenFor("lead", "level-4")
+
+		newUser := func(username, levelID string) map[string]interface{} {
+			return map[string]interface{}{
+				"username": username, "email": username + "@test.com", "fullName": username,
+				"password": "secret123", "hierarchyLevel": levelID,
+			}
+		}
+		Expect(send("POST", "/api/v1/admin/users", leadToken, newUser("sneaky", "level-admin")).Code).To(Equal(http.StatusForbidden))
+		Expect(send("POST", "/api/v1/admin/users", leadToken, newUser("newbie", "level-5")).Code).To(Equal(http.StatusCreated))
+
+		Expect(send("PUT", "/api/v1/admin/users/newbie", leadToken,
+			map[string]interface{}{"hierarchyLevel": "level-3"}).Code).To(Equal(http.StatusForbidden))
+		Expect(send("PUT", "/api/v1/admin/users/newbie", leadToken,
+			map[string]interface{}{"fullName": "New Member"}).Code).To(Equal(http.StatusOK))
+
+		// Accounts at a more privileged level are out of reach
+		Expect(send("PUT", "/api/v1/admin/users/admin", leadToken,
+			map[string]interface{}{"password": "taken-over"}).Code).To(Equal(http.StatusForbidden))
+		Expect(send("DELETE", "/api/v1/admin/users/admin", leadToken, nil).Code).To(Equal(http.StatusForbidden))
+
+		var level string
+		Expect(db.QueryRow(`SELECT hierarchy_level_id FROM users WHERE id = 'newbie'`).Scan(&level)).To(Succeed())
+		Expect(level).To(Equal("level-5"))
+		var sneaky int
+		Expect(db.QueryRow(`SELECT COUNT(*) FROM users WHERE id = 'sneaky'`).Scan(&sneaky)).To(Succeed())
+		Expect(sneaky).To(BeZero())
+
+		// Admins can still assign any level
+		Expect(send("POST", "/api/v1/admin/users", tokenFor("admin", "level-admin"), newUser("sneaky", "level-admin")).Code).To(Equal(http.StatusCreated))
+	})
+
+	It("should return all permissions of a level", func() {
+		w := send("GET", "/api/v1/admin/hierarchy-levels", tokenFor("admin", "level-admin"), nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		var resp struct {
+			Levels []struct {
+				ID          string          `json:"id"`
+				Permissions map[string]bool `json:"permissions"`
+			} `json:"levels"`
+		}
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		for _, level := range resp.Levels {
+			if level.ID == "level-admin" {
+				Expect(level.Permissions).To(HaveKeyWithValue("canConfigureSystem", true))
+				Expect(level.Permissions).To(HaveKeyWithValue("canExportData", true))
+			}
+		}
+	})
+
+	It("should not let admins remove Configure System from their own level", func() {
+		w := send("PUT", "/api/v1/admin/hierarchy-levels/level-admin", tokenFor("admin", "level-admin"),
+			map[string]interface{}{"permissions": map[string]bool{"canManageUsers": true}})
+		Expect(w.Code).To(Equal(http.StatusConflict))
+		Expect(send("GET", "/api/v1/admin/settings/dimensions", tokenFor("admin", "level-admin"), nil).Code).To(Equal(http.StatusOK))
+	})
+
+	It("should deny levels that do not exist", func() {
+		Expect(send("GET", "/api/v1/admin/users", tokenFor("ghost", "level-deleted"), nil).Code).To(Equal(http.StatusForbidden))
+	})
+})
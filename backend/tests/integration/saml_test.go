@@ -0,0 +1,306 @@
+package integration_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/api/middleware"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: SAML 2.0 Service Provider", func() {
+	const (
+		baseURL     = "https://teams360.example.com"
+		acsURL      = baseURL + "/api/v1/auth/saml/acs"
+		spEntityID  = baseURL + "/api/v1/auth/saml/metadata"
+		callbackURL = "https://app.example.com/auth/saml/callback"
+		state       = "browser-state-123"
+	)
+
+	var (
+		db      *sql.DB
+		cleanup func()
+		router  *gin.Engine
+		idp     *testhelpers.MockSAMLIdP
+	)
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-saml-tests")
+		idp = testhelpers.NewMockSAMLIdP("https://idp.example.com/metadata")
+		os.Setenv("SAML_IDP_SSO_URL", idp.SSOURL)
+		os.Setenv("SAML_IDP_ENTITY_ID", idp.EntityID)
+		os.Setenv("SAML_IDP_CERTIFICATE", idp.CertificatePEM())
+		os.Setenv("SAML_BASE_URL", baseURL)
+		os.Setenv("SAML_CALLBACK_URL", callbackURL)
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash, auth_type) VALUES
+			('saml_user', 'saml_user', 'saml.user@example.com', 'SAML User', 'level-4', '', 'sso'),
+			('saml_local', 'saml_local', 'saml.local@example.com', 'SAML Local', 'level-5', 'unused', 'local');
+			INSERT INTO teams (id, name) VALUES ('saml-team', 'SAML Team');
+			INSERT INTO team_members (team_id, user_id) VALUES ('saml-team', 'saml_user');
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	// The SAML configuration is read from the environment when routes are set up
+	JustBeforeEach(func() {
+		router = gin.New()
+		router.Use(middleware.ContentTypeValidator())
+		v1.SetupSSORoutes(router, postgres.NewUserRepository(db), postgres.NewTeamRepository(db), services.NewJWTService(),
+			postgres.NewOrganizationRepository(db), postgres.NewSAMLLoginRepository(db))
+	})
+
+	AfterEach(func() {
+		for _, key := range []string{"JWT_SECRET", "SAML_IDP_SSO_URL", "SAML_IDP_ENTITY_ID", "SAML_IDP_CERTIFICATE",
+			"SAML_BASE_URL", "SAML_CALLBACK_URL", "SAML_EMAIL_ATTRIBUTE", "SSO_JIT_PROVISIONING"} {
+			os.Unsetenv(key)
+		}
+		cleanup()
+	})
+
+	// startLogin follows GET /login and returns the ID of the AuthnRequest sent to the IdP
+	startLogin := func() string {
+		req, _ := http.NewRequest("GET", "/api/v1/auth/saml/login?state="+state, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusFound), w.Body.String())
+
+		location := w.Header().Get("Location")
+		Expect(location).To(HavePrefix(idp.SSOURL + "?"))
+		requestID, requestACS, _, err := idp.ParseAuthnRequest(location)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requestACS).To(Equal(acsURL))
+		return requestID
+	}
+
+	responseFor := func(requestID, email string) testhelpers.SAMLResponseOptions {
+		return testhelpers.SAMLResponseOptions{
+			InResponseTo: requestID,
+			Recipient:    acsURL,
+			Audience:     spEntityID,
+			NameID:       email,
+			Attributes:   map[string][]string{"email": {email}},
+		}
+	}
+
+	// postACS posts a Response like the IdP's HTML form and returns the redirect's query
+	postACS := func(samlResponse string) url.Values {
+		form := url.Values{"SAMLResponse": {samlResponse}}
+		req, _ := http.NewRequest("POST", "/api/v1/auth/saml/acs", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusSeeOther), w.Body.String())
+
+		location, err := url.Parse(w.Header().Get("Location"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(location.Scheme + "://" + location.Host + location.Path).To(Equal(callbackURL))
+		return location.Query()
+	}
+
+	redeem := func(code, redeemState string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		body, _ := json.Marshal(map[string]string{"code": code, "state": redeemState})
+		req, _ := http.NewRequest("POST", "/api/v1/auth/saml/token", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var resp map[string]interface{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		return w, resp
+	}
+
+	tamper := func(samlResponse, old, new string) string {
+		raw, err := base64.StdEncoding.DecodeString(samlResponse)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(raw)).To(ContainSubstring(old))
+		return base64.StdEncoding.EncodeToString([]byte(strings.Replace(string(raw), old, new, 1)))
+	}
+
+	It("serves SP metadata with the ACS endpoint", func() {
+		req, _ := http.NewRequest("GET", "/api/v1/auth/saml/metadata", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(ContainSubstring("application/samlmetadata+xml"))
+		Expect(w.Body.String()).To(ContainSubstring(`entityID="` + spEntityID + `"`))
+		Expect(w.Body.String()).To(ContainSubstring(`Location="` + acsURL + `"`))
+		Expect(w.Body.String()).To(ContainSubstring(`WantAssertionsSigned="true"`))
+	})
+
+	It("signs the user in with a signed assertion and issues the same token pair as a password login", func() {
+		query := postACS(idp.Response(responseFor(startLogin(), "saml.user@example.com")))
+		Expect(query.Get("error")).To(BeEmpty())
+		Expect(query.Get("code")).NotTo(BeEmpty())
+
+		w, resp := redeem(query.Get("code"), state)
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		Expect(resp["accessToken"]).NotTo(BeEmpty())
+		Expect(resp["refreshToken"]).NotTo(BeEmpty())
+		u := resp["user"].(map[string]interface{})
+		Expect(u["id"]).To(Equal("saml_user"))
+		Expect(u["hierarchyLevel"]).To(Equal("level-4"))
+		Expect(u["teamIds"]).To(ConsistOf("saml-team"))
+
+		claims, err := services.NewJWTService().ValidateAccessToken(resp["accessToken"].(string))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims.UserID).To(Equal("saml_user"))
+	})
+
+	It("accepts a signed Response wrapping an unsigned assertion", func() {
+		opts := responseFor(startLogin(), "saml.user@example.com")
+		opts.SignResponse = true
+		query := postACS(idp.Response(opts))
+		Expect(query.Get("code")).NotTo(BeEmpty())
+	})
+
+	It("redeems a login code once, and only with the state the login started with", func() {
+		code := postACS(idp.Response(responseFor(startLogin(), "saml.user@example.com"))).Get("code")
+
+		w, _ := redeem(code, "another-browser")
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+
+		w, _ = redeem(code, state)
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		w, _ = redeem(code, state)
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("rejects a replayed Response", func() {
+		samlResponse := idp.Response(responseFor(startLogin(), "saml.user@example.com"))
+		Expect(postACS(samlResponse).Get("code")).NotTo(BeEmpty())
+		Expect(postACS(samlResponse).Get("error")).To(Equal("login_expired"))
+	})
+
+	It("rejects unsolicited responses and responses to unknown requests", func() {
+		Expect(postACS(idp.Response(responseFor("_never-sent", "saml.user@example.com"))).Get("error")).To(Equal("login_expired"))
+		Expect(postACS(idp.Response(responseFor("", "saml.user@example.com"))).Get("error")).To(Equal("invalid_response"))
+	})
+
+	DescribeTable("rejects responses that fail validation",
+		func(modify func(*testhelpers.SAMLResponseOptions)) {
+			opts := responseFor(startLogin(), "saml.user@example.com")
+			modify(&opts)
+			query := postACS(idp.Response(opts))
+			Expect(query.Get("error")).To(Equal("invalid_response"))
+			Expect(query.Get("code")).To(BeEmpty())
+		},
+		Entry("unsigned", func(o *testhelpers.SAMLResponseOptions) { o.Unsigned = true }),
+		Entry("for another audience", func(o *testhelpers.SAMLResponseOptions) { o.Audience = "https://other-sp.example.com" }),
+		Entry("for another recipient", func(o *testhelpers.SAMLResponseOptions) { o.Recipient = "https://other-sp.example.com/acs" }),
+		Entry("from another issuer", func(o *testhelpers.SAMLResponseOptions) { o.Issuer = "https://evil.example.com" }),
+		Entry("expired", func(o *testhelpers.SAMLResponseOptions) { o.NotOnOrAfter = time.Now().Add(-10 * time.Minute) }),
+		Entry("with a failed status", func(o *testhelpers.SAMLResponseOptions) {
+			o.Status = "urn:oasis:names:tc:SAML:2.0:status:Responder"
+		}),
+	)
+
+	It("rejects a response signed by an untrusted key", func() {
+		impostor := testhelpers.NewMockSAMLIdP(idp.EntityID)
+		query := postACS(impostor.Response(responseFor(startLogin(), "saml.user@example.com")))
+		Expect(query.Get("error")).To(Equal("invalid_response"))
+	})
+
+	It("rejects an assertion modified after signing", func() {
+		samlResponse := idp.Response(responseFor(startLogin(), "saml.user@example.com"))
+		query := postACS(tamper(samlResponse, "<saml:AttributeValue>saml.user@example.com", "<saml:AttributeValue>saml.local@example.com"))
+		Expect(query.Get("error")).To(Equal("invalid_response"))
+	})
+
+	It("rejects a forged assertion placed next to a signed one", func() {
+		samlResponse := idp.Response(responseFor(startLogin(), "saml.user@example.com"))
+		forged := `<saml:Assertion ID="_forged" Version="2.0"><saml:Issuer>` + idp.EntityID + `</saml:Issuer></saml:Assertion><saml:Assertion `
+		query := postACS(tamper(samlResponse, "<saml:Assertion ", forged))
+		Expect(query.Get("error")).To(Equal("invalid_response"))
+	})
+
+	It("maps the email from the configured attribute", func() {
+		os.Setenv("SAML_EMAIL_ATTRIBUTE", "upn")
+		router = gin.New()
+		v1.SetupSSORoutes(router, postgres.NewUserRepository(db), postgres.NewTeamRepository(db), services.NewJWTService(),
+			postgres.NewOrganizationRepository(db), postgres.NewSAMLLoginRepository(db))
+
+		opts := responseFor(startLogin(), "someone.else@example.com")
+		opts.Attributes = map[string][]string{"upn": {"saml.user@example.com"}}
+		code := postACS(idp.Response(opts)).Get("code")
+
+		w, resp := redeem(code, state)
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		Expect(resp["user"].(map[string]interface{})["id"]).To(Equal("saml_user"))
+	})
+
+	It("falls back to an email-format NameID", func() {
+		opts := responseFor(startLogin(), "saml.user@example.com")
+		opts.Attributes = nil
+		Expect(postACS(idp.Response(opts)).Get("code")).NotTo(BeEmpty())
+	})
+
+	It("reports unknown users and local accounts", func() {
+		Expect(postACS(idp.Response(responseFor(startLogin(), "nobody@example.com"))).Get("error")).To(Equal("account_not_found"))
+		Expect(postACS(idp.Response(responseFor(startLogin(), "saml.local@example.com"))).Get("error")).To(Equal("sso_not_supported"))
+	})
+
+	It("provisions unknown users just in time when enabled", func() {
+		os.Setenv("SSO_JIT_PROVISIONING", "true")
+		router = gin.New()
+		v1.SetupSSORoutes(router, postgres.NewUserRepository(db), postgres.NewTeamRepository(db), services.NewJWTService(),
+			postgres.NewOrganizationRepository(db), postgres.NewSAMLLoginRepository(db))
+
+		opts := responseFor(startLogin(), "new.saml@example.com")
+		opts.Attributes["name"] = []string{"New SAML User"}
+		code := postACS(idp.Response(opts)).Get("code")
+
+		w, resp := redeem(code, state)
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		Expect(resp["user"].(map[string]interface{})["fullName"]).To(Equal("New SAML User"))
+	})
+
+	It("requires a state to start a login", func() {
+		req, _ := http.NewRequest("GET", "/api/v1/auth/saml/login", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("lists SAML in the public config", func() {
+		req, _ := http.NewRequest("GET", "/api/v1/config", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var resp map[string]interface{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		Expect(resp["saml"]).To(HaveKeyWithValue("loginUrl", "/api/v1/auth/saml/login"))
+	})
+
+	Context("when the IdP certificate is missing", func() {
+		BeforeEach(func() {
+			os.Unsetenv("SAML_IDP_CERTIFICATE")
+		})
+
+		It("disables SAML", func() {
+			req, _ := http.NewRequest("GET", "/api/v1/auth/saml/login?state="+state, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+		})
+	})
+})
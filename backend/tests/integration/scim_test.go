@@ -0,0 +1,305 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ = Describe("Integration: SCIM Provisioning", func() {
+	const scimToken = "test-scim-token"
+
+	var (
+		db         *sql.DB
+		cleanup    func()
+		router     *gin.Engine
+		jwtService *services.JWTService
+	)
+
+	scimRequest := func(method, path string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		var reader *bytes.Buffer
+		if body != nil {
+			data, _ := json.Marshal(body)
+			reader = bytes.NewBuffer(data)
+		} else {
+			reader = bytes.NewBuffer(nil)
+		}
+		req, _ := http.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/scim+json")
+		req.Header.Set("Authorization", "Bearer "+scimToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var resp map[string]interface{}
+		if w.Body.Len() > 0 {
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		}
+		return w, resp
+	}
+
+	createUser := func(userName, email string) map[string]interface{} {
+		w, resp := scimRequest("POST", "/scim/v2/Users", map[string]interface{}{
+			"schemas":    []string{"urn:ietf:params:scim:schemas:core:2.0:User"},
+			"userName":   userName,
+			"externalId": "ext-" + userName,
+			"name":       map[string]string{"givenName": "Scim", "familyName": "User"},
+			"emails":     []map[string]interface{}{{"value": email, "type": "work", "primary": true}},
+			"active":     true,
+		})
+		Expect(w.Code).To(Equal(http.StatusCreated), w.Body.String())
+		return resp
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		os.Setenv("SCIM_BEARER_TOKEN", scimToken)
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		jwtService = services.NewJWTServiceWithStore(postgres.NewTokenRepository(db))
+		userRepo := postgres.NewUserRepository(db)
+		teamRepo := postgres.NewTeamRepository(db)
+		orgRepo := postgres.NewOrganizationRepository(db)
+
+		router = gin.New()
+		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService, nil, nil)
+		v1.SetupSCIMRoutes(router, userRepo, teamRepo, jwtService)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("JWT_SECRET")
+		os.Unsetenv("SCIM_BEARER_TOKEN")
+		cleanup()
+	})
+
+	It("rejects requests without the bearer token", func() {
+		req, _ := http.NewRequest("GET", "/scim/v2/Users", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		Expect(w.Header().Get("Content-Type")).To(ContainSubstring("application/scim+json"))
+		Expect(w.Body.String()).To(ContainSubstring("urn:ietf:params:scim:api:messages:2.0:Error"))
+	})
+
+	Describe("Users", func() {
+		It("provisions an SSO user at the default level", func() {
+			created := createUser("jane.doe@example.com", "jane.doe@example.com")
+			id := created["id"].(string)
+			Expect(created["userName"]).To(Equal("jane.doe@example.com"))
+			Expect(created["active"]).To(BeTrue())
+
+			var authType, levelID, fullName, externalID string
+			Expect(db.QueryRow(`SELECT auth_type, hierarchy_level_id, full_name, external_id FROM users WHERE id = $1`, id).
+				Scan(&authType, &levelID, &fullName, &externalID)).To(Succeed())
+			Expect(authType).To(Equal("sso"))
+			Expect(levelID).To(Equal("level-5"))
+			Expect(fullName).To(Equal("Scim User"))
+			Expect(externalID).To(Equal("ext-jane.doe@example.com"))
+		})
+
+		It("rejects a duplicate userName with 409 uniqueness", func() {
+			createUser("dup.user", "dup1@example.com")
+
+			w, resp := scimRequest("POST", "/scim/v2/Users", map[string]interface{}{
+				"userName": "dup.user",
+				"emails":   []map[string]interface{}{{"value": "dup2@example.com"}},
+			})
+			Expect(w.Code).To(Equal(http.StatusConflict))
+			Expect(resp["scimType"]).To(Equal("uniqueness"))
+		})
+
+		It("finds users with a filter", func() {
+			createUser("filter.one", "filter.one@example.com")
+			createUser("filter.two", "filter.two@example.com")
+
+			filter := url.QueryEscape(`userName eq "FILTER.ONE"`)
+			w, resp := scimRequest("GET", "/scim/v2/Users?filter="+filter, nil)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(resp["totalResults"]).To(BeNumerically("==", 1))
+			resources := resp["Resources"].([]interface{})
+			Expect(resources[0].(map[string]interface{})["userName"]).To(Equal("filter.one"))
+
+			filter = url.QueryEscape(`emails[value co "filter."] and active eq true`)
+			_, resp = scimRequest("GET", "/scim/v2/Users?filter="+filter+"&count=1", nil)
+			Expect(resp["totalResults"]).To(BeNumerically("==", 2))
+			Expect(resp["itemsPerPage"]).To(BeNumerically("==", 1))
+		})
+
+		It("returns 404 for an unknown user", func() {
+			w, resp := scimRequest("GET", "/scim/v2/Users/missing", nil)
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+			Expect(resp["status"]).To(Equal("404"))
+		})
+
+		It("updates attributes with PATCH", func() {
+			id := createUser("patch.user", "patch.user@example.com")["id"].(string)
+
+			w, resp := scimRequest("PATCH", "/scim/v2/Users/"+id, map[string]interface{}{
+				"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+				"Operations": []map[string]interface{}{
+					{"op": "replace", "path": `emails[type eq "work"].value`, "value": "patched@example.com"},
+					{"op": "replace", "value": map[string]interface{}{"name.formatted": "Patched Name"}},
+				},
+			})
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			Expect(resp["displayName"]).To(Equal("Patched Name"))
+
+			var email string
+			Expect(db.QueryRow(`SELECT email FROM users WHERE id = $1`, id).Scan(&email)).To(Succeed())
+			Expect(email).To(Equal("patched@example.com"))
+		})
+
+		It("deactivates a leaver and revokes their sessions", func() {
+			_, err := db.Exec(`
+				INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash, auth_type)
+				VALUES ('scim_leaver', 'scim_leaver', 'scim_leaver@test.com', 'Leaver', 'level-5', '', 'sso')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			tokens, err := jwtService.GenerateTokenPair(context.Background(), "scim_leaver", "scim_leaver", "scim_leaver@test.com", "level-5", nil)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = jwtService.VerifyAccessToken(context.Background(), tokens.AccessToken)
+			Expect(err).NotTo(HaveOccurred())
+
+			w, resp := scimRequest("PATCH", "/scim/v2/Users/scim_leaver", map[string]interface{}{
+				"schemas":    []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+				"Operations": []map[string]interface{}{{"op": "Replace", "path": "active", "value": "False"}},
+			})
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			Expect(resp["active"]).To(BeFalse())
+			_, err = jwtService.VerifyAccessToken(context.Background(), tokens.AccessToken)
+			Expect(err).To(MatchError(services.ErrTokenRevoked))
+
+			// The user's history is kept
+			var deactivatedAt sql.NullTime
+			Expect(db.QueryRow(`SELECT deactivated_at FROM users WHERE id = 'scim_leaver'`).Scan(&deactivatedAt)).To(Succeed())
+			Expect(deactivatedAt.Valid).To(BeTrue())
+
+			// Reactivating clears the deactivation
+			w, _ = scimRequest("PATCH", "/scim/v2/Users/scim_leaver", map[string]interface{}{
+				"Operations": []map[string]interface{}{{"op": "replace", "value": map[string]interface{}{"active": true}}},
+			})
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(db.QueryRow(`SELECT deactivated_at FROM users WHERE id = 'scim_leaver'`).Scan(&deactivatedAt)).To(Succeed())
+			Expect(deactivatedAt.Valid).To(BeFalse())
+		})
+
+		It("leaves local password accounts alone", func() {
+			hashedPassword, err := bcrypt.GenerateFromPassword([]byte("testpass"), bcrypt.DefaultCost)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = db.Exec(`
+				INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash)
+				VALUES ('scim_local', 'scim_local', 'scim_local@test.com', 'Local User', 'level-5', $1)
+			`, string(hashedPassword))
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, id := range []string{"scim_local", "admin"} {
+				w, _ := scimRequest("GET", "/scim/v2/Users/"+id, nil)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				w, _ = scimRequest("PATCH", "/scim/v2/Users/"+id, map[string]interface{}{
+					"Operations": []map[string]interface{}{{"op": "replace", "path": `emails[type eq "work"].value`, "value": "attacker@example.com"}},
+				})
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				w, _ = scimRequest("PUT", "/scim/v2/Users/"+id, map[string]interface{}{
+					"userName": id, "active": false,
+					"emails": []map[string]interface{}{{"value": "attacker@example.com", "primary": true}},
+				})
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				w, _ = scimRequest("DELETE", "/scim/v2/Users/"+id, nil)
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			}
+
+			var count int
+			Expect(db.QueryRow(`SELECT COUNT(*) FROM users WHERE id IN ('scim_local', 'admin') AND email NOT LIKE 'attacker%' AND deactivated_at IS NULL`).Scan(&count)).To(Succeed())
+			Expect(count).To(Equal(2))
+
+			_, resp := scimRequest("GET", "/scim/v2/Users", nil)
+			Expect(resp["totalResults"]).To(BeNumerically("==", 0))
+		})
+
+		It("deletes a user", func() {
+			id := createUser("delete.me", "delete.me@example.com")["id"].(string)
+
+			tokens, err := jwtService.GenerateTokenPair(context.Background(), id, "delete.me", "delete.me@example.com", "level-5", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			w, _ := scimRequest("DELETE", "/scim/v2/Users/"+id, nil)
+			Expect(w.Code).To(Equal(http.StatusNoContent))
+			_, err = jwtService.VerifyAccessToken(context.Background(), tokens.AccessToken)
+			Expect(err).To(MatchError(services.ErrTokenRevoked))
+
+			w, _ = scimRequest("GET", "/scim/v2/Users/"+id, nil)
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("Groups", func() {
+		It("maps a group to a team and syncs its members", func() {
+			alice := createUser("group.alice", "group.alice@example.com")["id"].(string)
+			bob := createUser("group.bob", "group.bob@example.com")["id"].(string)
+
+			w, resp := scimRequest("POST", "/scim/v2/Groups", map[string]interface{}{
+				"schemas":     []string{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+				"displayName": "SCIM Platform",
+				"members":     []map[string]string{{"value": alice}},
+			})
+			Expect(w.Code).To(Equal(http.StatusCreated), w.Body.String())
+			groupID := resp["id"].(string)
+			Expect(groupID).To(Equal("scim-platform"))
+
+			var teamName string
+			Expect(db.QueryRow(`SELECT name FROM teams WHERE id = $1`, groupID).Scan(&teamName)).To(Succeed())
+			Expect(teamName).To(Equal("SCIM Platform"))
+
+			w, resp = scimRequest("PATCH", "/scim/v2/Groups/"+groupID, map[string]interface{}{
+				"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+				"Operations": []map[string]interface{}{
+					{"op": "add", "path": "members", "value": []map[string]string{{"value": bob}}},
+					{"op": "remove", "path": `members[value eq "` + alice + `"]`},
+				},
+			})
+			Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+			members := resp["members"].([]interface{})
+			Expect(members).To(HaveLen(1))
+			Expect(members[0].(map[string]interface{})["value"]).To(Equal(bob))
+
+			var count int
+			Expect(db.QueryRow(`SELECT COUNT(*) FROM team_members WHERE team_id = $1 AND user_id = $2`, groupID, alice).Scan(&count)).To(Succeed())
+			Expect(count).To(Equal(0))
+
+			// The user resource lists the group
+			_, userResp := scimRequest("GET", "/scim/v2/Users/"+bob, nil)
+			groups := userResp["groups"].([]interface{})
+			Expect(groups[0].(map[string]interface{})["value"]).To(Equal(groupID))
+
+			filter := url.QueryEscape(`displayName eq "SCIM Platform"`)
+			_, resp = scimRequest("GET", "/scim/v2/Groups?excludedAttributes=members&filter="+filter, nil)
+			Expect(resp["totalResults"]).To(BeNumerically("==", 1))
+			Expect(resp["Resources"].([]interface{})[0]).NotTo(HaveKey("members"))
+		})
+
+		It("rejects members that are not users", func() {
+			w, resp := scimRequest("POST", "/scim/v2/Groups", map[string]interface{}{
+				"displayName": "SCIM Ghosts",
+				"members":     []map[string]string{{"value": "no-such-user"}},
+			})
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp["scimType"]).To(Equal("invalidValue"))
+		})
+	})
+})
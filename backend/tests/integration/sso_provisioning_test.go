@@ -0,0 +1,189 @@
+package integration_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: SSO Just-in-Time Provisioning", func() {
+	var (
+		db       *sql.DB
+		cleanup  func()
+		router   *gin.Engine
+		provider *testhelpers.MockOIDCServer
+	)
+
+	setupRouter := func() {
+		router = gin.New()
+		userRepo := postgres.NewUserRepository(db)
+		v1.SetupSSORoutes(router, userRepo, postgres.NewTeamRepository(db), services.NewJWTService(), postgres.NewOrganizationRepository(db), postgres.NewSAMLLoginRepository(db))
+	}
+
+	loginWithClaims := func(claims map[string]interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		provider.SetIDTokenClaims(claims)
+
+		body, _ := json.Marshal(map[string]string{"code": "valid-code", "code_verifier": "valid-verifier"})
+		req, _ := http.NewRequest("POST", "/api/v1/auth/sso/callback", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var resp map[string]interface{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		return w, resp
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		provider = testhelpers.NewMockOIDCServer("test-client")
+		os.Setenv("OAUTH_CLIENT_ID", "test-client")
+		os.Setenv("OAUTH_ISSUER", provider.Issuer())
+		os.Setenv("OAUTH_REDIRECT_URI", "http://localhost:3000/auth/callback")
+		os.Setenv("SSO_JIT_PROVISIONING", "true")
+		os.Setenv("SSO_LEVEL_CLAIM", "title")
+		os.Setenv("SSO_LEVEL_MAP", "Engineering Manager=level-3;Director=level-2")
+		os.Setenv("SSO_MANAGER_EMAIL_CLAIM", "manager_email")
+		os.Setenv("SSO_GROUP_TEAM_MAP", "eng-platform=jit-platform;eng-mobile=jit-mobile")
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash, auth_type) VALUES
+			('jit_director', 'jit_director', 'director@example.com', 'JIT Director', 'level-2', '', 'sso'),
+			('jit_local', 'jit_local', 'local@example.com', 'JIT Local', 'level-5', 'unused', 'local');
+			INSERT INTO teams (id, name) VALUES
+			('jit-platform', 'JIT Platform'), ('jit-mobile', 'JIT Mobile'), ('jit-unmanaged', 'JIT Unmanaged');
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		setupRouter()
+	})
+
+	AfterEach(func() {
+		provider.Close()
+		for _, key := range []string{"JWT_SECRET", "OAUTH_CLIENT_ID", "OAUTH_ISSUER", "OAUTH_REDIRECT_URI",
+			"SSO_JIT_PROVISIONING", "SSO_LEVEL_CLAIM", "SSO_LEVEL_MAP", "SSO_MANAGER_EMAIL_CLAIM", "SSO_GROUP_TEAM_MAP"} {
+			os.Unsetenv(key)
+		}
+		cleanup()
+	})
+
+	It("creates the user on their first SSO login from the mapped claims", func() {
+		w, resp := loginWithClaims(map[string]interface{}{
+			"email":              "new.hire@example.com",
+			"preferred_username": "new.hire",
+			"name":               "New Hire",
+			"title":              "Engineering Manager",
+			"manager_email":      "director@example.com",
+			"groups":             []string{"eng-platform", "everyone"},
+		})
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+
+		u := resp["user"].(map[string]interface{})
+		Expect(u["username"]).To(Equal("new.hire"))
+		Expect(u["fullName"]).To(Equal("New Hire"))
+		Expect(u["hierarchyLevel"]).To(Equal("level-3"))
+		Expect(u["teamIds"]).To(ConsistOf("jit-platform"))
+		Expect(resp["accessToken"]).NotTo(BeEmpty())
+
+		var authType string
+		var reportsTo sql.NullString
+		Expect(db.QueryRow(`SELECT auth_type, reports_to FROM users WHERE email = 'new.hire@example.com'`).
+			Scan(&authType, &reportsTo)).To(Succeed())
+		Expect(authType).To(Equal("sso"))
+		Expect(reportsTo.String).To(Equal("jit_director"))
+	})
+
+	It("uses the default level when the level claim does not map", func() {
+		w, resp := loginWithClaims(map[string]interface{}{"email": "plain@example.com", "title": "Intern"})
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+
+		u := resp["user"].(map[string]interface{})
+		Expect(u["username"]).To(Equal("plain@example.com"))
+		Expect(u["hierarchyLevel"]).To(Equal("level-5"))
+	})
+
+	It("never takes a raw level ID from the claim when a level map is set", func() {
+		w, resp := loginWithClaims(map[string]interface{}{"email": "sneaky@example.com", "title": "level-admin"})
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		Expect(resp["user"].(map[string]interface{})["hierarchyLevel"]).To(Equal("level-5"))
+	})
+
+	It("accepts only unprivileged level IDs from the claim without a level map", func() {
+		os.Unsetenv("SSO_LEVEL_MAP")
+		setupRouter()
+
+		w, resp := loginWithClaims(map[string]interface{}{"email": "raw.admin@example.com", "title": "level-admin"})
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		Expect(resp["user"].(map[string]interface{})["hierarchyLevel"]).To(Equal("level-5"))
+
+		w, resp = loginWithClaims(map[string]interface{}{"email": "raw.lead@example.com", "title": "level-4"})
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		Expect(resp["user"].(map[string]interface{})["hierarchyLevel"]).To(Equal("level-4"))
+	})
+
+	It("refreshes an existing SSO user's attributes on every login", func() {
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash, auth_type)
+			VALUES ('jit_existing', 'jit_existing', 'existing@example.com', 'Old Name', 'level-5', '', 'sso');
+			INSERT INTO team_members (team_id, user_id) VALUES ('jit-platform', 'jit_existing'), ('jit-unmanaged', 'jit_existing');
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		w, resp := loginWithClaims(map[string]interface{}{
+			"email":         "existing@example.com",
+			"name":          "New Name",
+			"title":         "Director",
+			"manager_email": "director@example.com",
+			"groups":        []string{"eng-mobile"},
+		})
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+
+		u := resp["user"].(map[string]interface{})
+		Expect(u["id"]).To(Equal("jit_existing"))
+		Expect(u["username"]).To(Equal("jit_existing"))
+		Expect(u["fullName"]).To(Equal("New Name"))
+		Expect(u["hierarchyLevel"]).To(Equal("level-2"))
+		// Mapped teams follow the groups; other memberships are kept
+		Expect(u["teamIds"]).To(ConsistOf("jit-mobile", "jit-unmanaged"))
+
+		var reportsTo sql.NullString
+		Expect(db.QueryRow(`SELECT reports_to FROM users WHERE id = 'jit_existing'`).Scan(&reportsTo)).To(Succeed())
+		Expect(reportsTo.String).To(Equal("jit_director"))
+	})
+
+	It("does not provision or update local users", func() {
+		w, resp := loginWithClaims(map[string]interface{}{"email": "local@example.com", "name": "Changed"})
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		Expect(resp["error"]).To(ContainSubstring("does not support SSO"))
+
+		var name string
+		Expect(db.QueryRow(`SELECT full_name FROM users WHERE id = 'jit_local'`).Scan(&name)).To(Succeed())
+		Expect(name).To(Equal("JIT Local"))
+	})
+
+	It("rejects unknown users when provisioning is off", func() {
+		os.Unsetenv("SSO_JIT_PROVISIONING")
+		setupRouter()
+
+		w, resp := loginWithClaims(map[string]interface{}{"email": "stranger@example.com"})
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		Expect(resp["error"]).To(ContainSubstring("No account found"))
+
+		var count int
+		Expect(db.QueryRow(`SELECT COUNT(*) FROM users WHERE email = 'stranger@example.com'`).Scan(&count)).To(Succeed())
+		Expect(count).To(Equal(0))
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"time"
 
 	"github.com/agopalakrishnan/teams360/backend/application/services"
 	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
@@ -20,7 +21,7 @@ import (
 )
 
 // makeTestIDToken returns a minimal unsigned JWT containing the given email.
-// The SSO handler uses ParseUnverified so no real signature is needed in tests.
+// The SSO handler verifies signatures, so this token must be rejected.
 func makeTestIDToken(email string) string {
 	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
 	claimsJSON, _ := json.Marshal(map[string]interface{}{
@@ -31,24 +32,9 @@ func makeTestIDToken(email string) string {
 	return header + "." + payload + ".fakesig"
 }
 
-// startFakeTokenServer spins up an httptest server that mimics an OAuth token endpoint.
-func startFakeTokenServer(idToken string, statusCode int) *httptest.Server {
-	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(statusCode)
-		if statusCode == http.StatusOK {
-			json.NewEncoder(w).Encode(map[string]string{ //nolint:errcheck
-				"access_token": "fake-access-token",
-				"id_token":     idToken,
-				"token_type":   "Bearer",
-			})
-		} else {
-			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"}) //nolint:errcheck
-		}
-	}))
-}
-
 var _ = Describe("SSO Authentication Integration Tests", func() {
+	const clientID = "test-client-id"
+
 	var (
 		router  *gin.Engine
 		db      *sql.DB
@@ -59,20 +45,24 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 		os.Setenv("JWT_SECRET", "test-secret-key-for-sso-tests")
 		db, cleanup = testhelpers.SetupTestDatabase()
 		gin.SetMode(gin.TestMode)
+	})
+
+	// Providers are read from the environment when routes are set up, after each context's BeforeEach
+	JustBeforeEach(func() {
 		router = gin.New()
 		userRepo := postgres.NewUserRepository(db)
 		orgRepo := postgres.NewOrganizationRepository(db)
 		jwtService := services.NewJWTService()
-		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService)
-		v1.SetupSSORoutes(router, userRepo, jwtService, orgRepo)
+		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService, nil, nil)
+		v1.SetupSSORoutes(router, userRepo, postgres.NewTeamRepository(db), jwtService, orgRepo, postgres.NewSAMLLoginRepository(db))
 	})
 
 	AfterEach(func() {
 		cleanup()
-		os.Unsetenv("JWT_SECRET")
-		os.Unsetenv("OAUTH_CLIENT_ID")
-		os.Unsetenv("OAUTH_TOKEN_URL")
-		os.Unsetenv("OAUTH_REDIRECT_URI")
+		for _, key := range []string{"JWT_SECRET", "OAUTH_CLIENT_ID", "OAUTH_ISSUER", "OAUTH_TOKEN_URL", "OAUTH_REDIRECT_URI",
+			"OIDC_PROVIDERS", "OIDC_SECOND_ISSUER", "OIDC_SECOND_CLIENT_ID", "OIDC_SECOND_NAME"} {
+			os.Unsetenv(key)
+		}
 	})
 
 	// ── helpers ───────────────────────────────────────────────────────────────
@@ -97,8 +87,8 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 		return w
 	}
 
-	postSSOCallback := func(code, codeVerifier string) *httptest.ResponseRecorder {
-		body, _ := json.Marshal(map[string]string{"code": code, "code_verifier": codeVerifier})
+	postSSOCallbackWith := func(fields map[string]string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(fields)
 		req, _ := http.NewRequest("POST", "/api/v1/auth/sso/callback", bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
@@ -106,6 +96,16 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 		return w
 	}
 
+	postSSOCallback := func(code, codeVerifier string) *httptest.ResponseRecorder {
+		return postSSOCallbackWith(map[string]string{"code": code, "code_verifier": codeVerifier})
+	}
+
+	errorOf := func(w *httptest.ResponseRecorder) interface{} {
+		var resp map[string]interface{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		return resp["error"]
+	}
+
 	// ── 1. Username/password works when SSO vars are absent ──────────────────
 
 	Describe("POST /api/v1/auth/login — username/password login", func() {
@@ -126,9 +126,7 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 				w := postPasswordLogin("pwuser2", "wrongpassword")
 
 				Expect(w.Code).To(Equal(http.StatusUnauthorized))
-				var resp map[string]interface{}
-				Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
-				Expect(resp["error"]).To(Equal("Invalid username or password"))
+				Expect(errorOf(w)).To(Equal("Invalid username or password"))
 			})
 		})
 
@@ -136,8 +134,8 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 
 		Context("when SSO environment variables are present", func() {
 			BeforeEach(func() {
-				os.Setenv("OAUTH_CLIENT_ID", "test-client-id")
-				os.Setenv("OAUTH_TOKEN_URL", "http://fake-provider/token")
+				os.Setenv("OAUTH_CLIENT_ID", clientID)
+				os.Setenv("OAUTH_ISSUER", "http://fake-provider")
 				os.Setenv("OAUTH_REDIRECT_URI", "http://localhost:3000/auth/callback")
 			})
 
@@ -168,63 +166,66 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 				w := postSSOCallback("some-code", "some-verifier")
 
 				Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
-				var resp map[string]interface{}
-				Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
-				Expect(resp["error"]).To(ContainSubstring("not configured"))
+				Expect(errorOf(w)).To(ContainSubstring("not configured"))
+			})
+		})
+
+		Context("when a provider has no issuer to verify its tokens against", func() {
+			BeforeEach(func() {
+				os.Setenv("OAUTH_CLIENT_ID", clientID)
+				os.Setenv("OAUTH_TOKEN_URL", "http://fake-provider/token")
+			})
+
+			It("should treat SSO as not configured", func() {
+				w := postSSOCallback("some-code", "some-verifier")
+
+				Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
 			})
 		})
 
 		Context("when SSO is configured", func() {
+			var provider *testhelpers.MockOIDCServer
+
 			BeforeEach(func() {
-				os.Setenv("OAUTH_CLIENT_ID", "test-client-id")
+				provider = testhelpers.NewMockOIDCServer(clientID)
+				os.Setenv("OAUTH_CLIENT_ID", clientID)
+				os.Setenv("OAUTH_ISSUER", provider.Issuer())
 				os.Setenv("OAUTH_REDIRECT_URI", "http://localhost:3000/auth/callback")
 			})
 
-			Context("when required fields are missing from the request", func() {
-				BeforeEach(func() {
-					os.Setenv("OAUTH_TOKEN_URL", "http://fake-provider/token")
-				})
+			AfterEach(func() {
+				provider.Close()
+			})
 
+			Context("when required fields are missing from the request", func() {
 				It("should return 400 when code is missing", func() {
-					body, _ := json.Marshal(map[string]string{"code_verifier": "some-verifier"})
-					req, _ := http.NewRequest("POST", "/api/v1/auth/sso/callback", bytes.NewBuffer(body))
-					req.Header.Set("Content-Type", "application/json")
-					w := httptest.NewRecorder()
-					router.ServeHTTP(w, req)
-
+					w := postSSOCallbackWith(map[string]string{"code_verifier": "some-verifier"})
 					Expect(w.Code).To(Equal(http.StatusBadRequest))
 				})
 
 				It("should return 400 when code_verifier is missing", func() {
-					body, _ := json.Marshal(map[string]string{"code": "some-code"})
-					req, _ := http.NewRequest("POST", "/api/v1/auth/sso/callback", bytes.NewBuffer(body))
-					req.Header.Set("Content-Type", "application/json")
-					w := httptest.NewRecorder()
-					router.ServeHTTP(w, req)
-
+					w := postSSOCallbackWith(map[string]string{"code": "some-code"})
 					Expect(w.Code).To(Equal(http.StatusBadRequest))
 				})
 			})
 
 			Context("when the OAuth provider rejects the code", func() {
 				It("should return 401", func() {
-					fakeProvider := startFakeTokenServer("", http.StatusBadRequest)
-					defer fakeProvider.Close()
-					os.Setenv("OAUTH_TOKEN_URL", fakeProvider.URL)
+					provider.SetTokenStatus(http.StatusBadRequest)
 
 					w := postSSOCallback("bad-code", "some-verifier")
 
 					Expect(w.Code).To(Equal(http.StatusUnauthorized))
-					var resp map[string]interface{}
-					Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
-					Expect(resp["error"]).To(ContainSubstring("Token exchange"))
+					Expect(errorOf(w)).To(ContainSubstring("Token exchange"))
 				})
 			})
 
 			Context("when the OAuth provider is unreachable", func() {
-				It("should return 401", func() {
+				BeforeEach(func() {
 					os.Setenv("OAUTH_TOKEN_URL", "http://127.0.0.1:19999/nonexistent")
+				})
 
+				It("should return 401", func() {
 					w := postSSOCallback("some-code", "some-verifier")
 
 					Expect(w.Code).To(Equal(http.StatusUnauthorized))
@@ -233,23 +234,79 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 
 			Context("when the provider token has no email claim", func() {
 				It("should return 401", func() {
-					noEmailToken := func() string {
-						header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
-						claims, _ := json.Marshal(map[string]interface{}{"sub": "user-with-no-email"})
-						payload := base64.RawURLEncoding.EncodeToString(claims)
-						return header + "." + payload + ".fakesig"
-					}()
+					provider.SetIDTokenClaims(map[string]interface{}{"sub": "user-with-no-email"})
 
-					fakeProvider := startFakeTokenServer(noEmailToken, http.StatusOK)
-					defer fakeProvider.Close()
-					os.Setenv("OAUTH_TOKEN_URL", fakeProvider.URL)
+					w := postSSOCallback("some-code", "some-verifier")
+
+					Expect(w.Code).To(Equal(http.StatusUnauthorized))
+					Expect(errorOf(w)).To(ContainSubstring("email"))
+				})
+			})
+
+			// ── 3. ID token verification ──────────────────────────────────────
 
+			Context("when the ID token cannot be verified", func() {
+				BeforeEach(func() {
+					_, err := db.Exec(`
+						INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash, auth_type)
+						VALUES ('sso-user-1', 'ssouser', 'sso@example.com', 'SSO User', 'level-3', 'unused', 'sso')
+					`)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				expectRejected := func() {
 					w := postSSOCallback("some-code", "some-verifier")
+					Expect(w.Code).To(Equal(http.StatusUnauthorized))
+					Expect(errorOf(w)).To(ContainSubstring("could not be verified"))
+				}
 
+				It("should reject an unsigned token", func() {
+					provider.SetIDToken(makeTestIDToken("sso@example.com"))
+					expectRejected()
+				})
+
+				It("should reject a token signed by another provider's key", func() {
+					other := testhelpers.NewMockOIDCServer(clientID)
+					defer other.Close()
+					provider.SetIDToken(other.SignIDToken(map[string]interface{}{"iss": provider.Issuer(), "email": "sso@example.com"}))
+					expectRejected()
+				})
+
+				It("should reject a token for another client", func() {
+					provider.SetIDTokenClaims(map[string]interface{}{"aud": "another-client", "email": "sso@example.com"})
+					expectRejected()
+				})
+
+				It("should reject a token from another issuer", func() {
+					provider.SetIDTokenClaims(map[string]interface{}{"iss": "https://evil.example.com", "email": "sso@example.com"})
+					expectRejected()
+				})
+
+				It("should reject an expired token", func() {
+					provider.SetIDTokenClaims(map[string]interface{}{
+						"email": "sso@example.com",
+						"iat":   time.Now().Add(-2 * time.Hour).Unix(),
+						"exp":   time.Now().Add(-time.Hour).Unix(),
+					})
+					expectRejected()
+				})
+
+				It("should reject a token whose nonce does not match the login", func() {
+					provider.SetIDTokenClaims(map[string]interface{}{"email": "sso@example.com", "nonce": "expected-nonce"})
+
+					w := postSSOCallbackWith(map[string]string{"code": "c", "code_verifier": "v", "nonce": "other-nonce"})
 					Expect(w.Code).To(Equal(http.StatusUnauthorized))
-					var resp map[string]interface{}
-					Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
-					Expect(resp["error"]).To(ContainSubstring("email"))
+
+					w = postSSOCallbackWith(map[string]string{"code": "c", "code_verifier": "v", "nonce": "expected-nonce"})
+					Expect(w.Code).To(Equal(http.StatusOK))
+				})
+
+				It("should reject an email the provider has not verified", func() {
+					provider.SetIDTokenClaims(map[string]interface{}{"email": "sso@example.com", "email_verified": false})
+
+					w := postSSOCallback("some-code", "some-verifier")
+					Expect(w.Code).To(Equal(http.StatusUnauthorized))
+					Expect(errorOf(w)).To(ContainSubstring("not been verified"))
 				})
 			})
 
@@ -257,21 +314,17 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 
 			Context("when the email from the token does not match any user in the DB", func() {
 				It("should return 401 with a clear message directing the user to their administrator", func() {
-					fakeProvider := startFakeTokenServer(makeTestIDToken("ghost@external.com"), http.StatusOK)
-					defer fakeProvider.Close()
-					os.Setenv("OAUTH_TOKEN_URL", fakeProvider.URL)
+					provider.SetIDTokenClaims(map[string]interface{}{"email": "ghost@external.com"})
 
 					w := postSSOCallback("some-code", "some-verifier")
 
 					Expect(w.Code).To(Equal(http.StatusUnauthorized))
-					var resp map[string]interface{}
-					Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
-					Expect(resp["error"]).To(ContainSubstring("No account found"))
-					Expect(resp["error"]).To(ContainSubstring("administrator"))
+					Expect(errorOf(w)).To(ContainSubstring("No account found"))
+					Expect(errorOf(w)).To(ContainSubstring("administrator"))
 				})
 			})
 
-			// ── 3. Email matches a user — login successful ────────────────────
+			// ── 5. Email matches a user — login successful ────────────────────
 
 			Context("when the email from the token matches a user in the DB", func() {
 				BeforeEach(func() {
@@ -280,13 +333,10 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 						VALUES ('sso-user-1', 'ssouser', 'sso@example.com', 'SSO User', 'level-3', 'unused', 'sso')
 					`)
 					Expect(err).NotTo(HaveOccurred())
+					provider.SetIDTokenClaims(map[string]interface{}{"email": "sso@example.com"})
 				})
 
 				It("should return 200 with JWT tokens and correct user data", func() {
-					fakeProvider := startFakeTokenServer(makeTestIDToken("sso@example.com"), http.StatusOK)
-					defer fakeProvider.Close()
-					os.Setenv("OAUTH_TOKEN_URL", fakeProvider.URL)
-
 					w := postSSOCallback("valid-code", "valid-verifier")
 
 					Expect(w.Code).To(Equal(http.StatusOK))
@@ -303,6 +353,13 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 					Expect(resp["accessToken"]).NotTo(BeEmpty())
 					Expect(resp["refreshToken"]).NotTo(BeEmpty())
 					Expect(resp["expiresIn"]).To(BeNumerically(">", 0))
+
+					// The code and PKCE verifier were exchanged at the discovered token endpoint
+					requests := provider.TokenRequests()
+					Expect(requests).To(HaveLen(1))
+					Expect(requests[0].Get("code")).To(Equal("valid-code"))
+					Expect(requests[0].Get("code_verifier")).To(Equal("valid-verifier"))
+					Expect(requests[0].Get("client_id")).To(Equal(clientID))
 				})
 
 				It("should include the user's team memberships in the response", func() {
@@ -311,10 +368,6 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 					_, err = db.Exec(`INSERT INTO team_members (team_id, user_id) VALUES ('sso-team-1', 'sso-user-1')`)
 					Expect(err).NotTo(HaveOccurred())
 
-					fakeProvider := startFakeTokenServer(makeTestIDToken("sso@example.com"), http.StatusOK)
-					defer fakeProvider.Close()
-					os.Setenv("OAUTH_TOKEN_URL", fakeProvider.URL)
-
 					w := postSSOCallback("valid-code", "valid-verifier")
 
 					Expect(w.Code).To(Equal(http.StatusOK))
@@ -325,10 +378,6 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 				})
 
 				It("should produce a valid access token with correct claims", func() {
-					fakeProvider := startFakeTokenServer(makeTestIDToken("sso@example.com"), http.StatusOK)
-					defer fakeProvider.Close()
-					os.Setenv("OAUTH_TOKEN_URL", fakeProvider.URL)
-
 					w := postSSOCallback("valid-code", "valid-verifier")
 					Expect(w.Code).To(Equal(http.StatusOK))
 
@@ -342,6 +391,80 @@ var _ = Describe("SSO Authentication Integration Tests", func() {
 					Expect(claims.Email).To(Equal("sso@example.com"))
 					Expect(claims.HierarchyLevel).To(Equal("level-3"))
 				})
+
+				It("should cache signing keys and pick up rotated ones", func() {
+					Expect(postSSOCallback("code-1", "verifier").Code).To(Equal(http.StatusOK))
+					Expect(postSSOCallback("code-2", "verifier").Code).To(Equal(http.StatusOK))
+					Expect(provider.JWKSRequests()).To(Equal(1))
+
+					provider.RotateKey("ES256")
+					provider.SetIDTokenClaims(map[string]interface{}{"email": "sso@example.com"})
+
+					Expect(postSSOCallback("code-3", "verifier").Code).To(Equal(http.StatusOK))
+					Expect(provider.JWKSRequests()).To(Equal(2))
+				})
+			})
+
+			// ── 6. Several providers ──────────────────────────────────────────
+
+			Context("when several providers are configured", func() {
+				var second *testhelpers.MockOIDCServer
+
+				BeforeEach(func() {
+					second = testhelpers.NewMockOIDCServer("second-client")
+					os.Setenv("OIDC_PROVIDERS", "second")
+					os.Setenv("OIDC_SECOND_NAME", "Second IdP")
+					os.Setenv("OIDC_SECOND_ISSUER", second.Issuer())
+					os.Setenv("OIDC_SECOND_CLIENT_ID", "second-client")
+
+					_, err := db.Exec(`
+						INSERT INTO users (id, username, email, full_name, hierarchy_level_id, password_hash, auth_type)
+						VALUES ('sso-user-2', 'ssouser2', 'sso2@example.com', 'SSO User 2', 'level-4', '', 'sso')
+					`)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				AfterEach(func() {
+					second.Close()
+				})
+
+				It("should log in through the selected provider", func() {
+					second.SetIDTokenClaims(map[string]interface{}{"email": "sso2@example.com"})
+
+					w := postSSOCallbackWith(map[string]string{"code": "c", "code_verifier": "v", "provider": "second"})
+					Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+					Expect(second.TokenRequests()).To(HaveLen(1))
+					Expect(provider.TokenRequests()).To(BeEmpty())
+				})
+
+				It("should not accept one provider's token at another", func() {
+					// The default provider returns a token signed by the second provider
+					provider.SetIDToken(second.SignIDToken(map[string]interface{}{"email": "sso2@example.com"}))
+
+					w := postSSOCallback("c", "v")
+					Expect(w.Code).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("should reject an unknown provider", func() {
+					w := postSSOCallbackWith(map[string]string{"code": "c", "code_verifier": "v", "provider": "nope"})
+					Expect(w.Code).To(Equal(http.StatusBadRequest))
+				})
+
+				It("should list every provider in the public config", func() {
+					req, _ := http.NewRequest("GET", "/api/v1/config", nil)
+					w := httptest.NewRecorder()
+					router.ServeHTTP(w, req)
+					Expect(w.Code).To(Equal(http.StatusOK))
+
+					var resp map[string]interface{}
+					Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+					providers := resp["ssoProviders"].([]interface{})
+					Expect(providers).To(HaveLen(2))
+					Expect(providers[0].(map[string]interface{})["authorizeUrl"]).To(Equal(provider.Issuer() + "/authorize"))
+					Expect(providers[1].(map[string]interface{})["id"]).To(Equal("second"))
+					Expect(providers[1].(map[string]interface{})["name"]).To(Equal("Second IdP"))
+					Expect(resp["sso"].(map[string]interface{})["clientId"]).To(Equal(clientID))
+				})
 			})
 		})
 	})
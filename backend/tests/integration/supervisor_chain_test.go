@@ -44,7 +44,13 @@ var _ = Describe("Integration: Supervisor Chain API", func() {
 		orgRepo := postgres.NewOrganizationRepository(db)
 		userRepo := postgres.NewUserRepository(db)
 		teamRepo := postgres.NewTeamRepository(db)
-		v1.SetupAdminRoutes(router, orgRepo, userRepo, teamRepo, jwtService)
+		v1.SetupAdminRoutes(router, v1.AdminRouteDeps{
+			OrgRepo:     orgRepo,
+			UserRepo:    userRepo,
+			TeamRepo:    teamRepo,
+			JWTService:  jwtService,
+			Permissions: services.NewPermissionService(orgRepo),
+		})
 
 		// Insert test users needed for supervisor chain tests
 		_, err = db.Exec(`
@@ -0,0 +1,226 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/commands"
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/application/trends"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: Survey Drafts", func() {
+	const period = "2024 - 2nd Half"
+
+	var (
+		db              *sql.DB
+		cleanup         func()
+		router          *gin.Engine
+		healthCheckRepo healthcheck.Repository
+		memberToken     string
+	)
+
+	draftPath := "/api/v1/health-checks/drafts/draft_team/" + url.PathEscape(period)
+
+	doRequest := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+memberToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	countSessions := func() int {
+		var count int
+		Expect(db.QueryRow(`SELECT COUNT(*) FROM health_check_sessions WHERE team_id = 'draft_team'`).Scan(&count)).To(Succeed())
+		return count
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+		healthCheckRepo = postgres.NewHealthCheckRepository(db)
+		orgRepo := postgres.NewOrganizationRepository(db)
+
+		jwtService := services.NewJWTService()
+		tokenPair, err := jwtService.GenerateTokenPair(context.Background(), "draft_member", "draft_member", "draft_member@test.com", "level-5", []string{"draft_team"})
+		Expect(err).NotTo(HaveOccurred())
+		memberToken = tokenPair.AccessToken
+
+		router = gin.New()
+		v1.SetupHealthCheckRoutes(router, healthCheckRepo, orgRepo, postgres.NewSurveyTemplateRepository(db), postgres.NewTeamRepository(db), jwtService, services.NewPermissionService(orgRepo), nil, nil)
+		v1.SetupTeamDashboardRoutes(router, db, trends.NewService(db, orgRepo), jwtService, services.NewPermissionService(orgRepo))
+
+		_, err = db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id)
+			VALUES ('draft_member', 'draft_member', 'draft_member@test.com', 'Draft Member', 'level-5')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.Exec(`INSERT INTO teams (id, name) VALUES ('draft_team', 'Draft Team'), ('draft_other', 'Other Team')`)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.Exec(`INSERT INTO team_members (team_id, user_id) VALUES ('draft_team', 'draft_member')`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("JWT_SECRET")
+		cleanup()
+	})
+
+	It("should save partial answers, resume them and finalize the draft", func() {
+		w := doRequest("PUT", draftPath, map[string]interface{}{
+			"responses": []map[string]interface{}{
+				{"dimensionId": "mission", "score": 3},
+				{"dimensionId": "speed", "trend": "improving", "comment": "half done"},
+			},
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var draft dto.HealthCheckSessionResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &draft)).To(Succeed())
+		Expect(draft.Completed).To(BeFalse())
+
+		// Autosaving again updates the same draft
+		w = doRequest("PUT", draftPath, map[string]interface{}{
+			"responses": []map[string]interface{}{
+				{"dimensionId": "mission", "score": 3, "trend": "stable"},
+				{"dimensionId": "speed", "trend": "improving", "comment": "half done"},
+			},
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(countSessions()).To(Equal(1))
+
+		w = doRequest("GET", draftPath, nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var resumed dto.HealthCheckSessionResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &resumed)).To(Succeed())
+		Expect(resumed.ID).To(Equal(draft.ID))
+		Expect(resumed.Responses).To(HaveLen(2))
+		Expect(resumed.Responses[1].DimensionID).To(Equal("speed"))
+		Expect(resumed.Responses[1].Score).To(Equal(0))
+		Expect(resumed.Responses[1].Comment).To(Equal("half done"))
+
+		// An incomplete draft cannot be finalized
+		w = doRequest("POST", draftPath+"/submit", nil)
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+		w = doRequest("PUT", draftPath, map[string]interface{}{
+			"responses": []map[string]interface{}{
+				{"dimensionId": "mission", "score": 3, "trend": "stable"},
+				{"dimensionId": "speed", "score": 1, "trend": "improving", "comment": "done"},
+			},
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		w = doRequest("POST", draftPath+"/submit", nil)
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var submitted dto.HealthCheckSessionResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &submitted)).To(Succeed())
+		Expect(submitted.ID).To(Equal(draft.ID))
+		Expect(submitted.Completed).To(BeTrue())
+		Expect(countSessions()).To(Equal(1))
+
+		w = doRequest("GET", draftPath, nil)
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should leave drafts out of team reports", func() {
+		w := doRequest("PUT", draftPath, map[string]interface{}{
+			"responses": []map[string]interface{}{{"dimensionId": "mission", "score": 1, "trend": "declining"}},
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var draft dto.HealthCheckSessionResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &draft)).To(Succeed())
+
+		w = doRequest("GET", "/api/v1/health-checks/team/draft_team", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var sessions dto.HealthCheckSessionsResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &sessions)).To(Succeed())
+		Expect(sessions.Sessions).To(BeEmpty())
+
+		w = doRequest("GET", "/api/v1/health-checks/"+draft.ID, nil)
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+
+		w = doRequest("GET", "/api/v1/teams/draft_team/dashboard/response-distribution?assessmentPeriod="+url.QueryEscape(period), nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var distribution dto.ResponseDistribution
+		Expect(json.Unmarshal(w.Body.Bytes(), &distribution)).To(Succeed())
+		Expect(distribution.Distribution).To(BeEmpty())
+
+		status, err := healthCheckRepo.GetTeamSubmissionStatus(context.Background(), "draft_team", period)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.SubmittedMembers).To(Equal(0))
+	})
+
+	It("should replace the draft when the survey is submitted directly", func() {
+		w := doRequest("PUT", draftPath, map[string]interface{}{
+			"responses": []map[string]interface{}{{"dimensionId": "mission", "score": 2}},
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		session, err := commands.NewSubmitHealthCheckHandler(healthCheckRepo, nil).Handle(commands.SubmitHealthCheckCommand{
+			TeamID:           "draft_team",
+			UserID:           "draft_member",
+			Date:             time.Now().Format("2006-01-02"),
+			AssessmentPeriod: period,
+			Completed:        true,
+			Responses: []commands.HealthCheckResponseCommand{
+				{DimensionID: "mission", Score: 2, Trend: "stable"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(session.Completed).To(BeTrue())
+		Expect(countSessions()).To(Equal(1))
+
+		w = doRequest("GET", draftPath, nil)
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should reject invalid drafts and other teams", func() {
+		w := doRequest("PUT", draftPath, map[string]interface{}{
+			"responses": []map[string]interface{}{{"dimensionId": "mission", "score": 5}},
+		})
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+		w = doRequest("PUT", draftPath, map[string]interface{}{
+			"responses": []map[string]interface{}{
+				{"dimensionId": "mission", "score": 2},
+				{"dimensionId": "mission", "score": 3},
+			},
+		})
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+		w = doRequest("PUT", "/api/v1/health-checks/drafts/draft_team/not-a-period", map[string]interface{}{
+			"responses": []map[string]interface{}{},
+		})
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+		w = doRequest("PUT", "/api/v1/health-checks/drafts/draft_other/"+url.PathEscape(period), map[string]interface{}{
+			"responses": []map[string]interface{}{{"dimensionId": "mission", "score": 2}},
+		})
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+
+		w = doRequest("DELETE", draftPath, nil)
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,146 @@
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+)
+
+var _ = Describe("Integration: Survey Reminders", func() {
+	var (
+		db          *sql.DB
+		cleanup     func()
+		mockEmail   *testhelpers.MockEmailService
+		newService  func() *services.ReminderService
+		campaignEnd time.Time
+	)
+
+	BeforeEach(func() {
+		db, cleanup = testhelpers.SetupTestDatabase()
+		mockEmail = testhelpers.NewMockEmailService()
+
+		healthCheckRepo := postgres.NewHealthCheckRepository(db)
+		userRepo := postgres.NewUserRepository(db)
+		orgRepo := postgres.NewOrganizationRepository(db)
+		campaignRepo := postgres.NewCampaignRepository(db)
+		reminderRepo := postgres.NewReminderRepository(db)
+
+		newService = func() *services.ReminderService {
+			return services.NewReminderService(mockEmail, campaignRepo, healthCheckRepo, userRepo, orgRepo, reminderRepo)
+		}
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id) VALUES
+			('rem_done', 'rem_done', 'rem_done@test.com', 'Done Member', 'level-5'),
+			('rem_todo1', 'rem_todo1', 'rem_todo1@test.com', 'Todo One', 'level-5'),
+			('rem_todo2', 'rem_todo2', 'rem_todo2@test.com', 'Todo Two', 'level-5')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`INSERT INTO teams (id, name, cadence) VALUES ('rem_team', 'Reminder Team', 'monthly')`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO team_members (team_id, user_id) VALUES
+			('rem_team', 'rem_done'), ('rem_team', 'rem_todo1'), ('rem_team', 'rem_todo2')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO health_check_sessions (id, team_id, user_id, date, assessment_period, survey_type, completed)
+			VALUES ('rem_sess', 'rem_team', 'rem_done', '2025-05-02', '2025 May', 'individual', true)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		period := campaign.PeriodFor("monthly", time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC))
+		campaignEnd = period.EndDate
+		_, err = campaignRepo.Create(context.Background(), &campaign.Campaign{
+			ID:               "rem_campaign",
+			TeamID:           "rem_team",
+			AssessmentPeriod: period.Label,
+			Cadence:          "monthly",
+			StartDate:        period.StartDate,
+			EndDate:          period.EndDate,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`UPDATE app_settings SET email_notifications = true, reminder_offsets_days = '{7,3,1}' WHERE id = 1`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		cleanup()
+	})
+
+	recipients := func() []string {
+		to := []string{}
+		for _, e := range mockEmail.SentHTMLEmails {
+			to = append(to, e.To)
+		}
+		return to
+	}
+
+	It("should not send anything before the first offset is reached", func() {
+		sent, err := newService().RunAt(context.Background(), campaignEnd.AddDate(0, 0, -10))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(Equal(0))
+		Expect(mockEmail.SentHTMLEmails).To(BeEmpty())
+	})
+
+	It("should remind only members who have not submitted", func() {
+		sent, err := newService().RunAt(context.Background(), campaignEnd.AddDate(0, 0, -3))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(Equal(2))
+		Expect(recipients()).To(ConsistOf("rem_todo1@test.com", "rem_todo2@test.com"))
+		Expect(mockEmail.SentHTMLEmails[0].Subject).To(ContainSubstring("Reminder Team"))
+	})
+
+	It("should not duplicate reminders across runs or service restarts", func() {
+		at := campaignEnd.AddDate(0, 0, -3)
+
+		_, err := newService().RunAt(context.Background(), at)
+		Expect(err).NotTo(HaveOccurred())
+		sent, err := newService().RunAt(context.Background(), at.Add(2*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(Equal(0))
+		Expect(mockEmail.SentHTMLEmails).To(HaveLen(2))
+
+		var count int
+		Expect(db.QueryRow(`SELECT COUNT(*) FROM survey_reminders WHERE campaign_id = 'rem_campaign'`).Scan(&count)).To(Succeed())
+		Expect(count).To(Equal(2))
+	})
+
+	It("should send the next stage and stop once a member submits", func() {
+		_, err := newService().RunAt(context.Background(), campaignEnd.AddDate(0, 0, -3))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO health_check_sessions (id, team_id, user_id, date, assessment_period, survey_type, completed)
+			VALUES ('rem_sess2', 'rem_team', 'rem_todo1', '2025-05-29', '2025 May', 'individual', true)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		mockEmail.SentHTMLEmails = mockEmail.SentHTMLEmails[:0]
+		sent, err := newService().RunAt(context.Background(), campaignEnd.AddDate(0, 0, -1))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(Equal(1))
+		Expect(recipients()).To(ConsistOf("rem_todo2@test.com"))
+	})
+
+	It("should do nothing when email notifications are disabled", func() {
+		_, err := db.Exec(`UPDATE app_settings SET email_notifications = false WHERE id = 1`)
+		Expect(err).NotTo(HaveOccurred())
+
+		sent, err := newService().RunAt(context.Background(), campaignEnd.AddDate(0, 0, -1))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(Equal(0))
+	})
+})
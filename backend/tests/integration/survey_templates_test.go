@@ -0,0 +1,297 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/commands"
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/application/trends"
+	"github.com/agopalakrishnan/teams360/backend/domain/healthcheck"
+	"github.com/agopalakrishnan/teams360/backend/domain/survey"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: Survey Templates", func() {
+	var (
+		db           *sql.DB
+		cleanup      func()
+		router       *gin.Engine
+		adminToken   string
+		jwtService   *services.JWTService
+		templateRepo survey.Repository
+	)
+
+	doRequest := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	submit := func(id string, dimensionIDs ...string) (string, int, error) {
+		cmd := commands.SubmitHealthCheckCommand{
+			ID:               id,
+			TeamID:           "tpl_team",
+			UserID:           "tpl_member",
+			Date:             time.Now().Format("2006-01-02"),
+			AssessmentPeriod: "2024 - 2nd Half",
+			SurveyType:       "individual",
+			Completed:        true,
+		}
+		for _, dimensionID := range dimensionIDs {
+			cmd.Responses = append(cmd.Responses, commands.HealthCheckResponseCommand{
+				DimensionID: dimensionID, Score: 3, Trend: "stable",
+			})
+		}
+		session, err := commands.NewSubmitHealthCheckHandler(postgres.NewHealthCheckRepository(db), templateRepo).Handle(cmd)
+		if err != nil {
+			return "", 0, err
+		}
+		return session.TemplateID, session.TemplateVersion, nil
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+		templateRepo = postgres.NewSurveyTemplateRepository(db)
+
+		jwtService = services.NewJWTService()
+		tokenPair, err := jwtService.GenerateTokenPair(context.Background(), "admin", "admin", "admin@test.com", "level-admin", nil)
+		Expect(err).NotTo(HaveOccurred())
+		adminToken = tokenPair.AccessToken
+
+		router = gin.New()
+		v1.SetupSurveyTemplateRoutes(router, templateRepo, postgres.NewOrganizationRepository(db), jwtService, services.NewPermissionService(postgres.NewOrganizationRepository(db)), nil)
+		v1.SetupTeamDashboardRoutes(router, db, trends.NewService(db, postgres.NewOrganizationRepository(db)), jwtService, services.NewPermissionService(postgres.NewOrganizationRepository(db)))
+		v1.SetupHealthCheckRoutes(router, postgres.NewHealthCheckRepository(db), postgres.NewOrganizationRepository(db), templateRepo, postgres.NewTeamRepository(db), jwtService, services.NewPermissionService(postgres.NewOrganizationRepository(db)), nil, nil)
+
+		_, err = db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id)
+			VALUES ('tpl_member', 'tpl_member', 'tpl_member@test.com', 'Template Member', 'level-5')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.Exec(`INSERT INTO teams (id, name) VALUES ('tpl_team', 'Template Team')`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("JWT_SECRET")
+		cleanup()
+	})
+
+	It("should version templates and record the version on each session", func() {
+		w := doRequest("POST", "/api/v1/admin/survey-templates", dto.CreateSurveyTemplateRequest{
+			Name:         "Platform Teams",
+			DimensionIDs: []string{"mission", "speed"},
+		})
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		var created dto.SurveyTemplateDTO
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		Expect(created.ID).To(Equal("platform-teams"))
+		Expect(created.Version).To(Equal(1))
+
+		_, err := db.Exec(`UPDATE teams SET survey_template_id = 'platform-teams' WHERE id = 'tpl_team'`)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Responses must match the template exactly
+		_, _, err = submit("tpl_missing", "mission")
+		Expect(err).To(MatchError(ContainSubstring("required by survey template")))
+		_, _, err = submit("tpl_extra", "mission", "speed", "fun")
+		Expect(err).To(MatchError(ContainSubstring("not part of survey template")))
+
+		templateID, version, err := submit("tpl_v1", "mission", "speed")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(templateID).To(Equal("platform-teams"))
+		Expect(version).To(Equal(1))
+
+		// Renaming keeps the version; changing dimensions publishes version 2
+		w = doRequest("PUT", "/api/v1/admin/survey-templates/platform-teams", map[string]interface{}{"name": "Platform"})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		Expect(created.Version).To(Equal(1))
+
+		w = doRequest("PUT", "/api/v1/admin/survey-templates/platform-teams", map[string]interface{}{
+			"dimensionIds": []string{"mission", "speed", "fun"},
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		Expect(created.Version).To(Equal(2))
+
+		_, version, err = submit("tpl_v2", "mission", "speed", "fun")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version).To(Equal(2))
+
+		// Version 1 is unchanged
+		w = doRequest("GET", "/api/v1/admin/survey-templates/platform-teams/versions/1", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var v1Resp dto.SurveyTemplateVersionDTO
+		Expect(json.Unmarshal(w.Body.Bytes(), &v1Resp)).To(Succeed())
+		Expect(v1Resp.DimensionIDs).To(Equal([]string{"mission", "speed"}))
+
+		w = doRequest("GET", "/api/v1/admin/survey-templates/platform-teams", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var detail dto.SurveyTemplateDetailResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &detail)).To(Succeed())
+		Expect(detail.Versions).To(HaveLen(2))
+		Expect(detail.TeamCount).To(Equal(1))
+
+		var storedVersion int
+		Expect(db.QueryRow(`SELECT template_version FROM health_check_sessions WHERE id = 'tpl_v1'`).Scan(&storedVersion)).To(Succeed())
+		Expect(storedVersion).To(Equal(1))
+
+		// Templates with recorded sessions cannot be deleted
+		w = doRequest("DELETE", "/api/v1/admin/survey-templates/platform-teams", nil)
+		Expect(w.Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should aggregate each session against the template version it answered", func() {
+		w := doRequest("POST", "/api/v1/admin/survey-templates", dto.CreateSurveyTemplateRequest{
+			Name:         "Platform Teams",
+			DimensionIDs: []string{"mission", "speed"},
+		})
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		_, err := db.Exec(`
+			UPDATE teams SET survey_template_id = 'platform-teams' WHERE id = 'tpl_team';
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id)
+			VALUES ('tpl_manager', 'tpl_manager', 'tpl_manager@test.com', 'Template Manager', 'level-3');
+			INSERT INTO team_supervisors (team_id, user_id, hierarchy_level_id, position)
+			VALUES ('tpl_team', 'tpl_manager', 'level-3', 1);
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		submitScores := func(id, period string, scores map[string]int) {
+			cmd := commands.SubmitHealthCheckCommand{
+				ID:               id,
+				TeamID:           "tpl_team",
+				UserID:           "tpl_member",
+				Date:             time.Now().Format("2006-01-02"),
+				AssessmentPeriod: period,
+				SurveyType:       "individual",
+				Completed:        true,
+			}
+			for dimensionID, score := range scores {
+				cmd.Responses = append(cmd.Responses, commands.HealthCheckResponseCommand{
+					DimensionID: dimensionID, Score: score, Trend: "stable",
+				})
+			}
+			_, err := commands.NewSubmitHealthCheckHandler(postgres.NewHealthCheckRepository(db), templateRepo).Handle(cmd)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		// Version 1 asks about mission and speed; version 2 swaps speed for fun
+		submitScores("tpl_h1", "2024 - 1st Half", map[string]int{"mission": 3, "speed": 1})
+		w = doRequest("PUT", "/api/v1/admin/survey-templates/platform-teams", map[string]interface{}{
+			"dimensionIds": []string{"mission", "fun"},
+		})
+		Expect(w.Code).To(Equal(http.StatusOK))
+		submitScores("tpl_h2", "2024 - 2nd Half", map[string]int{"mission": 1, "fun": 3})
+
+		// Answers outside a session's version (e.g. left over from before templates) are not counted
+		_, err = db.Exec(`
+			INSERT INTO health_check_responses (session_id, dimension_id, score, trend) VALUES
+			('tpl_h1', 'fun', 1, 'stable'),
+			('tpl_h2', 'speed', 1, 'stable')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		score := func(v float64) *float64 { return &v }
+		trendsService := trends.NewService(db, postgres.NewOrganizationRepository(db))
+		teamTrends, err := trendsService.GetTrendsForTeam(context.Background(), "tpl_team", healthcheck.ScoringUnweighted)
+		Expect(err).NotTo(HaveOccurred())
+		managerTrends, err := trendsService.GetTrendsForManager(context.Background(), "tpl_manager", healthcheck.ScoringUnweighted)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, result := range []*trends.TrendResult{teamTrends, managerTrends} {
+			Expect(result.Periods).To(Equal([]string{"2024 - 1st Half", "2024 - 2nd Half"}))
+			Expect(result.Overall).To(Equal([]*float64{score(2), score(2)}))
+
+			byDimension := map[string][]*float64{}
+			for _, dim := range result.Dimensions {
+				byDimension[dim.DimensionID] = dim.Scores
+			}
+			Expect(byDimension).To(HaveLen(3))
+			Expect(byDimension["mission"]).To(Equal([]*float64{score(3), score(1)}))
+			Expect(byDimension["speed"]).To(Equal([]*float64{score(1), nil}))
+			Expect(byDimension["fun"]).To(Equal([]*float64{nil, score(3)}))
+		}
+
+		teams, err := postgres.NewHealthCheckRepository(db).FindTeamHealthByManager(context.Background(), "tpl_manager", "", healthcheck.ScoringUnweighted)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(teams).To(HaveLen(1))
+		Expect(teams[0].OverallHealth).To(Equal(2.0))
+		for _, dim := range teams[0].Dimensions {
+			Expect(dim.ResponseCount).To(Equal(1), dim.DimensionID)
+		}
+
+		w = doRequest("GET", "/api/v1/teams/tpl_team/dashboard/health-summary?scoring=unweighted&assessmentPeriod=2024%20-%201st%20Half", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var summary dto.TeamDashboardHealthSummary
+		Expect(json.Unmarshal(w.Body.Bytes(), &summary)).To(Succeed())
+		Expect(summary.OverallHealth).To(Equal(2.0))
+		Expect(summary.Dimensions).To(HaveLen(2))
+	})
+
+	It("should reject unknown, inactive and duplicate dimensions", func() {
+		_, err := db.Exec(`UPDATE health_dimensions SET is_active = false WHERE id = 'fun'`)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, dims := range [][]string{{"nope"}, {"fun"}, {"mission", "mission"}, {}} {
+			w := doRequest("POST", "/api/v1/admin/survey-templates", dto.CreateSurveyTemplateRequest{
+				Name:         "Bad Template",
+				DimensionIDs: dims,
+			})
+			Expect(w.Code).To(Equal(http.StatusBadRequest), "dimensions %v", dims)
+		}
+	})
+
+	It("should only show a team's survey to its members", func() {
+		surveyFor := func(teamIDs ...string) int {
+			tokenPair, err := jwtService.GenerateTokenPair(context.Background(), "tpl_member", "tpl_member", "tpl_member@test.com", "level-5", teamIDs)
+			Expect(err).NotTo(HaveOccurred())
+			req, _ := http.NewRequest("GET", "/api/v1/teams/tpl_team/survey", nil)
+			req.Header.Set("Authorization", "Bearer "+tokenPair.AccessToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w.Code
+		}
+
+		Expect(surveyFor("tpl_team")).To(Equal(http.StatusOK))
+		Expect(surveyFor("other_team")).To(Equal(http.StatusForbidden))
+	})
+
+	It("should accept all active dimensions for teams without a template", func() {
+		templateID, version, err := submit("tpl_legacy", "mission", "speed", "fun")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(templateID).To(BeEmpty())
+		Expect(version).To(Equal(0))
+
+		w := doRequest("POST", "/api/v1/admin/survey-templates", dto.CreateSurveyTemplateRequest{
+			Name:         "Unused",
+			DimensionIDs: []string{"mission"},
+		})
+		Expect(w.Code).To(Equal(http.StatusCreated))
+
+		w = doRequest("DELETE", "/api/v1/admin/survey-templates/unused", nil)
+		Expect(w.Code).To(Equal(http.StatusOK))
+	})
+})
@@ -48,8 +48,8 @@ var _ = Describe("Integration: Team Results API", func() {
 		teamRepo := postgres.NewTeamRepository(db)
 		orgRepo := postgres.NewOrganizationRepository(db)
 
-		v1.SetupHealthCheckRoutes(router, healthCheckRepo, orgRepo, jwtService, nil)
-		v1.SetupTeamRoutes(router, healthCheckRepo, teamRepo, jwtService)
+		v1.SetupHealthCheckRoutes(router, healthCheckRepo, orgRepo, postgres.NewSurveyTemplateRepository(db), teamRepo, jwtService, services.NewPermissionService(orgRepo), nil, nil)
+		v1.SetupTeamRoutes(router, healthCheckRepo, teamRepo, jwtService, services.NewPermissionService(orgRepo))
 	})
 
 	AfterEach(func() {
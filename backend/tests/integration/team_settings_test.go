@@ -0,0 +1,215 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: Team Settings for Leads", func() {
+	var (
+		db         *sql.DB
+		cleanup    func()
+		router     *gin.Engine
+		jwtService *services.JWTService
+	)
+
+	send := func(method, path, userID, levelID string, payload interface{}) *httptest.ResponseRecorder {
+		tokens, err := jwtService.GenerateTokenPair(context.Background(), userID, userID, userID+"@test.com", levelID, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		body := &bytes.Buffer{}
+		if payload != nil {
+			data, _ := json.Marshal(payload)
+			body = bytes.NewBuffer(data)
+		}
+		req := httptest.NewRequest(method, path, body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	memberIDs := func(w *httptest.ResponseRecorder) []string {
+		var resp dto.TeamSettingsResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		ids := []string{}
+		for _, m := range resp.Members {
+			ids = append(ids, m.UserID)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+		jwtService = services.NewJWTService()
+
+		teamRepo := postgres.NewTeamRepository(db)
+		audit := services.NewAdminAuditService(postgres.NewAdminAuditRepository(db))
+		router = gin.New()
+		v1.SetupTeamSettingsRoutes(router, db, teamRepo, postgres.NewUserRepository(db), jwtService, audit, nil)
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id) VALUES
+			('ts_lead', 'ts_lead', 'ts_lead@test.com', 'Settings Lead', 'level-4'),
+			('ts_member', 'ts_member', 'ts_member@test.com', 'Settings Member', 'level-5'),
+			('ts_new', 'ts_new', 'ts_new@test.com', 'New Member', 'level-5'),
+			('ts_manager', 'ts_manager', 'ts_manager@test.com', 'Settings Manager', 'level-3'),
+			('ts_other_lead', 'ts_other_lead', 'ts_other_lead@test.com', 'Other Lead', 'level-4')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO teams (id, name, team_lead_id, cadence) VALUES
+			('ts_team', 'Settings Team', 'ts_lead', 'quarterly'),
+			('ts_other', 'Other Team', 'ts_other_lead', 'monthly')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO team_members (team_id, user_id) VALUES
+			('ts_team', 'ts_lead'), ('ts_team', 'ts_member'), ('ts_other', 'ts_other_lead')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO team_supervisors (team_id, user_id, hierarchy_level_id, position) VALUES
+			('ts_team', 'ts_manager', 'level-3', 1)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		cleanup()
+	})
+
+	Describe("authorization", func() {
+		It("should allow the team lead and supervisors", func() {
+			Expect(send("GET", "/api/v1/teams/ts_team/settings", "ts_lead", "level-4", nil).Code).To(Equal(http.StatusOK))
+			Expect(send("GET", "/api/v1/teams/ts_team/settings", "ts_manager", "level-3", nil).Code).To(Equal(http.StatusOK))
+		})
+
+		It("should reject members, other leads and admins", func() {
+			Expect(send("GET", "/api/v1/teams/ts_team/settings", "ts_member", "level-5", nil).Code).To(Equal(http.StatusForbidden))
+			Expect(send("PUT", "/api/v1/teams/ts_team/settings", "ts_other_lead", "level-4", map[string]string{"cadence": "monthly"}).Code).To(Equal(http.StatusForbidden))
+			Expect(send("GET", "/api/v1/teams/ts_team/settings", "admin", "level-admin", nil).Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should return 404 for unknown teams", func() {
+			Expect(send("GET", "/api/v1/teams/no_such_team/settings", "ts_lead", "level-4", nil).Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:teamId/settings", func() {
+		It("should update the cadence and distribution list", func() {
+			w := send("PUT", "/api/v1/teams/ts_team/settings", "ts_lead", "level-4",
+				map[string]string{"cadence": "monthly", "distributionListEmail": "team@test.com"})
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var resp dto.TeamSettingsResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Cadence).To(Equal("monthly"))
+			Expect(*resp.DistributionListEmail).To(Equal("team@test.com"))
+
+			w = send("PUT", "/api/v1/teams/ts_team/settings", "ts_lead", "level-4", map[string]string{"distributionListEmail": ""})
+			Expect(w.Code).To(Equal(http.StatusOK))
+			resp = dto.TeamSettingsResponse{}
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp.Cadence).To(Equal("monthly"))
+			Expect(resp.DistributionListEmail).To(BeNil())
+		})
+
+		It("should move the next check date onto a changed cadence", func() {
+			_, err := db.Exec(`UPDATE teams SET next_check_date = '2000-01-01' WHERE id = 'ts_team'`)
+			Expect(err).NotTo(HaveOccurred())
+
+			w := send("PUT", "/api/v1/teams/ts_team/settings", "ts_lead", "level-4", map[string]string{"cadence": "monthly"})
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var next time.Time
+			Expect(db.QueryRow(`SELECT next_check_date FROM teams WHERE id = 'ts_team'`).Scan(&next)).To(Succeed())
+			Expect(next.Format("2006-01-02")).To(Equal(campaign.NextCheckDate("monthly", time.Now()).Format("2006-01-02")))
+
+			// Resending the same cadence leaves the schedule alone
+			_, err = db.Exec(`UPDATE teams SET next_check_date = '2000-01-01' WHERE id = 'ts_team'`)
+			Expect(err).NotTo(HaveOccurred())
+			w = send("PUT", "/api/v1/teams/ts_team/settings", "ts_lead", "level-4", map[string]string{"cadence": "monthly"})
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(db.QueryRow(`SELECT next_check_date FROM teams WHERE id = 'ts_team'`).Scan(&next)).To(Succeed())
+			Expect(next.Format("2006-01-02")).To(Equal("2000-01-01"))
+		})
+
+		It("should reject invalid values", func() {
+			Expect(send("PUT", "/api/v1/teams/ts_team/settings", "ts_lead", "level-4", map[string]string{"cadence": "daily"}).Code).To(Equal(http.StatusBadRequest))
+			Expect(send("PUT", "/api/v1/teams/ts_team/settings", "ts_lead", "level-4", map[string]string{"distributionListEmail": "not-an-email"}).Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should record the change in the audit log", func() {
+			Expect(send("PUT", "/api/v1/teams/ts_team/settings", "ts_lead", "level-4", map[string]string{"cadence": "yearly"}).Code).To(Equal(http.StatusOK))
+
+			var actor, action string
+			Expect(db.QueryRow(`SELECT actor_id, action FROM admin_audit_log WHERE target_id = 'ts_team'`).Scan(&actor, &action)).To(Succeed())
+			Expect(actor).To(Equal("ts_lead"))
+			Expect(action).To(Equal("team.settings.update"))
+		})
+	})
+
+	Describe("roster", func() {
+		It("should add and remove members", func() {
+			Expect(send("POST", "/api/v1/teams/ts_team/settings/members", "ts_lead", "level-4", map[string]string{"userId": "ts_new"}).Code).To(Equal(http.StatusCreated))
+			Expect(memberIDs(send("GET", "/api/v1/teams/ts_team/settings", "ts_lead", "level-4", nil))).To(ContainElement("ts_new"))
+
+			Expect(send("DELETE", "/api/v1/teams/ts_team/settings/members/ts_member", "ts_manager", "level-3", nil).Code).To(Equal(http.StatusOK))
+			Expect(memberIDs(send("GET", "/api/v1/teams/ts_team/settings", "ts_lead", "level-4", nil))).NotTo(ContainElement("ts_member"))
+		})
+
+		It("should not remove the team lead", func() {
+			Expect(send("DELETE", "/api/v1/teams/ts_team/settings/members/ts_lead", "ts_manager", "level-3", nil).Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should return 404 for unknown users and members", func() {
+			Expect(send("POST", "/api/v1/teams/ts_team/settings/members", "ts_lead", "level-4", map[string]string{"userId": "nobody"}).Code).To(Equal(http.StatusNotFound))
+			Expect(send("DELETE", "/api/v1/teams/ts_team/settings/members/ts_new", "ts_lead", "level-4", nil).Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("action items", func() {
+		It("should let a supervisor manage the team's action items", func() {
+			w := send("POST", "/api/v1/teams/ts_team/settings/action-items", "ts_manager", "level-3",
+				map[string]string{"title": "Fix the build", "assignedTo": "ts_member"})
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			var created struct {
+				ID string `json:"id"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+
+			Expect(send("PATCH", "/api/v1/teams/ts_team/settings/action-items/"+created.ID, "ts_lead", "level-4", map[string]string{"status": "done"}).Code).To(Equal(http.StatusOK))
+
+			w = send("GET", "/api/v1/teams/ts_team/settings/action-items", "ts_lead", "level-4", nil)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var items dto.ActionItemsResponse
+			Expect(json.Unmarshal(w.Body.Bytes(), &items)).To(Succeed())
+			Expect(items.ActionItems).To(HaveLen(1))
+			Expect(items.ActionItems[0].Status).To(Equal("done"))
+
+			Expect(send("DELETE", "/api/v1/teams/ts_team/settings/action-items/"+created.ID, "ts_other_lead", "level-4", nil).Code).To(Equal(http.StatusForbidden))
+			Expect(send("DELETE", "/api/v1/teams/ts_team/settings/action-items/"+created.ID, "ts_lead", "level-4", nil).Code).To(Equal(http.StatusOK))
+		})
+	})
+})
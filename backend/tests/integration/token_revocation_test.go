@@ -0,0 +1,192 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/middleware"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integration: Token Revocation", func() {
+	var (
+		db         *sql.DB
+		router     *gin.Engine
+		cleanup    func()
+		jwtService *services.JWTService
+		userTokens *services.TokenPair
+	)
+
+	// callProtected hits a JWT-protected endpoint with the given access token
+	callProtected := func(accessToken string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// refresh exchanges a refresh token and returns the recorder
+	refresh := func(refreshToken string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]string{"refreshToken": refreshToken})
+		req, _ := http.NewRequest("POST", "/api/v1/auth/refresh", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	BeforeEach(func() {
+		os.Setenv("JWT_SECRET", "test-secret-key-for-integration-tests")
+		gin.SetMode(gin.TestMode)
+
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id)
+			VALUES ('revoke_user', 'revoke_user', 'revoke_user@test.com', 'Revoke User', 'level-5')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		jwtService = services.NewJWTServiceWithStore(postgres.NewTokenRepository(db))
+		userTokens, err = jwtService.GenerateTokenPair(context.Background(), "revoke_user", "revoke_user", "revoke_user@test.com", "level-5", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		router = gin.New()
+		orgRepo := postgres.NewOrganizationRepository(db)
+		userRepo := postgres.NewUserRepository(db)
+		teamRepo := postgres.NewTeamRepository(db)
+		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService, nil, nil)
+		v1.SetupAdminRoutes(router, v1.AdminRouteDeps{
+			OrgRepo:     orgRepo,
+			UserRepo:    userRepo,
+			TeamRepo:    teamRepo,
+			JWTService:  jwtService,
+			Permissions: services.NewPermissionService(orgRepo),
+		})
+		router.GET("/protected", middleware.JWTAuthMiddleware(jwtService), func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"ok": true})
+		})
+	})
+
+	AfterEach(func() {
+		cleanup()
+		os.Unsetenv("JWT_SECRET")
+	})
+
+	It("should accept a freshly issued access token", func() {
+		Expect(callProtected(userTokens.AccessToken).Code).To(Equal(http.StatusOK))
+	})
+
+	Describe("POST /api/v1/auth/logout", func() {
+		It("should revoke the access token and refresh token immediately", func() {
+			body, _ := json.Marshal(map[string]string{"refreshToken": userTokens.RefreshToken})
+			req, _ := http.NewRequest("POST", "/api/v1/auth/logout", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+userTokens.AccessToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			w = callProtected(userTokens.AccessToken)
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			var resp map[string]interface{}
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp["error"]).To(Equal("Token has been revoked"))
+
+			Expect(refresh(userTokens.RefreshToken).Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Describe("POST /api/v1/auth/refresh", func() {
+		It("should rotate the refresh token", func() {
+			w := refresh(userTokens.RefreshToken)
+			Expect(w.Code).To(Equal(http.StatusOK))
+
+			var resp map[string]interface{}
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp["accessToken"]).NotTo(BeEmpty())
+			Expect(resp["refreshToken"]).NotTo(BeEmpty())
+			Expect(resp["refreshToken"]).NotTo(Equal(userTokens.RefreshToken))
+
+			Expect(refresh(resp["refreshToken"].(string)).Code).To(Equal(http.StatusOK))
+		})
+
+		It("should revoke the whole token family when a rotated token is reused", func() {
+			w := refresh(userTokens.RefreshToken)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var resp map[string]interface{}
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			rotated := resp["refreshToken"].(string)
+
+			// Replaying the original token is detected as reuse
+			w = refresh(userTokens.RefreshToken)
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+			Expect(resp["error"]).To(Equal("Refresh token has been revoked"))
+
+			// ...and the legitimate rotated token stops working too
+			Expect(refresh(rotated).Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Describe("POST /api/v1/admin/users/:id/revoke-sessions", func() {
+		var adminToken string
+
+		BeforeEach(func() {
+			adminTokens, err := jwtService.GenerateTokenPair(context.Background(), "admin", "admin", "admin@test.com", "level-admin", nil)
+			Expect(err).NotTo(HaveOccurred())
+			adminToken = adminTokens.AccessToken
+		})
+
+		revokeSessions := func(userID string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("POST", "/api/v1/admin/users/"+userID+"/revoke-sessions", nil)
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		It("should invalidate every token issued to the user", func() {
+			Expect(revokeSessions("revoke_user").Code).To(Equal(http.StatusOK))
+
+			Expect(callProtected(userTokens.AccessToken).Code).To(Equal(http.StatusUnauthorized))
+			Expect(refresh(userTokens.RefreshToken).Code).To(Equal(http.StatusUnauthorized))
+
+			// Other users are unaffected
+			Expect(callProtected(adminToken).Code).To(Equal(http.StatusOK))
+		})
+
+		It("should accept tokens issued right after the revocation", func() {
+			Expect(revokeSessions("revoke_user").Code).To(Equal(http.StatusOK))
+
+			// Logging in again within the same second must work
+			fresh, err := jwtService.GenerateTokenPair(context.Background(), "revoke_user", "revoke_user", "revoke_user@test.com", "level-5", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(callProtected(fresh.AccessToken).Code).To(Equal(http.StatusOK))
+			Expect(refresh(fresh.RefreshToken).Code).To(Equal(http.StatusOK))
+
+			Expect(callProtected(userTokens.AccessToken).Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should return 404 for an unknown user", func() {
+			Expect(revokeSessions("no_such_user").Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should require admin privileges", func() {
+			adminToken = userTokens.AccessToken
+			Expect(revokeSessions("revoke_user").Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
@@ -42,8 +42,14 @@ var _ = Describe("Integration: User Admin SSO", func() {
 		orgRepo := postgres.NewOrganizationRepository(db)
 		userRepo := postgres.NewUserRepository(db)
 		teamRepo := postgres.NewTeamRepository(db)
-		v1.SetupAdminRoutes(router, orgRepo, userRepo, teamRepo, jwtService)
-		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService)
+		v1.SetupAdminRoutes(router, v1.AdminRouteDeps{
+			OrgRepo:     orgRepo,
+			UserRepo:    userRepo,
+			TeamRepo:    teamRepo,
+			JWTService:  jwtService,
+			Permissions: services.NewPermissionService(orgRepo),
+		})
+		v1.SetupAuthRoutes(router, userRepo, orgRepo, jwtService, nil, nil)
 	})
 
 	AfterEach(func() {
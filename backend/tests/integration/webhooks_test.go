@@ -0,0 +1,305 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	v1 "github.com/agopalakrishnan/teams360/backend/interfaces/api/v1"
+	"github.com/agopalakrishnan/teams360/backend/interfaces/dto"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// webhookReceiver records the requests sent to a test endpoint
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	location string // sent as the Location header when set
+	body     string
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	if r.location != "" {
+		w.Header().Set("Location", r.location)
+	}
+	w.WriteHeader(r.status)
+	io.WriteString(w, r.body)
+}
+
+var _ = Describe("Integration: Webhooks", func() {
+	var (
+		db         *sql.DB
+		cleanup    func()
+		router     *gin.Engine
+		jwtService *services.JWTService
+		webhooks   *services.WebhookService
+		receiver   *webhookReceiver
+		server     *httptest.Server
+		adminToken string
+	)
+
+	tokenFor := func(userID, levelID string) string {
+		tokens, err := jwtService.GenerateTokenPair(context.Background(), userID, userID, userID+"@test.com", levelID, nil)
+		Expect(err).NotTo(HaveOccurred())
+		return tokens.AccessToken
+	}
+
+	send := func(method, path, token string, payload interface{}) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		if payload != nil {
+			data, _ := json.Marshal(payload)
+			body = bytes.NewBuffer(data)
+		}
+		req := httptest.NewRequest(method, path, body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	register := func(events ...string) dto.CreatedWebhookEndpointResponse {
+		w := send("POST", "/api/v1/admin/webhooks", adminToken,
+			map[string]interface{}{"url": server.URL, "description": "BI pipeline", "events": events})
+		Expect(w.Code).To(Equal(http.StatusCreated), w.Body.String())
+		var created dto.CreatedWebhookEndpointResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		return created
+	}
+
+	createActionItem := func() string {
+		w := send("POST", "/api/v1/teams/wh_team/action-items", tokenFor("wh_member", "level-5"),
+			map[string]interface{}{"title": "Fix the flaky build", "description": "CI is red"})
+		Expect(w.Code).To(Equal(http.StatusCreated), w.Body.String())
+		var created map[string]interface{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+		return created["id"].(string)
+	}
+
+	deliveries := func(endpointID, query string) dto.WebhookDeliveriesResponse {
+		w := send("GET", "/api/v1/admin/webhooks/"+endpointID+"/deliveries"+query, adminToken, nil)
+		Expect(w.Code).To(Equal(http.StatusOK), w.Body.String())
+		var resp dto.WebhookDeliveriesResponse
+		Expect(json.Unmarshal(w.Body.Bytes(), &resp)).To(Succeed())
+		return resp
+	}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		db, cleanup = testhelpers.SetupTestDatabase()
+
+		receiver = &webhookReceiver{status: http.StatusOK}
+		server = httptest.NewServer(receiver)
+
+		orgRepo := postgres.NewOrganizationRepository(db)
+		permissions := services.NewPermissionService(orgRepo)
+		audit := services.NewAdminAuditService(postgres.NewAdminAuditRepository(db))
+		webhooks = services.NewWebhookService(postgres.NewWebhookRepository(db), server.Client())
+		jwtService = services.NewJWTService()
+		adminToken = tokenFor("admin", "level-admin")
+
+		router = gin.New()
+		v1.SetupWebhookRoutes(router, webhooks, jwtService, permissions, audit)
+		v1.SetupActionItemRoutes(router, db, jwtService, permissions, webhooks)
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id) VALUES
+			('wh_member', 'wh_member', 'wh_member@test.com', 'Webhook Member', 'level-5');
+			INSERT INTO teams (id, name, cadence) VALUES ('wh_team', 'Webhook Team', 'quarterly');
+			INSERT INTO team_members (team_id, user_id) VALUES ('wh_team', 'wh_member');
+		`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		cleanup()
+	})
+
+	Describe("endpoint administration", func() {
+		It("should return the signing secret only when the endpoint is created", func() {
+			created := register("action_item.created")
+			Expect(created.Secret).To(HavePrefix("whsec_"))
+			Expect(created.Active).To(BeTrue())
+
+			w := send("GET", "/api/v1/admin/webhooks/"+created.ID, adminToken, nil)
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).NotTo(ContainSubstring(created.Secret))
+
+			var entry string
+			Expect(db.QueryRow(`SELECT COALESCE(after_state::text, '') FROM admin_audit_log WHERE action = 'webhook.create'`).Scan(&entry)).To(Succeed())
+			Expect(entry).NotTo(ContainSubstring(created.Secret))
+		})
+
+		It("should reject unknown events and non-HTTP URLs", func() {
+			Expect(send("POST", "/api/v1/admin/webhooks", adminToken,
+				map[string]interface{}{"url": server.URL, "events": []string{"team.deleted"}}).Code).To(Equal(http.StatusBadRequest))
+			Expect(send("POST", "/api/v1/admin/webhooks", adminToken,
+				map[string]interface{}{"url": "ftp://example.com/hook", "events": []string{"team.updated"}}).Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should require the configure system permission", func() {
+			Expect(send("GET", "/api/v1/admin/webhooks", tokenFor("wh_member", "level-5"), nil).Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("delivery", func() {
+		It("should send signed events to subscribed endpoints only", func() {
+			created := register("action_item.created")
+			register("team.updated")
+			itemID := createActionItem()
+
+			delivered, err := webhooks.RunAt(context.Background(), time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delivered).To(Equal(1))
+			Expect(receiver.requests).To(HaveLen(1))
+
+			req, body := receiver.requests[0], receiver.bodies[0]
+			Expect(req.Header.Get(services.WebhookEventHeader)).To(Equal("action_item.created"))
+			timestamp, err := strconv.ParseInt(req.Header.Get(services.WebhookTimestampHeader), 10, 64)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(req.Header.Get(services.WebhookSignatureHeader)).To(Equal(services.SignWebhook(created.Secret, timestamp, body)))
+
+			var event struct {
+				ID   string                 `json:"id"`
+				Type string                 `json:"type"`
+				Data dto.ActionItemResponse `json:"data"`
+			}
+			Expect(json.Unmarshal(body, &event)).To(Succeed())
+			Expect(event.ID).NotTo(BeEmpty())
+			Expect(event.Type).To(Equal("action_item.created"))
+			Expect(event.Data.ID).To(Equal(itemID))
+			Expect(event.Data.Title).To(Equal("Fix the flaky build"))
+
+			log := deliveries(created.ID, "")
+			Expect(log.Total).To(Equal(1))
+			Expect(log.Deliveries[0].Status).To(Equal("delivered"))
+			Expect(log.Deliveries[0].EventID).To(Equal(event.ID))
+			Expect(req.Header.Get(services.WebhookDeliveryHeader)).To(Equal(log.Deliveries[0].ID))
+
+			// Nothing is left to send
+			delivered, err = webhooks.RunAt(context.Background(), time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delivered).To(BeZero())
+		})
+
+		It("should publish action item updates", func() {
+			created := register("action_item.updated")
+			itemID := createActionItem()
+
+			Expect(send("PATCH", "/api/v1/teams/wh_team/action-items/"+itemID, tokenFor("wh_member", "level-5"),
+				map[string]interface{}{"status": "done"}).Code).To(Equal(http.StatusOK))
+
+			_, err := webhooks.RunAt(context.Background(), time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receiver.bodies).To(HaveLen(1))
+			Expect(string(receiver.bodies[0])).To(ContainSubstring(`"status":"done"`))
+			Expect(deliveries(created.ID, "?status=delivered").Total).To(Equal(1))
+		})
+
+		It("should retry with backoff, fail after the last attempt and retry on request", func() {
+			receiver.status = http.StatusServiceUnavailable
+			created := register("action_item.created")
+			createActionItem()
+
+			at := time.Now()
+			_, err := webhooks.RunAt(context.Background(), at)
+			Expect(err).NotTo(HaveOccurred())
+
+			pending := deliveries(created.ID, "?status=pending")
+			Expect(pending.Total).To(Equal(1))
+			Expect(pending.Deliveries[0].Attempts).To(Equal(1))
+			Expect(*pending.Deliveries[0].ResponseStatus).To(Equal(http.StatusServiceUnavailable))
+			Expect(pending.Deliveries[0].NextAttemptAt.After(at)).To(BeTrue())
+
+			// Not due again until the backoff has passed
+			_, err = webhooks.RunAt(context.Background(), at)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receiver.requests).To(HaveLen(1))
+
+			for i := 0; i < 9; i++ {
+				at = at.Add(2 * time.Hour)
+				_, err = webhooks.RunAt(context.Background(), at)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(receiver.requests).To(HaveLen(10))
+
+			failed := deliveries(created.ID, "?status=failed")
+			Expect(failed.Total).To(Equal(1))
+			deliveryID := failed.Deliveries[0].ID
+			Expect(failed.Deliveries[0].LastError).To(ContainSubstring("503"))
+
+			retryPath := "/api/v1/admin/webhooks/" + created.ID + "/deliveries/" + deliveryID + "/retry"
+			receiver.status = http.StatusOK
+			Expect(send("POST", retryPath, adminToken, nil).Code).To(Equal(http.StatusAccepted))
+			Expect(send("POST", retryPath, adminToken, nil).Code).To(Equal(http.StatusConflict))
+
+			delivered, err := webhooks.RunAt(context.Background(), time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delivered).To(Equal(1))
+			Expect(deliveries(created.ID, "?status=delivered").Total).To(Equal(1))
+		})
+
+		It("should not follow redirects or keep response bodies", func() {
+			receiver.status = http.StatusTemporaryRedirect
+			receiver.location = server.URL + "/elsewhere"
+			receiver.body = "internal error: connection to 10.0.0.5 refused"
+			created := register("action_item.created")
+			createActionItem()
+
+			delivered, err := webhooks.RunAt(context.Background(), time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delivered).To(BeZero())
+			Expect(receiver.requests).To(HaveLen(1))
+
+			pending := deliveries(created.ID, "?status=pending")
+			Expect(pending.Total).To(Equal(1))
+			Expect(*pending.Deliveries[0].ResponseStatus).To(Equal(http.StatusTemporaryRedirect))
+			Expect(pending.Deliveries[0].LastError).To(ContainSubstring("307"))
+			Expect(pending.Deliveries[0].LastError).NotTo(ContainSubstring("10.0.0.5"))
+		})
+
+		It("should refuse to connect to loopback addresses by default", func() {
+			created := register("action_item.created")
+			createActionItem()
+
+			guarded := services.NewWebhookService(postgres.NewWebhookRepository(db), nil)
+			delivered, err := guarded.RunAt(context.Background(), time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delivered).To(BeZero())
+			Expect(receiver.requests).To(BeEmpty())
+
+			pending := deliveries(created.ID, "?status=pending")
+			Expect(pending.Total).To(Equal(1))
+			Expect(pending.Deliveries[0].LastError).To(ContainSubstring(services.ErrWebhookAddressNotAllowed.Error()))
+		})
+
+		It("should not queue events for inactive endpoints", func() {
+			created := register("action_item.created")
+			Expect(send("PUT", "/api/v1/admin/webhooks/"+created.ID, adminToken,
+				map[string]interface{}{"active": false}).Code).To(Equal(http.StatusOK))
+			createActionItem()
+
+			Expect(deliveries(created.ID, "").Total).To(BeZero())
+		})
+	})
+})
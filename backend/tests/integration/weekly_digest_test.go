@@ -0,0 +1,126 @@
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/agopalakrishnan/teams360/backend/application/services"
+	"github.com/agopalakrishnan/teams360/backend/domain/campaign"
+	"github.com/agopalakrishnan/teams360/backend/infrastructure/persistence/postgres"
+	"github.com/agopalakrishnan/teams360/backend/tests/testhelpers"
+)
+
+var _ = Describe("Integration: Weekly Digest", func() {
+	var (
+		db        *sql.DB
+		cleanup   func()
+		mockEmail *testhelpers.MockEmailService
+		service   *services.DigestService
+		now       time.Time
+	)
+
+	BeforeEach(func() {
+		db, cleanup = testhelpers.SetupTestDatabase()
+		mockEmail = testhelpers.NewMockEmailService()
+		now = time.Now()
+
+		service = services.NewDigestService(
+			mockEmail,
+			postgres.NewHealthCheckRepository(db),
+			postgres.NewTeamRepository(db),
+			postgres.NewUserRepository(db),
+			postgres.NewOrganizationRepository(db),
+			postgres.NewDigestRepository(db),
+		)
+
+		_, err := db.Exec(`
+			INSERT INTO users (id, username, email, full_name, hierarchy_level_id) VALUES
+			('dg_manager', 'dg_manager', 'dg_manager@test.com', 'Digest Manager', 'level-3'),
+			('dg_member', 'dg_member', 'dg_member@test.com', 'Digest Member', 'level-5')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`INSERT INTO teams (id, name, cadence) VALUES ('dg_team', 'Digest Team', 'monthly')`)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.Exec(`INSERT INTO team_members (team_id, user_id) VALUES ('dg_team', 'dg_member')`)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.Exec(`
+			INSERT INTO team_supervisors (team_id, user_id, hierarchy_level_id, position)
+			VALUES ('dg_team', 'dg_manager', 'level-3', 1)
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Previous period all green, current period all red
+		current := campaign.PeriodFor("monthly", now)
+		previous := campaign.PeriodFor("monthly", current.StartDate.AddDate(0, 0, -1))
+		_, err = db.Exec(`
+			INSERT INTO health_check_sessions (id, team_id, user_id, date, assessment_period, survey_type, completed, created_at)
+			VALUES
+				('dg_prev', 'dg_team', 'dg_member', $1, $2, 'individual', true, NOW() - INTERVAL '40 days'),
+				('dg_curr', 'dg_team', 'dg_member', $3, $4, 'individual', true, NOW())
+		`, previous.StartDate, previous.Label, current.StartDate, current.Label)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.Exec(`
+			INSERT INTO health_check_responses (session_id, dimension_id, score, trend) VALUES
+			('dg_prev', 'mission', 3, 'stable'), ('dg_prev', 'speed', 3, 'stable'),
+			('dg_curr', 'mission', 1, 'declining'), ('dg_curr', 'speed', 1, 'declining')
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = db.Exec(`
+			INSERT INTO action_items (id, team_id, created_by, assigned_to, title, status, due_date)
+			VALUES
+				('dg_overdue', 'dg_team', 'dg_manager', 'dg_member', 'Fix the flaky pipeline', 'open', $1),
+				('dg_done', 'dg_team', 'dg_manager', NULL, 'Already finished', 'done', $1)
+		`, now.AddDate(0, 0, -3).Format("2006-01-02"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		cleanup()
+	})
+
+	It("should not send digests when the weekly digest setting is disabled", func() {
+		sent, err := service.RunAt(context.Background(), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(Equal(0))
+		Expect(mockEmail.SentHTMLEmails).To(BeEmpty())
+	})
+
+	Context("when the weekly digest is enabled", func() {
+		BeforeEach(func() {
+			_, err := db.Exec(`UPDATE app_settings SET weekly_digest = true WHERE id = 1`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should send the manager a digest with submissions, score drops and overdue items", func() {
+			sent, err := service.RunAt(context.Background(), now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sent).To(Equal(1))
+
+			Expect(mockEmail.SentHTMLEmails).To(HaveLen(1))
+			digest := mockEmail.SentHTMLEmails[0]
+			Expect(digest.To).To(Equal("dg_manager@test.com"))
+			Expect(digest.Subject).To(ContainSubstring("Weekly Digest"))
+			Expect(digest.Body).To(ContainSubstring("Digest Team"))
+			Expect(digest.Body).To(ContainSubstring("3.00"))
+			Expect(digest.Body).To(ContainSubstring("1.00"))
+			Expect(digest.Body).To(ContainSubstring("Fix the flaky pipeline"))
+			Expect(digest.Body).NotTo(ContainSubstring("Already finished"))
+		})
+
+		It("should send at most one digest per manager per week", func() {
+			_, err := service.RunAt(context.Background(), now)
+			Expect(err).NotTo(HaveOccurred())
+
+			sent, err := service.RunAt(context.Background(), now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sent).To(Equal(0))
+			Expect(mockEmail.SentHTMLEmails).To(HaveLen(1))
+		})
+	})
+})
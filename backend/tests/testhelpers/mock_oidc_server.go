@@ -0,0 +1,199 @@
+package testhelpers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// MockOIDCServer is a local OpenID Connect provider for testing SSO. It serves a discovery
+// document, a JWK set and a token endpoint that returns an ID token signed with its current key.
+type MockOIDCServer struct {
+	*httptest.Server
+	ClientID string
+
+	mu           sync.Mutex
+	keyID        string
+	method       jwt.SigningMethod
+	signingKey   crypto.Signer
+	idToken      string
+	claims       map[string]interface{}
+	tokenStatus  int
+	tokenForms   []url.Values
+	jwksRequests int
+	keyCounter   int
+}
+
+// NewMockOIDCServer starts a provider that signs with a new RS256 key
+func NewMockOIDCServer(clientID string) *MockOIDCServer {
+	m := &MockOIDCServer{ClientID: clientID, tokenStatus: http.StatusOK}
+	m.RotateKey("RS256")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"issuer":                                m.Issuer(),
+			"authorization_endpoint":                m.Issuer() + "/authorize",
+			"token_endpoint":                        m.Issuer() + "/token",
+			"jwks_uri":                              m.Issuer() + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256", "ES256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		m.jwksRequests++
+		jwk := publicJWK(m.keyID, m.method.Alg(), m.signingKey.Public())
+		m.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"keys": []interface{}{jwk}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		m.mu.Lock()
+		m.tokenForms = append(m.tokenForms, r.PostForm)
+		status, idToken, claims := m.tokenStatus, m.idToken, m.claims
+		m.mu.Unlock()
+
+		if status != http.StatusOK {
+			writeJSON(w, status, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		if idToken == "" && claims != nil {
+			idToken = m.SignIDToken(claims)
+		}
+		writeJSON(w, http.StatusOK, map[string]string{
+			"access_token": "mock-access-token",
+			"id_token":     idToken,
+			"token_type":   "Bearer",
+		})
+	})
+	m.Server = httptest.NewServer(mux)
+	return m
+}
+
+// Issuer is the issuer URL to configure
+func (m *MockOIDCServer) Issuer() string {
+	return m.URL
+}
+
+// SetIDTokenClaims makes the token endpoint return an ID token signed with these claims;
+// iss, aud, sub, iat and exp are filled in when absent
+func (m *MockOIDCServer) SetIDTokenClaims(claims map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.claims, m.idToken = claims, ""
+}
+
+// SetIDToken makes the token endpoint return this raw ID token
+func (m *MockOIDCServer) SetIDToken(idToken string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.idToken, m.claims = idToken, nil
+}
+
+// SetTokenStatus makes the token endpoint fail with the status
+func (m *MockOIDCServer) SetTokenStatus(status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokenStatus = status
+}
+
+// TokenRequests returns the forms posted to the token endpoint
+func (m *MockOIDCServer) TokenRequests() []url.Values {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]url.Values(nil), m.tokenForms...)
+}
+
+// JWKSRequests returns how often the JWK set was fetched
+func (m *MockOIDCServer) JWKSRequests() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.jwksRequests
+}
+
+// RotateKey replaces the signing key with a new one for the algorithm (RS256 or ES256),
+// under a new key ID. Only the new key is published.
+func (m *MockOIDCServer) RotateKey(alg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keyCounter++
+	m.keyID = "mock-key-" + strconv.Itoa(m.keyCounter)
+	var err error
+	switch alg {
+	case "ES256":
+		m.method = jwt.SigningMethodES256
+		m.signingKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		m.method = jwt.SigningMethodRS256
+		m.signingKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	}
+	if err != nil {
+		panic("failed to generate mock OIDC signing key: " + err.Error())
+	}
+}
+
+// SignIDToken signs claims with the current key, filling in iss, aud, sub, iat and exp when absent
+func (m *MockOIDCServer) SignIDToken(claims map[string]interface{}) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	full := jwt.MapClaims{
+		"iss": m.URL,
+		"aud": m.ClientID,
+		"sub": "mock-subject",
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+	}
+	for k, v := range claims {
+		full[k] = v
+	}
+
+	token := jwt.NewWithClaims(m.method, full)
+	token.Header["kid"] = m.keyID
+	signed, err := token.SignedString(m.signingKey)
+	if err != nil {
+		panic("failed to sign mock ID token: " + err.Error())
+	}
+	return signed
+}
+
+func publicJWK(kid, alg string, key crypto.PublicKey) map[string]string {
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA", "kid": kid, "alg": alg, "use": "sig",
+			"n": encode(k.N.Bytes()),
+			"e": encode(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		return map[string]string{
+			"kty": "EC", "kid": kid, "alg": alg, "use": "sig", "crv": "P-256",
+			"x": encode(k.X.FillBytes(make([]byte, size))),
+			"y": encode(k.Y.FillBytes(make([]byte, size))),
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
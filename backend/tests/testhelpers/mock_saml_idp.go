@@ -0,0 +1,238 @@
+package testhelpers
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	samlAssertionNS = "urn:oasis:names:tc:SAML:2.0:assertion"
+	samlProtocolNS  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	xmlDSigNS       = "http://www.w3.org/2000/09/xmldsig#"
+	excC14NAlg      = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	samlTimeFormat  = "2006-01-02T15:04:05Z"
+)
+
+// MockSAMLIdP is a SAML identity provider for testing SAML logins. It issues Responses signed
+// with its own self-signed certificate. The signed elements are written in canonical form,
+// then re-serialized the way IdPs commonly send them (namespaces declared once on the Response,
+// self-closing tags), so the service provider has to canonicalize them to verify.
+type MockSAMLIdP struct {
+	EntityID string
+	SSOURL   string
+
+	key  *rsa.PrivateKey
+	cert *x509.Certificate
+}
+
+// SAMLResponseOptions describes the Response to issue. Zero values get sensible defaults.
+type SAMLResponseOptions struct {
+	InResponseTo string // the AuthnRequest ID
+	Recipient    string // the SP's ACS URL
+	Audience     string // the SP's entity ID
+	NameID       string
+	NameIDFormat string
+	Attributes   map[string][]string
+	Issuer       string    // defaults to the IdP's entity ID
+	NotOnOrAfter time.Time // defaults to five minutes from now
+	Status       string    // defaults to Success
+	SignResponse bool      // sign the Response instead of the assertion
+	Unsigned     bool
+}
+
+// NewMockSAMLIdP creates an IdP with a new RSA key and self-signed certificate
+func NewMockSAMLIdP(entityID string) *MockSAMLIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic("failed to generate mock SAML IdP key: " + err.Error())
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: entityID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic("failed to create mock SAML IdP certificate: " + err.Error())
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic("failed to parse mock SAML IdP certificate: " + err.Error())
+	}
+	return &MockSAMLIdP{
+		EntityID: entityID,
+		SSOURL:   "https://idp.example.com/sso",
+		key:      key,
+		cert:     cert,
+	}
+}
+
+// CertificatePEM returns the IdP's signing certificate in PEM form
+func (m *MockSAMLIdP) CertificatePEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: m.cert.Raw}))
+}
+
+// ParseAuthnRequest decodes the AuthnRequest of an HTTP-Redirect binding URL and returns its
+// ID, AssertionConsumerServiceURL and the RelayState
+func (m *MockSAMLIdP) ParseAuthnRequest(redirectURL string) (id, acsURL, relayState string, err error) {
+	u, err := url.Parse(redirectURL)
+	if err != nil {
+		return "", "", "", err
+	}
+	compressed, err := base64.StdEncoding.DecodeString(u.Query().Get("SAMLRequest"))
+	if err != nil {
+		return "", "", "", fmt.Errorf("SAMLRequest is not base64: %w", err)
+	}
+	raw, err := io.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+	if err != nil {
+		return "", "", "", fmt.Errorf("SAMLRequest is not deflated: %w", err)
+	}
+	var request struct {
+		ID     string `xml:"ID,attr"`
+		ACSURL string `xml:"AssertionConsumerServiceURL,attr"`
+	}
+	if err := xml.Unmarshal(raw, &request); err != nil {
+		return "", "", "", fmt.Errorf("SAMLRequest is not XML: %w", err)
+	}
+	return request.ID, request.ACSURL, u.Query().Get("RelayState"), nil
+}
+
+// Response returns a base64-encoded Response, as posted to the ACS in the SAMLResponse field
+func (m *MockSAMLIdP) Response(opts SAMLResponseOptions) string {
+	return base64.StdEncoding.EncodeToString([]byte(m.ResponseXML(opts)))
+}
+
+// ResponseXML returns the Response document
+func (m *MockSAMLIdP) ResponseXML(opts SAMLResponseOptions) string {
+	now := time.Now().UTC()
+	if opts.Issuer == "" {
+		opts.Issuer = m.EntityID
+	}
+	if opts.NotOnOrAfter.IsZero() {
+		opts.NotOnOrAfter = now.Add(5 * time.Minute)
+	}
+	if opts.Status == "" {
+		opts.Status = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	}
+	if opts.NameIDFormat == "" {
+		opts.NameIDFormat = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	}
+	responseID, assertionID := "_resp"+randomHex(), "_assert"+randomHex()
+	issueInstant := now.Format(samlTimeFormat)
+	notOnOrAfter := opts.NotOnOrAfter.UTC().Format(samlTimeFormat)
+
+	// The assertion, in exclusive canonical form
+	var a strings.Builder
+	a.WriteString(`<saml:Assertion xmlns:saml="` + samlAssertionNS + `" ID="` + assertionID + `" IssueInstant="` + issueInstant + `" Version="2.0">`)
+	a.WriteString(`<saml:Issuer>` + escapeXMLText(opts.Issuer) + `</saml:Issuer>`)
+	a.WriteString(`<saml:Subject><saml:NameID Format="` + escapeXMLAttr(opts.NameIDFormat) + `">` + escapeXMLText(opts.NameID) + `</saml:NameID>`)
+	a.WriteString(`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">`)
+	a.WriteString(`<saml:SubjectConfirmationData InResponseTo="` + escapeXMLAttr(opts.InResponseTo) + `" NotOnOrAfter="` + notOnOrAfter + `" Recipient="` + escapeXMLAttr(opts.Recipient) + `"></saml:SubjectConfirmationData>`)
+	a.WriteString(`</saml:SubjectConfirmation></saml:Subject>`)
+	a.WriteString(`<saml:Conditions NotBefore="` + now.Add(-time.Minute).Format(samlTimeFormat) + `" NotOnOrAfter="` + notOnOrAfter + `">`)
+	a.WriteString(`<saml:AudienceRestriction><saml:Audience>` + escapeXMLText(opts.Audience) + `</saml:Audience></saml:AudienceRestriction></saml:Conditions>`)
+	a.WriteString(`<saml:AuthnStatement AuthnInstant="` + issueInstant + `" SessionIndex="` + assertionID + `"><saml:AuthnContext>`)
+	a.WriteString(`<saml:AuthnContextClassRef>urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport</saml:AuthnContextClassRef>`)
+	a.WriteString(`</saml:AuthnContext></saml:AuthnStatement>`)
+	if len(opts.Attributes) > 0 {
+		names := make([]string, 0, len(opts.Attributes))
+		for name := range opts.Attributes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		a.WriteString(`<saml:AttributeStatement>`)
+		for _, name := range names {
+			a.WriteString(`<saml:Attribute Name="` + escapeXMLAttr(name) + `">`)
+			for _, value := range opts.Attributes[name] {
+				a.WriteString(`<saml:AttributeValue>` + escapeXMLText(value) + `</saml:AttributeValue>`)
+			}
+			a.WriteString(`</saml:Attribute>`)
+		}
+		a.WriteString(`</saml:AttributeStatement>`)
+	}
+	a.WriteString(`</saml:Assertion>`)
+	assertion := a.String()
+	if !opts.Unsigned && !opts.SignResponse {
+		assertion = m.sign(assertion, assertionID, `</saml:Issuer>`)
+	}
+
+	// The Response, in exclusive canonical form
+	response := `<samlp:Response xmlns:samlp="` + samlProtocolNS + `" Destination="` + escapeXMLAttr(opts.Recipient) +
+		`" ID="` + responseID + `" InResponseTo="` + escapeXMLAttr(opts.InResponseTo) + `" IssueInstant="` + issueInstant + `" Version="2.0">` +
+		`<saml:Issuer xmlns:saml="` + samlAssertionNS + `">` + escapeXMLText(opts.Issuer) + `</saml:Issuer>` +
+		`<samlp:Status><samlp:StatusCode Value="` + escapeXMLAttr(opts.Status) + `"></samlp:StatusCode></samlp:Status>` +
+		assertion + `</samlp:Response>`
+	if !opts.Unsigned && opts.SignResponse {
+		response = m.sign(response, responseID, `</saml:Issuer>`)
+	}
+
+	// Serialize it as IdPs commonly do: the assertion namespace declared once on the Response
+	// and empty elements self-closed
+	response = strings.Replace(response, `<samlp:Response `, `<samlp:Response xmlns:saml="`+samlAssertionNS+`" `, 1)
+	response = strings.ReplaceAll(response, `<saml:Issuer xmlns:saml="`+samlAssertionNS+`">`, `<saml:Issuer>`)
+	response = strings.ReplaceAll(response, `<saml:Assertion xmlns:saml="`+samlAssertionNS+`" `, `<saml:Assertion `)
+	response = strings.ReplaceAll(response, `"></saml:SubjectConfirmationData>`, `"/>`)
+	response = strings.ReplaceAll(response, `"></samlp:StatusCode>`, `"/>`)
+	return `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + response
+}
+
+// sign inserts an enveloped signature over the canonical element after the first occurrence
+// of insertAfter (the element's Issuer, where the SAML schema places the signature)
+func (m *MockSAMLIdP) sign(canonical, id, insertAfter string) string {
+	digest := sha256.Sum256([]byte(canonical))
+	signedInfo := `<ds:SignedInfo xmlns:ds="` + xmlDSigNS + `">` +
+		`<ds:CanonicalizationMethod Algorithm="` + excC14NAlg + `"></ds:CanonicalizationMethod>` +
+		`<ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"></ds:SignatureMethod>` +
+		`<ds:Reference URI="#` + id + `"><ds:Transforms>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"></ds:Transform>` +
+		`<ds:Transform Algorithm="` + excC14NAlg + `"></ds:Transform></ds:Transforms>` +
+		`<ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"></ds:DigestMethod>` +
+		`<ds:DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</ds:DigestValue>` +
+		`</ds:Reference></ds:SignedInfo>`
+
+	hashed := sha256.Sum256([]byte(signedInfo))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, m.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		panic("failed to sign mock SAML response: " + err.Error())
+	}
+
+	// Within the Signature element, SignedInfo inherits the ds namespace declaration
+	element := `<ds:Signature xmlns:ds="` + xmlDSigNS + `">` +
+		strings.Replace(signedInfo, ` xmlns:ds="`+xmlDSigNS+`"`, "", 1) +
+		`<ds:SignatureValue>` + base64.StdEncoding.EncodeToString(signature) + `</ds:SignatureValue>` +
+		`<ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + base64.StdEncoding.EncodeToString(m.cert.Raw) +
+		`</ds:X509Certificate></ds:X509Data></ds:KeyInfo></ds:Signature>`
+	return strings.Replace(canonical, insertAfter, insertAfter+element, 1)
+}
+
+func randomHex() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%x", b)
+}
+
+func escapeXMLText(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func escapeXMLAttr(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;").Replace(s)
+}
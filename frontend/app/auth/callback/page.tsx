@@ -43,13 +43,18 @@ function CallbackHandler() {
       return;
     }
     sessionStorage.removeItem('pkce_verifier');
-  
+
+    const nonce = sessionStorage.getItem('oauth_nonce') ?? '';
+    const provider = sessionStorage.getItem('oauth_provider') ?? '';
+    sessionStorage.removeItem('oauth_nonce');
+    sessionStorage.removeItem('oauth_provider');
+
     (async () => {
       try {
         const res = await fetch(`${API_BASE_URL}/api/v1/auth/sso/callback`, {
           method: 'POST',
           headers: { 'Content-Type': 'application/json' },
-          body: JSON.stringify({ code, code_verifier: codeVerifier }),
+          body: JSON.stringify({ code, code_verifier: codeVerifier, provider, nonce }),
         });
 
         const data = await res.json();
@@ -0,0 +1,123 @@
+'use client';
+
+import { useEffect, useState, useRef, Suspense } from 'react';
+import { useRouter, useSearchParams } from 'next/navigation';
+import { setAuthData, LoginResponse } from '@/lib/auth';
+import { API_BASE_URL } from '@/lib/api/client';
+import { Loader2, AlertCircle } from 'lucide-react';
+
+// Error codes the backend's Assertion Consumer Service redirects with
+const SAML_ERRORS: Record<string, string> = {
+  invalid_response: 'The response from your identity provider could not be verified.',
+  login_expired: 'The login session has expired. Please start the login process again.',
+  email_missing: 'Your identity provider did not send an email address.',
+  account_not_found: 'No account found for this email address. Please contact your administrator.',
+  sso_not_supported: 'This account does not support SSO login. Please use username and password.',
+  account_deactivated: 'This account has been deactivated. Please contact your administrator.',
+};
+
+function SAMLCallbackHandler() {
+  const router = useRouter();
+  const searchParams = useSearchParams();
+  const [error, setError] = useState('');
+  const handled = useRef(false);
+
+  useEffect(() => {
+    if (handled.current) return;
+    handled.current = true;
+    const code = searchParams.get('code');
+    const errorParam = searchParams.get('error');
+
+    const state = sessionStorage.getItem('saml_state');
+    sessionStorage.removeItem('saml_state');
+
+    if (errorParam) {
+      setError(SAML_ERRORS[errorParam] ?? 'SAML login failed. Please try again.');
+      return;
+    }
+    if (!code) {
+      setError('Invalid callback: no login code received.');
+      return;
+    }
+    // The code can only be redeemed with the state this browser started the login with
+    if (!state) {
+      setError('Invalid login session. Please start the login process again.');
+      return;
+    }
+
+    (async () => {
+      try {
+        const res = await fetch(`${API_BASE_URL}/api/v1/auth/saml/token`, {
+          method: 'POST',
+          headers: { 'Content-Type': 'application/json' },
+          body: JSON.stringify({ code, state }),
+        });
+
+        const data = await res.json();
+        if (!res.ok) {
+          setError(data.error || 'Login failed. Your account may not be registered.');
+          return;
+        }
+
+        const loginData: LoginResponse = data;
+        setAuthData(loginData);
+
+        const { hierarchyLevel } = loginData.user;
+        if (hierarchyLevel === 'admin' || hierarchyLevel === 'level-admin') {
+          router.replace('/admin');
+        } else if (['level-1', 'level-2', 'level-3'].includes(hierarchyLevel)) {
+          router.replace('/manager');
+        } else if (hierarchyLevel === 'level-4') {
+          router.replace('/dashboard');
+        } else {
+          router.replace('/home');
+        }
+      } catch {
+        setError('Network error during authentication. Please try again.');
+      }
+    })();
+  }, [router, searchParams]);
+
+  if (error) {
+    return (
+      <div className="min-h-screen bg-gradient-to-br from-blue-50 to-indigo-100 flex items-center justify-center p-4">
+        <div className="bg-white rounded-xl shadow-lg p-8 max-w-sm w-full text-center">
+          <AlertCircle className="w-12 h-12 text-red-500 mx-auto mb-4" />
+          <h2 className="text-lg font-semibold text-gray-900 mb-2">Sign-in failed</h2>
+          <p className="text-sm text-gray-600 mb-6">{error}</p>
+          <button
+            onClick={() => router.replace('/login')}
+            className="w-full bg-indigo-600 text-white py-2.5 rounded-lg font-medium hover:bg-indigo-700 transition-colors"
+          >
+            Back to login
+          </button>
+        </div>
+      </div>
+    );
+  }
+
+  return (
+    <div className="min-h-screen bg-gradient-to-br from-blue-50 to-indigo-100 flex items-center justify-center">
+      <div className="bg-white rounded-xl shadow-lg p-8 max-w-sm w-full text-center">
+        <Loader2 className="w-10 h-10 text-indigo-600 animate-spin mx-auto mb-4" />
+        <p className="text-gray-600 font-medium">Completing sign-in...</p>
+        <p className="text-sm text-gray-400 mt-1">Please wait while we verify your identity.</p>
+      </div>
+    </div>
+  );
+}
+
+// useSearchParams requires a Suspense boundary in Next.js App Router
+export default function SAMLCallbackPage() {
+  return (
+    <Suspense
+      fallback={
+        <div className="min-h-screen bg-gradient-to-br from-blue-50 to-indigo-100 flex items-center justify-center">
+          <Loader2 className="w-10 h-10 text-indigo-600 animate-spin" />
+        </div>
+      }
+    >
+      <SAMLCallbackHandler />
+    </Suspense>
+  );
+}
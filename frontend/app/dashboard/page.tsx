@@ -47,7 +47,7 @@ interface IndividualResponse {
 
 interface TrendData {
   period: string;
-  [key: string]: string | number;
+  [key: string]: string | number | null; // null where a dimension has no data for the period
 }
 
 export default function DashboardPage() {
@@ -248,8 +248,8 @@ export default function DashboardPage() {
         if (data.periods && Array.isArray(data.periods) && data.dimensions) {
           const transformed = data.periods.map((period: string, idx: number) => {
             const row: TrendData = { period };
-            (data.dimensions || []).forEach((dim: { dimensionId: string; scores: number[] }) => {
-              row[dim.dimensionId] = dim.scores[idx] || 0;
+            (data.dimensions || []).forEach((dim: { dimensionId: string; scores: (number | null)[] }) => {
+              row[dim.dimensionId] = dim.scores[idx] ?? null;
             });
             return row;
           });
@@ -345,15 +345,15 @@ export default function DashboardPage() {
     () => HEALTH_DIMENSIONS.map((dim) => {
       const data = trends.map((t) => ({
         period: t.period as string,
-        value: (t[dim.id] as number) || 0,
+        value: (t[dim.id] as number | null) ?? null,
       }));
-      const validData = data.filter((p) => p.value > 0);
+      const validData = data.filter((p): p is { period: string; value: number } => p.value !== null);
       const latest = validData.length > 0 ? validData[validData.length - 1].value : 0;
       const prev = validData.length > 1 ? validData[validData.length - 2].value : latest;
       const direction: 'up' | 'down' | 'stable' =
         latest > prev + 0.1 ? 'up' : latest < prev - 0.1 ? 'down' : 'stable';
       return { dim, data, latest, direction };
-    }).filter((d) => d.data.some((p) => p.value > 0)),
+    }).filter((d) => d.data.some((p) => p.value !== null)),
     [trends]
   );
 
@@ -1323,6 +1323,7 @@ export default function DashboardPage() {
                                         <Line
                                           type="monotone"
                                           dataKey="value"
+                                          connectNulls
                                           stroke={lineColor}
                                           strokeWidth={2}
                                           dot={{ r: 3, fill: lineColor }}
@@ -1344,7 +1345,7 @@ export default function DashboardPage() {
                                           }}
                                           itemStyle={{ color: '#d1fae5' }}
                                           cursor={{ stroke: '#6366f1', strokeWidth: 1, strokeDasharray: '3 3' }}
-                                          formatter={(v: number) => [v.toFixed(2), 'Score']}
+                                          formatter={(v: number | null) => [v === null ? '—' : v.toFixed(2), 'Score']}
                                           labelFormatter={(period) => `Period: ${period}`}
                                         />
                                       </LineChart>
@@ -1408,7 +1409,7 @@ export default function DashboardPage() {
                                       }}
                                       labelStyle={{ color: '#9ca3af', fontWeight: 600, marginBottom: '6px' }}
                                       itemStyle={{ color: '#f9fafb', padding: '1px 0' }}
-                                      formatter={(value: number, name: string) => [value.toFixed(2), name]}
+                                      formatter={(value: number | null, name: string) => [value === null ? '—' : value.toFixed(2), name]}
                                       labelFormatter={(period) => `Period: ${period}`}
                                     />
                                     {HEALTH_DIMENSIONS.map((dim, idx) => (
@@ -1416,6 +1417,7 @@ export default function DashboardPage() {
                                         key={dim.id}
                                         type="monotone"
                                         dataKey={dim.id}
+                                        connectNulls
                                         name={dim.name}
                                         stroke={DIM_COLORS[idx]}
                                         strokeWidth={2}
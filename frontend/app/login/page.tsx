@@ -3,10 +3,10 @@
 import { Suspense, useState, useEffect } from 'react';
 import { useRouter, useSearchParams } from 'next/navigation';
 import { getOrgConfig } from '@/lib/org-config';
-import { setAuthData, LoginResponse } from '@/lib/auth';
-import { startSSOFlow, OAuthConfig } from '@/lib/sso';
+import { setAuthData, isMFAChallenge, LoginResponse, MFAChallengeResponse, MFAEnrollment, APIUser } from '@/lib/auth';
+import { startSSOFlow, startSAMLFlow, OAuthConfig, SAMLConfig } from '@/lib/sso';
 import { API_BASE_URL } from '@/lib/api/client';
-import { Lock, User, AlertCircle, Users, LogIn } from 'lucide-react';
+import { Lock, User, AlertCircle, Users, LogIn, KeyRound } from 'lucide-react';
 
 export default function LoginPage() {
   return (
@@ -28,8 +28,14 @@ function LoginPageContent() {
   const [password, setPassword] = useState('');
   const [error, setError] = useState('');
   const [ssoLoading, setSSOLoading] = useState(false);
-  const [ssoConfig, setSsoConfig] = useState<OAuthConfig | null>(null);
+  const [ssoProviders, setSsoProviders] = useState<OAuthConfig[]>([]);
+  const [samlConfig, setSamlConfig] = useState<SAMLConfig | null>(null);
   const [isDemoMode, setIsDemoMode] = useState(false);
+  const [mfaChallenge, setMfaChallenge] = useState<MFAChallengeResponse | null>(null);
+  const [mfaEnrollment, setMfaEnrollment] = useState<MFAEnrollment | null>(null);
+  const [mfaCode, setMfaCode] = useState('');
+  const [recoveryCodes, setRecoveryCodes] = useState<string[] | null>(null);
+  const [signedInUser, setSignedInUser] = useState<APIUser | null>(null);
   const config = getOrgConfig();
 
   useEffect(() => {
@@ -37,11 +43,43 @@ function LoginPageContent() {
       .then(res => res.json())
       .then(data => {
         if (data.appEnv === 'demo') setIsDemoMode(true);
-        if (data.sso) setSsoConfig(data.sso as OAuthConfig);
+        if (Array.isArray(data.ssoProviders)) setSsoProviders(data.ssoProviders as OAuthConfig[]);
+        else if (data.sso) setSsoProviders([data.sso as OAuthConfig]);
+        if (data.saml) setSamlConfig(data.saml as SAMLConfig);
       })
       .catch(() => {});
   }, []);
 
+  // Route based on permissions
+  const redirectForUser = (user: APIUser) => {
+    if (user.hierarchyLevel === 'admin' || user.hierarchyLevel === 'level-admin') {
+      // Admins go to admin dashboard
+      router.push('/admin');
+    } else if (user.hierarchyLevel === 'level-1' || user.hierarchyLevel === 'level-2' || user.hierarchyLevel === 'level-3') {
+      // VPs, Directors, Managers go to manager dashboard
+      router.push('/manager');
+    } else if (user.hierarchyLevel === 'level-4') {
+      // Team leads go to dashboard (their own team view)
+      router.push('/dashboard');
+    } else {
+      // Team members (level-5) go to home page with survey history
+      router.push('/home');
+    }
+  };
+
+  const completeLogin = (data: LoginResponse) => {
+    // Store JWT tokens and user data using the auth module
+    setAuthData(data);
+
+    // Recovery codes from an enrollment are shown once before continuing
+    if (data.recoveryCodes && data.recoveryCodes.length > 0) {
+      setRecoveryCodes(data.recoveryCodes);
+      setSignedInUser(data.user);
+      return;
+    }
+    redirectForUser(data.user);
+  };
+
   const handleLogin = async (e: React.FormEvent) => {
     e.preventDefault();
     setError('');
@@ -62,34 +100,71 @@ function LoginPageContent() {
         return;
       }
 
-      const data: LoginResponse = await response.json();
-      const user = data.user;
-
-      // Store JWT tokens and user data using the auth module
-      setAuthData(data);
-
-      // Route based on permissions
-      if (user.hierarchyLevel === 'admin' || user.hierarchyLevel === 'level-admin') {
-        // Admins go to admin dashboard
-        router.push('/admin');
-      } else if (user.hierarchyLevel === 'level-1' || user.hierarchyLevel === 'level-2' || user.hierarchyLevel === 'level-3') {
-        // VPs, Directors, Managers go to manager dashboard
-        router.push('/manager');
-      } else if (user.hierarchyLevel === 'level-4') {
-        // Team leads go to dashboard (their own team view)
-        router.push('/dashboard');
-      } else {
-        // Team members (level-5) go to home page with survey history
-        router.push('/home');
+      const data: LoginResponse | MFAChallengeResponse = await response.json();
+      if (!isMFAChallenge(data)) {
+        completeLogin(data);
+        return;
+      }
+
+      setMfaChallenge(data);
+      setMfaCode('');
+      if (data.enrollmentRequired) {
+        const enrollResponse = await fetch(`${API_BASE_URL}/api/v1/auth/mfa/enroll`, {
+          method: 'POST',
+          headers: {
+            'Content-Type': 'application/json',
+          },
+          body: JSON.stringify({ mfaToken: data.mfaToken }),
+        });
+        const enrollData = await enrollResponse.json();
+        if (!enrollResponse.ok) {
+          setError(enrollData.error || 'Failed to start authenticator setup');
+          return;
+        }
+        setMfaEnrollment(enrollData as MFAEnrollment);
       }
     } catch (err) {
       setError('Unable to connect. Please try again later.');
     }
   };
 
+  const cancelMFA = () => {
+    setMfaChallenge(null);
+    setMfaEnrollment(null);
+    setMfaCode('');
+  };
+
+  const handleVerifyMFA = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!mfaChallenge) return;
+    setError('');
+
+    try {
+      const response = await fetch(`${API_BASE_URL}/api/v1/auth/mfa/verify`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ mfaToken: mfaChallenge.mfaToken, code: mfaCode }),
+      });
+
+      if (!response.ok) {
+        const errorData = await response.json();
+        setError(errorData.error || 'Invalid verification code');
+        if (response.status === 401 && errorData.error !== 'Invalid verification code') {
+          // The challenge expired or the account was locked; start over from the password step
+          cancelMFA();
+        }
+        return;
+      }
+
+      completeLogin(await response.json());
+    } catch (err) {
+      setError('Unable to connect. Please try again later.');
+    }
+  };
 
-  const handleSSOLogin = async () => {
-    if (!ssoConfig) return;
+  const handleSSOLogin = async (ssoConfig: OAuthConfig) => {
     setSSOLoading(true);
     setError('');
     try {
@@ -100,6 +175,12 @@ function LoginPageContent() {
     }
   };
 
+  const handleSAMLLogin = (config: SAMLConfig) => {
+    setSSOLoading(true);
+    setError('');
+    startSAMLFlow(config);
+  };
+
   return (
     <div className="min-h-screen bg-gradient-to-br from-blue-50 to-indigo-100 flex items-center justify-center p-4">
       <div className="max-w-4xl w-full">
@@ -125,75 +206,175 @@ function LoginPageContent() {
                 </div>
               )}
 
-              <form onSubmit={handleLogin} className="space-y-6">
-                <div>
-                  <label htmlFor="username" className="block text-sm font-medium text-gray-700 mb-2">
-                    Username
-                  </label>
-                  <div className="relative">
-                    <input
-                      id="username"
-                      name="username"
-                      type="text"
-                      value={username}
-                      onChange={(e) => setUsername(e.target.value)}
-                      className="w-full px-4 py-3 pl-10 border border-gray-300 rounded-lg text-gray-900 focus:ring-2 focus:ring-indigo-500 focus:border-transparent placeholder:text-gray-400"
-                      placeholder="Enter username"
-                      required
-                    />
-                    <User className="w-5 h-5 text-gray-400 absolute left-3 top-1/2 -translate-y-1/2" />
+              {recoveryCodes && signedInUser ? (
+                <div className="space-y-6" data-testid="recovery-codes">
+                  <div className="text-sm text-gray-700">
+                    <p className="font-semibold text-gray-900 mb-2">Save your recovery codes</p>
+                    <p>Each code can be used once to sign in if you lose your authenticator app. They will not be shown again.</p>
+                  </div>
+                  <div className="grid grid-cols-2 gap-2 bg-gray-50 border border-gray-200 rounded-lg p-4">
+                    {recoveryCodes.map((code) => (
+                      <code key={code} className="font-mono text-sm text-gray-800">{code}</code>
+                    ))}
                   </div>
+                  <button
+                    onClick={() => redirectForUser(signedInUser)}
+                    className="w-full bg-indigo-600 text-white py-3 rounded-lg font-semibold hover:bg-indigo-700 transition-colors"
+                  >
+                    I have saved these codes
+                  </button>
                 </div>
+              ) : mfaChallenge ? (
+                <form onSubmit={handleVerifyMFA} className="space-y-6" data-testid="mfa-form">
+                  {mfaEnrollment ? (
+                    <div className="text-sm text-gray-700 space-y-2">
+                      <p>Your role requires two-factor authentication. Add Team360 to your authenticator app, then enter the 6-digit code it shows.</p>
+                      <p>
+                        <a href={mfaEnrollment.provisioningUri} className="text-indigo-600 hover:underline">
+                          Open in authenticator app
+                        </a>
+                        {' '}or enter this key manually:
+                      </p>
+                      <code className="block bg-gray-100 px-3 py-2 rounded font-mono text-xs text-gray-800 break-all" data-testid="mfa-secret">
+                        {mfaEnrollment.secret}
+                      </code>
+                    </div>
+                  ) : (
+                    <p className="text-sm text-gray-700">
+                      Enter the 6-digit code from your authenticator app, or one of your recovery codes.
+                    </p>
+                  )}
 
-                <div>
-                  <label htmlFor="password" className="block text-sm font-medium text-gray-700 mb-2">
-                    Password
-                  </label>
-                  <div className="relative">
-                    <input
-                      id="password"
-                      name="password"
-                      type="password"
-                      value={password}
-                      onChange={(e) => setPassword(e.target.value)}
-                      className="w-full px-4 py-3 pl-10 border border-gray-300 rounded-lg text-gray-900 focus:ring-2 focus:ring-indigo-500 focus:border-transparent placeholder:text-gray-400"
-                      placeholder="Enter password"
-                      required
-                    />
-                    <Lock className="w-5 h-5 text-gray-400 absolute left-3 top-1/2 -translate-y-1/2" />
+                  <div>
+                    <label htmlFor="mfa-code" className="block text-sm font-medium text-gray-700 mb-2">
+                      Verification code
+                    </label>
+                    <div className="relative">
+                      <input
+                        id="mfa-code"
+                        name="mfa-code"
+                        type="text"
+                        autoComplete="one-time-code"
+                        autoFocus
+                        value={mfaCode}
+                        onChange={(e) => setMfaCode(e.target.value)}
+                        className="w-full px-4 py-3 pl-10 border border-gray-300 rounded-lg text-gray-900 focus:ring-2 focus:ring-indigo-500 focus:border-transparent placeholder:text-gray-400"
+                        placeholder="123456"
+                        required
+                      />
+                      <KeyRound className="w-5 h-5 text-gray-400 absolute left-3 top-1/2 -translate-y-1/2" />
+                    </div>
+                  </div>
+
+                  {error && (
+                    <div className="flex items-center gap-2 text-red-600 text-sm bg-red-50 p-3 rounded-lg" data-testid="login-error">
+                      <AlertCircle className="w-4 h-4" />
+                      <span>{error}</span>
+                    </div>
+                  )}
+
+                  <button
+                    type="submit"
+                    className="w-full bg-indigo-600 text-white py-3 rounded-lg font-semibold hover:bg-indigo-700 transition-colors"
+                  >
+                    Verify
+                  </button>
+                  <button
+                    type="button"
+                    onClick={cancelMFA}
+                    className="w-full text-sm text-gray-500 hover:text-gray-700"
+                  >
+                    Back to sign in
+                  </button>
+                </form>
+              ) : (
+                <form onSubmit={handleLogin} className="space-y-6">
+                  <div>
+                    <label htmlFor="username" className="block text-sm font-medium text-gray-700 mb-2">
+                      Username
+                    </label>
+                    <div className="relative">
+                      <input
+                        id="username"
+                        name="username"
+                        type="text"
+                        value={username}
+                        onChange={(e) => setUsername(e.target.value)}
+                        className="w-full px-4 py-3 pl-10 border border-gray-300 rounded-lg text-gray-900 focus:ring-2 focus:ring-indigo-500 focus:border-transparent placeholder:text-gray-400"
+                        placeholder="Enter username"
+                        required
+                      />
+                      <User className="w-5 h-5 text-gray-400 absolute left-3 top-1/2 -translate-y-1/2" />
+                    </div>
                   </div>
-                </div>
 
-                {error && (
-                  <div className="flex items-center gap-2 text-red-600 text-sm bg-red-50 p-3 rounded-lg" data-testid="login-error">
-                    <AlertCircle className="w-4 h-4" />
-                    <span>{error}</span>
+                  <div>
+                    <label htmlFor="password" className="block text-sm font-medium text-gray-700 mb-2">
+                      Password
+                    </label>
+                    <div className="relative">
+                      <input
+                        id="password"
+                        name="password"
+                        type="password"
+                        value={password}
+                        onChange={(e) => setPassword(e.target.value)}
+                        className="w-full px-4 py-3 pl-10 border border-gray-300 rounded-lg text-gray-900 focus:ring-2 focus:ring-indigo-500 focus:border-transparent placeholder:text-gray-400"
+                        placeholder="Enter password"
+                        required
+                      />
+                      <Lock className="w-5 h-5 text-gray-400 absolute left-3 top-1/2 -translate-y-1/2" />
+                    </div>
                   </div>
-                )}
 
-                <button
-                  type="submit"
-                  className="w-full bg-indigo-600 text-white py-3 rounded-lg font-semibold hover:bg-indigo-700 transition-colors"
-                >
-                  Sign In
-                </button>
-              </form>
+                  {error && (
+                    <div className="flex items-center gap-2 text-red-600 text-sm bg-red-50 p-3 rounded-lg" data-testid="login-error">
+                      <AlertCircle className="w-4 h-4" />
+                      <span>{error}</span>
+                    </div>
+                  )}
 
-              {ssoConfig && (
+                  <button
+                    type="submit"
+                    className="w-full bg-indigo-600 text-white py-3 rounded-lg font-semibold hover:bg-indigo-700 transition-colors"
+                  >
+                    Sign In
+                  </button>
+                </form>
+              )}
+
+              {!mfaChallenge && !recoveryCodes && (ssoProviders.length > 0 || samlConfig) && (
                 <>
                   <div className="flex items-center gap-3 mt-6">
                     <div className="flex-1 h-px bg-gray-200" />
                     <span className="text-sm text-gray-400">or</span>
                     <div className="flex-1 h-px bg-gray-200" />
                   </div>
-                  <button
-                    onClick={handleSSOLogin}
-                    disabled={ssoLoading}
-                    className="w-full mt-4 flex items-center justify-center gap-2 border border-gray-300 text-gray-700 py-3 rounded-lg font-semibold hover:bg-gray-50 transition-colors disabled:opacity-60 disabled:cursor-not-allowed"
-                  >
-                    <LogIn className="w-5 h-5" />
-                    {ssoLoading ? 'Redirecting\u2026' : 'Sign in with SSO'}
-                  </button>
+                  {ssoProviders.map((provider) => (
+                    <button
+                      key={provider.id ?? provider.clientId}
+                      onClick={() => handleSSOLogin(provider)}
+                      disabled={ssoLoading}
+                      className="w-full mt-4 flex items-center justify-center gap-2 border border-gray-300 text-gray-700 py-3 rounded-lg font-semibold hover:bg-gray-50 transition-colors disabled:opacity-60 disabled:cursor-not-allowed"
+                    >
+                      <LogIn className="w-5 h-5" />
+                      {ssoLoading
+                        ? 'Redirecting\u2026'
+                        : ssoProviders.length > 1 && provider.name
+                          ? `Sign in with ${provider.name}`
+                          : 'Sign in with SSO'}
+                    </button>
+                  ))}
+                  {samlConfig && (
+                    <button
+                      onClick={() => handleSAMLLogin(samlConfig)}
+                      disabled={ssoLoading}
+                      className="w-full mt-4 flex items-center justify-center gap-2 border border-gray-300 text-gray-700 py-3 rounded-lg font-semibold hover:bg-gray-50 transition-colors disabled:opacity-60 disabled:cursor-not-allowed"
+                    >
+                      <LogIn className="w-5 h-5" />
+                      {ssoLoading ? 'Redirecting\u2026' : `Sign in with ${samlConfig.name}`}
+                    </button>
+                  )}
                 </>
               )}
             </div>
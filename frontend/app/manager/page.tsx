@@ -42,7 +42,7 @@ interface RadarData {
 
 interface TrendData {
   period: string;
-  [key: string]: string | number;
+  [key: string]: string | number | null; // null where a dimension has no data for the period
 }
 
 interface Subordinate {
@@ -283,8 +283,8 @@ export default function ManagerPage() {
         if (data.periods && Array.isArray(data.periods) && data.dimensions) {
           const transformed = data.periods.map((period: string, idx: number) => {
             const row: TrendData = { period };
-            (data.dimensions || []).forEach((dim: { dimensionId: string; scores: number[] }) => {
-              row[dim.dimensionId] = dim.scores[idx] || 0;
+            (data.dimensions || []).forEach((dim: { dimensionId: string; scores: (number | null)[] }) => {
+              row[dim.dimensionId] = dim.scores[idx] ?? null;
             });
             return row;
           });
@@ -417,15 +417,15 @@ export default function ManagerPage() {
   const dimSparklines = HEALTH_DIMENSIONS.map((dim) => {
     const data = trendsData.map((t) => ({
       period: t.period as string,
-      value: (t[dim.id] as number) || 0,
+      value: (t[dim.id] as number | null) ?? null,
     }));
-    const validData = data.filter((p) => p.value > 0);
+    const validData = data.filter((p): p is { period: string; value: number } => p.value !== null);
     const latest = validData.length > 0 ? validData[validData.length - 1].value : 0;
     const prev = validData.length > 1 ? validData[validData.length - 2].value : latest;
     const direction: 'up' | 'down' | 'stable' =
       latest > prev + 0.1 ? 'up' : latest < prev - 0.1 ? 'down' : 'stable';
     return { dim, data, latest, direction };
-  }).filter((d) => d.data.some((p) => p.value > 0));
+  }).filter((d) => d.data.some((p) => p.value !== null));
 
   if (!user) return null;
 
@@ -819,6 +819,7 @@ export default function ManagerPage() {
                                 <Line
                                   type="monotone"
                                   dataKey="value"
+                                  connectNulls
                                   stroke={lineColor}
                                   strokeWidth={2}
                                   dot={{ r: 2, fill: lineColor }}
@@ -836,7 +837,7 @@ export default function ManagerPage() {
                                   labelStyle={{ color: '#e5e7eb', fontWeight: 600, marginBottom: '2px' }}
                                   itemStyle={{ color: '#d1fae5' }}
                                   cursor={{ stroke: '#6366f1', strokeWidth: 1, strokeDasharray: '3 3' }}
-                                  formatter={(v: number) => [v.toFixed(2), 'Score']}
+                                  formatter={(v: number | null) => [v === null ? '—' : v.toFixed(2), 'Score']}
                                   labelFormatter={(period) => period}
                                 />
                               </LineChart>
@@ -872,6 +873,7 @@ export default function ManagerPage() {
                             key={dim.id}
                             type="monotone"
                             dataKey={dim.id}
+                            connectNulls
                             name={dim.name}
                             stroke={`hsl(${idx * 32}, 70%, 50%)`}
                             strokeWidth={2}
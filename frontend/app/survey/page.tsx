@@ -6,7 +6,7 @@ import { getCurrentUser, logout } from '@/lib/auth';
 import { HEALTH_DIMENSIONS } from '@/lib/data';
 import { HealthCheckResponse } from '@/lib/types';
 import { getAssessmentPeriod, toCadence } from '@/lib/assessment-period';
-import { submitHealthCheck, formatDateForAPI, HealthCheckAPIError } from '@/lib/api/health-checks';
+import { submitHealthCheck, formatDateForAPI, HealthCheckAPIError, getHealthCheckDraft, saveHealthCheckDraft } from '@/lib/api/health-checks';
 import { getTeamInfoCached, TeamInfo, TeamsAPIError } from '@/lib/api/teams';
 import { TrendingUp, TrendingDown, Minus, ChevronLeft, ChevronRight, Save, LogOut, CheckCircle, BarChart3, Loader2, AlertCircle, Info, X } from 'lucide-react';
 
@@ -60,6 +60,22 @@ function SurveyPageContent() {
   const [showHelpPanel, setShowHelpPanel] = useState(false);
   const helpAutoShown = useRef(false);
 
+  // Resume a draft autosaved on another device when this browser has none
+  const restoreServerDraft = (teamId: string, assessmentPeriod: string) => {
+    if (isPostWorkshop) return;
+    getHealthCheckDraft(teamId, assessmentPeriod)
+      .then((draft) => {
+        if (draft && draft.responses.length > 0) {
+          setResponses(draft.responses);
+          setCurrentDimension(Math.min(draft.responses.length, HEALTH_DIMENSIONS.length - 1));
+          setDraftRestored(true);
+        }
+      })
+      .catch(() => {
+        // Server drafts are best-effort; start a fresh survey
+      });
+  };
+
   useEffect(() => {
     const currentUser = getCurrentUser();
     if (!currentUser) {
@@ -76,20 +92,25 @@ function SurveyPageContent() {
           .then((teamInfo) => {
             setTeam(teamInfo);
             // Restore draft after team info loaded
+            const currentPeriod = getAssessmentPeriod(new Date(), toCadence(teamInfo.cadence));
+            let restored = false;
             try {
               const draftJson = localStorage.getItem(getDraftKey(currentUser.id, teamId));
               if (draftJson) {
                 const draft: SurveyDraft = JSON.parse(draftJson);
-                const currentPeriod = getAssessmentPeriod(new Date(), toCadence(teamInfo.cadence));
                 if (draft.assessmentPeriod === currentPeriod && draft.responses.length > 0) {
                   setResponses(draft.responses);
                   setCurrentDimension(draft.currentDimension);
                   setDraftRestored(true);
+                  restored = true;
                 }
               }
             } catch {
               // Ignore corrupt draft
             }
+            if (!restored) {
+              restoreServerDraft(teamId, currentPeriod);
+            }
             draftInitialized.current = true;
             setTeamLoading(false);
           })
@@ -144,20 +165,25 @@ function SurveyPageContent() {
       .then((teamInfo) => {
         setTeam(teamInfo);
         // Restore draft for the new team
+        const currentPeriod = getAssessmentPeriod(new Date(), toCadence(teamInfo.cadence));
+        let restored = false;
         try {
           const draftJson = localStorage.getItem(getDraftKey(user.id, newTeamId));
           if (draftJson) {
             const draft: SurveyDraft = JSON.parse(draftJson);
-            const currentPeriod = getAssessmentPeriod(new Date(), toCadence(teamInfo.cadence));
             if (draft.assessmentPeriod === currentPeriod && draft.responses.length > 0) {
               setResponses(draft.responses);
               setCurrentDimension(draft.currentDimension);
               setDraftRestored(true);
+              restored = true;
             }
           }
         } catch {
           // Ignore corrupt draft
         }
+        if (!restored) {
+          restoreServerDraft(newTeamId, currentPeriod);
+        }
         draftInitialized.current = true;
         setTeamLoading(false);
       })
@@ -182,12 +208,17 @@ function SurveyPageContent() {
         savedAt: new Date().toISOString(),
       };
       localStorage.setItem(getDraftKey(user.id, team.id), JSON.stringify(draft));
+      if (!isPostWorkshop) {
+        saveHealthCheckDraft(team.id, draft.assessmentPeriod, responses).catch(() => {
+          // The local draft is kept; the next autosave retries
+        });
+      }
     }, 300);
 
     return () => {
       if (debounceRef.current) clearTimeout(debounceRef.current);
     };
-  }, [responses, currentDimension, user, team, submitted]);
+  }, [responses, currentDimension, user, team, submitted, isPostWorkshop]);
 
   // beforeunload warning when survey has unsaved responses
   useEffect(() => {
@@ -4,7 +4,7 @@ import { useEffect, useState } from 'react';
 import { usePathname, useRouter } from 'next/navigation';
 import { getCurrentUser } from '@/lib/auth';
 
-const PUBLIC_PATHS = ['/', '/login', '/auth/callback'];
+const PUBLIC_PATHS = ['/', '/login', '/auth/callback', '/auth/saml/callback'];
 
 export default function AuthGuard({ children }: { children: React.ReactNode }) {
   const pathname = usePathname();
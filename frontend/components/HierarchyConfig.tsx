@@ -30,6 +30,7 @@ interface EditFormData {
   name: string;
   color: string;
   permissions: LocalPermissions;
+  requireMfa: boolean;
 }
 
 export default function HierarchyConfig() {
@@ -39,11 +40,7 @@ export default function HierarchyConfig() {
   const [editingLevel, setEditingLevel] = useState<string | null>(null);
   const [editFormData, setEditFormData] = useState<EditFormData | null>(null);
   const [showAddForm, setShowAddForm] = useState(false);
-  const [newLevel, setNewLevel] = useState<{
-    name: string;
-    color: string;
-    permissions: LocalPermissions;
-  }>({
+  const [newLevel, setNewLevel] = useState<EditFormData>({
     name: '',
     color: '#6366F1',
     permissions: {
@@ -55,7 +52,8 @@ export default function HierarchyConfig() {
       canExportData: false,
       canTakeSurvey: false,
       canViewAnalytics: false,
-    }
+    },
+    requireMfa: false,
   });
 
   useEffect(() => {
@@ -82,6 +80,9 @@ export default function HierarchyConfig() {
     canManageUsers: local.canManageUsers,
     canTakeSurvey: local.canTakeSurvey,
     canViewAnalytics: local.canViewAnalytics,
+    canConfigureSystem: local.canConfigureSystem,
+    canViewReports: local.canViewReports,
+    canExportData: local.canExportData,
   });
 
   const mapFromBackendPermissions = (backend: HierarchyPermissions): LocalPermissions => ({
@@ -90,10 +91,9 @@ export default function HierarchyConfig() {
     canManageUsers: backend.canManageUsers,
     canTakeSurvey: backend.canTakeSurvey,
     canViewAnalytics: backend.canViewAnalytics,
-    // Frontend-only permissions (not in backend yet)
-    canConfigureSystem: false,
-    canViewReports: false,
-    canExportData: false,
+    canConfigureSystem: backend.canConfigureSystem,
+    canViewReports: backend.canViewReports,
+    canExportData: backend.canExportData,
   });
 
   const handleAddLevel = async () => {
@@ -107,6 +107,7 @@ export default function HierarchyConfig() {
         name: newLevel.name.trim(),
         position: levels.length + 1,
         permissions: mapToBackendPermissions(newLevel.permissions),
+        requireMfa: newLevel.requireMfa,
       };
 
       await createHierarchyLevel(request);
@@ -126,7 +127,8 @@ export default function HierarchyConfig() {
           canExportData: false,
           canTakeSurvey: false,
           canViewAnalytics: false,
-        }
+        },
+        requireMfa: false,
       });
     } catch (err) {
       console.error('Failed to create hierarchy level:', err);
@@ -146,6 +148,7 @@ export default function HierarchyConfig() {
       const request: UpdateHierarchyLevelRequest = {
         name: editFormData.name.trim(),
         permissions: mapToBackendPermissions(editFormData.permissions),
+        requireMfa: editFormData.requireMfa,
       };
 
       await updateHierarchyLevel(levelId, request);
@@ -215,6 +218,7 @@ export default function HierarchyConfig() {
       name: level.name,
       color: '#6366F1', // Backend doesn't support color yet
       permissions: mapFromBackendPermissions(level.permissions),
+      requireMfa: level.requireMfa,
     });
   };
 
@@ -348,6 +352,17 @@ export default function HierarchyConfig() {
                 </label>
               ))}
             </div>
+            <label className="flex items-center gap-2 mt-3">
+              <input
+                data-testid="require-mfa-checkbox"
+                type="checkbox"
+                checked={newLevel.requireMfa}
+                onChange={(e) => setNewLevel({ ...newLevel, requireMfa: e.target.checked })}
+                className="w-4 h-4 text-indigo-600 rounded"
+                disabled={loading}
+              />
+              <span className="text-sm">Require MFA (users must set up an authenticator app)</span>
+            </label>
           </div>
 
           <div className="flex gap-4 mt-6">
@@ -375,7 +390,8 @@ export default function HierarchyConfig() {
                     canExportData: false,
                     canTakeSurvey: false,
                     canViewAnalytics: false,
-                  }
+                  },
+                  requireMfa: false,
                 });
               }}
               disabled={loading}
@@ -446,6 +462,17 @@ export default function HierarchyConfig() {
                       </label>
                     ))}
                   </div>
+                  <label className="flex items-center gap-2 mt-3">
+                    <input
+                      data-testid="edit-require-mfa-checkbox"
+                      type="checkbox"
+                      checked={editFormData?.requireMfa || false}
+                      onChange={(e) => setEditFormData(prev => prev ? { ...prev, requireMfa: e.target.checked } : null)}
+                      className="w-4 h-4 text-indigo-600 rounded"
+                      disabled={loading}
+                    />
+                    <span className="text-sm">Require MFA (users must set up an authenticator app)</span>
+                  </label>
                 </div>
 
                 <div className="flex gap-4">
@@ -1,5 +1,5 @@
 import { describe, it, expect, vi, beforeEach } from 'vitest';
-import { fetchSSOConfig } from '../sso';
+import { fetchSSOConfig, startSAMLFlow } from '../sso';
 
 // Mock the API client module so API_BASE_URL is always ''
 vi.mock('@/lib/api/client', () => ({ API_BASE_URL: '' }));
@@ -101,3 +101,19 @@ describe('fetchSSOConfig — login page contract', () => {
     expect(await fetchSSOConfig()).not.toBeNull();
   });
 });
+
+// ── 5. SAML login — the backend redirects to the IdP ─────────────────────────
+
+describe('startSAMLFlow', () => {
+  it('stores a state and sends the browser to the backend login URL with it', () => {
+    const location = { href: '' };
+    Object.defineProperty(window, 'location', { value: location, writable: true });
+    sessionStorage.clear();
+
+    startSAMLFlow({ name: 'Okta', loginUrl: '/api/v1/auth/saml/login' });
+
+    const state = sessionStorage.getItem('saml_state');
+    expect(state).toBeTruthy();
+    expect(location.href).toBe(`/api/v1/auth/saml/login?state=${encodeURIComponent(state!)}`);
+  });
+});
@@ -54,16 +54,26 @@ export interface DimensionDistribution {
 export interface IndividualResponses {
   teamId: string;
   responses: IndividualUserResponse[];
+  minRespondents: number;
+  suppressedResponses: number; // anonymous sessions withheld below minRespondents
+  anonymousComments: AnonymousComment[];
 }
 
 export interface IndividualUserResponse {
   sessionId: string;
-  userId: string;
-  userName: string;
+  userId: string; // respondent token when anonymous
+  userName: string; // empty when anonymous
   date: string;
+  anonymous?: boolean;
   dimensions: IndividualDimensionResp[];
 }
 
+export interface AnonymousComment {
+  dimensionId: string;
+  assessmentPeriod?: string;
+  comment: string;
+}
+
 export interface IndividualDimensionResp {
   dimensionId: string;
   score: number;
@@ -74,12 +84,15 @@ export interface IndividualDimensionResp {
 export interface TrendData {
   teamId: string;
   periods: string[];
+  overall: (number | null)[]; // weighted overall health per period
   dimensions: DimensionTrend[];
 }
 
 export interface DimensionTrend {
   dimensionId: string;
-  scores: number[];
+  name: string;
+  isActive: boolean; // inactive dimensions are included only when they have data
+  scores: (number | null)[]; // null where the period has no data
 }
 
 // =============================================================================
@@ -121,9 +134,13 @@ export interface ManagerAggregatedRadarResponse {
 export interface ManagerTrendsResponse {
   managerId: string;
   periods: string[];
+  overall: (number | null)[]; // weighted overall health per period
   dimensions: DimensionTrend[];
 }
 
+// Scoring mode for overall health; dimension weights apply unless 'unweighted'
+export type Scoring = 'weighted' | 'unweighted';
+
 // =============================================================================
 // Common API Types
 // =============================================================================
@@ -26,6 +26,9 @@ export interface HierarchyPermissions {
   canManageUsers: boolean;
   canTakeSurvey: boolean;
   canViewAnalytics: boolean;
+  canConfigureSystem: boolean;
+  canViewReports: boolean;
+  canExportData: boolean;
 }
 
 export interface HierarchyLevel {
@@ -33,6 +36,7 @@ export interface HierarchyLevel {
   name: string;
   position: number;
   permissions: HierarchyPermissions;
+  requireMfa: boolean;
   createdAt: string;
   updatedAt: string;
 }
@@ -41,11 +45,13 @@ export interface CreateHierarchyLevelRequest {
   name: string;
   position: number;
   permissions: HierarchyPermissions;
+  requireMfa?: boolean;
 }
 
 export interface UpdateHierarchyLevelRequest {
   name?: string;
   permissions?: HierarchyPermissions;
+  requireMfa?: boolean;
 }
 
 export interface UpdateHierarchyPositionRequest {
@@ -106,6 +112,12 @@ export interface AdminTeam {
   teamLeadName: string | null;
   cadence: string;
   distributionListEmail?: string | null;
+  chatWebhookUrl?: string | null;
+  chatWebhookFormat?: 'slack' | 'generic';
+  legalHold?: boolean;
+  surveyTemplateId?: string | null;
+  anonymous?: boolean;
+  anonymityMinRespondents?: number;
   memberCount: number;
   createdAt: string;
   updatedAt: string;
@@ -118,6 +130,11 @@ export interface CreateTeamRequest {
   teamLeadId?: string | null;
   cadence: string;
   distributionListEmail?: string | null;
+  chatWebhookUrl?: string | null;
+  chatWebhookFormat?: 'slack' | 'generic';
+  surveyTemplateId?: string | null;
+  anonymous?: boolean;
+  anonymityMinRespondents?: number;
   memberIds?: string[];
   // Future OAuth/groups support
   externalGroupId?: string;
@@ -128,6 +145,12 @@ export interface UpdateTeamRequest {
   teamLeadId?: string | null;
   cadence?: string;
   distributionListEmail?: string | null;
+  chatWebhookUrl?: string | null;
+  chatWebhookFormat?: 'slack' | 'generic';
+  legalHold?: boolean;
+  surveyTemplateId?: string; // empty string unassigns the template
+  anonymous?: boolean; // applies to surveys submitted from now on
+  anonymityMinRespondents?: number;
   memberIds?: string[];
   // Future OAuth/groups support
   externalGroupId?: string;
@@ -228,6 +251,24 @@ export interface RetentionPolicy {
 
 export interface UpdateRetentionPolicyRequest {
   keepSessionsMonths: number;
+  archiveEnabled?: boolean;
+}
+
+export interface ImportRowError {
+  line: number;
+  column?: string;
+  message: string;
+}
+
+export interface DirectoryImportResult {
+  valid: boolean;
+  applied: boolean;
+  rows: number;
+  usersCreated: number;
+  usersUpdated: number;
+  teamsCreated: number;
+  memberships: number;
+  errors: ImportRowError[];
 }
 
 // ============================================================================
@@ -572,6 +613,124 @@ export async function deleteDimension(dimensionId: string): Promise<void> {
   );
 }
 
+// ============================================================================
+// SURVEY TEMPLATE API METHODS
+// ============================================================================
+
+export interface SurveyTemplate {
+  id: string;
+  name: string;
+  description?: string;
+  version: number;
+  dimensionIds: string[];
+  teamCount: number;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface SurveyTemplateVersion {
+  version: number;
+  dimensionIds: string[];
+  createdAt: string;
+}
+
+export interface SurveyTemplateDetail extends SurveyTemplate {
+  versions: SurveyTemplateVersion[];
+}
+
+export interface SurveyTemplatesListResponse {
+  templates: SurveyTemplate[];
+  total: number;
+}
+
+export interface CreateSurveyTemplateRequest {
+  id?: string;
+  name: string;
+  description?: string;
+  dimensionIds: string[];
+}
+
+export interface UpdateSurveyTemplateRequest {
+  name?: string;
+  description?: string;
+  dimensionIds?: string[]; // a changed list publishes a new version
+}
+
+/**
+ * Fetches all survey templates
+ *
+ * @returns List of survey templates with their current version
+ */
+export async function listSurveyTemplates(): Promise<SurveyTemplatesListResponse> {
+  return createApiClient<SurveyTemplatesListResponse>(
+    `${API_BASE_URL}/api/v1/admin/survey-templates`
+  );
+}
+
+/**
+ * Fetches a survey template with its version history
+ *
+ * @param templateId - Template ID
+ * @returns Template with every published version, newest first
+ */
+export async function getSurveyTemplate(templateId: string): Promise<SurveyTemplateDetail> {
+  return createApiClient<SurveyTemplateDetail>(
+    `${API_BASE_URL}/api/v1/admin/survey-templates/${templateId}`
+  );
+}
+
+/**
+ * Creates a survey template
+ *
+ * @param request - Template data; the dimensions become version 1
+ * @returns Created template
+ */
+export async function createSurveyTemplate(
+  request: CreateSurveyTemplateRequest
+): Promise<SurveyTemplate> {
+  return createApiClient<SurveyTemplate>(
+    `${API_BASE_URL}/api/v1/admin/survey-templates`,
+    {
+      method: 'POST',
+      body: JSON.stringify(request),
+    }
+  );
+}
+
+/**
+ * Updates a survey template
+ *
+ * @param templateId - Template ID
+ * @param request - Fields to update
+ * @returns Updated template
+ */
+export async function updateSurveyTemplate(
+  templateId: string,
+  request: UpdateSurveyTemplateRequest
+): Promise<SurveyTemplate> {
+  return createApiClient<SurveyTemplate>(
+    `${API_BASE_URL}/api/v1/admin/survey-templates/${templateId}`,
+    {
+      method: 'PUT',
+      body: JSON.stringify(request),
+    }
+  );
+}
+
+/**
+ * Deletes a survey template that has no recorded health checks
+ *
+ * @param templateId - Template ID
+ */
+export async function deleteSurveyTemplate(templateId: string): Promise<void> {
+  await createApiClient<void>(
+    `${API_BASE_URL}/api/v1/admin/survey-templates/${templateId}`,
+    {
+      method: 'DELETE',
+    }
+  );
+}
+
 // ============================================================================
 // BRANDING SETTINGS API METHODS
 // ============================================================================
@@ -666,6 +825,45 @@ export async function updateRetentionPolicy(
   );
 }
 
+// ============================================================================
+// BULK IMPORT API METHODS
+// ============================================================================
+
+/**
+ * Validates a CSV import of users and teams without applying it
+ *
+ * @param csv - File contents, one user per row
+ * @returns Per-row errors and what the import would create
+ */
+export async function validateDirectoryImport(csv: string): Promise<DirectoryImportResult> {
+  return createApiClient<DirectoryImportResult>(
+    `${API_BASE_URL}/api/v1/admin/import/validate`,
+    {
+      method: 'POST',
+      body: JSON.stringify({ csv }),
+    }
+  );
+}
+
+/**
+ * Applies a CSV import of users and teams in one transaction.
+ * A file with row errors is rejected with 422; validate it first to see them.
+ *
+ * @param csv - File contents, one user per row
+ * @returns What the import created and updated
+ */
+export async function applyDirectoryImport(csv: string): Promise<DirectoryImportResult> {
+  const result = await createApiClient<DirectoryImportResult>(
+    `${API_BASE_URL}/api/v1/admin/import`,
+    {
+      method: 'POST',
+      body: JSON.stringify({ csv }),
+    }
+  );
+  clearAdminCache();
+  return result;
+}
+
 // ============================================================================
 // CACHE MANAGEMENT
 // ============================================================================
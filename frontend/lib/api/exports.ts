@@ -0,0 +1,59 @@
+/**
+ * Exports API Client
+ *
+ * Downloads health check results as CSV or XLSX. Requires the canExportData permission.
+ */
+
+import { API_BASE_URL, APIError, APIRequestError, apiRequest } from './client';
+
+export type ExportFormat = 'csv' | 'xlsx';
+
+export type ExportScope =
+  | { scope: 'team'; teamId: string }
+  | { scope: 'manager'; managerId: string }
+  | { scope: 'org' };
+
+export interface ExportOptions {
+  format?: ExportFormat;
+  assessmentPeriod?: string;
+  surveyType?: 'individual' | 'post_workshop';
+}
+
+/**
+ * Downloads health check results for a team, a manager's teams or the whole organization
+ *
+ * @param target Which teams to export
+ * @param options Format and filters
+ * @returns The exported file
+ */
+export async function exportHealthChecks(target: ExportScope, options: ExportOptions = {}): Promise<Blob> {
+  let path = '/api/v1/exports/health-checks';
+  if (target.scope === 'team') {
+    path = `/api/v1/exports/teams/${encodeURIComponent(target.teamId)}/health-checks`;
+  } else if (target.scope === 'manager') {
+    path = `/api/v1/exports/managers/${encodeURIComponent(target.managerId)}/health-checks`;
+  }
+
+  const params = new URLSearchParams();
+  if (options.format) params.append('format', options.format);
+  if (options.assessmentPeriod) params.append('assessmentPeriod', options.assessmentPeriod);
+  if (options.surveyType) params.append('surveyType', options.surveyType);
+
+  const response = await apiRequest(`${API_BASE_URL}${path}${params.toString() ? `?${params.toString()}` : ''}`);
+
+  if (!response.ok) {
+    let errorData: APIError | null = null;
+    try {
+      errorData = await response.json();
+    } catch {
+      // If response is not JSON, use status text
+    }
+    throw new APIRequestError(
+      errorData?.message || errorData?.error || response.statusText || 'Export failed',
+      response.status,
+      errorData || undefined
+    );
+  }
+
+  return response.blob();
+}
@@ -62,6 +62,86 @@ export async function submitHealthCheck(
   return handleResponse<HealthCheckSession>(response);
 }
 
+/**
+ * A dimension answered so far in a survey draft; score and trend are omitted until chosen
+ */
+export interface HealthCheckDraftResponse {
+  dimensionId: string;
+  score?: 1 | 2 | 3;
+  trend?: 'improving' | 'stable' | 'declining';
+  comment?: string;
+}
+
+function draftURL(teamId: string, assessmentPeriod: string): string {
+  return `${API_BASE_URL}/api/v1/health-checks/drafts/${encodeURIComponent(teamId)}/${encodeURIComponent(assessmentPeriod)}`;
+}
+
+/**
+ * Autosaves the current user's individual survey draft on the server
+ *
+ * @param teamId Team ID
+ * @param assessmentPeriod Assessment period the survey is for
+ * @param responses Answers given so far
+ * @returns The saved draft session (completed: false)
+ */
+export async function saveHealthCheckDraft(
+  teamId: string,
+  assessmentPeriod: string,
+  responses: HealthCheckDraftResponse[]
+): Promise<HealthCheckSession> {
+  const response = await apiRequest(draftURL(teamId, assessmentPeriod), {
+    method: 'PUT',
+    body: JSON.stringify({ responses }),
+  });
+
+  return handleResponse<HealthCheckSession>(response);
+}
+
+/**
+ * Fetches the current user's survey draft so it can be resumed on any device
+ *
+ * @returns The draft session, or null when there is none
+ */
+export async function getHealthCheckDraft(
+  teamId: string,
+  assessmentPeriod: string
+): Promise<HealthCheckSession | null> {
+  const response = await apiRequest(draftURL(teamId, assessmentPeriod));
+
+  if (response.status === 404) {
+    return null;
+  }
+
+  return handleResponse<HealthCheckSession>(response);
+}
+
+/**
+ * Finalizes the current user's survey draft. Fails if any answer is incomplete.
+ *
+ * @returns The completed health check session
+ */
+export async function submitHealthCheckDraft(
+  teamId: string,
+  assessmentPeriod: string
+): Promise<HealthCheckSession> {
+  const response = await apiRequest(`${draftURL(teamId, assessmentPeriod)}/submit`, {
+    method: 'POST',
+  });
+
+  return handleResponse<HealthCheckSession>(response);
+}
+
+/**
+ * Discards the current user's survey draft
+ */
+export async function discardHealthCheckDraft(teamId: string, assessmentPeriod: string): Promise<void> {
+  const response = await apiRequest(draftURL(teamId, assessmentPeriod), {
+    method: 'DELETE',
+  });
+
+  await handleResponse<{ message: string }>(response);
+}
+
 /**
  * Fetches all active health dimensions
  *
@@ -86,6 +166,61 @@ export async function getHealthCheckById(id: string): Promise<HealthCheckSession
   return handleResponse<HealthCheckSession>(response);
 }
 
+/**
+ * Replaces the responses of the current user's submission while its assessment period is open
+ *
+ * @param id Session ID
+ * @param responses Complete set of corrected responses
+ * @returns The amended health check session
+ */
+export async function amendHealthCheck(
+  id: string,
+  responses: HealthCheckResponse[]
+): Promise<HealthCheckSession> {
+  const response = await apiRequest(`${API_BASE_URL}/api/v1/health-checks/${id}`, {
+    method: 'PUT',
+    body: JSON.stringify({ responses }),
+  });
+
+  return handleResponse<HealthCheckSession>(response);
+}
+
+/**
+ * Withdraws the current user's submission while its assessment period is open
+ *
+ * @param id Session ID
+ */
+export async function withdrawHealthCheck(id: string): Promise<void> {
+  const response = await apiRequest(`${API_BASE_URL}/api/v1/health-checks/${id}`, {
+    method: 'DELETE',
+  });
+
+  await handleResponse<{ message: string }>(response);
+}
+
+/**
+ * One entry of a submission's revision history; responses are omitted for anonymous surveys and withdrawals
+ */
+export interface HealthCheckRevision {
+  revision: number;
+  action: 'submitted' | 'amended' | 'withdrawn';
+  responses?: HealthCheckResponse[];
+  createdAt: string;
+}
+
+/**
+ * Fetches the revision history of a submission, oldest first
+ *
+ * @param id Session ID
+ * @returns Revisions; empty when the submission was never changed
+ */
+export async function getHealthCheckRevisions(id: string): Promise<HealthCheckRevision[]> {
+  const response = await apiRequest(`${API_BASE_URL}/api/v1/health-checks/${id}/revisions`);
+
+  const data = await handleResponse<{ sessionId: string; revisions: HealthCheckRevision[] }>(response);
+  return data.revisions;
+}
+
 /**
  * Fetches all health check sessions for a team
  *
@@ -25,8 +25,31 @@ export interface LoginResponse {
   accessToken: string;
   refreshToken: string;
   expiresIn: number;
+  recoveryCodes?: string[]; // only when MFA was enrolled during this login
 }
 
+/**
+ * Returned by the login endpoint instead of tokens when the user must enter an MFA code.
+ * The mfaToken is exchanged for tokens at POST /api/v1/auth/mfa/verify.
+ */
+export interface MFAChallengeResponse {
+  mfaRequired: true;
+  mfaToken: string;
+  expiresIn: number;
+  enrollmentRequired: boolean; // the user must set up an authenticator app first
+}
+
+/**
+ * Authenticator app setup returned by POST /api/v1/auth/mfa/enroll
+ */
+export interface MFAEnrollment {
+  secret: string;
+  provisioningUri: string;
+}
+
+export const isMFAChallenge = (data: LoginResponse | MFAChallengeResponse): data is MFAChallengeResponse =>
+  'mfaRequired' in data && data.mfaRequired === true;
+
 /**
  * AuthUser type used for authenticated user in frontend components.
  *
@@ -85,35 +108,53 @@ export const getRefreshToken = (): string | null => {
   return localStorage.getItem(REFRESH_TOKEN_KEY);
 };
 
+// In-flight refresh shared by concurrent callers. Refresh tokens are single-use
+// (rotated on every refresh), so parallel refreshes with the same token would be
+// treated as token reuse and revoke the session.
+let refreshInFlight: Promise<string | null> | null = null;
+
 /**
- * Refreshes the access token using the refresh token
+ * Refreshes the access token using the refresh token.
+ * The server rotates the refresh token, so the new one replaces the old.
  */
 export const refreshAccessToken = async (): Promise<string | null> => {
+  if (refreshInFlight) return refreshInFlight;
+
   const refreshToken = getRefreshToken();
   if (!refreshToken) return null;
 
-  try {
-    const response = await fetch(`${API_BASE_URL}/api/v1/auth/refresh`, {
-      method: 'POST',
-      headers: {
-        'Content-Type': 'application/json',
-      },
-      body: JSON.stringify({ refreshToken }),
-    });
+  // Cleared once settled, never before it is assigned, so a failed refresh cannot stick
+  refreshInFlight = (async () => {
+    try {
+      const response = await fetch(`${API_BASE_URL}/api/v1/auth/refresh`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({ refreshToken }),
+      });
+
+      if (!response.ok) {
+        // Refresh failed - clear auth data
+        clearAuthData();
+        return null;
+      }
 
-    if (!response.ok) {
-      // Refresh failed - clear auth data
+      const data = await response.json();
+      localStorage.setItem(ACCESS_TOKEN_KEY, data.accessToken);
+      if (data.refreshToken) {
+        localStorage.setItem(REFRESH_TOKEN_KEY, data.refreshToken);
+      }
+      return data.accessToken;
+    } catch {
       clearAuthData();
       return null;
     }
+  })().finally(() => {
+    refreshInFlight = null;
+  });
 
-    const data = await response.json();
-    localStorage.setItem(ACCESS_TOKEN_KEY, data.accessToken);
-    return data.accessToken;
-  } catch {
-    clearAuthData();
-    return null;
-  }
+  return refreshInFlight;
 };
 
 /**
@@ -199,11 +240,20 @@ export const getCurrentUser = (): AuthUser | null => {
 };
 
 /**
- * Logs out the current user by clearing all auth data and calling logout API
+ * Logs out the current user by revoking both tokens server-side and clearing all auth data
  */
 export const logout = async () => {
+  const accessToken = getAccessToken();
+  const refreshToken = getRefreshToken();
   try {
-    await fetch(`${API_BASE_URL}/api/v1/auth/logout`, { method: 'POST' });
+    await fetch(`${API_BASE_URL}/api/v1/auth/logout`, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+        ...(accessToken ? { Authorization: `Bearer ${accessToken}` } : {}),
+      },
+      body: JSON.stringify({ refreshToken: refreshToken ?? '' }),
+    });
   } catch {
     // Ignore errors - clear local data regardless
   }
@@ -6,12 +6,19 @@
 import { API_BASE_URL } from '@/lib/api/client';
 
 export interface OAuthConfig {
+  id?: string;
+  name?: string;
   clientId: string;
   authorizeUrl: string;
   redirectUri: string;
   scopes: string;
 }
 
+export interface SAMLConfig {
+  name: string;
+  loginUrl: string;
+}
+
 /**
  * Fetches SSO config from the backend's runtime config endpoint.
  * Returns null when SSO is not configured (OAUTH_CLIENT_ID not set on backend).
@@ -30,16 +37,19 @@ export async function fetchSSOConfig(): Promise<OAuthConfig | null> {
 
 /**
  * Starts the OAuth Authorization Code + PKCE flow.
- * Generates a code verifier, stores it in sessionStorage, then redirects
- * the browser to the provider's authorization endpoint.
+ * Generates a code verifier and nonce, stores them in sessionStorage along with
+ * the provider, then redirects the browser to the provider's authorization endpoint.
  */
 export async function startSSOFlow(config: OAuthConfig): Promise<void> {
   const verifier = generateCodeVerifier();
   const challenge = await generateCodeChallenge(verifier);
   const state = generateState();
+  const nonce = generateState();
 
   sessionStorage.setItem('pkce_verifier', verifier);
   sessionStorage.setItem('oauth_state', state);
+  sessionStorage.setItem('oauth_nonce', nonce);
+  sessionStorage.setItem('oauth_provider', config.id ?? '');
 
   const params = new URLSearchParams({
     response_type: 'code',
@@ -49,11 +59,25 @@ export async function startSSOFlow(config: OAuthConfig): Promise<void> {
     code_challenge: challenge,
     code_challenge_method: 'S256',
     state,
+    nonce,
   });
 
   window.location.href = `${config.authorizeUrl}?${params.toString()}`;
 }
 
+/**
+ * Starts a SAML login. Generates a state, stores it in sessionStorage, then sends the
+ * browser to the backend, which redirects it to the IdP. The backend's Assertion Consumer
+ * Service sends it back to /auth/saml/callback with a code that is redeemed with the state.
+ */
+export function startSAMLFlow(config: SAMLConfig): void {
+  const state = generateState();
+  sessionStorage.setItem('saml_state', state);
+
+  const params = new URLSearchParams({ state });
+  window.location.href = `${API_BASE_URL}${config.loginUrl}?${params.toString()}`;
+}
+
 // ── PKCE + state helpers ───────────────────────────────────────────────────────
 
 function generateState(): string {
@@ -95,6 +95,9 @@ export interface HealthCheckSession {
   assessmentPeriod?: string; // e.g., "2024 - 1st Half"
   responses: HealthCheckResponse[];
   completed: boolean;
+  templateId?: string; // Survey template answered, if the team has one
+  templateVersion?: number;
+  anonymous?: boolean; // userId is a respondent token
   createdAt?: string; // Timestamp from backend
 }
 